@@ -23,7 +23,8 @@ type Brain struct {
 	memory     *memory.Store
 	history    []llm.Message
 	cfg        *config.Config
-	answerChan chan string // ask_user 工具等待用户回答
+	answerChan chan string       // ask_user 工具等待用户回答
+	injected   []memory.Injected // 本轮注入 system prompt 的记忆
 }
 
 // NewBrain 创建新大脑
@@ -33,9 +34,20 @@ func NewBrain(scheduler *cron.Scheduler, cfg *config.Config) *Brain {
 		// 记忆系统初始化失败不应阻止启动，打印警告继续
 		fmt.Printf("警告: 记忆系统初始化失败: %v\n", err)
 	}
+	executor := tools.NewExecutor(scheduler, cfg)
+	if store != nil {
+		if embedder, err := llm.NewEmbedder(cfg); err != nil {
+			fmt.Printf("警告: 语义检索未启用: %v\n", err)
+		} else if embedder != nil {
+			store.SetEmbedder(embedder)
+		}
+		for _, tool := range tools.NewMemoryTools(store) {
+			executor.RegisterTool(tool)
+		}
+	}
 	return &Brain{
 		provider:   llm.NewProviderManager(cfg),
-		executor:   tools.NewExecutor(scheduler, cfg),
+		executor:   executor,
 		memory:     store,
 		history:    []llm.Message{},
 		cfg:        cfg,
@@ -55,6 +67,7 @@ func (b *Brain) Answer(text string) {
 // Chat 处理对话（非流式，带自动工具调用循环）
 func (b *Brain) Chat(userInput string) (string, error) {
 	b.addMessage("user", userInput)
+	b.selectMemories(userInput)
 
 	maxRounds := b.cfg.LLM.MaxToolRounds
 	var allResults []string
@@ -92,8 +105,7 @@ func (b *Brain) Chat(userInput string) (string, error) {
 		content := choice.Message.Content
 		b.addMessage("assistant", content)
 		if b.memory != nil {
-			b.memory.SaveMessage("user", userInput)
-			b.memory.SaveMessage("assistant", content)
+			b.memory.SaveTurn(memory.MessageOrigin{Channel: "tui"}, userInput, content)
 		}
 		allResults = append(allResults, content)
 		return strings.Join(allResults, "\n\n"), nil
@@ -110,6 +122,7 @@ func (b *Brain) ChatStream(userInput string) (<-chan StreamEvent, error) {
 		defer close(eventChan)
 
 		b.addMessage("user", userInput)
+		b.selectMemories(userInput)
 
 		maxToolRounds := b.cfg.LLM.MaxToolRounds
 		var finalContent string
@@ -148,10 +161,7 @@ func (b *Brain) ChatStream(userInput string) (<-chan StreamEvent, error) {
 						finalContent = roundContent
 					}
 					if b.memory != nil {
-						b.memory.SaveMessage("user", userInput)
-						if finalContent != "" {
-							b.memory.SaveMessage("assistant", finalContent)
-						}
+						b.memory.SaveTurn(memory.MessageOrigin{Channel: "tui"}, userInput, finalContent)
 					}
 					eventChan <- StreamEvent{Type: "done"}
 					return
@@ -211,10 +221,7 @@ func (b *Brain) ChatStream(userInput string) (<-chan StreamEvent, error) {
 				finalContent = roundContent
 			}
 			if b.memory != nil {
-				b.memory.SaveMessage("user", userInput)
-				if finalContent != "" {
-					b.memory.SaveMessage("assistant", finalContent)
-				}
+				b.memory.SaveTurn(memory.MessageOrigin{Channel: "tui"}, userInput, finalContent)
 			}
 			eventChan <- StreamEvent{Type: "done"}
 			return
@@ -320,13 +327,9 @@ func (b *Brain) getMessages() []llm.Message {
 	b.mu.RLock()
 	historyCopy := make([]llm.Message, len(b.history))
 	copy(historyCopy, b.history)
+	memories := memory.FormatForPrompt(b.injected)
 	b.mu.RUnlock()
 
-	var memories []string
-	if b.memory != nil {
-		memories, _ = b.memory.GetRecentMemories(5)
-	}
-
 	systemContent := prompt.Build(prompt.BuildParams{
 		ToolNames: b.executor.ListTools(),
 		WorkDir:   b.executor.GetWorkDir(),
@@ -350,6 +353,19 @@ func (b *Brain) ClearHistory() {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	b.history = []llm.Message{}
+	b.injected = nil
+}
+
+// selectMemories 挑选与本轮输入相关的记忆，注入后续的 system prompt
+func (b *Brain) selectMemories(userInput string) {
+	if b.memory == nil {
+		return
+	}
+	scopes := memory.Scopes{Project: memory.ProjectScope(b.executor.GetWorkDir())}
+	injected, _ := b.memory.SelectForPrompt(scopes.List(), userInput, b.cfg.Memory.InjectTokens)
+	b.mu.Lock()
+	b.injected = injected
+	b.mu.Unlock()
 }
 
 // StreamEvent 流式事件（Agent 层）
@@ -2,10 +2,12 @@ package cli
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -23,16 +25,18 @@ func newBoardCmd() *cobra.Command {
 	planCmd := &cobra.Command{
 		Use:   "plan [goal]",
 		Short: "AI 自动分解目标为任务计划",
-		Long:  "使用 AI Agent 分析代码库，将模糊目标分解为具体的结构化任务。",
+		Long:  "使用 AI Agent 先只读探索代码库、生成项目概要，再将模糊目标分解为引用真实文件的结构化任务。",
 		Args:  cobra.MinimumNArgs(1),
 		RunE:  runBoardPlan,
 	}
+	planCmd.Flags().StringP("work-dir", "d", "", "要探索的仓库目录（默认当前目录）")
 
 	approveCmd := &cobra.Command{
 		Use:   "approve",
 		Short: "审批并执行任务计划",
 		RunE:  runBoardApprove,
 	}
+	approveCmd.Flags().String("draft", "", "计划草稿 ID")
 	approveCmd.Flags().StringP("plan", "p", "", "计划 JSON 文件路径或内联 JSON")
 	approveCmd.Flags().StringP("goal", "g", "", "原始目标")
 	approveCmd.Flags().StringP("work-dir", "d", "", "工作目录")
@@ -45,7 +49,27 @@ func newBoardCmd() *cobra.Command {
 	}
 	watchCmd.Flags().StringP("workspace", "w", "", "仅监听指定工作区")
 
-	boardCmd.AddCommand(planCmd, approveCmd, watchCmd)
+	graphCmd := &cobra.Command{
+		Use:   "graph <workspace-id>",
+		Short: "显示工作区任务依赖图",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runBoardGraph,
+	}
+	graphCmd.Flags().StringP("format", "f", "text", "输出格式 (text, dot)")
+
+	searchCmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "全文搜索任务日志、结果与错误",
+		Long:  "在所有任务的执行日志、结果和错误中搜索文本（不区分大小写的子串匹配），按任务列出命中片段。",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  runBoardSearch,
+	}
+	searchCmd.Flags().StringP("workspace", "w", "", "仅搜索指定工作区")
+	searchCmd.Flags().StringP("tool", "t", "", "仅搜索指定工具的调用与结果（如 bash）")
+	searchCmd.Flags().String("since", "", "仅搜索此后的记录（如 2h、3d、2006-01-02）")
+	searchCmd.Flags().IntP("limit", "n", 20, "最多显示的任务数")
+
+	boardCmd.AddCommand(planCmd, approveCmd, watchCmd, graphCmd, searchCmd, newBoardDraftCmd())
 	return boardCmd
 }
 
@@ -82,8 +106,12 @@ func runBoardOverview(cmd *cobra.Command, args []string) error {
 		}
 		fmt.Printf("%s %s [%s]  %d/%d slots\n",
 			statusIcon, ws.Name, ws.Status, ws.Running, ws.MaxConcurrent)
-		fmt.Printf("  backlog:%d  ready:%d  running:%d  done:%d  failed:%d\n\n",
-			ws.Backlog, ws.Ready, ws.Running, ws.Done, ws.Failed)
+		fmt.Printf("  backlog:%d  ready:%d  running:%d  review:%d  done:%d  failed:%d  blocked:%d\n",
+			ws.Backlog, ws.Ready, ws.Running, ws.Review, ws.Done, ws.Failed, ws.Blocked)
+		if ws.Budget != nil && hasBudget(ws.Budget) {
+			printBudget(ws.Budget, "  ")
+		}
+		fmt.Println()
 	}
 
 	return nil
@@ -91,6 +119,13 @@ func runBoardOverview(cmd *cobra.Command, args []string) error {
 
 func runBoardPlan(cmd *cobra.Command, args []string) error {
 	goal := strings.Join(args, " ")
+	workDir, _ := cmd.Flags().GetString("work-dir")
+	if workDir == "" {
+		workDir, _ = os.Getwd()
+	}
+	if abs, err := filepath.Abs(workDir); err == nil {
+		workDir = abs
+	}
 
 	conn, err := ensureDaemon()
 	if err != nil {
@@ -102,13 +137,36 @@ func runBoardPlan(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
 	stream, err := client.PlanWorkspace(ctx, &pb.PlanWorkspaceRequest{
-		Goal: goal,
+		Goal:    goal,
+		WorkDir: workDir,
 	})
 	if err != nil {
 		return fmt.Errorf("规划失败: %w", err)
 	}
 
-	var planJSON string
+	draftID, err := recvPlanEvents(stream)
+	if err != nil {
+		return err
+	}
+
+	draft, err := client.GetPlanDraft(ctx, &pb.GetPlanDraftRequest{Id: draftID})
+	if err != nil {
+		return fmt.Errorf("获取计划草稿失败: %w", err)
+	}
+	if err := printPlanDraft(draft); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n计划已保存为草稿。可以先编辑（kele board draft --help），再批准执行:\n")
+	fmt.Printf("  kele board approve --draft %s\n", draftID)
+	return nil
+}
+
+// recvPlanEvents prints planner progress and returns the draft ID from plan_ready.
+func recvPlanEvents(stream interface {
+	Recv() (*pb.PlanEventMsg, error)
+}) (string, error) {
+	var draftID string
 	for {
 		ev, err := stream.Recv()
 		if err != nil {
@@ -118,83 +176,45 @@ func runBoardPlan(cmd *cobra.Command, args []string) error {
 		case "thinking":
 			fmt.Printf("[Planner] %s\n", ev.Content)
 		case "reading":
-			fmt.Printf("[Planner] %s\n", ev.Content)
+			fmt.Printf("[Planner] 读取 %s\n", ev.Content)
+		case "brief":
+			fmt.Printf("\n[Planner] 项目概要:\n%s\n\n", ev.Content)
 		case "plan_ready":
-			planJSON = ev.PlanJson
+			draftID = ev.DraftId
 			fmt.Println("\n[Planner] 计划生成完成!")
 		case "error":
-			return fmt.Errorf("规划错误: %s", ev.Content)
+			return "", fmt.Errorf("规划错误: %s", ev.Content)
 		}
 	}
-
-	if planJSON == "" {
-		return fmt.Errorf("未生成有效计划")
-	}
-
-	// Pretty print the plan
-	var plan map[string]interface{}
-	json.Unmarshal([]byte(planJSON), &plan)
-
-	fmt.Printf("\n工作区: %s\n", plan["workspace_name"])
-	if mc, ok := plan["max_concurrent"]; ok {
-		fmt.Printf("并发数: %v\n", mc)
-	}
-
-	if tasks, ok := plan["tasks"].([]interface{}); ok {
-		fmt.Printf("任务数: %d\n\n", len(tasks))
-		for i, t := range tasks {
-			task := t.(map[string]interface{})
-			deps := ""
-			if d, ok := task["depends_on"].([]interface{}); ok && len(d) > 0 {
-				depStrs := make([]string, len(d))
-				for j, dep := range d {
-					depStrs[j] = fmt.Sprintf("#%v", dep)
-				}
-				deps = "  依赖: " + strings.Join(depStrs, ", ")
-			}
-			priority := "normal"
-			if p, ok := task["priority"].(float64); ok {
-				switch int(p) {
-				case 0:
-					priority = "critical"
-				case 1:
-					priority = "high"
-				case 3:
-					priority = "low"
-				}
-			}
-			fmt.Printf("  #%d  %s  优先级: %s%s\n", i+1, task["title"], priority, deps)
-		}
+	if draftID == "" {
+		return "", fmt.Errorf("未生成有效计划")
 	}
-
-	// Save plan for approve command
-	fmt.Printf("\n计划已保存。使用以下命令批准执行:\n")
-	fmt.Printf("  kele board approve -p '%s' -g '%s' --auto-start\n", planJSON, goal)
-
-	return nil
+	return draftID, nil
 }
 
 func runBoardApprove(cmd *cobra.Command, args []string) error {
+	draftID, _ := cmd.Flags().GetString("draft")
 	planStr, _ := cmd.Flags().GetString("plan")
 	goal, _ := cmd.Flags().GetString("goal")
 	workDir, _ := cmd.Flags().GetString("work-dir")
 	autoStart, _ := cmd.Flags().GetBool("auto-start")
 
-	if planStr == "" {
-		return fmt.Errorf("需要指定计划 JSON（-p 参数）")
+	if planStr == "" && draftID == "" {
+		return fmt.Errorf("需要指定计划草稿（--draft）或计划 JSON（-p 参数）")
 	}
 
 	// Check if it's a file path
-	if _, err := os.Stat(planStr); err == nil {
-		data, err := os.ReadFile(planStr)
-		if err != nil {
-			return fmt.Errorf("读取计划文件: %w", err)
+	if planStr != "" {
+		if _, err := os.Stat(planStr); err == nil {
+			data, err := os.ReadFile(planStr)
+			if err != nil {
+				return fmt.Errorf("读取计划文件: %w", err)
+			}
+			planStr = string(data)
+		}
+		if workDir == "" {
+			workDir, _ = os.Getwd()
 		}
-		planStr = string(data)
-	}
-
-	if workDir == "" {
-		workDir, _ = os.Getwd()
 	}
 
 	conn, err := ensureDaemon()
@@ -206,7 +226,23 @@ func runBoardApprove(cmd *cobra.Command, args []string) error {
 	client := pb.NewKeleServiceClient(conn)
 	ctx := context.Background()
 
+	if draftID != "" {
+		// Validate locally first so cycles are reported before anything is created
+		draft, err := client.GetPlanDraft(ctx, &pb.GetPlanDraftRequest{Id: draftID})
+		if err != nil {
+			return fmt.Errorf("获取计划草稿失败: %w", err)
+		}
+		plan, err := parseDraftPlan(draft)
+		if err != nil {
+			return err
+		}
+		if err := plan.Validate(); err != nil {
+			return fmt.Errorf("计划无效: %w", err)
+		}
+	}
+
 	resp, err := client.ApprovePlan(ctx, &pb.ApprovePlanRequest{
+		DraftId:   draftID,
 		PlanJson:  planStr,
 		Goal:      goal,
 		WorkDir:   workDir,
@@ -230,6 +266,90 @@ func runBoardApprove(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runBoardSearch(cmd *cobra.Command, args []string) error {
+	workspaceID, _ := cmd.Flags().GetString("workspace")
+	tool, _ := cmd.Flags().GetString("tool")
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	req := &pb.SearchTasksRequest{
+		Query:       strings.Join(args, " "),
+		WorkspaceId: workspaceID,
+		Tool:        tool,
+		Limit:       int32(limit),
+	}
+	if sinceFlag != "" {
+		since, err := parseSince(sinceFlag)
+		if err != nil {
+			return err
+		}
+		req.Since = since.Format(time.RFC3339)
+	}
+
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	resp, err := client.SearchTasks(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("搜索失败: %w", err)
+	}
+	if len(resp.Hits) == 0 {
+		fmt.Println("没有匹配的任务。")
+		return nil
+	}
+
+	for _, hit := range resp.Hits {
+		fmt.Printf("%s  %s [%s]  工作区 %s  %d 处匹配\n",
+			hit.TaskId, hit.Title, hit.Status, hit.WorkspaceId, hit.TotalMatches)
+		for _, m := range hit.Matches {
+			label := ""
+			switch m.Field {
+			case "result":
+				label = "[结果]"
+			case "error":
+				label = "[错误]"
+			default:
+				label = "[" + m.EventType
+				if m.ToolName != "" {
+					label += " " + m.ToolName
+				}
+				label += "]"
+			}
+			if m.Timestamp != "" {
+				label = m.Timestamp + " " + label
+			}
+			fmt.Printf("  %s %s\n", label, strings.Join(strings.Fields(m.Snippet), " "))
+		}
+		if more := int(hit.TotalMatches) - len(hit.Matches); more > 0 {
+			fmt.Printf("  ... 另有 %d 处匹配，使用 `kele task log %s` 查看\n", more, hit.TaskId)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// parseSince accepts a look-back duration (30m, 2h, 3d) or a local date/time.
+func parseSince(s string) (time.Time, error) {
+	if strings.HasSuffix(s, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(s, "d")); err == nil {
+			return time.Now().AddDate(0, 0, -days), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("无法解析时间: %s（示例: 2h、3d、2006-01-02）", s)
+}
+
 func runBoardWatch(cmd *cobra.Command, args []string) error {
 	workspaceID, _ := cmd.Flags().GetString("workspace")
 
@@ -268,6 +388,20 @@ func runBoardWatch(cmd *cobra.Command, args []string) error {
 			icon = "⊘"
 		case "task_ready":
 			icon = "◌"
+		case "task_merged":
+			icon = "⇢"
+		case "task_blocked":
+			icon = "⊗"
+		case "task_unblocked":
+			icon = "◌"
+		case "task_review":
+			icon = "◐"
+		case "task_approved":
+			icon = "✓"
+		case "task_rejected":
+			icon = "↺"
+		case "workspace_budget_exceeded":
+			icon = "⚠"
 		case "workspace_completed":
 			icon = "★"
 		}
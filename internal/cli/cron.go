@@ -0,0 +1,436 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/BlakeLiAFK/kele/internal/proto"
+)
+
+func newCronCmd() *cobra.Command {
+	cronCmd := &cobra.Command{
+		Use:   "cron",
+		Short: "定时任务",
+		Long: `管理 daemon 中的定时任务。任务有两种：
+  command  按计划执行 bash 命令
+  agent    按计划把指令交给模型在新会话中执行（指定 --prompt 即为 agent 任务）
+执行结果可以投递到消息渠道（--channel）。`,
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "列出定时任务",
+		RunE:  runCronList,
+	}
+
+	addCmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "添加定时任务",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runCronAdd,
+	}
+	addCronJobFlags(addCmd)
+
+	editCmd := &cobra.Command{
+		Use:   "edit <id>",
+		Short: "修改定时任务（只修改指定的选项）",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runCronEdit,
+	}
+	editCmd.Flags().String("name", "", "任务名称")
+	addCronJobFlags(editCmd)
+
+	rmCmd := &cobra.Command{
+		Use:   "rm <id>",
+		Short: "删除定时任务",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runCronRemove,
+	}
+
+	enableCmd := &cobra.Command{
+		Use:   "enable <id>",
+		Short: "启用定时任务",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runCronSetEnabled(true),
+	}
+
+	disableCmd := &cobra.Command{
+		Use:   "disable <id>",
+		Short: "暂停定时任务",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runCronSetEnabled(false),
+	}
+
+	runCmd := &cobra.Command{
+		Use:   "run <id>",
+		Short: "立即执行一次并等待结果（不影响计划）",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runCronRun,
+	}
+
+	logsCmd := &cobra.Command{
+		Use:   "logs <id>",
+		Short: "查看执行日志",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runCronLogs,
+	}
+	logsCmd.Flags().IntP("limit", "n", 20, "最多显示条数")
+	logsCmd.Flags().BoolP("full", "f", false, "显示完整输出")
+
+	nextCmd := &cobra.Command{
+		Use:   "next [id]",
+		Short: "预览接下来的执行时间",
+		Long: `预览任务接下来的执行时间；不指定任务时预览 --schedule 给出的表达式，例如:
+  kele cron next --schedule "0 9 * * 1-5" --tz Asia/Shanghai`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runCronNext,
+	}
+	nextCmd.Flags().StringP("schedule", "s", "", "cron 表达式")
+	nextCmd.Flags().String("tz", "", "时区（IANA 名称，如 Asia/Shanghai）")
+	nextCmd.Flags().IntP("count", "n", 5, "显示的次数")
+
+	cronCmd.AddCommand(listCmd, addCmd, editCmd, rmCmd, enableCmd, disableCmd, runCmd, logsCmd, nextCmd)
+	return cronCmd
+}
+
+func addCronJobFlags(cmd *cobra.Command) {
+	f := cmd.Flags()
+	f.StringP("schedule", "s", "", "执行计划：cron 表达式（5 段，或带秒的 6 段）、@daily、@every 30m 等")
+	f.StringP("command", "c", "", "要执行的 bash 命令（command 任务）")
+	f.StringP("prompt", "p", "", "交给模型的指令（agent 任务）")
+	f.String("model", "", "agent 任务使用的模型或档位，默认使用默认模型")
+	f.StringSlice("tools", nil, "agent 任务可用的工具，逗号分隔，默认除 ask_user 外全部可用")
+	f.String("dir", "", "执行目录，默认为 daemon 的启动目录")
+	f.String("tz", "", "计算执行时间的时区（IANA 名称，如 Asia/Shanghai），默认本地时区")
+	f.String("overlap", "", "上一次未结束时的处理: skip（默认）、queue、allow")
+	f.String("catch-up", "", "错过执行的补跑策略: none、once、all，默认使用 daemon 配置")
+	f.String("channel", "", "执行结果投递渠道（如 telegram，默认不投递）")
+	f.String("target", "", "渠道内的接收者（默认使用渠道配置）")
+	f.String("when", "", "投递条件: always（默认）、failure、non_empty")
+}
+
+func runCronList(cmd *cobra.Command, args []string) error {
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	resp, err := client.ListCronJobs(context.Background(), &pb.Empty{})
+	if err != nil {
+		return fmt.Errorf("列出定时任务失败: %w", err)
+	}
+	if len(resp.Jobs) == 0 {
+		fmt.Println("暂无定时任务。")
+		return nil
+	}
+
+	fmt.Printf("%-18s %-20s %-8s %-6s %-20s %s\n", "ID", "计划", "类型", "启用", "下次执行", "名称")
+	fmt.Println("────────────────────────────────────────────────────────────────")
+	for _, job := range resp.Jobs {
+		enabled := "是"
+		if !job.Enabled {
+			enabled = "否"
+		}
+		next := job.NextRun
+		if next == "" {
+			next = "-"
+		}
+		fmt.Printf("%-18s %-20s %-8s %-6s %-20s %s\n", job.Id, job.Schedule, job.Type, enabled, next, job.Name)
+		if job.LastError != "" {
+			fmt.Printf("  上次错误: %s\n", firstLine(job.LastError))
+		}
+	}
+	return nil
+}
+
+func runCronAdd(cmd *cobra.Command, args []string) error {
+	f := cmd.Flags()
+	req := &pb.CreateCronJobRequest{Name: args[0]}
+	req.Schedule, _ = f.GetString("schedule")
+	req.Command, _ = f.GetString("command")
+	req.Prompt, _ = f.GetString("prompt")
+	req.Model, _ = f.GetString("model")
+	req.Tools, _ = f.GetStringSlice("tools")
+	req.WorkDir, _ = f.GetString("dir")
+	req.Timezone, _ = f.GetString("tz")
+	req.Overlap, _ = f.GetString("overlap")
+	req.CatchUp, _ = f.GetString("catch-up")
+	req.DeliverChannel, _ = f.GetString("channel")
+	req.DeliverTarget, _ = f.GetString("target")
+	req.DeliverWhen, _ = f.GetString("when")
+	if req.Schedule == "" {
+		return fmt.Errorf("需要指定 --schedule")
+	}
+	switch {
+	case req.Prompt != "" && req.Command != "":
+		return fmt.Errorf("--command 和 --prompt 只能指定一个")
+	case req.Prompt != "":
+		req.Type = "agent"
+	case req.Command != "":
+		req.Type = "command"
+	default:
+		return fmt.Errorf("需要指定 --command 或 --prompt")
+	}
+
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	job, err := client.CreateCronJob(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("添加定时任务失败: %w", err)
+	}
+	fmt.Printf("定时任务已添加: [%s] %s (%s)，下次执行: %s\n", job.Id, job.Name, job.Schedule, job.NextRun)
+	return nil
+}
+
+func runCronEdit(cmd *cobra.Command, args []string) error {
+	f := cmd.Flags()
+	req := &pb.UpdateCronJobRequest{Id: args[0]}
+	for flag, field := range map[string]**string{
+		"name":     &req.Name,
+		"schedule": &req.Schedule,
+		"command":  &req.Command,
+		"prompt":   &req.Prompt,
+		"model":    &req.Model,
+		"dir":      &req.WorkDir,
+		"tz":       &req.Timezone,
+		"overlap":  &req.Overlap,
+		"catch-up": &req.CatchUp,
+		"channel":  &req.DeliverChannel,
+		"target":   &req.DeliverTarget,
+		"when":     &req.DeliverWhen,
+	} {
+		if f.Changed(flag) {
+			v, _ := f.GetString(flag)
+			*field = &v
+		}
+	}
+	if f.Changed("tools") {
+		req.Tools, _ = f.GetStringSlice("tools")
+		req.SetTools = true
+	}
+	// 只给出命令或指令时随之切换任务类型
+	switch {
+	case req.Prompt != nil && req.Command != nil:
+		return fmt.Errorf("--command 和 --prompt 只能指定一个")
+	case req.Prompt != nil:
+		req.Type = stringPtr("agent")
+	case req.Command != nil:
+		req.Type = stringPtr("command")
+	}
+
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	job, err := client.UpdateCronJob(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("修改定时任务失败: %w", err)
+	}
+	printCronJob(job)
+	return nil
+}
+
+func runCronRemove(cmd *cobra.Command, args []string) error {
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	if _, err := client.DeleteCronJob(context.Background(), &pb.CronJobRequest{Id: args[0]}); err != nil {
+		return fmt.Errorf("删除定时任务失败: %w", err)
+	}
+	fmt.Printf("定时任务 %s 已删除\n", args[0])
+	return nil
+}
+
+func runCronSetEnabled(enabled bool) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		conn, err := ensureDaemon()
+		if err != nil {
+			return fmt.Errorf("daemon 连接失败: %w", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewKeleServiceClient(conn)
+		job, err := client.SetCronJobEnabled(context.Background(), &pb.SetCronJobEnabledRequest{
+			Id:      args[0],
+			Enabled: enabled,
+		})
+		if err != nil {
+			return fmt.Errorf("更新定时任务失败: %w", err)
+		}
+		if job.Enabled {
+			fmt.Printf("定时任务 %s 已启用，下次执行: %s\n", job.Id, job.NextRun)
+		} else {
+			fmt.Printf("定时任务 %s 已暂停\n", job.Id)
+		}
+		return nil
+	}
+}
+
+func runCronRun(cmd *cobra.Command, args []string) error {
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	fmt.Printf("正在执行 %s ...\n", args[0])
+	entry, err := client.RunCronJob(context.Background(), &pb.CronJobRequest{Id: args[0]})
+	if err != nil {
+		return fmt.Errorf("执行失败: %w", err)
+	}
+	fmt.Printf("耗时 %s\n", time.Duration(entry.DurationMs)*time.Millisecond)
+	if entry.Output != "" {
+		fmt.Println(strings.TrimRight(entry.Output, "\n"))
+	}
+	if entry.Error != "" {
+		return fmt.Errorf("任务执行出错: %s", entry.Error)
+	}
+	return nil
+}
+
+func runCronLogs(cmd *cobra.Command, args []string) error {
+	limit, _ := cmd.Flags().GetInt("limit")
+	full, _ := cmd.Flags().GetBool("full")
+
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	resp, err := client.GetCronLogs(context.Background(), &pb.GetCronLogsRequest{Id: args[0], Limit: int32(limit)})
+	if err != nil {
+		return fmt.Errorf("获取执行日志失败: %w", err)
+	}
+	if len(resp.Logs) == 0 {
+		fmt.Println("暂无执行日志。")
+		return nil
+	}
+
+	for _, l := range resp.Logs {
+		status := "成功"
+		if l.Error != "" {
+			status = "失败"
+		}
+		fmt.Printf("%s  %s  %s\n", l.RunAt, status, time.Duration(l.DurationMs)*time.Millisecond)
+		output, errText := strings.TrimRight(l.Output, "\n"), l.Error
+		if !full {
+			output, errText = firstLine(output), firstLine(errText)
+		}
+		if output != "" {
+			fmt.Printf("  输出: %s\n", output)
+		}
+		if errText != "" {
+			fmt.Printf("  错误: %s\n", errText)
+		}
+	}
+	return nil
+}
+
+func runCronNext(cmd *cobra.Command, args []string) error {
+	req := &pb.PreviewCronScheduleRequest{}
+	req.Schedule, _ = cmd.Flags().GetString("schedule")
+	req.Timezone, _ = cmd.Flags().GetString("tz")
+	count, _ := cmd.Flags().GetInt("count")
+	req.Count = int32(count)
+	if len(args) > 0 {
+		if req.Schedule != "" {
+			return fmt.Errorf("指定任务时不能同时使用 --schedule")
+		}
+		req.Id = args[0]
+	} else if req.Schedule == "" {
+		return fmt.Errorf("需要指定任务 ID 或 --schedule")
+	}
+
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	resp, err := client.PreviewCronSchedule(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("计算执行时间失败: %w", err)
+	}
+	if len(resp.Times) == 0 {
+		fmt.Println("该计划之后不会再执行。")
+		return nil
+	}
+	now := time.Now()
+	for _, s := range resp.Times {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			fmt.Println(s)
+			continue
+		}
+		fmt.Printf("%s  %s  (%s 后)\n", t.Format("2006-01-02 15:04:05 MST"), weekdayNames[t.Weekday()],
+			t.Sub(now).Round(time.Second))
+	}
+	return nil
+}
+
+// printCronJob 显示任务的完整设置
+func printCronJob(job *pb.CronJobInfo) {
+	fmt.Printf("定时任务 [%s] %s\n", job.Id, job.Name)
+	fmt.Printf("  计划:      %s\n", job.Schedule)
+	if job.Timezone != "" {
+		fmt.Printf("  时区:      %s\n", job.Timezone)
+	}
+	if job.Type == "agent" {
+		fmt.Printf("  指令:      %s\n", job.Prompt)
+		if job.Model != "" {
+			fmt.Printf("  模型:      %s\n", job.Model)
+		}
+		if len(job.Tools) > 0 {
+			fmt.Printf("  工具:      %s\n", strings.Join(job.Tools, ", "))
+		}
+	} else {
+		fmt.Printf("  命令:      %s\n", job.Command)
+	}
+	if job.WorkDir != "" {
+		fmt.Printf("  目录:      %s\n", job.WorkDir)
+	}
+	if job.DeliverChannel != "" {
+		fmt.Printf("  投递:      %s %s (%s)\n", job.DeliverChannel, job.DeliverTarget, job.DeliverWhen)
+	}
+	if job.Overlap != "" {
+		fmt.Printf("  重叠策略:  %s\n", job.Overlap)
+	}
+	if job.CatchUp != "" {
+		fmt.Printf("  补跑策略:  %s\n", job.CatchUp)
+	}
+	fmt.Printf("  启用:      %v\n", job.Enabled)
+	fmt.Printf("  下次执行:  %s\n", job.NextRun)
+}
+
+var weekdayNames = [...]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i] + " ..."
+	}
+	return s
+}
+
+func stringPtr(s string) *string { return &s }
@@ -0,0 +1,399 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/BlakeLiAFK/kele/internal/proto"
+	"github.com/BlakeLiAFK/kele/internal/taskboard"
+)
+
+// 计划草稿编辑。命令行中的任务编号从 1 开始（与 `#1` 显示一致），
+// 发送给 daemon 时转换为从 0 开始的下标。
+
+func newBoardDraftCmd() *cobra.Command {
+	draftCmd := &cobra.Command{
+		Use:   "draft",
+		Short: "编辑待批准的计划草稿",
+		Long:  "`kele board plan` 生成的计划会保存为草稿，可在批准前增删任务、调整顺序、依赖和优先级，或让 Planner 按反馈修改。",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "列出计划草稿",
+		RunE:  runDraftList,
+	}
+
+	showCmd := &cobra.Command{
+		Use:   "show <draft-id>",
+		Short: "查看草稿及依赖图",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDraftShow,
+	}
+
+	addCmd := &cobra.Command{
+		Use:   "add <draft-id>",
+		Short: "添加任务",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDraftAdd,
+	}
+	addCmd.Flags().StringP("title", "t", "", "任务标题（必填）")
+	addCmd.Flags().StringP("prompt", "p", "", "任务 prompt（必填）")
+	addCmd.Flags().StringP("description", "d", "", "任务描述")
+	addCmd.Flags().IntP("priority", "P", 2, "优先级 (0=critical, 1=high, 2=normal, 3=low)")
+	addCmd.Flags().String("depends", "", "依赖任务编号（逗号分隔，如 1,3）")
+	addCmd.Flags().StringSlice("tags", nil, "标签")
+	addCmd.Flags().Int("at", 0, "插入位置（任务编号，默认追加到末尾）")
+
+	rmCmd := &cobra.Command{
+		Use:   "rm <draft-id> <n>",
+		Short: "删除任务（依赖它的任务会移除该依赖）",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runDraftRemove,
+	}
+
+	mvCmd := &cobra.Command{
+		Use:   "mv <draft-id> <from> <to>",
+		Short: "调整任务顺序",
+		Args:  cobra.ExactArgs(3),
+		RunE:  runDraftMove,
+	}
+
+	setCmd := &cobra.Command{
+		Use:   "set <draft-id> <n>",
+		Short: "修改任务的依赖、优先级或内容",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runDraftSet,
+	}
+	setCmd.Flags().IntP("priority", "P", 2, "优先级 (0=critical, 1=high, 2=normal, 3=low)")
+	setCmd.Flags().String("depends", "", "依赖任务编号（逗号分隔；传空字符串清除依赖）")
+	setCmd.Flags().StringP("title", "t", "", "任务标题")
+	setCmd.Flags().StringP("prompt", "p", "", "任务 prompt")
+	setCmd.Flags().StringP("description", "d", "", "任务描述")
+	setCmd.Flags().StringSlice("tags", nil, "标签")
+
+	reviseCmd := &cobra.Command{
+		Use:   "revise <draft-id> <feedback>",
+		Short: "让 Planner 根据反馈修改计划",
+		Args:  cobra.MinimumNArgs(2),
+		RunE:  runDraftRevise,
+	}
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete <draft-id>",
+		Short: "丢弃草稿",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDraftDelete,
+	}
+
+	draftCmd.AddCommand(listCmd, showCmd, addCmd, rmCmd, mvCmd, setCmd, reviseCmd, deleteCmd)
+	return draftCmd
+}
+
+func runDraftList(cmd *cobra.Command, args []string) error {
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	resp, err := client.ListPlanDrafts(context.Background(), &pb.Empty{})
+	if err != nil {
+		return fmt.Errorf("列出草稿失败: %w", err)
+	}
+
+	if len(resp.Drafts) == 0 {
+		fmt.Println("暂无计划草稿。使用 `kele board plan \"目标\"` 生成。")
+		return nil
+	}
+
+	fmt.Printf("%-28s %-20s %-6s %s\n", "ID", "更新时间", "任务数", "目标")
+	fmt.Println("────────────────────────────────────────────────────────────────────────────")
+	for _, d := range resp.Drafts {
+		count := 0
+		if plan, err := parseDraftPlan(d); err == nil {
+			count = len(plan.Tasks)
+		}
+		goal := d.Goal
+		if len(goal) > 40 {
+			goal = goal[:40] + ".."
+		}
+		fmt.Printf("%-28s %-20s %-6d %s\n", d.Id, d.UpdatedAt, count, goal)
+	}
+	return nil
+}
+
+func runDraftShow(cmd *cobra.Command, args []string) error {
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	d, err := client.GetPlanDraft(context.Background(), &pb.GetPlanDraftRequest{Id: args[0]})
+	if err != nil {
+		return fmt.Errorf("获取计划草稿失败: %w", err)
+	}
+	return printPlanDraft(d)
+}
+
+func runDraftAdd(cmd *cobra.Command, args []string) error {
+	title, _ := cmd.Flags().GetString("title")
+	prompt, _ := cmd.Flags().GetString("prompt")
+	description, _ := cmd.Flags().GetString("description")
+	priority, _ := cmd.Flags().GetInt("priority")
+	dependsStr, _ := cmd.Flags().GetString("depends")
+	tags, _ := cmd.Flags().GetStringSlice("tags")
+	at, _ := cmd.Flags().GetInt("at")
+
+	if title == "" {
+		return fmt.Errorf("需要指定 --title")
+	}
+	if prompt == "" {
+		return fmt.Errorf("需要指定 --prompt")
+	}
+	depends, err := parseTaskNumbers(dependsStr)
+	if err != nil {
+		return err
+	}
+
+	req := &pb.AddPlanTaskRequest{
+		DraftId:     args[0],
+		Title:       title,
+		Description: description,
+		Prompt:      prompt,
+		DependsOn:   depends,
+		Tags:        tags,
+	}
+	if cmd.Flags().Changed("priority") {
+		p := int32(priority)
+		req.Priority = &p
+	}
+	if cmd.Flags().Changed("at") {
+		pos := int32(at - 1)
+		req.Position = &pos
+	}
+
+	return editDraft(func(client pb.KeleServiceClient) (*pb.PlanDraftInfo, error) {
+		return client.AddPlanTask(context.Background(), req)
+	})
+}
+
+func runDraftRemove(cmd *cobra.Command, args []string) error {
+	n, err := parseTaskNumber(args[1])
+	if err != nil {
+		return err
+	}
+	return editDraft(func(client pb.KeleServiceClient) (*pb.PlanDraftInfo, error) {
+		return client.RemovePlanTask(context.Background(), &pb.RemovePlanTaskRequest{
+			DraftId: args[0],
+			Index:   n,
+		})
+	})
+}
+
+func runDraftMove(cmd *cobra.Command, args []string) error {
+	from, err := parseTaskNumber(args[1])
+	if err != nil {
+		return err
+	}
+	to, err := parseTaskNumber(args[2])
+	if err != nil {
+		return err
+	}
+	return editDraft(func(client pb.KeleServiceClient) (*pb.PlanDraftInfo, error) {
+		return client.MovePlanTask(context.Background(), &pb.MovePlanTaskRequest{
+			DraftId: args[0],
+			From:    from,
+			To:      to,
+		})
+	})
+}
+
+func runDraftSet(cmd *cobra.Command, args []string) error {
+	n, err := parseTaskNumber(args[1])
+	if err != nil {
+		return err
+	}
+	req := &pb.UpdatePlanTaskRequest{DraftId: args[0], Index: n}
+	req.Title, _ = cmd.Flags().GetString("title")
+	req.Prompt, _ = cmd.Flags().GetString("prompt")
+	req.Description, _ = cmd.Flags().GetString("description")
+	req.Tags, _ = cmd.Flags().GetStringSlice("tags")
+
+	if cmd.Flags().Changed("priority") {
+		priority, _ := cmd.Flags().GetInt("priority")
+		p := int32(priority)
+		req.Priority = &p
+	}
+	if cmd.Flags().Changed("depends") {
+		dependsStr, _ := cmd.Flags().GetString("depends")
+		if req.DependsOn, err = parseTaskNumbers(dependsStr); err != nil {
+			return err
+		}
+		req.SetDependsOn = true
+	}
+
+	return editDraft(func(client pb.KeleServiceClient) (*pb.PlanDraftInfo, error) {
+		return client.UpdatePlanTask(context.Background(), req)
+	})
+}
+
+func runDraftRevise(cmd *cobra.Command, args []string) error {
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	ctx := context.Background()
+
+	stream, err := client.RevisePlan(ctx, &pb.RevisePlanRequest{
+		DraftId:  args[0],
+		Feedback: strings.Join(args[1:], " "),
+	})
+	if err != nil {
+		return fmt.Errorf("修改计划失败: %w", err)
+	}
+	if _, err := recvPlanEvents(stream); err != nil {
+		return err
+	}
+
+	d, err := client.GetPlanDraft(ctx, &pb.GetPlanDraftRequest{Id: args[0]})
+	if err != nil {
+		return fmt.Errorf("获取计划草稿失败: %w", err)
+	}
+	return printPlanDraft(d)
+}
+
+func runDraftDelete(cmd *cobra.Command, args []string) error {
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	if _, err := client.DeletePlanDraft(context.Background(), &pb.DeletePlanDraftRequest{Id: args[0]}); err != nil {
+		return fmt.Errorf("删除草稿失败: %w", err)
+	}
+	fmt.Printf("草稿 %s 已删除\n", args[0])
+	return nil
+}
+
+// editDraft runs a draft edit RPC and prints the updated draft.
+func editDraft(edit func(pb.KeleServiceClient) (*pb.PlanDraftInfo, error)) error {
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	d, err := edit(pb.NewKeleServiceClient(conn))
+	if err != nil {
+		return fmt.Errorf("编辑草稿失败: %w", err)
+	}
+	return printPlanDraft(d)
+}
+
+func parseDraftPlan(d *pb.PlanDraftInfo) (*taskboard.PlanResult, error) {
+	var plan taskboard.PlanResult
+	if err := json.Unmarshal([]byte(d.PlanJson), &plan); err != nil {
+		return nil, fmt.Errorf("解析计划失败: %w", err)
+	}
+	return &plan, nil
+}
+
+// printPlanDraft renders a draft with its tasks grouped into dependency layers.
+func printPlanDraft(d *pb.PlanDraftInfo) error {
+	plan, err := parseDraftPlan(d)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("计划草稿: %s\n", d.Id)
+	if d.Goal != "" {
+		fmt.Printf("  目标:        %s\n", d.Goal)
+	}
+	fmt.Printf("  工作区:      %s\n", plan.WorkspaceName)
+	if plan.MaxConcurrent > 0 {
+		fmt.Printf("  并发数:      %d\n", plan.MaxConcurrent)
+	}
+	fmt.Printf("  任务数:      %d\n", len(plan.Tasks))
+
+	fmt.Printf("\n任务:\n")
+	for i, t := range plan.Tasks {
+		fmt.Printf("  #%-3d %s  [%s]\n", i+1, t.Title, priorityName(t.Priority))
+	}
+
+	levels, err := plan.Levels()
+	if err != nil {
+		fmt.Printf("\n⚠ 依赖关系有误: %v\n", err)
+		return nil
+	}
+	fmt.Printf("\n依赖图:\n")
+	for l, level := range levels {
+		fmt.Printf("  第 %d 层\n", l+1)
+		for _, i := range level {
+			t := plan.Tasks[i]
+			deps := ""
+			if len(t.DependsOn) > 0 {
+				depStrs := make([]string, len(t.DependsOn))
+				for j, dep := range t.DependsOn {
+					depStrs[j] = fmt.Sprintf("#%d", dep+1)
+				}
+				deps = "  ← " + strings.Join(depStrs, ", ")
+			}
+			fmt.Printf("    #%d %s%s\n", i+1, t.Title, deps)
+		}
+	}
+
+	if err := plan.Validate(); err != nil {
+		fmt.Printf("\n⚠ 计划无效: %v\n", err)
+	}
+	return nil
+}
+
+func priorityName(p int) string {
+	switch p {
+	case 0:
+		return "critical"
+	case 1:
+		return "high"
+	case 3:
+		return "low"
+	}
+	return "normal"
+}
+
+// parseTaskNumber converts a 1-based task number ("3" or "#3") to a 0-based index.
+func parseTaskNumber(s string) (int32, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(s), "#"))
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("无效的任务编号: %s", s)
+	}
+	return int32(n - 1), nil
+}
+
+func parseTaskNumbers(s string) ([]int32, error) {
+	var out []int32
+	for _, part := range strings.Split(s, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		n, err := parseTaskNumber(part)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/BlakeLiAFK/kele/internal/proto"
+	"github.com/BlakeLiAFK/kele/internal/taskboard"
+)
+
+func runBoardGraph(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	if format != "text" && format != "dot" {
+		return fmt.Errorf("不支持的格式: %s（可选 text, dot）", format)
+	}
+
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	ctx := context.Background()
+
+	ws, err := client.GetWorkspace(ctx, &pb.GetWorkspaceRequest{Id: args[0]})
+	if err != nil {
+		return fmt.Errorf("获取工作区失败: %w", err)
+	}
+	resp, err := client.ListTasks(ctx, &pb.ListTasksRequest{WorkspaceId: ws.Id})
+	if err != nil {
+		return fmt.Errorf("列出任务失败: %w", err)
+	}
+
+	tasks := make([]*taskboard.Task, len(resp.Tasks))
+	for i, t := range resp.Tasks {
+		tasks[i] = &taskboard.Task{
+			ID:            t.Id,
+			Title:         t.Title,
+			Status:        taskboard.TaskStatus(t.Status),
+			Priority:      int(t.Priority),
+			DependsOn:     t.DependsOn,
+			BlockedReason: t.BlockedReason,
+		}
+	}
+
+	if format == "dot" {
+		writeGraphDOT(os.Stdout, ws.Name, tasks)
+		return nil
+	}
+	return writeGraphText(os.Stdout, ws.Name, tasks)
+}
+
+// writeGraphText prints tasks layer by layer; each task lists the tasks it waits on.
+func writeGraphText(w io.Writer, name string, tasks []*taskboard.Task) error {
+	fmt.Fprintf(w, "工作区: %s  任务数: %d\n", name, len(tasks))
+	if len(tasks) == 0 {
+		return nil
+	}
+	levels, err := taskboard.TaskLevels(tasks)
+	if err != nil {
+		return fmt.Errorf("依赖关系有误: %w", err)
+	}
+	for l, level := range levels {
+		fmt.Fprintf(w, "\n第 %d 层\n", l+1)
+		for _, t := range level {
+			deps := ""
+			if len(t.DependsOn) > 0 {
+				deps = "  ← " + strings.Join(t.DependsOn, ", ")
+			}
+			fmt.Fprintf(w, "  %s [%s] %s (%s)%s\n", taskStatusIcon(string(t.Status)), t.ID, t.Title, t.Status, deps)
+			if t.BlockedReason != "" {
+				fmt.Fprintf(w, "      阻塞: %s\n", t.BlockedReason)
+			}
+		}
+	}
+	return nil
+}
+
+// writeGraphDOT prints the task graph in Graphviz DOT format, with edges
+// pointing from a dependency to the task that waits on it.
+func writeGraphDOT(w io.Writer, name string, tasks []*taskboard.Task) {
+	fmt.Fprintf(w, "digraph %s {\n", dotQuote(name))
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, `  node [shape=box, style="rounded,filled", fontname="sans-serif"];`)
+	for _, t := range tasks {
+		attrs := fmt.Sprintf("label=%s, fillcolor=%s",
+			dotQuote(fmt.Sprintf("%s\n%s (%s)", t.ID, t.Title, t.Status)),
+			dotQuote(dotStatusColor(t.Status)))
+		if t.BlockedReason != "" {
+			attrs += ", tooltip=" + dotQuote(t.BlockedReason)
+		}
+		fmt.Fprintf(w, "  %s [%s];\n", dotQuote(t.ID), attrs)
+	}
+	for _, t := range tasks {
+		for _, dep := range t.DependsOn {
+			fmt.Fprintf(w, "  %s -> %s;\n", dotQuote(dep), dotQuote(t.ID))
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+func dotStatusColor(status taskboard.TaskStatus) string {
+	switch status {
+	case taskboard.StatusDone:
+		return "#c8e6c9"
+	case taskboard.StatusRunning:
+		return "#bbdefb"
+	case taskboard.StatusReady:
+		return "#fff9c4"
+	case taskboard.StatusFailed:
+		return "#ffcdd2"
+	case taskboard.StatusBlocked:
+		return "#ffe0b2"
+	case taskboard.StatusCancelled:
+		return "#e0e0e0"
+	case taskboard.StatusReview:
+		return "#e1bee7"
+	}
+	return "#ffffff"
+}
+
+func taskStatusIcon(status string) string {
+	switch status {
+	case "done":
+		return "✓"
+	case "running":
+		return "●"
+	case "failed":
+		return "✗"
+	case "ready":
+		return "◌"
+	case "cancelled":
+		return "⊘"
+	case "blocked":
+		return "⊗"
+	case "review":
+		return "◐"
+	}
+	return " "
+}
@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/BlakeLiAFK/kele/internal/proto"
+)
+
+func newHeartbeatCmd() *cobra.Command {
+	heartbeatCmd := &cobra.Command{
+		Use:   "heartbeat",
+		Short: "心跳",
+		Long: `daemon 按计划把系统快照和 HEARTBEAT.md 交给模型，由模型决定是否调用工具执行检查或维护。
+执行间隔、静默时段、执行日和最多轮数可在配置中设置（heartbeat.*），也可写在 HEARTBEAT.md 开头的 frontmatter 中:
+  ---
+  interval: 30m
+  quiet_hours: 23:00-07:00
+  active_days: mon-fri
+  max_rounds: 8
+  ---`,
+	}
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "查看心跳状态",
+		RunE:  runHeartbeatStatus,
+	}
+
+	logCmd := &cobra.Command{
+		Use:   "log [id]",
+		Short: "查看心跳记录，指定 ID 时显示完整过程",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runHeartbeatLog,
+	}
+	logCmd.Flags().IntP("limit", "n", 20, "最多显示条数")
+
+	heartbeatCmd.AddCommand(statusCmd, logCmd)
+	return heartbeatCmd
+}
+
+func runHeartbeatStatus(cmd *cobra.Command, args []string) error {
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	st, err := client.GetHeartbeatStatus(context.Background(), &pb.Empty{})
+	if err != nil {
+		return fmt.Errorf("获取心跳状态失败: %w", err)
+	}
+
+	active := "运行中"
+	if !st.Active {
+		active = "未启用"
+	}
+	fmt.Printf("状态:      %s\n", active)
+	fmt.Printf("间隔:      %d 分钟\n", st.IntervalMinutes)
+	fmt.Printf("静默时段:  %s\n", orDash(st.QuietHours))
+	days := st.ActiveDays
+	if days == "" {
+		days = "每天"
+	}
+	fmt.Printf("执行日:    %s\n", days)
+	fmt.Printf("最多轮数:  %d\n", st.MaxRounds)
+	fmt.Printf("下次执行:  %s\n", orDash(st.NextRun))
+	fmt.Printf("上次执行:  %s\n", orDash(st.LastRun))
+	fmt.Printf("累计:      %d 次心跳，%d 次工具调用\n", st.TotalHeartbeats, st.ActionsTaken)
+	if st.LastDecision != "" {
+		fmt.Printf("上次结论:  %s\n", firstLine(st.LastDecision))
+	}
+	return nil
+}
+
+func runHeartbeatLog(cmd *cobra.Command, args []string) error {
+	limit, _ := cmd.Flags().GetInt("limit")
+	req := &pb.GetHeartbeatHistoryRequest{Limit: int32(limit)}
+	if len(args) > 0 {
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil || id <= 0 {
+			return fmt.Errorf("无效的记录 ID: %s", args[0])
+		}
+		req.Id = id
+	}
+
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	resp, err := client.GetHeartbeatHistory(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("获取心跳记录失败: %w", err)
+	}
+	if req.Id != 0 {
+		printHeartbeatRecord(resp.Records[0])
+		return nil
+	}
+	if len(resp.Records) == 0 {
+		fmt.Println("暂无心跳记录。")
+		return nil
+	}
+
+	fmt.Printf("%-6s %-20s %-10s %-6s %-6s %-8s %s\n", "ID", "时间", "状态", "轮数", "工具", "耗时", "结论")
+	fmt.Println("────────────────────────────────────────────────────────────────")
+	for _, rec := range resp.Records {
+		summary := rec.Decision
+		if rec.Error != "" {
+			summary = "错误: " + rec.Error
+		}
+		fmt.Printf("%-6d %-20s %-10s %-6d %-6d %-8s %s\n", rec.Id, rec.Timestamp, rec.Status, rec.Rounds, rec.ActionsTaken,
+			(time.Duration(rec.DurationMs) * time.Millisecond).Round(time.Second), truncate(firstLine(summary), 60))
+	}
+	return nil
+}
+
+// printHeartbeatRecord 显示一次心跳的完整过程
+func printHeartbeatRecord(rec *pb.HeartbeatRecord) {
+	fmt.Printf("心跳 #%d  %s  %s  %d 轮 / %d 次工具调用  耗时 %s\n", rec.Id, rec.Timestamp, rec.Status,
+		rec.Rounds, rec.ActionsTaken, time.Duration(rec.DurationMs)*time.Millisecond)
+	if rec.Error != "" {
+		fmt.Printf("错误: %s\n", rec.Error)
+	}
+	for _, m := range rec.Transcript {
+		fmt.Println("────────────────────────────────────────")
+		switch m.Role {
+		case "user":
+			fmt.Println("[提示词]")
+		case "assistant":
+			fmt.Println("[模型]")
+		case "tool":
+			fmt.Println("[工具结果]")
+		default:
+			fmt.Printf("[%s]\n", m.Role)
+		}
+		if content := strings.TrimSpace(m.Content); content != "" {
+			fmt.Println(content)
+		}
+		for _, call := range m.ToolCalls {
+			fmt.Printf("→ %s\n", call)
+		}
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/BlakeLiAFK/kele/internal/proto"
+)
+
+func newHistoryCmd() *cobra.Command {
+	historyCmd := &cobra.Command{
+		Use:   "history",
+		Short: "对话归档",
+		Long:  "检索所有会话（命令行、Telegram、任务）的历史对话。",
+	}
+
+	searchCmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "搜索历史对话",
+		Long: `按关键词搜索历史对话，多个关键词需全部出现。
+--since/--until 接受时长（30m、2h、7d）或本地日期时间（2006-01-02、2006-01-02 15:04）。`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runHistorySearch,
+	}
+	searchCmd.Flags().StringP("session", "s", "", "只搜索指定会话（ID 或名称）")
+	searchCmd.Flags().StringP("channel", "c", "", "只搜索指定渠道（cli、telegram、task）")
+	searchCmd.Flags().String("since", "", "只搜索此时间之后的消息")
+	searchCmd.Flags().String("until", "", "只搜索此时间之前的消息")
+	searchCmd.Flags().IntP("limit", "n", 20, "最多显示条数")
+
+	historyCmd.AddCommand(searchCmd)
+	return historyCmd
+}
+
+func runHistorySearch(cmd *cobra.Command, args []string) error {
+	session, _ := cmd.Flags().GetString("session")
+	channel, _ := cmd.Flags().GetString("channel")
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	untilFlag, _ := cmd.Flags().GetString("until")
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	req := &pb.SearchHistoryRequest{
+		Query:   strings.Join(args, " "),
+		Session: session,
+		Channel: channel,
+		Limit:   int32(limit),
+	}
+	if sinceFlag != "" {
+		since, err := parseSince(sinceFlag)
+		if err != nil {
+			return err
+		}
+		req.Since = since.Format(time.RFC3339)
+	}
+	if untilFlag != "" {
+		until, err := parseSince(untilFlag)
+		if err != nil {
+			return err
+		}
+		req.Until = until.Format(time.RFC3339)
+	}
+
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	resp, err := client.SearchHistory(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("搜索失败: %w", err)
+	}
+	if len(resp.Hits) == 0 {
+		fmt.Println("没有匹配的历史消息。")
+		return nil
+	}
+
+	for _, h := range resp.Hits {
+		session := h.SessionName
+		if session == "" {
+			session = h.SessionId
+		}
+		if h.Channel != "" {
+			session += " · " + h.Channel
+		}
+		role := "用户"
+		if h.Role == "assistant" {
+			role = "助手"
+		}
+		fmt.Printf("%s  [%s]  %s: %s\n", h.Timestamp, session, role, strings.Join(strings.Fields(h.Snippet), " "))
+	}
+	return nil
+}
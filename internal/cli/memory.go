@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/BlakeLiAFK/kele/internal/proto"
+)
+
+func newMemoryCmd() *cobra.Command {
+	memCmd := &cobra.Command{
+		Use:   "memory",
+		Short: "长期记忆管理",
+		Long:  "管理长期记忆：为语义检索重建向量索引。",
+	}
+
+	reindexCmd := &cobra.Command{
+		Use:   "reindex",
+		Short: "为长期记忆生成向量",
+		Long: `为缺少向量的记忆补齐向量（首次启用 memory.embedder 或更换嵌入模型后执行）。
+--all 重建全部向量。`,
+		Args: cobra.NoArgs,
+		RunE: runMemoryReindex,
+	}
+	reindexCmd.Flags().Bool("all", false, "重建全部向量，而不只是缺少或过期的")
+
+	memCmd.AddCommand(reindexCmd)
+	return memCmd
+}
+
+func runMemoryReindex(cmd *cobra.Command, args []string) error {
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	all, _ := cmd.Flags().GetBool("all")
+
+	resp, err := client.ReindexMemory(context.Background(), &pb.ReindexMemoryRequest{All: all})
+	if err != nil {
+		return fmt.Errorf("重建索引失败: %w", err)
+	}
+	fmt.Printf("已为 %d 条记忆生成向量（共 %d 条，嵌入器 %s）\n", resp.Indexed, resp.Total, resp.Embedder)
+	return nil
+}
@@ -50,6 +50,10 @@ func NewRootCmd() *cobra.Command {
 	rootCmd.AddCommand(newWorkspaceCmd())
 	rootCmd.AddCommand(newTaskCmd())
 	rootCmd.AddCommand(newConfigCmd())
+	rootCmd.AddCommand(newMemoryCmd())
+	rootCmd.AddCommand(newHistoryCmd())
+	rootCmd.AddCommand(newCronCmd())
+	rootCmd.AddCommand(newHeartbeatCmd())
 
 	return rootCmd
 }
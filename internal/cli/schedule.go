@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/BlakeLiAFK/kele/internal/proto"
+)
+
+func newWorkspaceScheduleCmd() *cobra.Command {
+	schedCmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "定时工作区",
+		Long:  "按 cron 表达式定时克隆模板工作区的任务并执行，运行结束后将报告发送到指定渠道。",
+	}
+
+	addCmd := &cobra.Command{
+		Use:   "add <template-id>",
+		Short: "为模板工作区添加定时运行",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runScheduleAdd,
+	}
+	addCmd.Flags().String("cron", "", "cron 表达式（分 时 日 月 周，如 \"0 9 * * 1\"）")
+	addCmd.Flags().String("channel", "", "运行报告发送渠道（如 telegram，默认不发送）")
+	addCmd.Flags().String("target", "", "渠道内的接收者（如 Telegram chat ID，默认使用渠道配置）")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "列出定时运行",
+		RunE:  runScheduleList,
+	}
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete <id>",
+		Short: "删除定时运行",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runScheduleDelete,
+	}
+
+	enableCmd := &cobra.Command{
+		Use:   "enable <id>",
+		Short: "启用定时运行",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runScheduleSetEnabled(true),
+	}
+
+	disableCmd := &cobra.Command{
+		Use:   "disable <id>",
+		Short: "停用定时运行",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runScheduleSetEnabled(false),
+	}
+
+	runCmd := &cobra.Command{
+		Use:   "run <id>",
+		Short: "立即运行一次",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runScheduleRun,
+	}
+
+	schedCmd.AddCommand(addCmd, listCmd, deleteCmd, enableCmd, disableCmd, runCmd)
+	return schedCmd
+}
+
+func runScheduleAdd(cmd *cobra.Command, args []string) error {
+	cronExpr, _ := cmd.Flags().GetString("cron")
+	channel, _ := cmd.Flags().GetString("channel")
+	target, _ := cmd.Flags().GetString("target")
+	if cronExpr == "" {
+		return fmt.Errorf("需要指定 --cron")
+	}
+
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	sc, err := client.CreateWorkspaceSchedule(context.Background(), &pb.CreateWorkspaceScheduleRequest{
+		TemplateId: args[0],
+		Cron:       cronExpr,
+		Channel:    channel,
+		Target:     target,
+	})
+	if err != nil {
+		return fmt.Errorf("添加定时运行失败: %w", err)
+	}
+	fmt.Printf("定时运行已添加: [%s] %s (%s)，下次运行: %s\n", sc.Id, sc.TemplateName, sc.Cron, sc.NextRun)
+	return nil
+}
+
+func runScheduleList(cmd *cobra.Command, args []string) error {
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	resp, err := client.ListWorkspaceSchedules(context.Background(), &pb.Empty{})
+	if err != nil {
+		return fmt.Errorf("列出定时运行失败: %w", err)
+	}
+	if len(resp.Schedules) == 0 {
+		fmt.Println("暂无定时运行。")
+		return nil
+	}
+
+	fmt.Printf("%-24s %-16s %-6s %-20s %-10s %s\n", "ID", "Cron", "启用", "下次运行", "渠道", "模板")
+	fmt.Println("────────────────────────────────────────────────────────────────")
+	for _, sc := range resp.Schedules {
+		enabled := "是"
+		if !sc.Enabled {
+			enabled = "否"
+		}
+		channel := sc.Channel
+		if channel == "" {
+			channel = "-"
+		}
+		fmt.Printf("%-24s %-16s %-6s %-20s %-10s %s\n", sc.Id, sc.Cron, enabled, sc.NextRun, channel, sc.TemplateName)
+		if sc.LastWorkspaceId != "" {
+			fmt.Printf("  上次运行: %s → %s\n", sc.LastRun, sc.LastWorkspaceId)
+		}
+		if sc.LastError != "" {
+			fmt.Printf("  错误: %s\n", sc.LastError)
+		}
+	}
+	return nil
+}
+
+func runScheduleDelete(cmd *cobra.Command, args []string) error {
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	if _, err := client.DeleteWorkspaceSchedule(context.Background(), &pb.WorkspaceScheduleRequest{Id: args[0]}); err != nil {
+		return fmt.Errorf("删除定时运行失败: %w", err)
+	}
+	fmt.Printf("定时运行 %s 已删除\n", args[0])
+	return nil
+}
+
+func runScheduleSetEnabled(enabled bool) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		conn, err := ensureDaemon()
+		if err != nil {
+			return fmt.Errorf("daemon 连接失败: %w", err)
+		}
+		defer conn.Close()
+
+		client := pb.NewKeleServiceClient(conn)
+		sc, err := client.SetWorkspaceScheduleEnabled(context.Background(), &pb.SetWorkspaceScheduleEnabledRequest{
+			Id:      args[0],
+			Enabled: enabled,
+		})
+		if err != nil {
+			return fmt.Errorf("更新定时运行失败: %w", err)
+		}
+		if sc.Enabled {
+			fmt.Printf("定时运行 %s 已启用，下次运行: %s\n", sc.Id, sc.NextRun)
+		} else {
+			fmt.Printf("定时运行 %s 已停用\n", sc.Id)
+		}
+		return nil
+	}
+}
+
+func runScheduleRun(cmd *cobra.Command, args []string) error {
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	ws, err := client.RunWorkspaceSchedule(context.Background(), &pb.WorkspaceScheduleRequest{Id: args[0]})
+	if err != nil {
+		return fmt.Errorf("运行失败: %w", err)
+	}
+	fmt.Printf("已创建工作区: [%s] %s (%d 个任务)\n", ws.Id, ws.Name, ws.TaskCount)
+	return nil
+}
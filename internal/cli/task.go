@@ -3,7 +3,9 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -31,6 +33,15 @@ func newTaskCmd() *cobra.Command {
 	createCmd.Flags().Int("max-retries", 1, "最大重试次数")
 	createCmd.Flags().StringSlice("tags", nil, "标签")
 	createCmd.Flags().Bool("auto-ready", false, "直接设为 ready 状态")
+	createCmd.Flags().String("verify-cmd", "", "验收命令（在任务工作目录执行，退出码 0 为通过）")
+	createCmd.Flags().String("verify-prompt", "", "验收标准（交给 LLM 审查）")
+	createCmd.Flags().Duration("timeout", 0, "单次执行超时（如 30m，默认使用工作区设置）")
+	createCmd.Flags().Bool("require-approval", false, "执行完成后进入 review，需人工审批")
+	createCmd.Flags().String("model", "", "执行模型（模型名，或 small/large 档位，默认使用当前模型）")
+	createCmd.Flags().Float64("temperature", 0, "采样温度（默认使用配置）")
+	createCmd.Flags().StringSlice("tools", nil, "允许使用的工具（逗号分隔，如 read,search,list,git，默认全部）")
+	createCmd.Flags().Int("max-tool-rounds", 0, "最大工具调用轮数（默认使用配置）")
+	createCmd.Flags().StringSlice("artifacts", nil, "执行完成后收集的产出文件（相对工作目录，支持通配符）")
 
 	listCmd := &cobra.Command{
 		Use:   "list",
@@ -76,7 +87,45 @@ func newTaskCmd() *cobra.Command {
 	}
 	logCmd.Flags().IntP("limit", "n", 100, "最多显示条数")
 
-	taskCmd.AddCommand(createCmd, listCmd, showCmd, startCmd, cancelCmd, retryCmd, logCmd)
+	diffCmd := &cobra.Command{
+		Use:   "diff <id>",
+		Short: "查看任务分支的改动",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runTaskDiff,
+	}
+
+	mergeCmd := &cobra.Command{
+		Use:   "merge <id>",
+		Short: "将任务分支合并到工作目录（fast-forward）",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runTaskMerge,
+	}
+
+	approveCmd := &cobra.Command{
+		Use:   "approve <id>",
+		Short: "审批通过 review 中的任务",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runTaskApprove,
+	}
+	approveCmd.Flags().String("feedback", "", "审阅意见（记录到任务日志）")
+
+	rejectCmd := &cobra.Command{
+		Use:   "reject <id>",
+		Short: "退回 review 中的任务，附带意见重新执行",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runTaskReject,
+	}
+	rejectCmd.Flags().String("feedback", "", "审阅意见（注入下一次执行的 prompt）")
+
+	artifactsCmd := &cobra.Command{
+		Use:   "artifacts <id> [name]",
+		Short: "列出任务产出物，指定名称时输出其内容",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE:  runTaskArtifacts,
+	}
+	artifactsCmd.Flags().StringP("output", "o", "", "将产出物保存到文件（默认输出到标准输出）")
+
+	taskCmd.AddCommand(createCmd, listCmd, showCmd, startCmd, cancelCmd, retryCmd, logCmd, diffCmd, mergeCmd, approveCmd, rejectCmd, artifactsCmd)
 	return taskCmd
 }
 
@@ -90,6 +139,19 @@ func runTaskCreate(cmd *cobra.Command, args []string) error {
 	maxRetries, _ := cmd.Flags().GetInt("max-retries")
 	tags, _ := cmd.Flags().GetStringSlice("tags")
 	autoReady, _ := cmd.Flags().GetBool("auto-ready")
+	verifyCmd, _ := cmd.Flags().GetString("verify-cmd")
+	verifyPrompt, _ := cmd.Flags().GetString("verify-prompt")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	requireApproval, _ := cmd.Flags().GetBool("require-approval")
+	model, _ := cmd.Flags().GetString("model")
+	allowedTools, _ := cmd.Flags().GetStringSlice("tools")
+	maxToolRounds, _ := cmd.Flags().GetInt("max-tool-rounds")
+	artifacts, _ := cmd.Flags().GetStringSlice("artifacts")
+	var temperature *float64
+	if cmd.Flags().Changed("temperature") {
+		v, _ := cmd.Flags().GetFloat64("temperature")
+		temperature = &v
+	}
 
 	if title == "" {
 		return fmt.Errorf("需要指定 --title")
@@ -124,6 +186,18 @@ func runTaskCreate(cmd *cobra.Command, args []string) error {
 		MaxRetries:  int32(maxRetries),
 		Tags:        tags,
 		AutoReady:   autoReady,
+
+		VerifyCommand:  verifyCmd,
+		VerifyPrompt:   verifyPrompt,
+		TimeoutSeconds: int64(timeout / time.Second),
+
+		RequiresApproval: requireApproval,
+
+		Model:         model,
+		Temperature:   temperature,
+		Tools:         allowedTools,
+		MaxToolRounds: int32(maxToolRounds),
+		Artifacts:     artifacts,
 	})
 	if err != nil {
 		return fmt.Errorf("创建任务失败: %w", err)
@@ -162,19 +236,7 @@ func runTaskList(cmd *cobra.Command, args []string) error {
 	fmt.Printf("%-16s %-10s %-5s %-30s %s\n", "ID", "状态", "优先级", "标题", "工作区")
 	fmt.Println("────────────────────────────────────────────────────────────────────────────")
 	for _, t := range resp.Tasks {
-		icon := " "
-		switch t.Status {
-		case "done":
-			icon = "✓"
-		case "running":
-			icon = "●"
-		case "failed":
-			icon = "✗"
-		case "ready":
-			icon = "◌"
-		case "cancelled":
-			icon = "⊘"
-		}
+		icon := taskStatusIcon(t.Status)
 		title := t.Title
 		if len(title) > 28 {
 			title = title[:28] + ".."
@@ -206,6 +268,12 @@ func runTaskShow(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  状态:        %s\n", t.Status)
 	fmt.Printf("  优先级:      %d\n", t.Priority)
 	fmt.Printf("  重试:        %d/%d\n", t.RetryCount, t.MaxRetries)
+	if t.TransientRetries > 0 {
+		fmt.Printf("  临时错误重试: %d\n", t.TransientRetries)
+	}
+	if t.NextAttemptAt != "" {
+		fmt.Printf("  下次重试:    %s\n", t.NextAttemptAt)
+	}
 	fmt.Printf("  创建时间:    %s\n", t.CreatedAt)
 	if t.StartedAt != "" {
 		fmt.Printf("  开始时间:    %s\n", t.StartedAt)
@@ -222,6 +290,33 @@ func runTaskShow(cmd *cobra.Command, args []string) error {
 	if t.Description != "" {
 		fmt.Printf("  描述:        %s\n", t.Description)
 	}
+	if t.Branch != "" {
+		fmt.Printf("  分支:        %s\n", t.Branch)
+	}
+	if t.MergeStatus != "" {
+		fmt.Printf("  合并状态:    %s\n", t.MergeStatus)
+	}
+	if t.TimeoutSeconds > 0 {
+		fmt.Printf("  超时:        %s\n", time.Duration(t.TimeoutSeconds)*time.Second)
+	}
+	if t.RequiresApproval {
+		fmt.Printf("  需要审批:    是\n")
+	}
+	if t.Model != "" {
+		fmt.Printf("  模型:        %s\n", t.Model)
+	}
+	if t.Temperature != nil {
+		fmt.Printf("  温度:        %.2f\n", *t.Temperature)
+	}
+	if len(t.Tools) > 0 {
+		fmt.Printf("  工具:        %s\n", strings.Join(t.Tools, ", "))
+	}
+	if t.MaxToolRounds > 0 {
+		fmt.Printf("  最大工具轮数: %d\n", t.MaxToolRounds)
+	}
+	if len(t.Artifacts) > 0 {
+		fmt.Printf("  产出物:      %s\n", strings.Join(t.Artifacts, ", "))
+	}
 
 	if t.Prompt != "" {
 		fmt.Printf("\nPrompt:\n")
@@ -241,8 +336,48 @@ func runTaskShow(cmd *cobra.Command, args []string) error {
 		fmt.Println(result)
 	}
 
+	if t.VerifyCommand != "" || t.VerifyPrompt != "" {
+		fmt.Printf("\n验收:\n")
+		if t.VerifyCommand != "" {
+			fmt.Printf("  命令:        %s\n", t.VerifyCommand)
+		}
+		if t.VerifyPrompt != "" {
+			fmt.Printf("  标准:        %s\n", t.VerifyPrompt)
+		}
+		verdict := t.Verdict
+		if verdict == "" {
+			verdict = "未验收"
+		}
+		fmt.Printf("  结论:        %s\n", verdict)
+		if t.VerifyNotes != "" {
+			notes := t.VerifyNotes
+			if len(notes) > 1000 {
+				notes = notes[:1000] + "..."
+			}
+			fmt.Println(notes)
+		}
+	}
+
+	if t.BlockedReason != "" {
+		fmt.Printf("\n阻塞原因: %s\n", t.BlockedReason)
+	}
+
+	if t.ReviewFeedback != "" {
+		fmt.Printf("\n审阅意见: %s\n", t.ReviewFeedback)
+	}
+	if t.Status == "review" {
+		fmt.Printf("\n等待审批: kele task approve %s 或 kele task reject %s --feedback \"...\"\n", t.Id, t.Id)
+	}
+
 	if t.Error != "" {
-		fmt.Printf("\n错误: %s\n", t.Error)
+		switch t.ErrorClass {
+		case "transient":
+			fmt.Printf("\n错误（临时）: %s\n", t.Error)
+		case "task":
+			fmt.Printf("\n错误（任务）: %s\n", t.Error)
+		default:
+			fmt.Printf("\n错误: %s\n", t.Error)
+		}
 	}
 
 	return nil
@@ -305,6 +440,93 @@ func runTaskRetry(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runTaskDiff(cmd *cobra.Command, args []string) error {
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	ctx := context.Background()
+
+	t, err := client.GetTask(ctx, &pb.GetTaskRequest{Id: args[0]})
+	if err != nil {
+		return fmt.Errorf("获取任务失败: %w", err)
+	}
+
+	if t.Diff == "" {
+		fmt.Println("该任务没有代码改动。")
+		return nil
+	}
+	fmt.Print(t.Diff)
+	return nil
+}
+
+func runTaskMerge(cmd *cobra.Command, args []string) error {
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	ctx := context.Background()
+
+	t, err := client.MergeTask(ctx, &pb.MergeTaskRequest{Id: args[0]})
+	if err != nil {
+		return fmt.Errorf("合并任务失败: %w", err)
+	}
+
+	fmt.Printf("任务 %s 已合并 (分支: %s)\n", t.Title, t.Branch)
+	return nil
+}
+
+func runTaskApprove(cmd *cobra.Command, args []string) error {
+	feedback, _ := cmd.Flags().GetString("feedback")
+
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	ctx := context.Background()
+
+	t, err := client.ApproveTask(ctx, &pb.ReviewTaskRequest{Id: args[0], Feedback: feedback})
+	if err != nil {
+		return fmt.Errorf("审批任务失败: %w", err)
+	}
+
+	fmt.Printf("任务 %s 已审批通过\n", t.Title)
+	if t.MergeStatus == "pending" {
+		fmt.Printf("分支 %s 待合并: kele task merge %s\n", t.Branch, t.Id)
+	}
+	return nil
+}
+
+func runTaskReject(cmd *cobra.Command, args []string) error {
+	feedback, _ := cmd.Flags().GetString("feedback")
+
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	ctx := context.Background()
+
+	t, err := client.RejectTask(ctx, &pb.ReviewTaskRequest{Id: args[0], Feedback: feedback})
+	if err != nil {
+		return fmt.Errorf("退回任务失败: %w", err)
+	}
+
+	fmt.Printf("任务 %s 已退回，将带着审阅意见重新执行\n", t.Title)
+	return nil
+}
+
 func runTaskLog(cmd *cobra.Command, args []string) error {
 	limit, _ := cmd.Flags().GetInt("limit")
 
@@ -343,6 +565,14 @@ func runTaskLog(cmd *cobra.Command, args []string) error {
 			prefix = fmt.Sprintf("[结果] %s", entry.ToolName)
 		case "error":
 			prefix = "[错误]"
+		case "verify":
+			prefix = fmt.Sprintf("[验收] %s", entry.ToolName)
+		case "verdict":
+			prefix = "[结论]"
+		case "artifact":
+			prefix = "[产出]"
+		case "attempt":
+			prefix = "[执行]"
 		default:
 			prefix = fmt.Sprintf("[%s]", entry.EventType)
 		}
@@ -355,3 +585,46 @@ func runTaskLog(cmd *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+func runTaskArtifacts(cmd *cobra.Command, args []string) error {
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	ctx := context.Background()
+
+	if len(args) == 2 {
+		output, _ := cmd.Flags().GetString("output")
+		a, err := client.GetTaskArtifact(ctx, &pb.GetTaskArtifactRequest{TaskId: args[0], Name: args[1]})
+		if err != nil {
+			return fmt.Errorf("获取产出物失败: %w", err)
+		}
+		if output == "" {
+			_, err = os.Stdout.Write(a.Content)
+			return err
+		}
+		if err := os.WriteFile(output, a.Content, 0o644); err != nil {
+			return fmt.Errorf("保存产出物失败: %w", err)
+		}
+		fmt.Printf("已保存 %s 到 %s（%d 字节）\n", a.Info.Name, output, a.Info.Size)
+		return nil
+	}
+
+	resp, err := client.ListTaskArtifacts(ctx, &pb.GetTaskRequest{Id: args[0]})
+	if err != nil {
+		return fmt.Errorf("获取产出物失败: %w", err)
+	}
+	if len(resp.Artifacts) == 0 {
+		fmt.Println("暂无产出物。")
+		return nil
+	}
+	fmt.Printf("%-32s %-8s %10s  %s\n", "名称", "类型", "大小", "创建时间")
+	for _, a := range resp.Artifacts {
+		fmt.Printf("%-32s %-8s %10d  %s\n", a.Name, a.Kind, a.Size, a.CreatedAt)
+	}
+	fmt.Printf("\n查看内容: kele task artifacts %s <name> [-o file]\n", args[0])
+	return nil
+}
@@ -3,7 +3,10 @@ package cli
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -28,6 +31,10 @@ func newWorkspaceCmd() *cobra.Command {
 	createCmd.Flags().StringP("context", "x", "", "工作区上下文（注入 system prompt）")
 	createCmd.Flags().StringP("goal", "g", "", "工作区目标")
 	createCmd.Flags().StringP("work-dir", "d", "", "工作目录")
+	createCmd.Flags().String("merge", "manual", "任务分支合并策略 (manual, auto)")
+	createCmd.Flags().Duration("task-timeout", 0, "每个任务单次执行的超时（如 1h，0 表示不限制）")
+	createCmd.Flags().StringSlice("approval-tags", nil, "带有这些标签的任务完成后需人工审批")
+	addBudgetFlags(createCmd)
 
 	listCmd := &cobra.Command{
 		Use:   "list",
@@ -70,7 +77,43 @@ func newWorkspaceCmd() *cobra.Command {
 		RunE:  runWorkspaceSummary,
 	}
 
-	wsCmd.AddCommand(createCmd, listCmd, showCmd, pauseCmd, resumeCmd, deleteCmd, summaryCmd)
+	budgetCmd := &cobra.Command{
+		Use:   "budget <id>",
+		Short: "修改工作区预算（超出预算时工作区自动暂停）",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runWorkspaceBudget,
+	}
+	addBudgetFlags(budgetCmd)
+
+	templateCmd := &cobra.Command{
+		Use:   "template <id>",
+		Short: "将工作区转为模板（不再执行，供定时任务克隆）",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runWorkspaceTemplate,
+	}
+
+	exportCmd := &cobra.Command{
+		Use:   "export <id>",
+		Short: "导出工作区为计划文件（YAML/JSON，可分享或提交到仓库）",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runWorkspaceExport,
+	}
+	exportCmd.Flags().StringP("output", "o", "", "保存到文件（默认输出到标准输出）")
+	exportCmd.Flags().String("format", "", "文件格式 (yaml, json)，默认按 -o 的扩展名，否则为 yaml")
+	exportCmd.Flags().Bool("with-results", false, "包含任务状态、结果和完成报告")
+	exportCmd.Flags().Int("logs", 0, "每个任务包含的日志条数（0 表示不包含）")
+
+	importCmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "从计划文件创建工作区（- 表示标准输入）",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runWorkspaceImport,
+	}
+	importCmd.Flags().StringArray("var", nil, "模板变量，填充计划文件中的 {{name}}（格式 name=value，可重复）")
+	importCmd.Flags().StringP("work-dir", "d", "", "工作目录（覆盖计划文件中的 work_dir）")
+	importCmd.Flags().Bool("paused", false, "创建后暂停，不立即执行")
+
+	wsCmd.AddCommand(createCmd, listCmd, showCmd, pauseCmd, resumeCmd, deleteCmd, summaryCmd, budgetCmd, templateCmd, exportCmd, importCmd, newWorkspaceScheduleCmd())
 	return wsCmd
 }
 
@@ -80,6 +123,9 @@ func runWorkspaceCreate(cmd *cobra.Command, args []string) error {
 	ctx_, _ := cmd.Flags().GetString("context")
 	goal, _ := cmd.Flags().GetString("goal")
 	workDir, _ := cmd.Flags().GetString("work-dir")
+	mergePolicy, _ := cmd.Flags().GetString("merge")
+	taskTimeout, _ := cmd.Flags().GetDuration("task-timeout")
+	approvalTags, _ := cmd.Flags().GetStringSlice("approval-tags")
 
 	if workDir == "" {
 		workDir, _ = os.Getwd()
@@ -100,6 +146,11 @@ func runWorkspaceCreate(cmd *cobra.Command, args []string) error {
 		MaxConcurrent: int32(maxConcurrent),
 		Context:       ctx_,
 		WorkDir:       workDir,
+		MergePolicy:   mergePolicy,
+
+		TaskTimeoutSeconds: int64(taskTimeout / time.Second),
+		ApprovalTags:       approvalTags,
+		Budget:             budgetFromFlags(cmd, &pb.BudgetInfo{}),
 	})
 	if err != nil {
 		return fmt.Errorf("创建工作区失败: %w", err)
@@ -160,6 +211,20 @@ func runWorkspaceShow(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  并发数:      %d\n", ws.MaxConcurrent)
 	fmt.Printf("  任务数:      %d (运行中: %d)\n", ws.TaskCount, ws.RunningCount)
 	fmt.Printf("  工作目录:    %s\n", ws.WorkDir)
+	fmt.Printf("  合并策略:    %s\n", ws.MergePolicy)
+	if ws.TaskTimeoutSeconds > 0 {
+		fmt.Printf("  任务超时:    %s\n", time.Duration(ws.TaskTimeoutSeconds)*time.Second)
+	}
+	if len(ws.ApprovalTags) > 0 {
+		fmt.Printf("  审批标签:    %s\n", strings.Join(ws.ApprovalTags, ", "))
+	}
+	if b := ws.Budget; b != nil && hasBudget(b) {
+		fmt.Printf("  预算:\n")
+		printBudget(b, "    ")
+	}
+	if ws.ScheduleId != "" {
+		fmt.Printf("  定时运行:    %s\n", ws.ScheduleId)
+	}
 	fmt.Printf("  创建时间:    %s\n", ws.CreatedAt)
 	if ws.Description != "" {
 		fmt.Printf("  描述:        %s\n", ws.Description)
@@ -173,19 +238,7 @@ func runWorkspaceShow(cmd *cobra.Command, args []string) error {
 	if err == nil && len(tasks.Tasks) > 0 {
 		fmt.Printf("\n任务列表:\n")
 		for _, t := range tasks.Tasks {
-			icon := " "
-			switch t.Status {
-			case "done":
-				icon = "✓"
-			case "running":
-				icon = "●"
-			case "failed":
-				icon = "✗"
-			case "ready":
-				icon = "◌"
-			case "cancelled":
-				icon = "⊘"
-			}
+			icon := taskStatusIcon(t.Status)
 			fmt.Printf("  %s [%s] %s  (优先级:%d, 状态:%s)\n",
 				icon, t.Id, t.Title, t.Priority, t.Status)
 		}
@@ -262,3 +315,195 @@ func runWorkspaceSummary(cmd *cobra.Command, args []string) error {
 	fmt.Println(ws.Summary)
 	return nil
 }
+
+func addBudgetFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64("max-tokens", 0, "Token 预算（所有任务合计，0 表示不限制）")
+	cmd.Flags().Float64("max-cost", 0, "费用预算（美元，按 --token-price 估算）")
+	cmd.Flags().Float64("token-price", 0, "每百万 Token 的价格（美元），用于估算费用")
+	cmd.Flags().Duration("max-duration", 0, "运行时长预算（从第一个任务开始计时，如 8h）")
+	cmd.Flags().Int("max-tool-calls", 0, "单个任务每次执行最多的工具调用次数")
+}
+
+// budgetFromFlags applies the budget flags given on the command line to b.
+func budgetFromFlags(cmd *cobra.Command, b *pb.BudgetInfo) *pb.BudgetInfo {
+	f := cmd.Flags()
+	if f.Changed("max-tokens") {
+		b.MaxTokens, _ = f.GetInt64("max-tokens")
+	}
+	if f.Changed("max-cost") {
+		b.MaxCost, _ = f.GetFloat64("max-cost")
+	}
+	if f.Changed("token-price") {
+		b.TokenPrice, _ = f.GetFloat64("token-price")
+	}
+	if f.Changed("max-duration") {
+		d, _ := f.GetDuration("max-duration")
+		b.MaxDurationSeconds = int64(d / time.Second)
+	}
+	if f.Changed("max-tool-calls") {
+		n, _ := f.GetInt("max-tool-calls")
+		b.MaxToolCalls = int32(n)
+	}
+	return b
+}
+
+func hasBudget(b *pb.BudgetInfo) bool {
+	return b.MaxTokens > 0 || b.MaxCost > 0 || b.MaxDurationSeconds > 0 || b.MaxToolCalls > 0
+}
+
+// printBudget prints each limit that is set next to its usage.
+func printBudget(b *pb.BudgetInfo, indent string) {
+	if b.MaxTokens > 0 {
+		fmt.Printf("%sToken:     %d / %d\n", indent, b.UsedTokens, b.MaxTokens)
+	}
+	if b.MaxCost > 0 {
+		fmt.Printf("%s费用:      $%.2f / $%.2f (每百万 Token $%.2f)\n", indent, b.UsedCost, b.MaxCost, b.TokenPrice)
+	}
+	if b.MaxDurationSeconds > 0 {
+		fmt.Printf("%s时长:      %s / %s\n", indent,
+			time.Duration(b.ElapsedSeconds)*time.Second, time.Duration(b.MaxDurationSeconds)*time.Second)
+	}
+	if b.MaxToolCalls > 0 {
+		fmt.Printf("%s工具调用:  %d 次/任务 (已用 %d)\n", indent, b.MaxToolCalls, b.UsedToolCalls)
+	}
+	if b.Exceeded != "" {
+		fmt.Printf("%s已超出:    %s\n", indent, b.Exceeded)
+	}
+}
+
+func runWorkspaceBudget(cmd *cobra.Command, args []string) error {
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	ctx := context.Background()
+
+	ws, err := client.GetWorkspace(ctx, &pb.GetWorkspaceRequest{Id: args[0]})
+	if err != nil {
+		return fmt.Errorf("获取工作区失败: %w", err)
+	}
+	budget := ws.Budget
+	if budget == nil {
+		budget = &pb.BudgetInfo{}
+	}
+	ws, err = client.UpdateWorkspace(ctx, &pb.UpdateWorkspaceRequest{
+		Id:     ws.Id,
+		Budget: budgetFromFlags(cmd, budget),
+	})
+	if err != nil {
+		return fmt.Errorf("更新工作区失败: %w", err)
+	}
+
+	fmt.Printf("工作区 %s 预算已更新\n", ws.Name)
+	if hasBudget(ws.Budget) {
+		printBudget(ws.Budget, "  ")
+	}
+	if ws.Status == "paused" {
+		fmt.Printf("工作区处于暂停状态，恢复执行: kele workspace resume %s\n", ws.Id)
+	}
+	return nil
+}
+
+func runWorkspaceTemplate(cmd *cobra.Command, args []string) error {
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	ws, err := client.MakeWorkspaceTemplate(context.Background(), &pb.GetWorkspaceRequest{Id: args[0]})
+	if err != nil {
+		return fmt.Errorf("转为模板失败: %w", err)
+	}
+	fmt.Printf("工作区 %s 已转为模板，添加定时运行: kele workspace schedule add %s --cron \"0 9 * * 1\"\n", ws.Name, ws.Id)
+	return nil
+}
+
+func runWorkspaceExport(cmd *cobra.Command, args []string) error {
+	output, _ := cmd.Flags().GetString("output")
+	format, _ := cmd.Flags().GetString("format")
+	withResults, _ := cmd.Flags().GetBool("with-results")
+	logLimit, _ := cmd.Flags().GetInt("logs")
+	if format == "" && strings.HasSuffix(strings.ToLower(output), ".json") {
+		format = "json"
+	}
+
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	resp, err := client.ExportWorkspace(context.Background(), &pb.ExportWorkspaceRequest{
+		Id:          args[0],
+		Format:      format,
+		WithResults: withResults,
+		LogLimit:    int32(logLimit),
+	})
+	if err != nil {
+		return fmt.Errorf("导出工作区失败: %w", err)
+	}
+	if output == "" {
+		_, err = os.Stdout.Write(resp.Data)
+		return err
+	}
+	if err := os.WriteFile(output, resp.Data, 0o644); err != nil {
+		return fmt.Errorf("保存计划文件失败: %w", err)
+	}
+	fmt.Printf("工作区已导出到 %s，导入: kele workspace import %s\n", output, output)
+	return nil
+}
+
+func runWorkspaceImport(cmd *cobra.Command, args []string) error {
+	varFlags, _ := cmd.Flags().GetStringArray("var")
+	workDir, _ := cmd.Flags().GetString("work-dir")
+	paused, _ := cmd.Flags().GetBool("paused")
+
+	vars := make(map[string]string, len(varFlags))
+	for _, kv := range varFlags {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("无效的变量 %q，格式应为 name=value", kv)
+		}
+		vars[name] = value
+	}
+
+	var data []byte
+	var err error
+	if args[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("读取计划文件失败: %w", err)
+	}
+
+	conn, err := ensureDaemon()
+	if err != nil {
+		return fmt.Errorf("daemon 连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewKeleServiceClient(conn)
+	ws, err := client.ImportWorkspace(context.Background(), &pb.ImportWorkspaceRequest{
+		Data:    data,
+		Vars:    vars,
+		WorkDir: workDir,
+		Paused:  paused,
+	})
+	if err != nil {
+		return fmt.Errorf("导入工作区失败: %w", err)
+	}
+
+	fmt.Printf("工作区已导入: %s (ID: %s, 任务: %d)\n", ws.Name, ws.Id, ws.TaskCount)
+	if paused {
+		fmt.Printf("工作区处于暂停状态，开始执行: kele workspace resume %s\n", ws.Id)
+	}
+	return nil
+}
@@ -12,12 +12,14 @@ const Version = "0.4.0"
 
 // Config 全局配置
 type Config struct {
-	LLM      LLMConfig
-	Tools    ToolsConfig
-	Memory   MemoryConfig
-	TUI      TUIConfig
-	Cron     CronConfig
-	Telegram TelegramConfig
+	LLM       LLMConfig
+	Tools     ToolsConfig
+	Memory    MemoryConfig
+	TUI       TUIConfig
+	Cron      CronConfig
+	Heartbeat HeartbeatConfig
+	TaskBoard TaskBoardConfig
+	Telegram  TelegramConfig
 
 	// 全局选项
 	Debug      bool
@@ -67,6 +69,11 @@ type MemoryConfig struct {
 	MemoryFile string
 	SessionDir string
 	AuditLog   string
+
+	AutoExtract    bool   // 对话结束时用小模型提取候选记忆，等待用户确认
+	Embedder       string // 语义检索嵌入器: none, ollama, openai, hash
+	EmbeddingModel string // 嵌入模型，空则使用嵌入器默认模型
+	InjectTokens   int    // 每轮注入 system prompt 的记忆 token 预算
 }
 
 // TUIConfig TUI 配置
@@ -80,6 +87,27 @@ type CronConfig struct {
 	JobTimeout    int // 秒
 	LogRetention  int
 	MaxConcurrent int
+	CatchUp       string // 任务未单独设置时的补跑策略: none / once / all
+	CatchUpWindow int    // 小时，只补跑这段时间内错过的执行，0 = 不限
+}
+
+// HeartbeatConfig 心跳配置，HEARTBEAT.md 的 frontmatter 可覆盖除 Enabled 外的各项
+type HeartbeatConfig struct {
+	Enabled    bool
+	Interval   int    // 分钟
+	QuietHours string // 不执行心跳的时段，如 23:00-07:00，none = 不设
+	ActiveDays string // 执行心跳的星期，如 mon-fri，空 = 每天
+	MaxRounds  int    // 每次心跳最多调用模型的轮数
+}
+
+// TaskBoardConfig 任务看板调度配置
+type TaskBoardConfig struct {
+	MaxConcurrent int // 所有工作区同时运行的任务上限，0 = 只受各工作区限制
+	AgingMinutes  int // 就绪任务每等待多少分钟提升一级优先级，0 = 不老化
+
+	RetryBackoff        int // 首次重试前等待的秒数，之后每次翻倍，0 = 立即重试
+	RetryMaxBackoff     int // 重试等待上限（秒）
+	MaxTransientRetries int // 临时错误（限流、网络）的重试次数，不占用任务的重试次数
 }
 
 // DefaultDangerousCommands 默认危险命令列表
@@ -118,6 +146,8 @@ func Load() *Config {
 			MemoryFile: getEnv("KELE_MEMORY_FILE", filepath.Join(keleDir(), "MEMORY.md")),
 			SessionDir: getEnv("KELE_SESSION_DIR", filepath.Join(keleDir(), "sessions")),
 			AuditLog:   getEnv("KELE_AUDIT_LOG", filepath.Join(keleDir(), "audit.log")),
+
+			InjectTokens: 600,
 		},
 		TUI: TUIConfig{
 			MaxSessions:   9,
@@ -127,6 +157,22 @@ func Load() *Config {
 			JobTimeout:    300,
 			LogRetention:  50,
 			MaxConcurrent: 5,
+			CatchUp:       "none",
+			CatchUpWindow: 24,
+		},
+		Heartbeat: HeartbeatConfig{
+			Enabled:    true,
+			Interval:   15,
+			QuietHours: "23:00-07:00",
+			MaxRounds:  5,
+		},
+		TaskBoard: TaskBoardConfig{
+			MaxConcurrent: 6,
+			AgingMinutes:  10,
+
+			RetryBackoff:        30,
+			RetryMaxBackoff:     1800,
+			MaxTransientRetries: 5,
 		},
 	}
 
@@ -234,6 +280,72 @@ func applyEnvOverrides(cfg *Config) {
 			cfg.Cron.MaxConcurrent = n
 		}
 	}
+	if v := os.Getenv("KELE_CRON_CATCH_UP"); v != "" {
+		cfg.Cron.CatchUp = v
+	}
+	if v := os.Getenv("KELE_CRON_CATCH_UP_WINDOW"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Cron.CatchUpWindow = n
+		}
+	}
+
+	// Heartbeat
+	if v := os.Getenv("KELE_HEARTBEAT_ENABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Heartbeat.Enabled = b
+		}
+	}
+	if v := os.Getenv("KELE_HEARTBEAT_INTERVAL"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Heartbeat.Interval = n
+		}
+	}
+	if v := os.Getenv("KELE_HEARTBEAT_QUIET_HOURS"); v != "" {
+		cfg.Heartbeat.QuietHours = v
+	}
+	if v := os.Getenv("KELE_HEARTBEAT_ACTIVE_DAYS"); v != "" {
+		cfg.Heartbeat.ActiveDays = v
+	}
+	if v := os.Getenv("KELE_HEARTBEAT_MAX_ROUNDS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Heartbeat.MaxRounds = n
+		}
+	}
+
+	// Memory
+	if v := os.Getenv("KELE_MEMORY_AUTO_EXTRACT"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Memory.AutoExtract = b
+		}
+	}
+	if v := os.Getenv("KELE_MEMORY_EMBEDDER"); v != "" {
+		cfg.Memory.Embedder = v
+	}
+	if v := os.Getenv("KELE_EMBEDDING_MODEL"); v != "" {
+		cfg.Memory.EmbeddingModel = v
+	}
+	if v := os.Getenv("KELE_MEMORY_INJECT_TOKENS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Memory.InjectTokens = n
+		}
+	}
+
+	// TaskBoard
+	if v := os.Getenv("KELE_TASKBOARD_MAX_CONCURRENT"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.TaskBoard.MaxConcurrent = n
+		}
+	}
+	if v := os.Getenv("KELE_TASKBOARD_AGING_MINUTES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.TaskBoard.AgingMinutes = n
+		}
+	}
+	if v := os.Getenv("KELE_TASKBOARD_RETRY_BACKOFF"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.TaskBoard.RetryBackoff = n
+		}
+	}
 }
 
 // ApplyFlags 应用 CLI 参数覆盖
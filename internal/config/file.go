@@ -155,10 +155,32 @@ func ApplyToConfig(cfg *Config) {
 	applyInt(entries, "tui.max_sessions", &cfg.TUI.MaxSessions)
 	applyInt(entries, "tui.max_input_chars", &cfg.TUI.MaxInputChars)
 
+	// Memory
+	applyBool(entries, "memory.auto_extract", &cfg.Memory.AutoExtract)
+	applyStr(entries, "memory.embedder", &cfg.Memory.Embedder)
+	applyStr(entries, "memory.embedding_model", &cfg.Memory.EmbeddingModel)
+	applyInt(entries, "memory.inject_tokens", &cfg.Memory.InjectTokens)
+
 	// Cron
 	applyInt(entries, "cron.job_timeout", &cfg.Cron.JobTimeout)
 	applyInt(entries, "cron.log_retention", &cfg.Cron.LogRetention)
 	applyInt(entries, "cron.max_concurrent", &cfg.Cron.MaxConcurrent)
+	applyStr(entries, "cron.catch_up", &cfg.Cron.CatchUp)
+	applyInt(entries, "cron.catch_up_window", &cfg.Cron.CatchUpWindow)
+
+	// Heartbeat
+	applyBool(entries, "heartbeat.enabled", &cfg.Heartbeat.Enabled)
+	applyInt(entries, "heartbeat.interval", &cfg.Heartbeat.Interval)
+	applyStr(entries, "heartbeat.quiet_hours", &cfg.Heartbeat.QuietHours)
+	applyStr(entries, "heartbeat.active_days", &cfg.Heartbeat.ActiveDays)
+	applyInt(entries, "heartbeat.max_rounds", &cfg.Heartbeat.MaxRounds)
+
+	// TaskBoard
+	applyInt(entries, "taskboard.max_concurrent", &cfg.TaskBoard.MaxConcurrent)
+	applyInt(entries, "taskboard.aging_minutes", &cfg.TaskBoard.AgingMinutes)
+	applyInt(entries, "taskboard.retry_backoff", &cfg.TaskBoard.RetryBackoff)
+	applyInt(entries, "taskboard.retry_max_backoff", &cfg.TaskBoard.RetryMaxBackoff)
+	applyInt(entries, "taskboard.max_transient_retries", &cfg.TaskBoard.MaxTransientRetries)
 
 	// Telegram
 	applyStr(entries, "telegram.bot_token", &cfg.Telegram.BotToken)
@@ -204,6 +226,14 @@ func applyInt64(entries map[string]string, key string, target *int64) {
 	}
 }
 
+func applyBool(entries map[string]string, key string, target *bool) {
+	if v, ok := entries[key]; ok {
+		if b, err := strconv.ParseBool(v); err == nil {
+			*target = b
+		}
+	}
+}
+
 func applyFloat(entries map[string]string, key string, target *float64) {
 	if v, ok := entries[key]; ok {
 		if f, err := strconv.ParseFloat(v, 64); err == nil {
@@ -238,10 +268,32 @@ func AllSettings(cfg *Config) map[string]string {
 		"tui.max_sessions":   strconv.Itoa(cfg.TUI.MaxSessions),
 		"tui.max_input_chars": strconv.Itoa(cfg.TUI.MaxInputChars),
 
+		// Memory
+		"memory.auto_extract":    strconv.FormatBool(cfg.Memory.AutoExtract),
+		"memory.embedder":        cfg.Memory.Embedder,
+		"memory.embedding_model": cfg.Memory.EmbeddingModel,
+		"memory.inject_tokens":   strconv.Itoa(cfg.Memory.InjectTokens),
+
 		// Cron
-		"cron.job_timeout":    strconv.Itoa(cfg.Cron.JobTimeout),
-		"cron.log_retention":  strconv.Itoa(cfg.Cron.LogRetention),
-		"cron.max_concurrent": strconv.Itoa(cfg.Cron.MaxConcurrent),
+		"cron.job_timeout":     strconv.Itoa(cfg.Cron.JobTimeout),
+		"cron.log_retention":   strconv.Itoa(cfg.Cron.LogRetention),
+		"cron.max_concurrent":  strconv.Itoa(cfg.Cron.MaxConcurrent),
+		"cron.catch_up":        cfg.Cron.CatchUp,
+		"cron.catch_up_window": strconv.Itoa(cfg.Cron.CatchUpWindow),
+
+		// Heartbeat
+		"heartbeat.enabled":     strconv.FormatBool(cfg.Heartbeat.Enabled),
+		"heartbeat.interval":    strconv.Itoa(cfg.Heartbeat.Interval),
+		"heartbeat.quiet_hours": cfg.Heartbeat.QuietHours,
+		"heartbeat.active_days": cfg.Heartbeat.ActiveDays,
+		"heartbeat.max_rounds":  strconv.Itoa(cfg.Heartbeat.MaxRounds),
+
+		// TaskBoard
+		"taskboard.max_concurrent":        strconv.Itoa(cfg.TaskBoard.MaxConcurrent),
+		"taskboard.aging_minutes":         strconv.Itoa(cfg.TaskBoard.AgingMinutes),
+		"taskboard.retry_backoff":         strconv.Itoa(cfg.TaskBoard.RetryBackoff),
+		"taskboard.retry_max_backoff":     strconv.Itoa(cfg.TaskBoard.RetryMaxBackoff),
+		"taskboard.max_transient_retries": strconv.Itoa(cfg.TaskBoard.MaxTransientRetries),
 
 		// Telegram
 		"telegram.bot_token":   maskSecret(cfg.Telegram.BotToken),
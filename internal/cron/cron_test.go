@@ -1,8 +1,11 @@
 package cron
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -226,6 +229,78 @@ func TestShortcuts(t *testing.T) {
 	}
 }
 
+func TestParseSeconds(t *testing.T) {
+	expr, err := Parse("*/15 30 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !expr.Matches(time.Date(2026, 2, 7, 9, 30, 45, 0, time.Local)) {
+		t.Error("应匹配 09:30:45")
+	}
+	if expr.Matches(time.Date(2026, 2, 7, 9, 30, 10, 0, time.Local)) {
+		t.Error("不应匹配 09:30:10")
+	}
+
+	next := expr.NextAfter(time.Date(2026, 2, 7, 9, 30, 46, 0, time.Local))
+	if want := time.Date(2026, 2, 8, 9, 30, 0, 0, time.Local); !next.Equal(want) {
+		t.Errorf("NextAfter 期望 %v, 得到 %v", want, next)
+	}
+	next = expr.NextAfter(time.Date(2026, 2, 7, 9, 30, 16, 0, time.Local))
+	if want := time.Date(2026, 2, 7, 9, 30, 30, 0, time.Local); !next.Equal(want) {
+		t.Errorf("NextAfter 期望 %v, 得到 %v", want, next)
+	}
+
+	if _, err := Parse("60 * * * * *"); err == nil {
+		t.Error("秒字段越界应返回错误")
+	}
+}
+
+func TestParseEvery(t *testing.T) {
+	sched, err := ParseSchedule("@every 90s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := time.Date(2026, 2, 7, 12, 0, 0, 500, time.Local)
+	if next := sched.Next(base); !next.Equal(time.Date(2026, 2, 7, 12, 1, 30, 0, time.Local)) {
+		t.Errorf("@every 90s 的下一次执行不正确: %v", next)
+	}
+
+	if _, err := ParseSchedule("0 9 * * *"); err != nil {
+		t.Errorf("ParseSchedule 应支持 cron 表达式: %v", err)
+	}
+	for _, bad := range []string{"@every", "@every abc", "@every 500ms"} {
+		if _, err := ParseSchedule(bad); err == nil {
+			t.Errorf("ParseSchedule(%q) 应返回错误", bad)
+		}
+	}
+}
+
+func TestDayOfMonthOrDayOfWeek(t *testing.T) {
+	// 每月 1 号和每个周一
+	expr, err := Parse("0 9 1 * 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := time.Date(2026, 2, 1, 9, 0, 0, 0, time.Local)  // 周日
+	monday := time.Date(2026, 2, 9, 9, 0, 0, 0, time.Local) // 周一
+	other := time.Date(2026, 2, 10, 9, 0, 0, 0, time.Local) // 周二
+	if !expr.Matches(first) || !expr.Matches(monday) {
+		t.Error("日和周都有限制时任一匹配即可")
+	}
+	if expr.Matches(other) {
+		t.Error("日和周都不匹配时不应执行")
+	}
+	if next := expr.NextAfter(first); !next.Equal(time.Date(2026, 2, 2, 9, 0, 0, 0, time.Local)) {
+		t.Errorf("NextAfter 应为下一个周一, 得到 %v", next)
+	}
+
+	// 周字段为 * 时仍按日字段
+	expr, _ = Parse("0 9 1 * *")
+	if expr.Matches(monday) {
+		t.Error("周字段为 * 时只按日匹配")
+	}
+}
+
 // --- 调度器测试 ---
 
 func setupTestDB(t *testing.T) (*Scheduler, func()) {
@@ -522,3 +597,219 @@ func TestListEnabled(t *testing.T) {
 		t.Errorf("启用的应是 %s, 得到 %s", j1.ID, enabled[0].ID)
 	}
 }
+
+// --- agent 任务与结果投递 ---
+
+type delivered struct {
+	channel, target, text string
+}
+
+func TestAgentJobDelivery(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	var gotJob Job
+	s.SetAgentRunner(func(ctx context.Context, job Job) (string, error) {
+		gotJob = job
+		if _, ok := ctx.Deadline(); !ok {
+			t.Error("agent 任务应有超时")
+		}
+		return "今天有 3 个提交", nil
+	})
+	var sent []delivered
+	s.SetNotifier(func(channel, target, text string) error {
+		sent = append(sent, delivered{channel, target, text})
+		return nil
+	})
+
+	job, err := s.AddJob(Job{
+		Name:     "git-summary",
+		Schedule: "0 9 * * *",
+		Type:     JobAgent,
+		Prompt:   "总结昨天的 git log",
+		Model:    "small",
+		Tools:    []string{"git", "read"},
+		Deliver:  Delivery{Channel: "telegram", Target: "42"},
+	})
+	if err != nil {
+		t.Fatalf("创建 agent 任务失败: %v", err)
+	}
+
+	got, _, err := s.GetJob(job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Type != JobAgent || got.Prompt != "总结昨天的 git log" || got.Model != "small" ||
+		strings.Join(got.Tools, ",") != "git,read" || got.Deliver.Channel != "telegram" || got.Deliver.Target != "42" {
+		t.Fatalf("读回的任务字段不正确: %+v", got)
+	}
+
+	s.executeJob(*got, time.Now())
+	if gotJob.Prompt != "总结昨天的 git log" || gotJob.WorkDir == "" {
+		t.Errorf("执行器收到的任务不正确: %+v", gotJob)
+	}
+	if len(sent) != 1 || sent[0].channel != "telegram" || sent[0].target != "42" || !strings.Contains(sent[0].text, "今天有 3 个提交") {
+		t.Fatalf("应投递一次执行结果, 实际 %+v", sent)
+	}
+	_, logs, _ := s.GetJob(job.ID)
+	if len(logs) != 1 || logs[0].Output != "今天有 3 个提交" {
+		t.Errorf("应记录 agent 的回复, 实际 %+v", logs)
+	}
+}
+
+func TestDeliverWhen(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	var sent []string
+	s.SetNotifier(func(channel, target, text string) error {
+		sent = append(sent, text)
+		return nil
+	})
+
+	cases := []struct {
+		when, output, errStr string
+		want                 bool
+	}{
+		{"", "ok", "", true},
+		{DeliverAlways, "", "", true},
+		{DeliverFailure, "ok", "", false},
+		{DeliverFailure, "", "exit status 1", true},
+		{DeliverNonEmpty, "  \n", "", false},
+		{DeliverNonEmpty, "磁盘使用 91%", "", true},
+		{DeliverNonEmpty, "", "exit status 1", true},
+	}
+	for _, tc := range cases {
+		sent = nil
+		job := Job{Name: "check", Deliver: Delivery{Channel: "telegram", When: tc.when}}
+		if err := s.deliver(job, tc.output, tc.errStr); err != nil {
+			t.Fatalf("投递失败: %v", err)
+		}
+		if (len(sent) == 1) != tc.want {
+			t.Errorf("when=%q output=%q err=%q 时投递 %v, 期望 %v", tc.when, tc.output, tc.errStr, len(sent) == 1, tc.want)
+		}
+	}
+
+	// 未设置渠道不投递
+	sent = nil
+	s.deliver(Job{Name: "quiet"}, "output", "")
+	if len(sent) != 0 {
+		t.Error("未设置渠道时不应投递")
+	}
+}
+
+func TestCommandJobWorkDirAndFailure(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	var sent []string
+	s.SetNotifier(func(channel, target, text string) error {
+		sent = append(sent, text)
+		return nil
+	})
+
+	dir := t.TempDir()
+	job, err := s.AddJob(Job{Name: "pwd", Schedule: "@daily", Command: "pwd; exit 3", WorkDir: dir,
+		Deliver: Delivery{Channel: "telegram", When: DeliverFailure}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Type != JobCommand {
+		t.Errorf("未指定类型时应为 command, 得到 %s", job.Type)
+	}
+	s.executeJob(*job, time.Now())
+
+	got, _, _ := s.GetJob(job.ID)
+	if strings.TrimSpace(got.LastResult) != dir || got.LastError == "" {
+		t.Errorf("命令应在任务目录中执行并记录错误: result=%q err=%q", got.LastResult, got.LastError)
+	}
+	if len(sent) != 1 || !strings.Contains(sent[0], "执行失败") {
+		t.Errorf("失败时应投递, 实际 %v", sent)
+	}
+}
+
+func TestAgentJobWithoutRunner(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	job, _ := s.AddJob(Job{Name: "agent", Schedule: "@daily", Type: JobAgent, Prompt: "hi"})
+	s.executeJob(*job, time.Now())
+	got, _, _ := s.GetJob(job.ID)
+	if got.LastError == "" {
+		t.Error("未设置执行器时应记录错误")
+	}
+}
+
+func TestAddJobValidation(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	bad := []Job{
+		{Name: "no-prompt", Schedule: "@daily", Type: JobAgent},
+		{Name: "no-command", Schedule: "@daily"},
+		{Name: "bad-type", Schedule: "@daily", Type: "python", Command: "x"},
+		{Name: "bad-when", Schedule: "@daily", Command: "x", Deliver: Delivery{Channel: "telegram", When: "sometimes"}},
+		{Name: "no-channel", Schedule: "@daily", Command: "x", Deliver: Delivery{When: DeliverFailure}},
+		{Name: "bad-dir", Schedule: "@daily", Command: "x", WorkDir: "/nonexistent/kele-dir"},
+	}
+	for _, job := range bad {
+		if _, err := s.AddJob(job); err == nil {
+			t.Errorf("%s 应返回错误", job.Name)
+		}
+	}
+}
+
+func TestUpdateJobAgentFields(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	job, _ := s.CreateJob("convert", "@daily", "echo 1")
+
+	// 改为 agent 任务但缺少指令
+	if _, err := s.UpdateJob(job.ID, map[string]interface{}{"type": JobAgent}); err == nil {
+		t.Error("缺少指令时不应允许改为 agent 任务")
+	}
+
+	updated, err := s.UpdateJob(job.ID, map[string]interface{}{
+		"type":            JobAgent,
+		"prompt":          "检查磁盘",
+		"tools":           []interface{}{"bash"},
+		"deliver_channel": "telegram",
+		"deliver_when":    DeliverNonEmpty,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Type != JobAgent || updated.Prompt != "检查磁盘" || strings.Join(updated.Tools, ",") != "bash" ||
+		updated.Deliver.When != DeliverNonEmpty {
+		t.Errorf("更新后的任务不正确: %+v", updated)
+	}
+}
+
+func TestMigrateOldJobsTable(t *testing.T) {
+	path := t.TempDir() + "/old_cron.db"
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`CREATE TABLE cron_jobs (
+		id TEXT PRIMARY KEY, name TEXT NOT NULL, schedule TEXT NOT NULL, command TEXT NOT NULL,
+		enabled INTEGER DEFAULT 1, last_run DATETIME, next_run DATETIME,
+		last_result TEXT DEFAULT '', last_error TEXT DEFAULT '',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP, updated_at DATETIME DEFAULT CURRENT_TIMESTAMP);
+	INSERT INTO cron_jobs (id, name, schedule, command) VALUES ('cj_old', 'old', '@daily', 'echo old');`)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewScheduler(path, t.TempDir())
+	defer s.Close()
+	jobs, err := s.ListJobs()
+	if err != nil || len(jobs) != 1 {
+		t.Fatalf("旧任务应可读取: %+v, %v", jobs, err)
+	}
+	if jobs[0].Type != JobCommand || jobs[0].Command != "echo old" {
+		t.Errorf("旧任务应视为 command 任务: %+v", jobs[0])
+	}
+}
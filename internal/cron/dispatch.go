@@ -0,0 +1,223 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	maxIdle        = time.Minute // 没有临近的任务时重新检查的间隔
+	missedGrace    = time.Minute // 执行时刻过去超过此时长才算错过（daemon 停止或系统休眠）
+	maxCatchUpRuns = 10          // CatchUpAll 最多补跑的次数
+	maxQueuedRuns  = 10          // OverlapQueue 最多排队的次数
+)
+
+// run 调度主循环：睡到最近一个任务的执行时刻，任务增删改时提前醒来重新计算
+func (s *Scheduler) run(done <-chan struct{}) {
+	for {
+		timer := time.NewTimer(s.dispatch(time.Now()))
+		select {
+		case <-done:
+			timer.Stop()
+			return
+		case <-s.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// notifyChange 通知调度循环任务已变化
+func (s *Scheduler) notifyChange() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// dispatch 启动到期的任务并推进其下次执行时间，返回距下一个执行时刻的时长
+func (s *Scheduler) dispatch(now time.Time) time.Duration {
+	wait := maxIdle
+	jobs, err := s.listEnabled()
+	if err != nil {
+		return wait
+	}
+	for _, job := range jobs {
+		runs, next, err := s.due(job, now)
+		if err != nil {
+			continue
+		}
+		if !next.IsZero() && next.Sub(now) < wait {
+			wait = next.Sub(now)
+		}
+		if runs > 0 {
+			s.launch(job, runs)
+		}
+	}
+	return wait
+}
+
+// due 计算任务此刻应执行的次数和新的下次执行时间，并先持久化下次执行时间，
+// 避免同一个执行时刻被启动两次。错过的执行按补跑策略处理。
+func (s *Scheduler) due(job Job, now time.Time) (int, time.Time, error) {
+	sched, loc, err := jobSchedule(job)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	if job.NextRun != nil && job.NextRun.After(now) {
+		return 0, *job.NextRun, nil
+	}
+
+	runs := 0
+	if job.NextRun != nil {
+		if now.Sub(*job.NextRun) <= missedGrace {
+			runs = 1
+		} else {
+			runs = s.catchUp(job, sched, loc, now)
+		}
+	}
+	next := sched.Next(now.In(loc))
+	if _, err := s.db.Exec("UPDATE cron_jobs SET next_run=? WHERE id=?", nullTime(next), job.ID); err != nil {
+		return 0, time.Time{}, err
+	}
+	return runs, next, nil
+}
+
+// catchUp 统计 daemon 停止期间错过的执行，按补跑策略返回需要补跑的次数并记入日志
+func (s *Scheduler) catchUp(job Job, sched Schedule, loc *time.Location, now time.Time) int {
+	s.mu.Lock()
+	policy, window := s.opts.CatchUp, s.opts.CatchUpWindow
+	s.mu.Unlock()
+	if job.CatchUp != "" {
+		policy = job.CatchUp
+	}
+
+	missed, inWindow := 0, 0
+	for t := job.NextRun.In(loc); !t.IsZero() && !t.After(now); t = sched.Next(t) {
+		missed++
+		if window <= 0 || now.Sub(t) <= window {
+			inWindow++
+		}
+		if missed >= 1000 {
+			break
+		}
+	}
+
+	runs := 0
+	switch policy {
+	case CatchUpOnce:
+		runs = min(inWindow, 1)
+	case CatchUpAll:
+		runs = min(inWindow, maxCatchUpRuns)
+	}
+	s.logExecution(job.ID, now, "",
+		fmt.Sprintf("daemon 未运行或系统休眠期间错过 %d 次执行，按补跑策略 %s 补跑 %d 次", missed, policy, runs), 0)
+	return runs
+}
+
+// launch 按重叠策略启动任务的 runs 次执行，多次执行依次进行
+func (s *Scheduler) launch(job Job, runs int) {
+	s.mu.Lock()
+	if s.active[job.ID] > 0 {
+		switch job.Overlap {
+		case OverlapQueue:
+			s.queued[job.ID] = min(s.queued[job.ID]+runs, maxQueuedRuns)
+			s.mu.Unlock()
+			return
+		case OverlapAllow:
+		default:
+			s.mu.Unlock()
+			s.logExecution(job.ID, time.Now(), "", "上一次执行尚未结束，本次跳过", 0)
+			return
+		}
+	}
+	s.active[job.ID]++
+	s.mu.Unlock()
+	go s.runInstance(job, runs)
+}
+
+// runInstance 依次执行 runs 次任务，之后接着执行排队的次数
+func (s *Scheduler) runInstance(job Job, runs int) {
+	for runs > 0 {
+		for ; runs > 0; runs-- {
+			slots, ok := s.acquire()
+			if !ok {
+				s.finish(job.ID)
+				return
+			}
+			s.executeJob(job, time.Now())
+			<-slots
+		}
+		runs = s.takeQueued(job.ID)
+	}
+}
+
+// takeQueued 一次执行结束时取出排队的次数；没有排队时该实例结束
+func (s *Scheduler) takeQueued(jobID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	runs := s.queued[jobID]
+	delete(s.queued, jobID)
+	if runs == 0 {
+		if s.active[jobID]--; s.active[jobID] <= 0 {
+			delete(s.active, jobID)
+		}
+	}
+	return runs
+}
+
+// finish 调度器停止时放弃剩余的执行
+func (s *Scheduler) finish(jobID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.queued, jobID)
+	if s.active[jobID]--; s.active[jobID] <= 0 {
+		delete(s.active, jobID)
+	}
+}
+
+// acquire 占用一个并发名额，执行结束后从返回的 channel 读出一个值归还；
+// 调度器停止时返回 false
+func (s *Scheduler) acquire() (chan struct{}, bool) {
+	s.mu.Lock()
+	slots, done := s.slots, s.done
+	s.mu.Unlock()
+	select {
+	case slots <- struct{}{}:
+		return slots, true
+	case <-done:
+		return nil, false
+	}
+}
+
+// jobSchedule 解析任务的执行计划和时区
+func jobSchedule(job Job) (Schedule, *time.Location, error) {
+	sched, err := ParseSchedule(job.Schedule)
+	if err != nil {
+		return nil, nil, fmt.Errorf("无效的 cron 表达式: %v", err)
+	}
+	loc := time.Local
+	if job.Timezone != "" {
+		if loc, err = time.LoadLocation(job.Timezone); err != nil {
+			return nil, nil, fmt.Errorf("无效的时区: %s", job.Timezone)
+		}
+	}
+	return sched, loc, nil
+}
+
+// nextRunAfter 计算任务在 t 之后的下一次执行时间
+func nextRunAfter(job Job, t time.Time) (time.Time, error) {
+	sched, loc, err := jobSchedule(job)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return sched.Next(t.In(loc)), nil
+}
+
+// nullTime 把零值时间存为 NULL
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
@@ -0,0 +1,421 @@
+package cron
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestJobTimezone(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	job, err := s.AddJob(Job{Name: "tokyo", Schedule: "0 9 * * *", Command: "date", Timezone: "Asia/Tokyo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	got, _, _ := s.GetJob(job.ID)
+	if next := got.NextRun.In(tokyo); next.Hour() != 9 || next.Minute() != 0 {
+		t.Errorf("应按东京时间 09:00 执行, 得到 %v", next)
+	}
+
+	if _, err := s.AddJob(Job{Name: "bad", Schedule: "@daily", Command: "date", Timezone: "Mars/Base"}); err == nil {
+		t.Error("无效时区应返回错误")
+	}
+
+	// 修改时区后重算下次执行时间
+	updated, err := s.UpdateJob(job.ID, map[string]interface{}{"timezone": "UTC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next := updated.NextRun.UTC(); next.Hour() != 9 {
+		t.Errorf("改为 UTC 后应按 UTC 09:00 执行, 得到 %v", next)
+	}
+}
+
+func TestDueCatchUp(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local)
+	cases := []struct {
+		policy string
+		window time.Duration
+		want   int
+	}{
+		{CatchUpNone, 0, 0},
+		{CatchUpOnce, 0, 1},
+		{CatchUpAll, 0, 4},
+		{CatchUpAll, 90 * time.Minute, 2},
+		{CatchUpOnce, 30 * time.Second, 1}, // 只有 12:00 这一次在窗口内
+	}
+	for _, tc := range cases {
+		s, cleanup := setupTestDB(t)
+		s.SetOptions(Options{CatchUpWindow: tc.window})
+		job, _ := s.AddJob(Job{Name: "hourly", Schedule: "@every 1h", Command: "true", CatchUp: tc.policy})
+		// 错过 09:00、10:00、11:00、12:00 四次
+		missed := now.Add(-3 * time.Hour)
+		job.NextRun = &missed
+
+		runs, next, err := s.due(*job, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if runs != tc.want {
+			t.Errorf("策略 %s 窗口 %v: 期望补跑 %d 次, 得到 %d", tc.policy, tc.window, tc.want, runs)
+		}
+		if !next.Equal(now.Add(time.Hour)) {
+			t.Errorf("下次执行应从现在算起, 得到 %v", next)
+		}
+		got, logs, _ := s.GetJob(job.ID)
+		if got.NextRun == nil || !got.NextRun.Equal(next) {
+			t.Errorf("下次执行时间应已保存, 得到 %v", got.NextRun)
+		}
+		if len(logs) != 1 || !strings.Contains(logs[0].Error, "错过 4 次") {
+			t.Errorf("应记录错过的执行, 得到 %+v", logs)
+		}
+		cleanup()
+	}
+}
+
+func TestDueOnTime(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	job, _ := s.AddJob(Job{Name: "every-minute", Schedule: "* * * * *", Command: "true"})
+	now := *job.NextRun
+
+	// 未到时间
+	if runs, next, _ := s.due(*job, now.Add(-time.Second)); runs != 0 || !next.Equal(now) {
+		t.Errorf("未到时间不应执行: runs=%d next=%v", runs, next)
+	}
+	// 稍有延迟仍按正常执行一次
+	runs, next, _ := s.due(*job, now.Add(2*time.Second))
+	if runs != 1 || !next.Equal(now.Add(time.Minute)) {
+		t.Errorf("到期应执行一次: runs=%d next=%v", runs, next)
+	}
+	// 已推进下次执行时间，同一时刻不会再次启动
+	got, _, _ := s.GetJob(job.ID)
+	if runs, _, _ := s.due(*got, now.Add(3*time.Second)); runs != 0 {
+		t.Error("同一个执行时刻不应启动两次")
+	}
+}
+
+func TestResumeDoesNotCatchUp(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	job, _ := s.AddJob(Job{Name: "paused", Schedule: "@hourly", Command: "true", CatchUp: CatchUpAll})
+	s.UpdateJob(job.ID, map[string]interface{}{"enabled": false})
+	s.db.Exec("UPDATE cron_jobs SET next_run=? WHERE id=?", time.Now().Add(-5*time.Hour), job.ID)
+
+	resumed, err := s.UpdateJob(job.ID, map[string]interface{}{"enabled": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resumed.NextRun == nil || !resumed.NextRun.After(time.Now()) {
+		t.Errorf("恢复后应从现在开始计算下次执行, 得到 %v", resumed.NextRun)
+	}
+}
+
+// blockingRunner 返回一个阻塞到 release 关闭的 AgentRunner，并统计调用次数和最大并发
+type blockingRunner struct {
+	mu      sync.Mutex
+	calls   int
+	running int
+	peak    int
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingRunner() *blockingRunner {
+	return &blockingRunner{started: make(chan struct{}, 20), release: make(chan struct{})}
+}
+
+func (r *blockingRunner) run(ctx context.Context, job Job) (string, error) {
+	r.mu.Lock()
+	r.calls++
+	r.running++
+	r.peak = max(r.peak, r.running)
+	r.mu.Unlock()
+	r.started <- struct{}{}
+	<-r.release
+	r.mu.Lock()
+	r.running--
+	r.mu.Unlock()
+	return "ok", nil
+}
+
+func (r *blockingRunner) stats() (calls, peak int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.calls, r.peak
+}
+
+// waitIdle 等待任务的所有执行结束
+func waitIdle(t *testing.T, s *Scheduler, jobID string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		n := s.active[jobID] + s.queued[jobID]
+		s.mu.Unlock()
+		if n == 0 {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("任务未在 5 秒内结束")
+}
+
+func TestOverlapPolicies(t *testing.T) {
+	cases := []struct {
+		overlap   string
+		wantCalls int
+		wantPeak  int
+	}{
+		{OverlapSkip, 1, 1},
+		{OverlapQueue, 3, 1},
+		{OverlapAllow, 3, 3},
+	}
+	for _, tc := range cases {
+		s, cleanup := setupTestDB(t)
+		r := newBlockingRunner()
+		s.SetAgentRunner(r.run)
+		job, _ := s.AddJob(Job{Name: "slow", Schedule: "@daily", Type: JobAgent, Prompt: "x", Overlap: tc.overlap})
+
+		s.launch(*job, 1)
+		<-r.started
+		s.launch(*job, 1)
+		s.launch(*job, 1)
+		if tc.overlap == OverlapAllow {
+			<-r.started
+			<-r.started
+		}
+		close(r.release)
+		waitIdle(t, s, job.ID)
+
+		calls, peak := r.stats()
+		if calls != tc.wantCalls || peak != tc.wantPeak {
+			t.Errorf("%s: 期望执行 %d 次、最大并发 %d, 得到 %d 次、%d", tc.overlap, tc.wantCalls, tc.wantPeak, calls, peak)
+		}
+		if tc.overlap == OverlapSkip {
+			_, logs, _ := s.GetJob(job.ID)
+			skipped := 0
+			for _, l := range logs {
+				if strings.Contains(l.Error, "跳过") {
+					skipped++
+				}
+			}
+			if skipped != 2 {
+				t.Errorf("应记录 2 次跳过, 得到 %d", skipped)
+			}
+		}
+		cleanup()
+	}
+}
+
+func TestMaxConcurrent(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+	s.SetOptions(Options{MaxConcurrent: 2})
+	r := newBlockingRunner()
+	s.SetAgentRunner(r.run)
+
+	var ids []string
+	for i := 0; i < 4; i++ {
+		job, _ := s.AddJob(Job{Name: "job", Schedule: "@daily", Type: JobAgent, Prompt: "x"})
+		ids = append(ids, job.ID)
+		s.launch(*job, 1)
+	}
+	<-r.started
+	<-r.started
+	time.Sleep(50 * time.Millisecond)
+	if calls, _ := r.stats(); calls != 2 {
+		t.Errorf("并发上限为 2 时应只有 2 个在执行, 得到 %d", calls)
+	}
+	close(r.release)
+	for _, id := range ids {
+		waitIdle(t, s, id)
+	}
+	if calls, peak := r.stats(); calls != 4 || peak != 2 {
+		t.Errorf("期望共执行 4 次、最大并发 2, 得到 %d 次、%d", calls, peak)
+	}
+}
+
+func TestJobTimeoutOption(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+	s.SetOptions(Options{JobTimeout: 200 * time.Millisecond})
+
+	job, _ := s.AddJob(Job{Name: "sleep", Schedule: "@daily", Command: "sleep 5"})
+	start := time.Now()
+	s.executeJob(*job, start)
+	if time.Since(start) > 3*time.Second {
+		t.Error("应按配置的超时终止命令")
+	}
+	got, _, _ := s.GetJob(job.ID)
+	if got.LastError == "" {
+		t.Error("超时应记录错误")
+	}
+
+	if d := s.jobTimeout(Job{Type: JobAgent}); d != agentMinTimeout {
+		t.Errorf("agent 任务超时不应低于 %v, 得到 %v", agentMinTimeout, d)
+	}
+}
+
+func TestLogRetentionOption(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+	s.SetOptions(Options{LogRetention: 3})
+
+	job, _ := s.CreateJob("logs", "@daily", "true")
+	for i := 0; i < 6; i++ {
+		s.logExecution(job.ID, time.Now(), "out", "", 1)
+	}
+	_, logs, _ := s.GetJob(job.ID)
+	if len(logs) != 3 {
+		t.Errorf("应只保留 3 条日志, 得到 %d", len(logs))
+	}
+}
+
+func TestSchedulerRunsDueJob(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+	r := newBlockingRunner()
+	close(r.release)
+	s.SetAgentRunner(r.run)
+
+	s.Start()
+	job, _ := s.AddJob(Job{Name: "fast", Schedule: "@every 1s", Type: JobAgent, Prompt: "x"})
+	select {
+	case <-r.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("@every 1s 的任务应在几秒内执行")
+	}
+	s.UpdateJob(job.ID, map[string]interface{}{"enabled": false})
+	s.Stop()
+	waitIdle(t, s, job.ID)
+}
+
+func TestRunJob(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	job, _ := s.CreateJob("echo", "@daily", "echo hello")
+	entry, err := s.RunJob(job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(entry.Output, "hello") || entry.Error != "" {
+		t.Errorf("应返回本次执行结果, 得到 %+v", entry)
+	}
+	got, _, _ := s.GetJob(job.ID)
+	if !got.NextRun.Equal(*job.NextRun) {
+		t.Errorf("立即执行不应改变下次执行时间: %v -> %v", job.NextRun, got.NextRun)
+	}
+	if _, err := s.RunJob("missing"); err == nil {
+		t.Error("不存在的任务应返回错误")
+	}
+
+	// 任务正在执行时拒绝
+	r := newBlockingRunner()
+	s.SetAgentRunner(r.run)
+	slow, _ := s.AddJob(Job{Name: "slow", Schedule: "@daily", Type: JobAgent, Prompt: "x"})
+	s.launch(*slow, 1)
+	<-r.started
+	if _, err := s.RunJob(slow.ID); err == nil {
+		t.Error("任务正在执行时应返回错误")
+	}
+	close(r.release)
+	waitIdle(t, s, slow.ID)
+}
+
+func TestLogs(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	job, _ := s.CreateJob("logs", "@daily", "true")
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		s.logExecution(job.ID, base.Add(time.Duration(i)*time.Minute), "out", "", int64(i))
+	}
+	logs, err := s.Logs(job.ID, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 3 || logs[0].DurationMs != 4 {
+		t.Fatalf("应按时间倒序返回 3 条, 得到 %+v", logs)
+	}
+	if logs[0].RunAt.IsZero() || !logs[0].RunAt.After(logs[1].RunAt) {
+		t.Errorf("执行时间应正确解析: %v, %v", logs[0].RunAt, logs[1].RunAt)
+	}
+}
+
+func TestNextRuns(t *testing.T) {
+	from := time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC)
+	times, err := NextRuns("0 9 * * 1-5", "UTC", from, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2026-03-01 是周日
+	want := []string{"2026-03-02 09:00", "2026-03-03 09:00", "2026-03-04 09:00"}
+	if len(times) != len(want) {
+		t.Fatalf("期望 %d 个时刻, 得到 %v", len(want), times)
+	}
+	for i, w := range want {
+		if got := times[i].Format("2006-01-02 15:04"); got != w {
+			t.Errorf("第 %d 个时刻期望 %s, 得到 %s", i+1, w, got)
+		}
+	}
+
+	times, _ = NextRuns("@every 90m", "", from, 2)
+	if len(times) != 2 || times[1].Sub(times[0]) != 90*time.Minute {
+		t.Errorf("@every 应等间隔, 得到 %v", times)
+	}
+	if _, err := NextRuns("bad", "", from, 3); err == nil {
+		t.Error("无效表达式应返回错误")
+	}
+	if _, err := NextRuns("@daily", "Mars/Base", from, 3); err == nil {
+		t.Error("无效时区应返回错误")
+	}
+}
+
+func TestStartCatchUpUsesAgentRunner(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	job, _ := s.AddJob(Job{Name: "missed", Schedule: "@hourly", Type: JobAgent, Prompt: "x", CatchUp: CatchUpOnce,
+		Deliver: Delivery{Channel: "telegram"}})
+	s.db.Exec("UPDATE cron_jobs SET next_run=? WHERE id=?", time.Now().Add(-3*time.Hour), job.ID)
+
+	// 与 daemon 一致：先设置执行器和投递渠道，再启动
+	r := newBlockingRunner()
+	close(r.release)
+	s.SetAgentRunner(r.run)
+	delivered := make(chan string, 1)
+	s.SetNotifier(func(channel, target, text string) error {
+		delivered <- text
+		return nil
+	})
+	s.Start()
+	defer s.Stop()
+
+	select {
+	case <-r.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("启动时应补跑错过的 agent 任务")
+	}
+	select {
+	case <-delivered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("补跑结果应投递到渠道")
+	}
+	waitIdle(t, s, job.ID)
+	_, logs, _ := s.GetJob(job.ID)
+	for _, l := range logs {
+		if strings.Contains(l.Error, "未设置") {
+			t.Errorf("补跑时执行器或渠道不应为空: %s", l.Error)
+		}
+	}
+}
@@ -7,14 +7,51 @@ import (
 	"time"
 )
 
+// Schedule 任务的执行计划：cron 表达式或固定间隔
+type Schedule interface {
+	// Next 返回严格晚于 t 的下一次执行时刻，按 t 所在时区计算，没有则返回零值
+	Next(t time.Time) time.Time
+}
+
 // CronExpr 解析后的 cron 表达式
 type CronExpr struct {
+	Seconds     []bool // [0..59]，5 字段表达式为 nil，只在整分执行
 	Minutes     []bool // [0..59]
 	Hours       []bool // [0..23]
 	DaysOfMonth []bool // [0..31], index 0 未使用
 	Months      []bool // [0..12], index 0 未使用
 	DaysOfWeek  []bool // [0..6], Sunday=0
 	Raw         string
+
+	// 日和周字段是否以 * 开头；两者都有限制时按标准 cron 取并集
+	domStar bool
+	dowStar bool
+}
+
+// Every 固定间隔的执行计划（@every 90s）
+type Every struct {
+	Interval time.Duration
+}
+
+// Next 返回 t 之后一个间隔的时刻（精确到秒）
+func (e Every) Next(t time.Time) time.Time {
+	return t.Truncate(time.Second).Add(e.Interval)
+}
+
+// ParseSchedule 解析任务的执行计划，除 cron 表达式外还支持 "@every <间隔>"，如 @every 90s、@every 1h30m
+func ParseSchedule(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if rest, ok := strings.CutPrefix(strings.ToLower(expr), "@every"); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("无效的间隔: %q，示例: @every 90s、@every 1h30m", expr)
+		}
+		if d < time.Second {
+			return nil, fmt.Errorf("间隔不能小于 1 秒: %s", d)
+		}
+		return Every{Interval: d.Truncate(time.Second)}, nil
+	}
+	return Parse(expr)
 }
 
 // 预定义快捷方式
@@ -28,9 +65,9 @@ var shortcuts = map[string]string{
 	"@hourly":   "0 * * * *",
 }
 
-// Parse 解析 cron 表达式（5字段标准格式）
-// 格式：分 时 日 月 周
-// 支持：* */N N N-M N,M 及其组合
+// Parse 解析 cron 表达式（5字段标准格式，或在前面加秒字段的 6 字段格式）
+// 格式：[秒] 分 时 日 月 周
+// 支持：* */N N N-M N,M 及其组合；日和周都有限制时任一匹配即可
 func Parse(expr string) (*CronExpr, error) {
 	expr = strings.TrimSpace(expr)
 
@@ -40,8 +77,8 @@ func Parse(expr string) (*CronExpr, error) {
 	}
 
 	fields := strings.Fields(expr)
-	if len(fields) != 5 {
-		return nil, fmt.Errorf("cron 表达式需要 5 个字段，收到 %d 个: %q", len(fields), expr)
+	if len(fields) != 5 && len(fields) != 6 {
+		return nil, fmt.Errorf("cron 表达式需要 5 个字段（或带秒的 6 个），收到 %d 个: %q", len(fields), expr)
 	}
 
 	c := &CronExpr{
@@ -53,6 +90,16 @@ func Parse(expr string) (*CronExpr, error) {
 		Raw:         expr,
 	}
 
+	if len(fields) == 6 {
+		c.Seconds = make([]bool, 60)
+		if err := parseField(fields[0], c.Seconds, 0, 59); err != nil {
+			return nil, fmt.Errorf("second 字段错误: %v", err)
+		}
+		fields = fields[1:]
+	}
+	c.domStar = strings.HasPrefix(fields[2], "*")
+	c.dowStar = strings.HasPrefix(fields[4], "*")
+
 	if err := parseField(fields[0], c.Minutes, 0, 59); err != nil {
 		return nil, fmt.Errorf("minute 字段错误: %v", err)
 	}
@@ -72,18 +119,37 @@ func Parse(expr string) (*CronExpr, error) {
 	return c, nil
 }
 
-// Matches 检查给定时间是否匹配该 cron 表达式
+// Matches 检查给定时间是否匹配该 cron 表达式（5 字段表达式忽略秒）
 func (c *CronExpr) Matches(t time.Time) bool {
-	return c.Minutes[t.Minute()] &&
+	return (c.Seconds == nil || c.Seconds[t.Second()]) &&
+		c.Minutes[t.Minute()] &&
 		c.Hours[t.Hour()] &&
-		c.DaysOfMonth[t.Day()] &&
-		c.Months[int(t.Month())] &&
-		c.DaysOfWeek[int(t.Weekday())]
+		c.dayMatches(t) &&
+		c.Months[int(t.Month())]
+}
+
+// dayMatches 检查日期是否匹配日和周字段：一方为 * 时两者都需匹配，都有限制时任一匹配即可
+func (c *CronExpr) dayMatches(t time.Time) bool {
+	dom := c.DaysOfMonth[t.Day()]
+	dow := c.DaysOfWeek[int(t.Weekday())]
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Next 实现 Schedule，等同于 NextAfter
+func (c *CronExpr) Next(t time.Time) time.Time {
+	return c.NextAfter(t)
 }
 
 // NextAfter 计算指定时间之后的下一个匹配时刻
 func (c *CronExpr) NextAfter(t time.Time) time.Time {
-	t = t.Truncate(time.Minute).Add(time.Minute)
+	if c.Seconds != nil {
+		t = t.Truncate(time.Second).Add(time.Second)
+	} else {
+		t = t.Truncate(time.Minute).Add(time.Minute)
+	}
 	limit := t.Add(366 * 24 * time.Hour)
 
 	for t.Before(limit) {
@@ -93,7 +159,7 @@ func (c *CronExpr) NextAfter(t time.Time) time.Time {
 			continue
 		}
 		// 快速跳过不匹配的日
-		if !c.DaysOfMonth[t.Day()] || !c.DaysOfWeek[int(t.Weekday())] {
+		if !c.dayMatches(t) {
 			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
 			continue
 		}
@@ -102,10 +168,15 @@ func (c *CronExpr) NextAfter(t time.Time) time.Time {
 			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
 			continue
 		}
-		if c.Minutes[t.Minute()] {
+		// 快速跳过不匹配的分
+		if !c.Minutes[t.Minute()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, t.Location())
+			continue
+		}
+		if c.Seconds == nil || c.Seconds[t.Second()] {
 			return t
 		}
-		t = t.Add(time.Minute)
+		t = t.Add(time.Second)
 	}
 	return time.Time{}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -14,12 +15,61 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// 任务类型
+const (
+	JobCommand = "command" // 执行 bash 命令
+	JobAgent   = "agent"   // 在新会话中让模型执行一段指令
+)
+
+// 结果投递条件
+const (
+	DeliverAlways   = "always"    // 每次执行后都投递
+	DeliverFailure  = "failure"   // 仅执行失败时投递
+	DeliverNonEmpty = "non_empty" // 仅输出非空或失败时投递
+)
+
+// 上一次执行尚未结束时的处理方式
+const (
+	OverlapSkip  = "skip"  // 跳过本次执行
+	OverlapQueue = "queue" // 等上一次结束后再执行
+	OverlapAllow = "allow" // 同时执行
+)
+
+// daemon 停止期间错过的执行的补跑策略
+const (
+	CatchUpNone = "none" // 不补跑
+	CatchUpOnce = "once" // 无论错过几次只补跑一次
+	CatchUpAll  = "all"  // 每次都补跑（最多 maxCatchUpRuns 次）
+)
+
+const (
+	defaultJobTimeout    = 5 * time.Minute
+	defaultLogRetention  = 50
+	defaultMaxConcurrent = 5
+	agentMinTimeout      = 15 * time.Minute // agent 任务的超时不低于此值
+	maxDeliverSize       = 3500             // 投递消息的最大字符数
+)
+
+// jobColumns queryJobs 读取的列，顺序与 Scan 一致
+const jobColumns = `id, name, schedule, type, command, prompt, model, tools, work_dir,
+	deliver_channel, deliver_target, deliver_when, timezone, overlap, catch_up, enabled,
+	last_run, next_run, last_result, last_error, created_at, updated_at`
+
 // Job 定时任务
 type Job struct {
 	ID         string
 	Name       string
 	Schedule   string
-	Command    string
+	Type       string   // JobCommand 或 JobAgent，为空视为 JobCommand
+	Command    string   // command 任务执行的 bash 命令
+	Prompt     string   // agent 任务交给模型的指令
+	Model      string   // agent 任务使用的模型或档位，为空用默认模型
+	Tools      []string // agent 任务可用的工具，为空不限
+	WorkDir    string   // 执行目录，为空使用 daemon 的启动目录
+	Deliver    Delivery // 执行结果的投递方式
+	Timezone   string   // 计算执行时间所用的时区（IANA 名称），为空使用本地时区
+	Overlap    string   // 上一次未结束时的处理方式，为空视为 OverlapSkip
+	CatchUp    string   // 错过执行的补跑策略，为空使用调度器的默认策略
 	Enabled    bool
 	LastRun    *time.Time
 	NextRun    *time.Time
@@ -29,6 +79,19 @@ type Job struct {
 	UpdatedAt  time.Time
 }
 
+// Delivery 执行结果投递到消息渠道的设置，Channel 为空表示不投递
+type Delivery struct {
+	Channel string // 渠道名，如 telegram
+	Target  string // 渠道内的目标，为空使用渠道默认目标
+	When    string // 投递条件，为空视为 DeliverAlways
+}
+
+// AgentRunner 在新会话中执行 agent 任务，返回模型的最终回复
+type AgentRunner func(ctx context.Context, job Job) (string, error)
+
+// NotifyFunc 把执行结果发送到消息渠道
+type NotifyFunc func(channel, target, text string) error
+
 // LogEntry 执行日志
 type LogEntry struct {
 	ID         int
@@ -39,12 +102,28 @@ type LogEntry struct {
 	DurationMs int64
 }
 
+// Options 调度器配置，零值字段使用默认值
+type Options struct {
+	JobTimeout    time.Duration // 单次执行超时，默认 5 分钟；agent 任务不低于 15 分钟
+	LogRetention  int           // 每个任务保留的日志条数，默认 50
+	MaxConcurrent int           // 所有任务同时执行的上限，默认 5
+	CatchUp       string        // 任务未设置补跑策略时使用，默认 CatchUpNone
+	CatchUpWindow time.Duration // 只补跑这段时间内错过的执行，0 不限
+}
+
 // Scheduler 定时任务调度器
 type Scheduler struct {
 	db      *sql.DB
 	workDir string
+	opts    Options
 	done    chan struct{}
+	wake    chan struct{} // 任务变化时唤醒调度循环
 	running bool
+	agent   AgentRunner    // 为 nil 时 agent 任务无法执行
+	notify  NotifyFunc     // 为 nil 时不投递结果
+	active  map[string]int // 任务 ID → 正在执行的实例数
+	queued  map[string]int // 任务 ID → 排队等待的执行次数（OverlapQueue）
+	slots   chan struct{}  // 并发执行的名额
 	mu      sync.Mutex
 }
 
@@ -60,11 +139,35 @@ func NewScheduler(dbPath, workDir string) *Scheduler {
 	s := &Scheduler{
 		db:      db,
 		workDir: workDir,
+		wake:    make(chan struct{}, 1),
+		active:  make(map[string]int),
+		queued:  make(map[string]int),
 	}
+	s.SetOptions(Options{})
 	s.initSchema()
 	return s
 }
 
+// SetOptions 设置超时、日志保留、并发和补跑配置，需在 Start 之前调用
+func (s *Scheduler) SetOptions(opts Options) {
+	if opts.JobTimeout <= 0 {
+		opts.JobTimeout = defaultJobTimeout
+	}
+	if opts.LogRetention <= 0 {
+		opts.LogRetention = defaultLogRetention
+	}
+	if opts.MaxConcurrent <= 0 {
+		opts.MaxConcurrent = defaultMaxConcurrent
+	}
+	if opts.CatchUp == "" {
+		opts.CatchUp = CatchUpNone
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.opts = opts
+	s.slots = make(chan struct{}, opts.MaxConcurrent)
+}
+
 // initSchema 初始化数据表
 func (s *Scheduler) initSchema() {
 	schema := `
@@ -96,6 +199,70 @@ func (s *Scheduler) initSchema() {
 	if _, err := s.db.Exec(schema); err != nil {
 		panic(fmt.Sprintf("初始化 cron 表失败: %v", err))
 	}
+	if err := s.addMissingColumns(); err != nil {
+		panic(fmt.Sprintf("升级 cron 表失败: %v", err))
+	}
+}
+
+// columnMigrations 初始表结构之后新增的列，旧库通过 ALTER TABLE 补充
+var columnMigrations = []struct {
+	column, def string
+}{
+	{"type", "TEXT DEFAULT 'command'"},
+	{"prompt", "TEXT DEFAULT ''"},
+	{"model", "TEXT DEFAULT ''"},
+	{"tools", "TEXT DEFAULT '[]'"},
+	{"work_dir", "TEXT DEFAULT ''"},
+	{"deliver_channel", "TEXT DEFAULT ''"},
+	{"deliver_target", "TEXT DEFAULT ''"},
+	{"deliver_when", "TEXT DEFAULT ''"},
+	{"timezone", "TEXT DEFAULT ''"},
+	{"overlap", "TEXT DEFAULT ''"},
+	{"catch_up", "TEXT DEFAULT ''"},
+}
+
+// addMissingColumns 为 cron_jobs 补充缺少的列
+func (s *Scheduler) addMissingColumns() error {
+	rows, err := s.db.Query("PRAGMA table_info(cron_jobs)")
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, typ string
+		var dflt sql.NullString
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &dflt, &pk); err != nil {
+			rows.Close()
+			return err
+		}
+		existing[name] = true
+	}
+	rows.Close()
+
+	for _, m := range columnMigrations {
+		if existing[m.column] {
+			continue
+		}
+		if _, err := s.db.Exec(fmt.Sprintf("ALTER TABLE cron_jobs ADD COLUMN %s %s", m.column, m.def)); err != nil {
+			return fmt.Errorf("添加列 %s 失败: %w", m.column, err)
+		}
+	}
+	return nil
+}
+
+// SetAgentRunner 设置 agent 任务的执行方式（由 daemon 创建会话执行）
+func (s *Scheduler) SetAgentRunner(run AgentRunner) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.agent = run
+}
+
+// SetNotifier 设置执行结果的投递方式
+func (s *Scheduler) SetNotifier(notify NotifyFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notify = notify
 }
 
 // Start 启动调度器
@@ -107,7 +274,7 @@ func (s *Scheduler) Start() {
 	}
 	s.running = true
 	s.done = make(chan struct{})
-	go s.run()
+	go s.run(s.done)
 }
 
 // Stop 停止调度器
@@ -129,95 +296,124 @@ func (s *Scheduler) Close() {
 	}
 }
 
-// run 调度主循环
-func (s *Scheduler) run() {
-	// 对齐到下一分钟边界
-	now := time.Now()
-	next := now.Truncate(time.Minute).Add(time.Minute)
-	select {
-	case <-time.After(time.Until(next)):
-	case <-s.done:
-		return
+// executeJob 执行单个任务，记录结果并按设置投递，返回本次执行的日志
+func (s *Scheduler) executeJob(job Job, runAt time.Time) LogEntry {
+	// 安全检查
+	if job.Type != JobAgent && isDangerous(job.Command) {
+		s.logExecution(job.ID, runAt, "", "禁止执行危险命令", 0)
+		return LogEntry{JobID: job.ID, RunAt: runAt, Error: "禁止执行危险命令"}
 	}
 
-	s.tick()
-
-	ticker := time.NewTicker(time.Minute)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-s.done:
-			return
-		case <-ticker.C:
-			s.tick()
-		}
+	start := time.Now()
+	var output string
+	var err error
+	if job.Type == JobAgent {
+		output, err = s.runAgent(job)
+	} else {
+		output, err = s.runCommand(job)
 	}
-}
+	duration := time.Since(start).Milliseconds()
 
-// tick 每分钟检查并执行匹配的任务
-func (s *Scheduler) tick() {
-	now := time.Now()
-	jobs, err := s.listEnabled()
+	errStr := ""
 	if err != nil {
-		return
-	}
-	for _, job := range jobs {
-		expr, err := Parse(job.Schedule)
-		if err != nil {
-			continue
-		}
-		if !expr.Matches(now) {
-			continue
-		}
-		// 本分钟已执行过则跳过
-		if job.LastRun != nil && now.Truncate(time.Minute).Equal(job.LastRun.Truncate(time.Minute)) {
-			continue
-		}
-		go s.executeJob(job, now)
+		errStr = err.Error()
 	}
-}
 
-// executeJob 执行单个任务
-func (s *Scheduler) executeJob(job Job, runAt time.Time) {
-	// 安全检查
-	if isDangerous(job.Command) {
-		s.logExecution(job.ID, runAt, "", "禁止执行危险命令", 0)
-		return
-	}
+	// 更新 job 状态（下次执行时间由调度循环维护）
+	s.db.Exec(`UPDATE cron_jobs SET last_run=?, last_result=?, last_error=?,
+		updated_at=CURRENT_TIMESTAMP WHERE id=?`,
+		runAt, output, errStr, job.ID)
 
-	start := time.Now()
+	// 记录日志
+	s.logExecution(job.ID, runAt, output, errStr, duration)
 
-	// 5 分钟超时
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	if err := s.deliver(job, output, errStr); err != nil {
+		s.logExecution(job.ID, time.Now(), "", "投递结果失败: "+err.Error(), 0)
+	}
+	return LogEntry{JobID: job.ID, RunAt: runAt, Output: output, Error: errStr, DurationMs: duration}
+}
+
+// runCommand 在任务目录中执行 bash 命令
+func (s *Scheduler) runCommand(job Job) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.jobTimeout(job))
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, "bash", "-c", job.Command)
 	cmd.Dir = s.workDir
+	if job.WorkDir != "" {
+		cmd.Dir = job.WorkDir
+	}
 	output, err := cmd.CombinedOutput()
-	duration := time.Since(start).Milliseconds()
+	return string(output), err
+}
 
-	errStr := ""
-	if err != nil {
-		errStr = err.Error()
+// runAgent 交给 AgentRunner 在新会话中执行指令
+func (s *Scheduler) runAgent(job Job) (string, error) {
+	s.mu.Lock()
+	run := s.agent
+	s.mu.Unlock()
+	if run == nil {
+		return "", fmt.Errorf("agent 任务执行器未设置")
+	}
+	if job.WorkDir == "" {
+		job.WorkDir = s.workDir
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.jobTimeout(job))
+	defer cancel()
+	return run(ctx, job)
+}
+
+// jobTimeout 返回单次执行的超时，agent 任务需要多轮模型调用，不低于 agentMinTimeout
+func (s *Scheduler) jobTimeout(job Job) time.Duration {
+	s.mu.Lock()
+	timeout := s.opts.JobTimeout
+	s.mu.Unlock()
+	if job.Type == JobAgent && timeout < agentMinTimeout {
+		timeout = agentMinTimeout
 	}
+	return timeout
+}
 
-	// 计算下次执行时间
-	var nextRun *time.Time
-	if expr, e := Parse(job.Schedule); e == nil {
-		t := expr.NextAfter(time.Now())
-		if !t.IsZero() {
-			nextRun = &t
+// deliver 按投递条件把执行结果发送到消息渠道
+func (s *Scheduler) deliver(job Job, output, errStr string) error {
+	if job.Deliver.Channel == "" {
+		return nil
+	}
+	output = strings.TrimSpace(output)
+	switch job.Deliver.When {
+	case DeliverFailure:
+		if errStr == "" {
+			return nil
+		}
+	case DeliverNonEmpty:
+		if errStr == "" && output == "" {
+			return nil
 		}
 	}
+	s.mu.Lock()
+	notify := s.notify
+	s.mu.Unlock()
+	if notify == nil {
+		return fmt.Errorf("消息渠道未设置")
+	}
+	return notify(job.Deliver.Channel, job.Deliver.Target, formatDelivery(job, output, errStr))
+}
 
-	// 更新 job 状态
-	s.db.Exec(`UPDATE cron_jobs SET last_run=?, last_result=?, last_error=?,
-		next_run=?, updated_at=CURRENT_TIMESTAMP WHERE id=?`,
-		runAt, string(output), errStr, nextRun, job.ID)
-
-	// 记录日志
-	s.logExecution(job.ID, runAt, string(output), errStr, duration)
+// formatDelivery 生成投递的消息内容
+func formatDelivery(job Job, output, errStr string) string {
+	var b strings.Builder
+	if errStr != "" {
+		fmt.Fprintf(&b, "定时任务「%s」执行失败: %s\n", job.Name, errStr)
+	} else {
+		fmt.Fprintf(&b, "定时任务「%s」执行完成\n", job.Name)
+	}
+	if output != "" {
+		if r := []rune(output); len(r) > maxDeliverSize {
+			output = string(r[:maxDeliverSize]) + "\n...(已截断)"
+		}
+		b.WriteString("\n" + output)
+	}
+	return b.String()
 }
 
 // logExecution 记录执行日志
@@ -225,57 +421,112 @@ func (s *Scheduler) logExecution(jobID string, runAt time.Time, output, errStr s
 	s.db.Exec(`INSERT INTO cron_logs (job_id, run_at, output, error, duration_ms)
 		VALUES (?, ?, ?, ?, ?)`, jobID, runAt, output, errStr, durationMs)
 
-	// 保留最近 50 条日志
+	// 保留最近的日志
+	s.mu.Lock()
+	retention := s.opts.LogRetention
+	s.mu.Unlock()
 	s.db.Exec(`DELETE FROM cron_logs WHERE job_id=? AND id NOT IN
-		(SELECT id FROM cron_logs WHERE job_id=? ORDER BY run_at DESC LIMIT 50)`,
-		jobID, jobID)
+		(SELECT id FROM cron_logs WHERE job_id=? ORDER BY run_at DESC, id DESC LIMIT ?)`,
+		jobID, jobID, retention)
 }
 
 // listEnabled 获取所有启用的任务
 func (s *Scheduler) listEnabled() ([]Job, error) {
-	return s.queryJobs("SELECT id, name, schedule, command, enabled, last_run, next_run, last_result, last_error, created_at, updated_at FROM cron_jobs WHERE enabled=1")
+	return s.queryJobs("SELECT " + jobColumns + " FROM cron_jobs WHERE enabled=1")
 }
 
 // --- CRUD ---
 
-// CreateJob 创建定时任务
+// CreateJob 创建执行 bash 命令的定时任务
 func (s *Scheduler) CreateJob(name, schedule, command string) (*Job, error) {
-	expr, err := Parse(schedule)
-	if err != nil {
-		return nil, fmt.Errorf("无效的 cron 表达式: %v", err)
+	return s.AddJob(Job{Name: name, Schedule: schedule, Type: JobCommand, Command: command})
+}
+
+// AddJob 创建定时任务，job 中的 ID、状态和时间字段会被忽略
+func (s *Scheduler) AddJob(job Job) (*Job, error) {
+	if job.Type == "" {
+		job.Type = JobCommand
+	}
+	if err := validateJob(job); err != nil {
+		return nil, err
 	}
 
-	id := generateID()
+	job.ID = generateID()
+	job.Enabled = true
 	now := time.Now()
-	next := expr.NextAfter(now)
-
-	_, err = s.db.Exec(`INSERT INTO cron_jobs (id, name, schedule, command, enabled, next_run, created_at, updated_at)
-		VALUES (?, ?, ?, ?, 1, ?, ?, ?)`,
-		id, name, schedule, command, next, now, now)
+	next, _ := nextRunAfter(job, now)
+	tools, _ := json.Marshal(job.Tools)
+
+	_, err := s.db.Exec(`INSERT INTO cron_jobs (id, name, schedule, type, command, prompt, model, tools, work_dir,
+		deliver_channel, deliver_target, deliver_when, timezone, overlap, catch_up, enabled, next_run, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1, ?, ?, ?)`,
+		job.ID, job.Name, job.Schedule, job.Type, job.Command, job.Prompt, job.Model, string(tools), job.WorkDir,
+		job.Deliver.Channel, job.Deliver.Target, job.Deliver.When, job.Timezone, job.Overlap, job.CatchUp,
+		nullTime(next), now, now)
 	if err != nil {
 		return nil, fmt.Errorf("创建任务失败: %v", err)
 	}
+	s.notifyChange()
+
+	if !next.IsZero() {
+		job.NextRun = &next
+	}
+	job.CreatedAt = now
+	job.UpdatedAt = now
+	return &job, nil
+}
 
-	return &Job{
-		ID:        id,
-		Name:      name,
-		Schedule:  schedule,
-		Command:   command,
-		Enabled:   true,
-		NextRun:   &next,
-		CreatedAt: now,
-		UpdatedAt: now,
-	}, nil
+// validateJob 检查执行计划、任务类型与内容、投递和执行策略设置
+func validateJob(job Job) error {
+	if _, _, err := jobSchedule(job); err != nil {
+		return err
+	}
+	switch job.Type {
+	case JobCommand:
+		if strings.TrimSpace(job.Command) == "" {
+			return fmt.Errorf("command 任务缺少命令")
+		}
+	case JobAgent:
+		if strings.TrimSpace(job.Prompt) == "" {
+			return fmt.Errorf("agent 任务缺少指令")
+		}
+	default:
+		return fmt.Errorf("未知任务类型: %s，可用: %s, %s", job.Type, JobCommand, JobAgent)
+	}
+	switch job.Deliver.When {
+	case "", DeliverAlways, DeliverFailure, DeliverNonEmpty:
+	default:
+		return fmt.Errorf("未知投递条件: %s，可用: %s, %s, %s", job.Deliver.When, DeliverAlways, DeliverFailure, DeliverNonEmpty)
+	}
+	if job.Deliver.Channel == "" && (job.Deliver.Target != "" || job.Deliver.When != "") {
+		return fmt.Errorf("设置投递目标或条件时需要指定渠道")
+	}
+	switch job.Overlap {
+	case "", OverlapSkip, OverlapQueue, OverlapAllow:
+	default:
+		return fmt.Errorf("未知重叠策略: %s，可用: %s, %s, %s", job.Overlap, OverlapSkip, OverlapQueue, OverlapAllow)
+	}
+	switch job.CatchUp {
+	case "", CatchUpNone, CatchUpOnce, CatchUpAll:
+	default:
+		return fmt.Errorf("未知补跑策略: %s，可用: %s, %s, %s", job.CatchUp, CatchUpNone, CatchUpOnce, CatchUpAll)
+	}
+	if job.WorkDir != "" {
+		if info, err := os.Stat(job.WorkDir); err != nil || !info.IsDir() {
+			return fmt.Errorf("工作目录不存在: %s", job.WorkDir)
+		}
+	}
+	return nil
 }
 
 // ListJobs 列出所有任务
 func (s *Scheduler) ListJobs() ([]Job, error) {
-	return s.queryJobs("SELECT id, name, schedule, command, enabled, last_run, next_run, last_result, last_error, created_at, updated_at FROM cron_jobs ORDER BY created_at DESC")
+	return s.queryJobs("SELECT " + jobColumns + " FROM cron_jobs ORDER BY created_at DESC")
 }
 
 // GetJob 获取任务详情 + 最近日志
 func (s *Scheduler) GetJob(id string) (*Job, []LogEntry, error) {
-	jobs, err := s.queryJobs("SELECT id, name, schedule, command, enabled, last_run, next_run, last_result, last_error, created_at, updated_at FROM cron_jobs WHERE id=?", id)
+	jobs, err := s.queryJobs("SELECT "+jobColumns+" FROM cron_jobs WHERE id=?", id)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -284,30 +535,84 @@ func (s *Scheduler) GetJob(id string) (*Job, []LogEntry, error) {
 	}
 
 	// 查询最近 10 条日志
-	rows, err := s.db.Query("SELECT id, job_id, run_at, output, error, duration_ms FROM cron_logs WHERE job_id=? ORDER BY run_at DESC LIMIT 10", id)
+	logs, err := s.Logs(id, 10)
 	if err != nil {
 		return &jobs[0], nil, nil
 	}
+	return &jobs[0], logs, nil
+}
+
+// Logs 返回任务最近的执行日志，最新的在前
+func (s *Scheduler) Logs(id string, limit int) ([]LogEntry, error) {
+	rows, err := s.db.Query(`SELECT id, job_id, run_at, output, error, duration_ms FROM cron_logs
+		WHERE job_id=? ORDER BY run_at DESC, id DESC LIMIT ?`, id, limit)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
 	var logs []LogEntry
 	for rows.Next() {
 		var l LogEntry
-		var runAt string
+		var runAt sql.NullString
 		if err := rows.Scan(&l.ID, &l.JobID, &runAt, &l.Output, &l.Error, &l.DurationMs); err != nil {
 			continue
 		}
-		l.RunAt, _ = time.Parse("2006-01-02 15:04:05", runAt)
+		l.RunAt = parseSQLiteTime(runAt.String)
 		logs = append(logs, l)
 	}
+	return logs, rows.Err()
+}
 
-	return &jobs[0], logs, nil
+// RunJob 立即执行一次任务并等待结束，不改变计划的下次执行时间。
+// 任务正在执行且重叠策略不是 allow 时返回错误。
+func (s *Scheduler) RunJob(id string) (*LogEntry, error) {
+	job, _, err := s.GetJob(id)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	if s.active[id] > 0 && job.Overlap != OverlapAllow {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("任务正在执行: %s", id)
+	}
+	s.active[id]++
+	s.mu.Unlock()
+
+	slots, ok := s.acquire()
+	if !ok {
+		s.finish(id)
+		return nil, fmt.Errorf("调度器已停止")
+	}
+	entry := s.executeJob(*job, time.Now())
+	<-slots
+	// 手动执行期间排队的计划执行
+	if runs := s.takeQueued(id); runs > 0 {
+		go s.runInstance(*job, runs)
+	}
+	return &entry, nil
+}
+
+// NextRuns 返回执行计划在 from 之后的 n 个执行时刻，timezone 为空使用本地时区
+func NextRuns(schedule, timezone string, from time.Time, n int) ([]time.Time, error) {
+	sched, loc, err := jobSchedule(Job{Schedule: schedule, Timezone: timezone})
+	if err != nil {
+		return nil, err
+	}
+	var times []time.Time
+	for t := from.In(loc); len(times) < n; {
+		if t = sched.Next(t); t.IsZero() {
+			break
+		}
+		times = append(times, t)
+	}
+	return times, nil
 }
 
 // UpdateJob 更新任务属性
 func (s *Scheduler) UpdateJob(id string, updates map[string]interface{}) (*Job, error) {
 	// 检查任务是否存在
-	jobs, err := s.queryJobs("SELECT id, name, schedule, command, enabled, last_run, next_run, last_result, last_error, created_at, updated_at FROM cron_jobs WHERE id=?", id)
+	jobs, err := s.queryJobs("SELECT "+jobColumns+" FROM cron_jobs WHERE id=?", id)
 	if err != nil {
 		return nil, err
 	}
@@ -317,37 +622,74 @@ func (s *Scheduler) UpdateJob(id string, updates map[string]interface{}) (*Job,
 
 	var setClauses []string
 	var args []interface{}
+	merged := jobs[0]
 
 	if v, ok := updates["name"]; ok {
 		setClauses = append(setClauses, "name=?")
 		args = append(args, v)
 	}
-	if v, ok := updates["schedule"]; ok {
-		schedule := v.(string)
-		if _, err := Parse(schedule); err != nil {
-			return nil, fmt.Errorf("无效的 cron 表达式: %v", err)
+	// 字符串字段：更新键 → 列名与合并后的字段
+	for _, f := range []struct {
+		key, column string
+		field       *string
+	}{
+		{"schedule", "schedule", &merged.Schedule},
+		{"timezone", "timezone", &merged.Timezone},
+		{"overlap", "overlap", &merged.Overlap},
+		{"catch_up", "catch_up", &merged.CatchUp},
+		{"type", "type", &merged.Type},
+		{"command", "command", &merged.Command},
+		{"prompt", "prompt", &merged.Prompt},
+		{"model", "model", &merged.Model},
+		{"work_dir", "work_dir", &merged.WorkDir},
+		{"deliver_channel", "deliver_channel", &merged.Deliver.Channel},
+		{"deliver_target", "deliver_target", &merged.Deliver.Target},
+		{"deliver_when", "deliver_when", &merged.Deliver.When},
+	} {
+		v, ok := updates[f.key]
+		if !ok {
+			continue
 		}
-		setClauses = append(setClauses, "schedule=?")
-		args = append(args, schedule)
-		// 重算下次执行时间
-		if expr, e := Parse(schedule); e == nil {
-			next := expr.NextAfter(time.Now())
-			setClauses = append(setClauses, "next_run=?")
-			args = append(args, next)
+		str, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s 应为字符串", f.key)
 		}
+		*f.field = str
+		setClauses = append(setClauses, f.column+"=?")
+		args = append(args, str)
 	}
-	if v, ok := updates["command"]; ok {
-		setClauses = append(setClauses, "command=?")
-		args = append(args, v)
+	if v, ok := updates["tools"]; ok {
+		tools, err := toStrings(v)
+		if err != nil {
+			return nil, err
+		}
+		merged.Tools = tools
+		data, _ := json.Marshal(tools)
+		setClauses = append(setClauses, "tools=?")
+		args = append(args, string(data))
+	}
+	if err := validateJob(merged); err != nil {
+		return nil, err
+	}
+	_, reschedule := updates["schedule"]
+	if _, ok := updates["timezone"]; ok {
+		reschedule = true
 	}
 	if v, ok := updates["enabled"]; ok {
 		setClauses = append(setClauses, "enabled=?")
 		if v.(bool) {
 			args = append(args, 1)
+			// 恢复暂停的任务时从现在开始计算，暂停期间的执行不算错过
+			reschedule = reschedule || !merged.Enabled
 		} else {
 			args = append(args, 0)
 		}
 	}
+	if reschedule {
+		next, _ := nextRunAfter(merged, time.Now())
+		setClauses = append(setClauses, "next_run=?")
+		args = append(args, nullTime(next))
+	}
 
 	if len(setClauses) == 0 {
 		return &jobs[0], nil
@@ -360,9 +702,10 @@ func (s *Scheduler) UpdateJob(id string, updates map[string]interface{}) (*Job,
 	if _, err := s.db.Exec(query, args...); err != nil {
 		return nil, fmt.Errorf("更新失败: %v", err)
 	}
+	s.notifyChange()
 
 	// 返回更新后的任务
-	updated, _ := s.queryJobs("SELECT id, name, schedule, command, enabled, last_run, next_run, last_result, last_error, created_at, updated_at FROM cron_jobs WHERE id=?", id)
+	updated, _ := s.queryJobs("SELECT "+jobColumns+" FROM cron_jobs WHERE id=?", id)
 	if len(updated) > 0 {
 		return &updated[0], nil
 	}
@@ -381,6 +724,7 @@ func (s *Scheduler) DeleteJob(id string) error {
 	}
 	// 清理日志
 	s.db.Exec("DELETE FROM cron_logs WHERE job_id=?", id)
+	s.notifyChange()
 	return nil
 }
 
@@ -398,12 +742,18 @@ func (s *Scheduler) queryJobs(query string, args ...interface{}) ([]Job, error)
 	for rows.Next() {
 		var j Job
 		var enabled int
+		var tools string
 		var lastRun, nextRun, createdAt, updatedAt sql.NullString
 
-		if err := rows.Scan(&j.ID, &j.Name, &j.Schedule, &j.Command, &enabled,
+		if err := rows.Scan(&j.ID, &j.Name, &j.Schedule, &j.Type, &j.Command, &j.Prompt, &j.Model, &tools, &j.WorkDir,
+			&j.Deliver.Channel, &j.Deliver.Target, &j.Deliver.When, &j.Timezone, &j.Overlap, &j.CatchUp, &enabled,
 			&lastRun, &nextRun, &j.LastResult, &j.LastError, &createdAt, &updatedAt); err != nil {
 			continue
 		}
+		if j.Type == "" {
+			j.Type = JobCommand
+		}
+		json.Unmarshal([]byte(tools), &j.Tools)
 
 		j.Enabled = enabled == 1
 		if lastRun.Valid {
@@ -444,6 +794,33 @@ func parseSQLiteTime(s string) time.Time {
 	return time.Time{}
 }
 
+// toStrings 把更新值转为字符串列表，接受 []string、JSON 解码得到的 []interface{} 或逗号分隔的字符串
+func toStrings(v interface{}) ([]string, error) {
+	switch t := v.(type) {
+	case []string:
+		return t, nil
+	case []interface{}:
+		out := make([]string, 0, len(t))
+		for _, item := range t {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("tools 应为字符串列表")
+			}
+			out = append(out, str)
+		}
+		return out, nil
+	case string:
+		var out []string
+		for _, item := range strings.Split(t, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				out = append(out, item)
+			}
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("tools 应为字符串列表")
+}
+
 // generateID 生成任务 ID
 func generateID() string {
 	b := make([]byte, 4)
@@ -0,0 +1,60 @@
+package daemon
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/BlakeLiAFK/kele/internal/taskboard"
+)
+
+// watchBoardReviews 订阅看板事件，任务进入待审批状态时推送到 Telegram
+func (d *Daemon) watchBoardReviews() {
+	id, events := d.board.Subscribe()
+	d.boardWatchID = id
+	go func() {
+		for ev := range events {
+			if ev.Type != taskboard.EventTaskReview {
+				continue
+			}
+			if err := d.notifyReview(ev); err != nil {
+				log.Printf("review notify for task %s failed: %v", ev.TaskID, err)
+			}
+		}
+	}()
+}
+
+// notifyReview 发送审批提醒，目标: allowedChat > lastChatID
+func (d *Daemon) notifyReview(ev taskboard.BoardEvent) error {
+	target := ""
+	if d.cfg.Telegram.AllowedChat == 0 {
+		chatID := d.telegram.LastChatID()
+		if chatID == 0 {
+			return fmt.Errorf("no target chat ID")
+		}
+		target = strconv.FormatInt(chatID, 10)
+	}
+
+	msg := fmt.Sprintf("任务待审批: [%s] %s\n", ev.TaskID, ev.Detail)
+	if t, err := d.board.GetTask(ev.TaskID); err == nil && t.Result != "" {
+		msg += "\n" + truncateText(t.Result, 1000) + "\n"
+	}
+	msg += fmt.Sprintf("\n通过: kele task approve %s\n退回: kele task reject %s --feedback \"...\"", ev.TaskID, ev.TaskID)
+
+	_, err := d.dispatcher.Send("telegram", target, msg)
+	return err
+}
+
+// reportSchedule 将定时工作区的运行报告和定时任务的执行结果发送到指定渠道
+func (d *Daemon) reportSchedule(channel, target, text string) error {
+	_, err := d.dispatcher.Send(channel, target, text)
+	return err
+}
+
+func truncateText(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max]) + "..."
+}
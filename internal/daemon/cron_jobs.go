@@ -0,0 +1,58 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/BlakeLiAFK/kele/internal/cron"
+)
+
+// runCronAgent 在新建的会话中执行 agent 定时任务，返回模型的最终回复。
+// 定时任务无人值守，不开放 ask_user；会话在执行结束后删除。
+func (d *Daemon) runCronAgent(ctx context.Context, job cron.Job) (string, error) {
+	tools := job.Tools
+	if len(tools) == 0 {
+		for _, name := range d.executor.ListTools() {
+			if name != "ask_user" {
+				tools = append(tools, name)
+			}
+		}
+	}
+	sess := d.sessions.CreateWithOptions("cron:"+job.Name, SessionOptions{
+		WorkDir:      job.WorkDir,
+		Channel:      "cron",
+		Model:        job.Model,
+		AllowedTools: tools,
+	})
+	defer d.sessions.Delete(sess.ID)
+
+	events, err := sess.ChatStreamForTask(ctx, job.Prompt)
+	if err != nil {
+		return "", err
+	}
+	// 工具调用之前的内容是过程说明，只保留最后一轮的回复
+	var reply strings.Builder
+	var chatErr string
+	for ev := range events {
+		switch ev.Type {
+		case "content":
+			reply.WriteString(ev.Content)
+		case "tool_call":
+			reply.Reset()
+		case "error":
+			if chatErr == "" {
+				chatErr = ev.Error
+			}
+		}
+	}
+	if chatErr != "" {
+		return reply.String(), fmt.Errorf("%s", chatErr)
+	}
+	if err := ctx.Err(); err != nil {
+		return reply.String(), err
+	}
+	log.Printf("[cron] agent job %s (%s) finished", job.ID, job.Name)
+	return reply.String(), nil
+}
@@ -37,10 +37,15 @@ type Daemon struct {
 	scheduler *cron.Scheduler
 	sessions  *SessionManager
 	heartbeat *heartbeat.Runner
+	heartbeatStore *heartbeat.Store
 	board      *taskboard.Board
 	boardSched *taskboard.Scheduler
 	planner    *taskboard.Planner
 	telegram   *tgbot.Bot
+	dispatcher *ChannelDispatcher
+	boardWatchID int // board event subscription of the review notifier
+	recurrer     *taskboard.Recurrer
+	memorySync   chan struct{} // closes to stop the MEMORY.md watcher
 	agentPool  *agent.WorkerPool
 	server     *grpc.Server
 	startTime time.Time
@@ -143,6 +148,20 @@ func (d *Daemon) initResources() error {
 		log.Printf("Warning: memory store init failed: %v", err)
 	}
 	d.store = store
+	if store != nil {
+		// 会话 ID 在重启后会复用，上次运行留下的会话记忆一律清掉
+		if err := store.ClearSessionScopes(); err != nil {
+			log.Printf("Warning: clear session memories: %v", err)
+		}
+		embedder, err := llm.NewEmbedder(d.cfg)
+		if err != nil {
+			log.Printf("Warning: memory embedder disabled: %v", err)
+		} else if embedder != nil {
+			store.SetEmbedder(embedder)
+			log.Printf("Memory embedder: %s", embedder.Name())
+		}
+		d.watchMemoryFiles()
+	}
 
 	// LLM provider
 	d.provider = llm.NewProviderManager(d.cfg)
@@ -150,7 +169,13 @@ func (d *Daemon) initResources() error {
 	// Cron scheduler
 	wd, _ := os.Getwd()
 	d.scheduler = cron.NewScheduler(d.cfg.Memory.DBPath, wd)
-	d.scheduler.Start()
+	d.scheduler.SetOptions(cron.Options{
+		JobTimeout:    time.Duration(d.cfg.Cron.JobTimeout) * time.Second,
+		LogRetention:  d.cfg.Cron.LogRetention,
+		MaxConcurrent: d.cfg.Cron.MaxConcurrent,
+		CatchUp:       d.cfg.Cron.CatchUp,
+		CatchUpWindow: time.Duration(d.cfg.Cron.CatchUpWindow) * time.Hour,
+	})
 
 	// Tool executor
 	d.executor = tools.NewExecutor(d.scheduler, d.cfg)
@@ -162,6 +187,14 @@ func (d *Daemon) initResources() error {
 	d.executor.RegisterTool(tools.NewAgentResultTool(d.agentPool))
 	log.Println("Agent tools registered")
 
+	// 记忆工具：agent 可自行保存、搜索、修改和删除长期记忆，并检索历史对话
+	if d.store != nil {
+		for _, tool := range tools.NewMemoryTools(d.store) {
+			d.executor.RegisterTool(tool)
+		}
+		d.executor.RegisterTool(tools.NewConversationSearchTool(d.store))
+	}
+
 	// 工作空间管理器
 	ws := workspace.NewManager()
 
@@ -171,9 +204,25 @@ func (d *Daemon) initResources() error {
 	// Create default session
 	d.sessions.Create("default")
 
+	// agent 定时任务在新会话中执行
+	d.scheduler.SetAgentRunner(d.runCronAgent)
+
 	// Heartbeat runner
-	d.heartbeat = heartbeat.NewRunner(d.provider, d.executor, d.sessions.Count)
-	d.heartbeat.Start()
+	d.heartbeat = heartbeat.NewRunner(d.provider, d.executor, d.sessions.Count, heartbeat.Options{
+		Interval:   time.Duration(d.cfg.Heartbeat.Interval) * time.Minute,
+		QuietHours: d.cfg.Heartbeat.QuietHours,
+		ActiveDays: d.cfg.Heartbeat.ActiveDays,
+		MaxRounds:  d.cfg.Heartbeat.MaxRounds,
+	})
+	if store, err := heartbeat.OpenStore(d.cfg.Memory.DBPath); err != nil {
+		log.Printf("Heartbeat history disabled: %v", err)
+	} else {
+		d.heartbeatStore = store
+		d.heartbeat.SetStore(store)
+	}
+	if d.cfg.Heartbeat.Enabled {
+		d.heartbeat.Start()
+	}
 
 	// TaskBoard
 	homeDir, _ := os.UserHomeDir()
@@ -189,8 +238,23 @@ func (d *Daemon) initResources() error {
 		}
 
 		d.board = taskboard.NewBoard(tbStore)
+		d.board.SetWorktreeManager(taskboard.NewWorktreeManager(filepath.Join(homeDir, ".kele", "worktrees")))
+		if blocked, err := d.board.ReconcileBlocked(); err != nil {
+			log.Printf("Warning: reconcile blocked tasks: %v", err)
+		} else if blocked > 0 {
+			log.Printf("Updated blocked state of %d tasks", blocked)
+		}
 		adapter := NewTaskSessionAdapter(d.sessions)
 		d.boardSched = taskboard.NewScheduler(d.board, adapter)
+		d.boardSched.SetOptions(taskboard.SchedulerOptions{
+			MaxConcurrent: d.cfg.TaskBoard.MaxConcurrent,
+			AgingInterval: time.Duration(d.cfg.TaskBoard.AgingMinutes) * time.Minute,
+			Throttle:      d.provider.RateLimitedUntil,
+
+			RetryBackoff:        time.Duration(d.cfg.TaskBoard.RetryBackoff) * time.Second,
+			MaxRetryBackoff:     time.Duration(d.cfg.TaskBoard.RetryMaxBackoff) * time.Second,
+			MaxTransientRetries: d.cfg.TaskBoard.MaxTransientRetries,
+		})
 		d.board.SetScheduler(d.boardSched)
 		d.boardSched.Start()
 		d.planner = taskboard.NewPlanner(adapter)
@@ -214,15 +278,29 @@ func (d *Daemon) initResources() error {
 	}
 
 	// 消息推送工具
-	dispatcher := NewChannelDispatcher()
+	d.dispatcher = NewChannelDispatcher()
 	if d.telegram != nil {
-		dispatcher.RegisterTelegram(d.telegram, d.cfg.Telegram.AllowedChat)
+		d.dispatcher.RegisterTelegram(d.telegram, d.cfg.Telegram.AllowedChat)
 	}
-	if len(dispatcher.Channels()) > 0 {
-		d.executor.RegisterTool(tools.NewSendMessageTool(dispatcher))
+	d.scheduler.SetNotifier(d.reportSchedule)
+	// agent 执行器和投递渠道都设置好后再启动，启动时的补跑才能正常执行和投递
+	d.scheduler.Start()
+	if len(d.dispatcher.Channels()) > 0 {
+		d.executor.RegisterTool(tools.NewSendMessageTool(d.dispatcher))
 		log.Println("send_message tool registered")
 	}
 
+	// 任务审批提醒
+	if d.board != nil && d.telegram != nil {
+		d.watchBoardReviews()
+	}
+
+	// 定时工作区
+	if d.board != nil {
+		d.recurrer = taskboard.NewRecurrer(d.board, d.planner, d.reportSchedule)
+		d.recurrer.Start()
+	}
+
 	// 启动通知
 	if d.telegram != nil {
 		go d.sendStartupNotify()
@@ -240,15 +318,27 @@ func (d *Daemon) cleanup() {
 	if d.telegram != nil {
 		d.telegram.Stop()
 	}
+	if d.boardWatchID != 0 {
+		d.board.Unsubscribe(d.boardWatchID)
+	}
+	if d.recurrer != nil {
+		d.recurrer.Stop()
+	}
 	if d.boardSched != nil {
 		d.boardSched.Stop()
 	}
 	if d.heartbeat != nil {
 		d.heartbeat.Stop()
 	}
+	if d.heartbeatStore != nil {
+		d.heartbeatStore.Close()
+	}
 	if d.scheduler != nil {
 		d.scheduler.Stop()
 	}
+	if d.memorySync != nil {
+		close(d.memorySync)
+	}
 	if d.store != nil {
 		d.store.Close()
 	}
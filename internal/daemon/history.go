@@ -0,0 +1,62 @@
+package daemon
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/BlakeLiAFK/kele/internal/memory"
+)
+
+// recallLimit /recall 显示的消息条数
+const recallLimit = 10
+
+// archiveTurn 把一轮对话写入对话归档，供 /recall 和 conversation_search 检索
+func (sb *SessionBrain) archiveTurn(userInput, reply string) {
+	if sb.memory == nil {
+		return
+	}
+	origin := memory.MessageOrigin{SessionID: sb.sessionID, SessionName: sb.sessionName, Channel: sb.channel}
+	if _, err := sb.memory.SaveTurn(origin, userInput, reply); err != nil {
+		log.Printf("[memory] archive turn: %v", err)
+	}
+}
+
+// handleRecall 处理 /recall，在所有会话的历史对话中检索
+func (sb *SessionBrain) handleRecall(args []string) string {
+	if len(args) == 0 {
+		return "用法: /recall <关键词>"
+	}
+	if sb.memory == nil {
+		return "记忆系统未初始化"
+	}
+	hits, err := sb.memory.SearchHistory(memory.HistoryQuery{Text: strings.Join(args, " "), Limit: recallLimit})
+	if err != nil {
+		return fmt.Sprintf("搜索失败: %v", err)
+	}
+	if len(hits) == 0 {
+		return "历史对话中没有找到相关内容"
+	}
+	return fmt.Sprintf("找到 %d 条历史消息:\n\n%s", len(hits), formatHistoryHits(hits))
+}
+
+// formatHistoryHits 每条命中一行：时间、会话、角色和摘要
+func formatHistoryHits(hits []memory.HistoryHit) string {
+	var s strings.Builder
+	for _, h := range hits {
+		role := "用户"
+		if h.Role == "assistant" {
+			role = "助手"
+		}
+		session := h.SessionName
+		if session == "" {
+			session = h.SessionID
+		}
+		if h.Channel != "" {
+			session += " · " + h.Channel
+		}
+		fmt.Fprintf(&s, "%s [%s] %s: %s\n", h.Timestamp.Local().Format("2006-01-02 15:04"), session, role,
+			strings.Join(strings.Fields(h.Snippet), " "))
+	}
+	return strings.TrimRight(s.String(), "\n")
+}
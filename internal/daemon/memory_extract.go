@@ -0,0 +1,327 @@
+package daemon
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/BlakeLiAFK/kele/internal/llm"
+	"github.com/BlakeLiAFK/kele/internal/memory"
+)
+
+// endConversation 对话结束（清空或关闭会话）时调用：开启 memory.auto_extract
+// 后在后台用小模型提取候选记忆，等待用户通过 /memory 确认
+func (sb *SessionBrain) endConversation() bool {
+	sb.mu.Lock()
+	history := sb.history
+	sb.history = []llm.Message{}
+	sb.injected = nil
+	sb.mu.Unlock()
+
+	if sb.memory == nil || !sb.cfg.Memory.AutoExtract || len(history) < 2 {
+		return false
+	}
+	scopes := sb.memoryScopes()
+	go func() {
+		added, err := sb.memory.ExtractProposals(sb.provider.Complete, history, scopes)
+		if err != nil {
+			log.Printf("[memory] extract proposals: %v", err)
+			return
+		}
+		if len(added) > 0 {
+			log.Printf("[memory] %d memory proposal(s) waiting for review", len(added))
+		}
+	}()
+	return true
+}
+
+// handleMemory 处理 /memory 命令
+func (sb *SessionBrain) handleMemory(args []string) string {
+	if sb.memory == nil {
+		return "记忆系统未初始化"
+	}
+	scopes := sb.memoryScopes()
+	if len(args) == 0 {
+		entries, _ := sb.memory.ListMemoriesIn(scopes.List())
+		proposals, _ := sb.memory.ListProposals()
+		auto := "关闭"
+		if sb.cfg.Memory.AutoExtract {
+			auto = "开启"
+		}
+		semantic := "未启用（设置 memory.embedder 后重启 daemon 生效）"
+		if e := sb.memory.Embedder(); e != nil {
+			semantic = e.Name()
+		}
+		pinned := 0
+		perScope := make(map[string]int)
+		for _, e := range entries {
+			perScope[e.Scope]++
+			if e.Pinned {
+				pinned++
+			}
+		}
+		var visible strings.Builder
+		for _, scope := range scopes.List() {
+			fmt.Fprintf(&visible, "  %-8s %d 条  %s\n", memory.ScopeKind(scope), perScope[scope], memory.DescribeScope(scope))
+		}
+		sb.mu.RLock()
+		injected := sb.injected
+		sb.mu.RUnlock()
+		lastTurn := "  （尚未对话）"
+		if len(injected) > 0 {
+			tokens := 0
+			for _, m := range injected {
+				tokens += m.Tokens
+			}
+			lastTurn = fmt.Sprintf("  %d 条，约 %d / %d tokens\n", len(injected), tokens, sb.cfg.Memory.InjectTokens) +
+				"  " + strings.ReplaceAll(formatInjected(injected), "\n", "\n  ")
+		}
+		return fmt.Sprintf(`记忆系统
+
+  可见记忆: %d 条（置顶 %d 条）
+  待确认候选: %d 条
+  自动提取: %s（/config set memory.auto_extract true 开启）
+  语义检索: %s
+
+作用域（由近到远，同 key 以靠前的为准）:
+%s
+最近一轮注入的记忆:
+%s
+
+命令:
+  /remember <text>        添加到长期记忆
+  /search <query>         搜索记忆
+  /memory extract         从当前对话提取候选记忆
+  /memory pending         查看待确认的候选
+  /memory accept <id|all> 接受候选，写入长期记忆
+  /memory reject <id|all> 丢弃候选
+  /memory pin <key>       置顶，每轮都注入
+  /memory unpin <key>     取消置顶
+  /memory list [scope]    按作用域列出记忆
+  /memory move <key> <scope>  移到 global/project/workspace/session
+  /memory promote <key>   提升到更大的作用域（会话 → 工作区 → 项目 → 全局）
+  /memory conflicts       查看 MEMORY.md 与数据库的同步冲突
+
+存储: %s
+文件: %s（可直接编辑，修改会自动同步；项目记忆在仓库的 .kele/MEMORY.md）`, len(entries), pinned, len(proposals), auto, semantic, visible.String(), lastTurn, sb.cfg.Memory.DBPath, sb.cfg.Memory.MemoryFile)
+	}
+
+	switch args[0] {
+	case "extract":
+		sb.mu.RLock()
+		history := make([]llm.Message, len(sb.history))
+		copy(history, sb.history)
+		sb.mu.RUnlock()
+		added, err := sb.memory.ExtractProposals(sb.provider.Complete, history, scopes)
+		if err != nil {
+			return err.Error()
+		}
+		if len(added) == 0 {
+			return "没有提取到新的候选记忆"
+		}
+		return fmt.Sprintf("提取到 %d 条候选记忆:\n\n%s\n使用 /memory accept <id|all> 接受", len(added), formatProposals(added))
+
+	case "pending":
+		proposals, err := sb.memory.ListProposals()
+		if err != nil {
+			return fmt.Sprintf("查询失败: %v", err)
+		}
+		if len(proposals) == 0 {
+			return "没有待确认的候选记忆"
+		}
+		return fmt.Sprintf("待确认的候选记忆 (%d 条):\n\n%s\n使用 /memory accept <id|all> 接受，/memory reject <id|all> 丢弃",
+			len(proposals), formatProposals(proposals))
+
+	case "accept", "reject":
+		if len(args) < 2 {
+			return fmt.Sprintf("用法: /memory %s <id|all>", args[0])
+		}
+		ids, err := sb.proposalIDs(args[1:])
+		if err != nil {
+			return err.Error()
+		}
+		var s strings.Builder
+		for _, id := range ids {
+			if args[0] == "accept" {
+				p, err := sb.memory.AcceptProposal(id)
+				if err != nil {
+					fmt.Fprintf(&s, "#%d 失败: %v\n", id, err)
+					continue
+				}
+				fmt.Fprintf(&s, "#%d 已写入记忆 [%s]\n", id, p.Key)
+			} else {
+				if err := sb.memory.RejectProposal(id); err != nil {
+					fmt.Fprintf(&s, "#%d 失败: %v\n", id, err)
+					continue
+				}
+				fmt.Fprintf(&s, "#%d 已丢弃\n", id)
+			}
+		}
+		if s.Len() == 0 {
+			return "没有待确认的候选记忆"
+		}
+		return strings.TrimSpace(s.String())
+
+	case "pin", "unpin":
+		if len(args) < 2 {
+			return fmt.Sprintf("用法: /memory %s <key>", args[0])
+		}
+		entry, err := sb.memory.FindMemory(scopes.List(), args[1])
+		if err == nil {
+			err = sb.memory.PinMemory(entry.Scope, args[1], args[0] == "pin")
+		}
+		if err != nil {
+			return memoryError(args[1], err)
+		}
+		if args[0] == "pin" {
+			return fmt.Sprintf("已置顶 [%s]，每轮对话都会注入", args[1])
+		}
+		return fmt.Sprintf("已取消置顶 [%s]", args[1])
+
+	case "conflicts":
+		conflicts, err := sb.memory.Conflicts(10)
+		if err != nil {
+			return fmt.Sprintf("查询失败: %v", err)
+		}
+		if len(conflicts) == 0 {
+			return "没有同步冲突"
+		}
+		var s strings.Builder
+		s.WriteString("最近的同步冲突（文件和数据库都修改了同一条记忆，保留较新的一方）:\n\n")
+		for _, c := range conflicts {
+			fmt.Fprintf(&s, "%s [%s] %s，保留%s\n", c.CreatedAt.Local().Format("01-02 15:04"), c.Key, memory.DescribeScope(c.Scope), conflictSide(c.Kept))
+			fmt.Fprintf(&s, "  文件:   %s\n", conflictValue(c.FileValue))
+			fmt.Fprintf(&s, "  数据库: %s\n", conflictValue(c.DBValue))
+		}
+		return strings.TrimRight(s.String(), "\n")
+
+	case "list":
+		list := scopes.List()
+		if len(args) > 1 {
+			scope, err := scopes.Resolve(args[1])
+			if err != nil {
+				return err.Error()
+			}
+			list = []string{scope}
+		}
+		entries, err := sb.memory.ListMemoriesIn(list)
+		if err != nil {
+			return fmt.Sprintf("查询失败: %v", err)
+		}
+		var s strings.Builder
+		for _, scope := range list {
+			fmt.Fprintf(&s, "%s:\n", memory.DescribeScope(scope))
+			n := 0
+			for _, e := range entries {
+				if e.Scope != scope {
+					continue
+				}
+				pin := ""
+				if e.Pinned {
+					pin = " [置顶]"
+				}
+				fmt.Fprintf(&s, "  [%s]%s %s\n", e.Key, pin, truncateText(e.Value, 80))
+				n++
+			}
+			if n == 0 {
+				s.WriteString("  （无）\n")
+			}
+		}
+		return strings.TrimRight(s.String(), "\n")
+
+	case "move", "promote":
+		if (args[0] == "move" && len(args) < 3) || len(args) < 2 {
+			if args[0] == "move" {
+				return "用法: /memory move <key> <global|project|workspace|session>"
+			}
+			return "用法: /memory promote <key>"
+		}
+		key := args[1]
+		entry, err := sb.memory.FindMemory(scopes.List(), key)
+		if err != nil {
+			return memoryError(key, err)
+		}
+		var target string
+		if args[0] == "move" {
+			target, err = scopes.Resolve(args[2])
+		} else {
+			target, err = scopes.Broader(entry.Scope)
+		}
+		if err != nil {
+			return err.Error()
+		}
+		if target == entry.Scope {
+			return fmt.Sprintf("记忆 [%s] 已在%s中", key, memory.DescribeScope(target))
+		}
+		if err := sb.memory.MoveMemory(entry.Scope, key, target); err != nil {
+			return memoryError(key, err)
+		}
+		return fmt.Sprintf("已将 [%s] 从%s移到%s", key, memory.DescribeScope(entry.Scope), memory.DescribeScope(target))
+
+	default:
+		return fmt.Sprintf("未知子命令: %s\n输入 /memory 查看用法", args[0])
+	}
+}
+
+func conflictSide(kept string) string {
+	if kept == "db" {
+		return "数据库"
+	}
+	return "文件"
+}
+
+func conflictValue(v string) string {
+	if v == "" {
+		return "（已删除）"
+	}
+	return truncateText(v, 80)
+}
+
+// memoryError 把记忆操作的错误转为提示
+func memoryError(key string, err error) string {
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Sprintf("记忆 [%s] 不存在", key)
+	}
+	return fmt.Sprintf("操作失败: %v", err)
+}
+
+// proposalIDs 解析候选 ID 列表，all 表示全部待确认候选
+func (sb *SessionBrain) proposalIDs(args []string) ([]int64, error) {
+	if len(args) == 1 && args[0] == "all" {
+		proposals, err := sb.memory.ListProposals()
+		if err != nil {
+			return nil, err
+		}
+		var ids []int64
+		for _, p := range proposals {
+			ids = append(ids, p.ID)
+		}
+		return ids, nil
+	}
+	var ids []int64
+	for _, a := range args {
+		id, err := strconv.ParseInt(strings.TrimPrefix(a, "#"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("无效的候选 ID: %s", a)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func formatProposals(proposals []memory.Proposal) string {
+	var s strings.Builder
+	for _, p := range proposals {
+		fmt.Fprintf(&s, "#%d [%s] %s\n", p.ID, p.Key, p.Value)
+		if p.Scope != memory.ScopeGlobal {
+			fmt.Fprintf(&s, "    作用域: %s\n", memory.DescribeScope(p.Scope))
+		}
+		if p.Reason != "" {
+			fmt.Fprintf(&s, "    原因: %s\n", p.Reason)
+		}
+	}
+	return s.String()
+}
@@ -0,0 +1,71 @@
+package daemon
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/BlakeLiAFK/kele/internal/memory"
+)
+
+// recentQueryTurns 检索记忆时除当前输入外再带上的最近用户发言条数
+const recentQueryTurns = 2
+
+// memoryScopes 返回会话可见的记忆作用域：本会话、所属工作区、
+// 工作目录所在项目和全局
+func (sb *SessionBrain) memoryScopes() memory.Scopes {
+	return memory.Scopes{
+		Session:   memory.SessionScope(sb.sessionID),
+		Workspace: memory.WorkspaceScope(sb.workspaceID),
+		Project:   memory.ProjectScope(sb.executor.GetWorkDir()),
+	}
+}
+
+// selectMemories 按当前输入和最近几条用户发言挑选本轮注入的记忆，
+// 结果保存到 sb.injected，供 getMessages 和 /memory 使用
+func (sb *SessionBrain) selectMemories(userInput string) []memory.Injected {
+	if sb.memory == nil {
+		return nil
+	}
+
+	sb.mu.RLock()
+	query := []string{userInput}
+	// history 末尾是刚加入的当前输入，从它之前开始找
+	for i := len(sb.history) - 2; i >= 0 && len(query) <= recentQueryTurns; i-- {
+		if sb.history[i].Role == "user" {
+			query = append(query, sb.history[i].Content)
+		}
+	}
+	sb.mu.RUnlock()
+
+	scopes := sb.memoryScopes()
+	// 首次进入项目时导入仓库中的 .kele/MEMORY.md
+	if _, err := sb.memory.TrackScope(scopes.Project); err != nil {
+		log.Printf("[memory] sync project memory: %v", err)
+	}
+	injected, err := sb.memory.SelectForPrompt(scopes.List(), strings.Join(query, "\n"), sb.cfg.Memory.InjectTokens)
+	if err != nil {
+		log.Printf("[memory] select memories: %v", err)
+	}
+	if sb.cfg.Debug && len(injected) > 0 {
+		log.Printf("[memory] injected %d memories:\n%s", len(injected), formatInjected(injected))
+	}
+
+	sb.mu.Lock()
+	sb.injected = injected
+	sb.mu.Unlock()
+	return injected
+}
+
+// formatInjected 列出注入的记忆及原因
+func formatInjected(items []memory.Injected) string {
+	var s strings.Builder
+	for _, m := range items {
+		scope := ""
+		if m.Scope != memory.ScopeGlobal {
+			scope = memory.ScopeKind(m.Scope) + "，"
+		}
+		fmt.Fprintf(&s, "[%s] %s（%s%s，约 %d tokens）\n", m.Key, truncateText(m.Value, 60), scope, m.Reason, m.Tokens)
+	}
+	return strings.TrimRight(s.String(), "\n")
+}
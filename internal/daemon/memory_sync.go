@@ -0,0 +1,39 @@
+package daemon
+
+import (
+	"log"
+	"time"
+)
+
+// memoryFileInterval 检查 MEMORY.md 修改的间隔
+const memoryFileInterval = 5 * time.Second
+
+// watchMemoryFiles 定期把 MEMORY.md 和项目 .kele/MEMORY.md 的手工修改同步到数据库
+func (d *Daemon) watchMemoryFiles() {
+	d.memorySync = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(memoryFileInterval)
+		defer ticker.Stop()
+		for {
+			reports, err := d.store.SyncFiles()
+			if err != nil {
+				log.Printf("[memory] sync files: %v", err)
+			}
+			for _, r := range reports {
+				log.Printf("[memory] synced %s: added %v, updated %v, deleted %v",
+					r.Path, r.Added, r.Updated, r.Deleted)
+				for _, c := range r.Conflicts {
+					log.Printf("[memory] conflict on [%s] in %s, kept %s version", c.Key, r.Path, c.Kept)
+				}
+				if len(r.Conflicts) > 0 {
+					log.Printf("[memory] %d conflicts, see /memory conflicts", len(r.Conflicts))
+				}
+			}
+			select {
+			case <-d.memorySync:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
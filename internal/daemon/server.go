@@ -2,11 +2,15 @@ package daemon
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/BlakeLiAFK/kele/internal/config"
+	"github.com/BlakeLiAFK/kele/internal/cron"
+	"github.com/BlakeLiAFK/kele/internal/heartbeat"
+	"github.com/BlakeLiAFK/kele/internal/memory"
 	pb "github.com/BlakeLiAFK/kele/internal/proto"
 	"github.com/BlakeLiAFK/kele/internal/taskboard"
 )
@@ -50,6 +54,9 @@ func (s *Service) Chat(req *pb.ChatRequest, stream pb.KeleService_ChatServer) er
 	}
 
 	for ev := range eventChan {
+		if ev.Type == "usage" {
+			continue
+		}
 		if err := stream.Send(&pb.ChatEvent{
 			Type:       ev.Type,
 			Content:    ev.Content,
@@ -112,6 +119,9 @@ func (s *Service) CreateSession(_ context.Context, req *pb.CreateSessionRequest)
 
 // DeleteSession removes a session.
 func (s *Service) DeleteSession(_ context.Context, req *pb.DeleteSessionRequest) (*pb.Empty, error) {
+	if sess := s.daemon.sessions.Get(req.SessionId); sess != nil {
+		sess.brain.endConversation()
+	}
 	s.daemon.sessions.Delete(req.SessionId)
 	return &pb.Empty{}, nil
 }
@@ -161,6 +171,12 @@ func (s *Service) GetHeartbeatStatus(_ context.Context, _ *pb.Empty) (*pb.Heartb
 		lastRun = hb.LastRun().Format("2006-01-02 15:04:05")
 	}
 
+	nextRun := ""
+	if !hb.NextRun().IsZero() {
+		nextRun = hb.NextRun().Format("2006-01-02 15:04:05")
+	}
+	settings := hb.Settings()
+
 	return &pb.HeartbeatStatusResponse{
 		Active:          hb.IsActive(),
 		IntervalMinutes: int32(hb.IntervalMinutes()),
@@ -168,9 +184,297 @@ func (s *Service) GetHeartbeatStatus(_ context.Context, _ *pb.Empty) (*pb.Heartb
 		LastDecision:    hb.LastDecision(),
 		TotalHeartbeats: int32(hb.TotalHeartbeats()),
 		ActionsTaken:    int32(hb.TotalActions()),
+		NextRun:         nextRun,
+		QuietHours:      settings.QuietHours,
+		ActiveDays:      settings.ActiveDays,
+		MaxRounds:       int32(settings.MaxRounds),
+	}, nil
+}
+
+// GetHeartbeatHistory lists recent heartbeats, or returns one with its transcript.
+func (s *Service) GetHeartbeatHistory(_ context.Context, req *pb.GetHeartbeatHistoryRequest) (*pb.GetHeartbeatHistoryResponse, error) {
+	hb := s.daemon.heartbeat
+	if hb == nil {
+		return nil, fmt.Errorf("heartbeat not available")
+	}
+	if req.Id != 0 {
+		rec, err := hb.Record(req.Id)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.GetHeartbeatHistoryResponse{Records: []*pb.HeartbeatRecord{heartbeatRecordToProto(rec)}}, nil
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 20
+	}
+	records, err := hb.History(limit)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.GetHeartbeatHistoryResponse{}
+	for i := range records {
+		resp.Records = append(resp.Records, heartbeatRecordToProto(&records[i]))
+	}
+	return resp, nil
+}
+
+// ReindexMemory backfills embedding vectors for long-term memories.
+func (s *Service) ReindexMemory(ctx context.Context, req *pb.ReindexMemoryRequest) (*pb.ReindexMemoryResponse, error) {
+	store := s.daemon.store
+	if store == nil {
+		return nil, fmt.Errorf("memory store not available")
+	}
+	embedder := store.Embedder()
+	if embedder == nil {
+		return nil, fmt.Errorf("no embedder configured (set memory.embedder to ollama, openai or hash)")
+	}
+	indexed, total, err := store.Reindex(ctx, req.All)
+	if err != nil {
+		return nil, fmt.Errorf("reindex after %d of %d memories: %w", indexed, total, err)
+	}
+	return &pb.ReindexMemoryResponse{
+		Indexed:  int32(indexed),
+		Total:    int32(total),
+		Embedder: embedder.Name(),
 	}, nil
 }
 
+func (s *Service) SearchHistory(_ context.Context, req *pb.SearchHistoryRequest) (*pb.SearchHistoryResponse, error) {
+	store := s.daemon.store
+	if store == nil {
+		return nil, fmt.Errorf("memory store not available")
+	}
+	q := memory.HistoryQuery{
+		Text:    req.Query,
+		Session: req.Session,
+		Channel: req.Channel,
+		Limit:   int(req.Limit),
+	}
+	var err error
+	if req.Since != "" {
+		if q.Since, err = time.Parse(time.RFC3339, req.Since); err != nil {
+			return nil, fmt.Errorf("invalid since: %w", err)
+		}
+	}
+	if req.Until != "" {
+		if q.Until, err = time.Parse(time.RFC3339, req.Until); err != nil {
+			return nil, fmt.Errorf("invalid until: %w", err)
+		}
+	}
+	hits, err := store.SearchHistory(q)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.SearchHistoryResponse{}
+	for _, h := range hits {
+		resp.Hits = append(resp.Hits, &pb.HistoryHit{
+			Id:          h.ID,
+			TurnId:      h.TurnID,
+			SessionId:   h.SessionID,
+			SessionName: h.SessionName,
+			Channel:     h.Channel,
+			Role:        h.Role,
+			Snippet:     h.Snippet,
+			Timestamp:   h.Timestamp.Local().Format("2006-01-02 15:04:05"),
+		})
+	}
+	return resp, nil
+}
+
+// --- Cron ---
+
+func (s *Service) schedulerOrErr() (*cron.Scheduler, error) {
+	if s.daemon.scheduler == nil {
+		return nil, fmt.Errorf("cron scheduler not available")
+	}
+	return s.daemon.scheduler, nil
+}
+
+func (s *Service) ListCronJobs(_ context.Context, _ *pb.Empty) (*pb.ListCronJobsResponse, error) {
+	sched, err := s.schedulerOrErr()
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := sched.ListJobs()
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListCronJobsResponse{}
+	for i := range jobs {
+		resp.Jobs = append(resp.Jobs, cronJobToProto(&jobs[i]))
+	}
+	return resp, nil
+}
+
+func (s *Service) GetCronJob(_ context.Context, req *pb.CronJobRequest) (*pb.CronJobInfo, error) {
+	sched, err := s.schedulerOrErr()
+	if err != nil {
+		return nil, err
+	}
+	job, _, err := sched.GetJob(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return cronJobToProto(job), nil
+}
+
+func (s *Service) CreateCronJob(_ context.Context, req *pb.CreateCronJobRequest) (*pb.CronJobInfo, error) {
+	sched, err := s.schedulerOrErr()
+	if err != nil {
+		return nil, err
+	}
+	job, err := sched.AddJob(cron.Job{
+		Name:     req.Name,
+		Schedule: req.Schedule,
+		Type:     req.Type,
+		Command:  req.Command,
+		Prompt:   req.Prompt,
+		Model:    req.Model,
+		Tools:    req.Tools,
+		WorkDir:  req.WorkDir,
+		Deliver: cron.Delivery{
+			Channel: req.DeliverChannel,
+			Target:  req.DeliverTarget,
+			When:    req.DeliverWhen,
+		},
+		Timezone: req.Timezone,
+		Overlap:  req.Overlap,
+		CatchUp:  req.CatchUp,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cronJobToProto(job), nil
+}
+
+func (s *Service) UpdateCronJob(_ context.Context, req *pb.UpdateCronJobRequest) (*pb.CronJobInfo, error) {
+	sched, err := s.schedulerOrErr()
+	if err != nil {
+		return nil, err
+	}
+	updates := map[string]interface{}{}
+	for key, v := range map[string]*string{
+		"name":            req.Name,
+		"schedule":        req.Schedule,
+		"type":            req.Type,
+		"command":         req.Command,
+		"prompt":          req.Prompt,
+		"model":           req.Model,
+		"work_dir":        req.WorkDir,
+		"deliver_channel": req.DeliverChannel,
+		"deliver_target":  req.DeliverTarget,
+		"deliver_when":    req.DeliverWhen,
+		"timezone":        req.Timezone,
+		"overlap":         req.Overlap,
+		"catch_up":        req.CatchUp,
+	} {
+		if v != nil {
+			updates[key] = *v
+		}
+	}
+	if req.SetTools {
+		updates["tools"] = req.Tools
+	}
+	job, err := sched.UpdateJob(req.Id, updates)
+	if err != nil {
+		return nil, err
+	}
+	return cronJobToProto(job), nil
+}
+
+func (s *Service) DeleteCronJob(_ context.Context, req *pb.CronJobRequest) (*pb.Empty, error) {
+	sched, err := s.schedulerOrErr()
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Empty{}, sched.DeleteJob(req.Id)
+}
+
+func (s *Service) SetCronJobEnabled(_ context.Context, req *pb.SetCronJobEnabledRequest) (*pb.CronJobInfo, error) {
+	sched, err := s.schedulerOrErr()
+	if err != nil {
+		return nil, err
+	}
+	job, err := sched.UpdateJob(req.Id, map[string]interface{}{"enabled": req.Enabled})
+	if err != nil {
+		return nil, err
+	}
+	return cronJobToProto(job), nil
+}
+
+func (s *Service) RunCronJob(_ context.Context, req *pb.CronJobRequest) (*pb.CronLogEntry, error) {
+	sched, err := s.schedulerOrErr()
+	if err != nil {
+		return nil, err
+	}
+	entry, err := sched.RunJob(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return cronLogToProto(*entry), nil
+}
+
+func (s *Service) GetCronLogs(_ context.Context, req *pb.GetCronLogsRequest) (*pb.GetCronLogsResponse, error) {
+	sched, err := s.schedulerOrErr()
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := sched.GetJob(req.Id); err != nil {
+		return nil, err
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 20
+	}
+	logs, err := sched.Logs(req.Id, limit)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.GetCronLogsResponse{}
+	for _, l := range logs {
+		resp.Logs = append(resp.Logs, cronLogToProto(l))
+	}
+	return resp, nil
+}
+
+func (s *Service) PreviewCronSchedule(_ context.Context, req *pb.PreviewCronScheduleRequest) (*pb.PreviewCronScheduleResponse, error) {
+	schedule, timezone := req.Schedule, req.Timezone
+	if req.Id != "" {
+		sched, err := s.schedulerOrErr()
+		if err != nil {
+			return nil, err
+		}
+		job, _, err := sched.GetJob(req.Id)
+		if err != nil {
+			return nil, err
+		}
+		schedule = job.Schedule
+		if timezone == "" {
+			timezone = job.Timezone
+		}
+	}
+	if schedule == "" {
+		return nil, fmt.Errorf("id or schedule is required")
+	}
+	count := int(req.Count)
+	if count <= 0 {
+		count = 5
+	}
+	if count > 100 {
+		count = 100
+	}
+	times, err := cron.NextRuns(schedule, timezone, time.Now(), count)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.PreviewCronScheduleResponse{}
+	for _, t := range times {
+		resp.Times = append(resp.Times, t.Format(time.RFC3339))
+	}
+	return resp, nil
+}
+
 // ============================================================
 // TaskBoard RPC Handlers
 // ============================================================
@@ -196,6 +500,13 @@ func (s *Service) CreateWorkspace(_ context.Context, req *pb.CreateWorkspaceRequ
 		MaxConcurrent: int(req.MaxConcurrent),
 		Context:       req.Context,
 		WorkDir:       req.WorkDir,
+		MergePolicy:   taskboard.MergePolicy(req.MergePolicy),
+		TaskTimeout:   time.Duration(req.TaskTimeoutSeconds) * time.Second,
+		ApprovalTags:  req.ApprovalTags,
+		Budget:        budgetFromProto(req.Budget),
+	}
+	if ws.MergePolicy != "" && !ws.MergePolicy.Valid() {
+		return nil, fmt.Errorf("invalid merge policy: %s", req.MergePolicy)
 	}
 	if err := board.CreateWorkspace(ws); err != nil {
 		return nil, err
@@ -237,7 +548,27 @@ func (s *Service) UpdateWorkspace(_ context.Context, req *pb.UpdateWorkspaceRequ
 		ws.Context = req.Context
 	}
 	if req.Status != "" {
-		ws.Status = taskboard.WorkspaceStatus(req.Status)
+		status := taskboard.WorkspaceStatus(req.Status)
+		if status != ws.Status && (status == taskboard.WorkspaceTemplate || ws.Status == taskboard.WorkspaceTemplate) {
+			return nil, fmt.Errorf("template status can only be set with MakeWorkspaceTemplate")
+		}
+		ws.Status = status
+	}
+	if req.MergePolicy != "" {
+		policy := taskboard.MergePolicy(req.MergePolicy)
+		if !policy.Valid() {
+			return nil, fmt.Errorf("invalid merge policy: %s", req.MergePolicy)
+		}
+		ws.MergePolicy = policy
+	}
+	if req.TaskTimeoutSeconds != nil {
+		ws.TaskTimeout = time.Duration(*req.TaskTimeoutSeconds) * time.Second
+	}
+	if req.SetApprovalTags {
+		ws.ApprovalTags = req.ApprovalTags
+	}
+	if req.Budget != nil {
+		ws.Budget = budgetFromProto(req.Budget)
 	}
 	if err := board.UpdateWorkspace(ws); err != nil {
 		return nil, err
@@ -294,6 +625,18 @@ func (s *Service) CreateTask(_ context.Context, req *pb.CreateTaskRequest) (*pb.
 		MaxRetries:  int(req.MaxRetries),
 		Tags:        req.Tags,
 		Status:      status,
+
+		VerifyCommand: req.VerifyCommand,
+		VerifyPrompt:  req.VerifyPrompt,
+		Timeout:       time.Duration(req.TimeoutSeconds) * time.Second,
+
+		RequiresApproval: req.RequiresApproval,
+
+		Model:         req.Model,
+		Temperature:   req.Temperature,
+		AllowedTools:  req.Tools,
+		MaxToolRounds: int(req.MaxToolRounds),
+		Artifacts:     req.Artifacts,
 	}
 	if err := board.CreateTask(t); err != nil {
 		return nil, err
@@ -337,6 +680,39 @@ func (s *Service) UpdateTaskRPC(_ context.Context, req *pb.UpdateTaskRequest) (*
 	if len(req.Tags) > 0 {
 		t.Tags = req.Tags
 	}
+	if req.VerifyCommand != "" {
+		t.VerifyCommand = req.VerifyCommand
+	}
+	if req.VerifyPrompt != "" {
+		t.VerifyPrompt = req.VerifyPrompt
+	}
+	if req.SetDependsOn {
+		t.DependsOn = req.DependsOn
+		if t.DependsOn == nil {
+			t.DependsOn = []string{}
+		}
+	}
+	if req.TimeoutSeconds != nil {
+		t.Timeout = time.Duration(*req.TimeoutSeconds) * time.Second
+	}
+	if req.RequiresApproval != nil {
+		t.RequiresApproval = *req.RequiresApproval
+	}
+	if req.Model != nil {
+		t.Model = *req.Model
+	}
+	if req.Temperature != nil {
+		t.Temperature = req.Temperature
+	}
+	if req.SetTools {
+		t.AllowedTools = req.Tools
+	}
+	if req.MaxToolRounds != nil {
+		t.MaxToolRounds = int(*req.MaxToolRounds)
+	}
+	if req.SetArtifacts {
+		t.Artifacts = req.Artifacts
+	}
 	if err := board.UpdateTask(t); err != nil {
 		return nil, err
 	}
@@ -405,22 +781,85 @@ func (s *Service) RetryTask(_ context.Context, req *pb.RetryTaskRequest) (*pb.Ta
 	return taskToProto(t), nil
 }
 
+func (s *Service) MergeTask(_ context.Context, req *pb.MergeTaskRequest) (*pb.TaskInfo, error) {
+	board, err := s.boardOrErr()
+	if err != nil {
+		return nil, err
+	}
+	t, err := board.MergeTask(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return taskToProto(t), nil
+}
+
+func (s *Service) ApproveTask(_ context.Context, req *pb.ReviewTaskRequest) (*pb.TaskInfo, error) {
+	board, err := s.boardOrErr()
+	if err != nil {
+		return nil, err
+	}
+	t, err := board.ApproveTask(req.Id, req.Feedback)
+	if err != nil {
+		return nil, err
+	}
+	return taskToProto(t), nil
+}
+
+func (s *Service) RejectTask(_ context.Context, req *pb.ReviewTaskRequest) (*pb.TaskInfo, error) {
+	board, err := s.boardOrErr()
+	if err != nil {
+		return nil, err
+	}
+	t, err := board.RejectTask(req.Id, req.Feedback)
+	if err != nil {
+		return nil, err
+	}
+	return taskToProto(t), nil
+}
+
 // --- Planner ---
 
 func (s *Service) PlanWorkspace(req *pb.PlanWorkspaceRequest, stream pb.KeleService_PlanWorkspaceServer) error {
 	if s.daemon.planner == nil {
 		return fmt.Errorf("planner not initialized")
 	}
-	events, err := s.daemon.planner.Plan(req.Goal)
+	events, err := s.daemon.planner.Plan(req.Goal, req.WorkDir)
 	if err != nil {
 		return err
 	}
+	return s.streamPlanEvents(stream, events, func(plan *taskboard.PlanResult) (string, error) {
+		board, err := s.boardOrErr()
+		if err != nil {
+			return "", err
+		}
+		d, err := board.CreatePlanDraft(req.Goal, req.WorkDir, plan)
+		if err != nil {
+			return "", err
+		}
+		return d.ID, nil
+	})
+}
+
+// streamPlanEvents forwards planner events to the client; on plan_ready the
+// plan is handed to save, which persists it and returns the draft ID.
+func (s *Service) streamPlanEvents(stream interface{ Send(*pb.PlanEventMsg) error }, events <-chan taskboard.PlanEvent,
+	save func(*taskboard.PlanResult) (string, error)) error {
 	for ev := range events {
-		if err := stream.Send(&pb.PlanEventMsg{
+		msg := &pb.PlanEventMsg{
 			Type:     ev.Type,
 			Content:  ev.Content,
 			PlanJson: ev.PlanJSON,
-		}); err != nil {
+		}
+		if ev.Type == "plan_ready" {
+			plan, err := taskboard.ParsePlan(ev.PlanJSON)
+			if err == nil {
+				msg.DraftId, err = save(plan)
+			}
+			if err != nil {
+				msg = &pb.PlanEventMsg{Type: "error", Content: fmt.Sprintf("save draft: %v", err)}
+			}
+		}
+		if err := stream.Send(msg); err != nil {
 			return err
 		}
 	}
@@ -435,14 +874,38 @@ func (s *Service) ApprovePlan(_ context.Context, req *pb.ApprovePlanRequest) (*p
 	if s.daemon.planner == nil {
 		return nil, fmt.Errorf("planner not initialized")
 	}
-	plan, err := taskboard.ParsePlan(req.PlanJson)
-	if err != nil {
-		return nil, err
+	var plan *taskboard.PlanResult
+	goal, workDir := req.Goal, req.WorkDir
+	if req.DraftId != "" {
+		d, err := board.GetPlanDraft(req.DraftId)
+		if err != nil {
+			return nil, fmt.Errorf("draft %s: %w", req.DraftId, err)
+		}
+		if err := d.Plan.Validate(); err != nil {
+			return nil, err
+		}
+		plan = d.Plan
+		if goal == "" {
+			goal = d.Goal
+		}
+		if workDir == "" {
+			workDir = d.WorkDir
+		}
+	} else {
+		plan, err = taskboard.ParsePlan(req.PlanJson)
+		if err != nil {
+			return nil, err
+		}
 	}
-	ws, tasks, err := s.daemon.planner.ApproveAndCreate(board, plan, req.Goal, req.WorkDir, req.AutoStart)
+	ws, tasks, err := s.daemon.planner.ApproveAndCreate(board, plan, goal, workDir, req.AutoStart)
 	if err != nil {
 		return nil, err
 	}
+	if req.DraftId != "" {
+		if err := board.DeletePlanDraft(req.DraftId); err != nil {
+			log.Printf("delete approved draft %s: %v", req.DraftId, err)
+		}
+	}
 	wsInfo, err := wsToProto(ws, board)
 	if err != nil {
 		return nil, err
@@ -454,6 +917,148 @@ func (s *Service) ApprovePlan(_ context.Context, req *pb.ApprovePlanRequest) (*p
 	return resp, nil
 }
 
+// --- Plan Drafts ---
+
+func (s *Service) ListPlanDrafts(_ context.Context, _ *pb.Empty) (*pb.ListPlanDraftsResponse, error) {
+	board, err := s.boardOrErr()
+	if err != nil {
+		return nil, err
+	}
+	drafts, err := board.ListPlanDrafts()
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListPlanDraftsResponse{}
+	for _, d := range drafts {
+		resp.Drafts = append(resp.Drafts, draftToProto(d))
+	}
+	return resp, nil
+}
+
+func (s *Service) GetPlanDraft(_ context.Context, req *pb.GetPlanDraftRequest) (*pb.PlanDraftInfo, error) {
+	board, err := s.boardOrErr()
+	if err != nil {
+		return nil, err
+	}
+	d, err := board.GetPlanDraft(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return draftToProto(d), nil
+}
+
+func (s *Service) DeletePlanDraft(_ context.Context, req *pb.DeletePlanDraftRequest) (*pb.Empty, error) {
+	board, err := s.boardOrErr()
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Empty{}, board.DeletePlanDraft(req.Id)
+}
+
+func (s *Service) AddPlanTask(_ context.Context, req *pb.AddPlanTaskRequest) (*pb.PlanDraftInfo, error) {
+	return s.editPlanDraft(req.DraftId, func(p *taskboard.PlanResult) error {
+		i := p.AddTask(taskboard.PlannedTask{
+			Title:       req.Title,
+			Description: req.Description,
+			Prompt:      req.Prompt,
+			Priority:    2,
+			DependsOn:   intsFromProto(req.DependsOn),
+			Tags:        req.Tags,
+		})
+		if req.Priority != nil {
+			if err := p.SetPriority(i, int(*req.Priority)); err != nil {
+				return err
+			}
+		}
+		if req.Position != nil {
+			return p.MoveTask(i, int(*req.Position))
+		}
+		return nil
+	})
+}
+
+func (s *Service) RemovePlanTask(_ context.Context, req *pb.RemovePlanTaskRequest) (*pb.PlanDraftInfo, error) {
+	return s.editPlanDraft(req.DraftId, func(p *taskboard.PlanResult) error {
+		return p.RemoveTask(int(req.Index))
+	})
+}
+
+func (s *Service) MovePlanTask(_ context.Context, req *pb.MovePlanTaskRequest) (*pb.PlanDraftInfo, error) {
+	return s.editPlanDraft(req.DraftId, func(p *taskboard.PlanResult) error {
+		return p.MoveTask(int(req.From), int(req.To))
+	})
+}
+
+func (s *Service) UpdatePlanTask(_ context.Context, req *pb.UpdatePlanTaskRequest) (*pb.PlanDraftInfo, error) {
+	return s.editPlanDraft(req.DraftId, func(p *taskboard.PlanResult) error {
+		i := int(req.Index)
+		t, err := p.TaskAt(i)
+		if err != nil {
+			return err
+		}
+		if req.SetDependsOn {
+			if err := p.SetDependencies(i, intsFromProto(req.DependsOn)); err != nil {
+				return err
+			}
+		}
+		if req.Priority != nil {
+			if err := p.SetPriority(i, int(*req.Priority)); err != nil {
+				return err
+			}
+		}
+		if req.Title != "" {
+			t.Title = req.Title
+		}
+		if req.Description != "" {
+			t.Description = req.Description
+		}
+		if req.Prompt != "" {
+			t.Prompt = req.Prompt
+		}
+		if len(req.Tags) > 0 {
+			t.Tags = req.Tags
+		}
+		return nil
+	})
+}
+
+func (s *Service) RevisePlan(req *pb.RevisePlanRequest, stream pb.KeleService_RevisePlanServer) error {
+	board, err := s.boardOrErr()
+	if err != nil {
+		return err
+	}
+	if s.daemon.planner == nil {
+		return fmt.Errorf("planner not initialized")
+	}
+	d, err := board.GetPlanDraft(req.DraftId)
+	if err != nil {
+		return fmt.Errorf("draft %s: %w", req.DraftId, err)
+	}
+	events, err := s.daemon.planner.Revise(d.Goal, d.Plan, req.Feedback)
+	if err != nil {
+		return err
+	}
+	return s.streamPlanEvents(stream, events, func(plan *taskboard.PlanResult) (string, error) {
+		_, err := board.EditPlanDraft(d.ID, func(p *taskboard.PlanResult) error {
+			*p = *plan
+			return nil
+		})
+		return d.ID, err
+	})
+}
+
+func (s *Service) editPlanDraft(id string, edit func(*taskboard.PlanResult) error) (*pb.PlanDraftInfo, error) {
+	board, err := s.boardOrErr()
+	if err != nil {
+		return nil, err
+	}
+	d, err := board.EditPlanDraft(id, edit)
+	if err != nil {
+		return nil, err
+	}
+	return draftToProto(d), nil
+}
+
 // --- Board Overview ---
 
 func (s *Service) GetBoardOverview(_ context.Context, _ *pb.Empty) (*pb.BoardOverviewMsg, error) {
@@ -481,7 +1086,10 @@ func (s *Service) GetBoardOverview(_ context.Context, _ *pb.Empty) (*pb.BoardOve
 			Running:       int32(wo.Running),
 			Done:          int32(wo.Done),
 			Failed:        int32(wo.Failed),
+			Blocked:       int32(wo.Blocked),
+			Review:        int32(wo.Review),
 			MaxConcurrent: int32(wo.MaxConcurrent),
+			Budget:        budgetToProto(wo.Budget, wo.Usage),
 		})
 	}
 	return resp, nil
@@ -543,8 +1151,308 @@ func (s *Service) GetTaskLog(_ context.Context, req *pb.GetTaskLogRequest) (*pb.
 	return resp, nil
 }
 
+func (s *Service) SearchTasks(_ context.Context, req *pb.SearchTasksRequest) (*pb.SearchTasksResponse, error) {
+	board, err := s.boardOrErr()
+	if err != nil {
+		return nil, err
+	}
+	q := taskboard.SearchQuery{
+		Text:        req.Query,
+		WorkspaceID: req.WorkspaceId,
+		Tool:        req.Tool,
+		Limit:       int(req.Limit),
+	}
+	if req.Since != "" {
+		if q.Since, err = time.Parse(time.RFC3339, req.Since); err != nil {
+			return nil, fmt.Errorf("invalid since: %w", err)
+		}
+	}
+	hits, err := board.Store().SearchTasks(q)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.SearchTasksResponse{}
+	for _, h := range hits {
+		hit := &pb.TaskSearchHit{
+			TaskId:       h.Task.ID,
+			WorkspaceId:  h.Task.WorkspaceID,
+			Title:        h.Task.Title,
+			Status:       string(h.Task.Status),
+			TotalMatches: int32(h.Total),
+		}
+		for _, m := range h.Matches {
+			match := &pb.TaskSearchMatch{
+				Field:     m.Field,
+				EventType: m.EventType,
+				ToolName:  m.ToolName,
+				Snippet:   m.Snippet,
+			}
+			if !m.Timestamp.IsZero() {
+				match.Timestamp = m.Timestamp.Local().Format("2006-01-02 15:04:05")
+			}
+			hit.Matches = append(hit.Matches, match)
+		}
+		resp.Hits = append(resp.Hits, hit)
+	}
+	return resp, nil
+}
+
+// --- Workspace Schedules ---
+
+func (s *Service) MakeWorkspaceTemplate(_ context.Context, req *pb.GetWorkspaceRequest) (*pb.WorkspaceInfo, error) {
+	board, err := s.boardOrErr()
+	if err != nil {
+		return nil, err
+	}
+	ws, err := board.MakeTemplate(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return wsToProto(ws, board)
+}
+
+func (s *Service) CreateWorkspaceSchedule(_ context.Context, req *pb.CreateWorkspaceScheduleRequest) (*pb.WorkspaceScheduleInfo, error) {
+	board, err := s.boardOrErr()
+	if err != nil {
+		return nil, err
+	}
+	sc := &taskboard.WorkspaceSchedule{
+		TemplateID: req.TemplateId,
+		Cron:       req.Cron,
+		Channel:    req.Channel,
+		Target:     req.Target,
+		Enabled:    true,
+	}
+	if err := board.CreateSchedule(sc); err != nil {
+		return nil, err
+	}
+	return scheduleToProto(sc, board), nil
+}
+
+func (s *Service) ListWorkspaceSchedules(_ context.Context, _ *pb.Empty) (*pb.ListWorkspaceSchedulesResponse, error) {
+	board, err := s.boardOrErr()
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := board.Store().ListSchedules()
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListWorkspaceSchedulesResponse{}
+	for _, sc := range schedules {
+		resp.Schedules = append(resp.Schedules, scheduleToProto(sc, board))
+	}
+	return resp, nil
+}
+
+func (s *Service) DeleteWorkspaceSchedule(_ context.Context, req *pb.WorkspaceScheduleRequest) (*pb.Empty, error) {
+	board, err := s.boardOrErr()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := board.Store().GetSchedule(req.Id); err != nil {
+		return nil, err
+	}
+	return &pb.Empty{}, board.Store().DeleteSchedule(req.Id)
+}
+
+func (s *Service) SetWorkspaceScheduleEnabled(_ context.Context, req *pb.SetWorkspaceScheduleEnabledRequest) (*pb.WorkspaceScheduleInfo, error) {
+	board, err := s.boardOrErr()
+	if err != nil {
+		return nil, err
+	}
+	sc, err := board.SetScheduleEnabled(req.Id, req.Enabled)
+	if err != nil {
+		return nil, err
+	}
+	return scheduleToProto(sc, board), nil
+}
+
+func (s *Service) RunWorkspaceSchedule(_ context.Context, req *pb.WorkspaceScheduleRequest) (*pb.WorkspaceInfo, error) {
+	board, err := s.boardOrErr()
+	if err != nil {
+		return nil, err
+	}
+	ws, err := board.RunSchedule(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return wsToProto(ws, board)
+}
+
+// --- Workspace Plan Files ---
+
+func (s *Service) ExportWorkspace(_ context.Context, req *pb.ExportWorkspaceRequest) (*pb.ExportWorkspaceResponse, error) {
+	board, err := s.boardOrErr()
+	if err != nil {
+		return nil, err
+	}
+	f, err := board.ExportWorkspace(req.Id, taskboard.ExportOptions{
+		Results: req.WithResults,
+		Logs:    int(req.LogLimit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, err := taskboard.MarshalPlanFile(f, req.Format)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ExportWorkspaceResponse{Data: data}, nil
+}
+
+func (s *Service) ImportWorkspace(_ context.Context, req *pb.ImportWorkspaceRequest) (*pb.WorkspaceInfo, error) {
+	board, err := s.boardOrErr()
+	if err != nil {
+		return nil, err
+	}
+	f, err := taskboard.ParsePlanFile(req.Data)
+	if err != nil {
+		return nil, err
+	}
+	ws, _, err := board.ImportPlanFile(f, req.Vars, req.WorkDir, req.Paused)
+	if err != nil {
+		return nil, err
+	}
+	return wsToProto(ws, board)
+}
+
+// --- Task Artifacts ---
+
+func (s *Service) ListTaskArtifacts(_ context.Context, req *pb.GetTaskRequest) (*pb.ListTaskArtifactsResponse, error) {
+	board, err := s.boardOrErr()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := board.GetTask(req.Id); err != nil {
+		return nil, err
+	}
+	artifacts, err := board.Store().ListArtifacts(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListTaskArtifactsResponse{}
+	for _, a := range artifacts {
+		resp.Artifacts = append(resp.Artifacts, artifactToProto(a))
+	}
+	return resp, nil
+}
+
+func (s *Service) GetTaskArtifact(_ context.Context, req *pb.GetTaskArtifactRequest) (*pb.TaskArtifact, error) {
+	board, err := s.boardOrErr()
+	if err != nil {
+		return nil, err
+	}
+	a, data, err := board.Store().ReadArtifact(req.TaskId, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.TaskArtifact{Info: artifactToProto(a), Content: data}, nil
+}
+
 // --- Proto conversion helpers ---
 
+func heartbeatRecordToProto(rec *heartbeat.Record) *pb.HeartbeatRecord {
+	info := &pb.HeartbeatRecord{
+		Id:           rec.ID,
+		Timestamp:    rec.Timestamp.Local().Format("2006-01-02 15:04:05"),
+		Status:       rec.Status,
+		Decision:     rec.Decision,
+		Error:        rec.Error,
+		ActionsTaken: int32(rec.ActionsTaken),
+		Rounds:       int32(rec.Rounds),
+		DurationMs:   rec.Duration.Milliseconds(),
+	}
+	for _, m := range rec.Transcript {
+		msg := &pb.HeartbeatMessage{Role: m.Role, Content: m.Content}
+		for _, tc := range m.ToolCalls {
+			msg.ToolCalls = append(msg.ToolCalls, fmt.Sprintf("%s(%s)", tc.Function.Name, tc.Function.Arguments))
+		}
+		info.Transcript = append(info.Transcript, msg)
+	}
+	return info
+}
+
+func cronJobToProto(job *cron.Job) *pb.CronJobInfo {
+	info := &pb.CronJobInfo{
+		Id:             job.ID,
+		Name:           job.Name,
+		Schedule:       job.Schedule,
+		Type:           job.Type,
+		Command:        job.Command,
+		Prompt:         job.Prompt,
+		Model:          job.Model,
+		Tools:          job.Tools,
+		WorkDir:        job.WorkDir,
+		DeliverChannel: job.Deliver.Channel,
+		DeliverTarget:  job.Deliver.Target,
+		DeliverWhen:    job.Deliver.When,
+		Timezone:       job.Timezone,
+		Overlap:        job.Overlap,
+		CatchUp:        job.CatchUp,
+		Enabled:        job.Enabled,
+		LastResult:     job.LastResult,
+		LastError:      job.LastError,
+		CreatedAt:      job.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+	}
+	if info.Type == "" {
+		info.Type = cron.JobCommand
+	}
+	if job.LastRun != nil {
+		info.LastRun = job.LastRun.Local().Format("2006-01-02 15:04:05")
+	}
+	if job.NextRun != nil {
+		info.NextRun = job.NextRun.Local().Format("2006-01-02 15:04:05")
+	}
+	return info
+}
+
+func cronLogToProto(l cron.LogEntry) *pb.CronLogEntry {
+	return &pb.CronLogEntry{
+		Id:         int64(l.ID),
+		JobId:      l.JobID,
+		RunAt:      l.RunAt.Local().Format("2006-01-02 15:04:05"),
+		Output:     l.Output,
+		Error:      l.Error,
+		DurationMs: l.DurationMs,
+	}
+}
+
+func scheduleToProto(sc *taskboard.WorkspaceSchedule, board *taskboard.Board) *pb.WorkspaceScheduleInfo {
+	info := &pb.WorkspaceScheduleInfo{
+		Id:              sc.ID,
+		TemplateId:      sc.TemplateID,
+		Cron:            sc.Cron,
+		Channel:         sc.Channel,
+		Target:          sc.Target,
+		Enabled:         sc.Enabled,
+		LastWorkspaceId: sc.LastWorkspaceID,
+		LastError:       sc.LastError,
+		LastSummary:     sc.LastSummary,
+	}
+	if tmpl, err := board.GetWorkspace(sc.TemplateID); err == nil {
+		info.TemplateName = tmpl.Name
+	}
+	if !sc.LastRun.IsZero() {
+		info.LastRun = sc.LastRun.Format("2006-01-02 15:04:05")
+	}
+	if !sc.NextRun.IsZero() {
+		info.NextRun = sc.NextRun.Format("2006-01-02 15:04:05")
+	}
+	return info
+}
+
+func artifactToProto(a *taskboard.Artifact) *pb.ArtifactInfo {
+	return &pb.ArtifactInfo{
+		TaskId:    a.TaskID,
+		Name:      a.Name,
+		Kind:      string(a.Kind),
+		Size:      a.Size,
+		Path:      a.Path,
+		CreatedAt: a.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
 func wsToProto(ws *taskboard.Workspace, board *taskboard.Board) (*pb.WorkspaceInfo, error) {
 	counts, _ := board.Store().CountByStatus(ws.ID)
 	taskCount := 0
@@ -566,9 +1474,44 @@ func wsToProto(ws *taskboard.Workspace, board *taskboard.Board) (*pb.WorkspaceIn
 		TaskCount:     int32(taskCount),
 		RunningCount:  int32(runningCount),
 		CreatedAt:     ws.CreatedAt.Format("2006-01-02 15:04:05"),
+		MergePolicy:   string(ws.MergePolicy),
+
+		TaskTimeoutSeconds: int64(ws.TaskTimeout / time.Second),
+		ApprovalTags:       ws.ApprovalTags,
+		Budget:             budgetToProto(ws.Budget, ws.Usage),
+		ScheduleId:         ws.ScheduleID,
 	}, nil
 }
 
+func budgetToProto(b taskboard.Budget, u taskboard.BudgetUsage) *pb.BudgetInfo {
+	now := time.Now()
+	return &pb.BudgetInfo{
+		MaxTokens:          b.MaxTokens,
+		MaxCost:            b.MaxCost,
+		TokenPrice:         b.TokenPrice,
+		MaxDurationSeconds: int64(b.MaxDuration / time.Second),
+		MaxToolCalls:       int32(b.MaxToolCalls),
+		UsedTokens:         u.Tokens,
+		UsedToolCalls:      u.ToolCalls,
+		UsedCost:           u.Cost(b),
+		ElapsedSeconds:     int64(u.Elapsed(now) / time.Second),
+		Exceeded:           b.Exceeded(u, now),
+	}
+}
+
+func budgetFromProto(b *pb.BudgetInfo) taskboard.Budget {
+	if b == nil {
+		return taskboard.Budget{}
+	}
+	return taskboard.Budget{
+		MaxTokens:    b.MaxTokens,
+		MaxCost:      b.MaxCost,
+		TokenPrice:   b.TokenPrice,
+		MaxDuration:  time.Duration(b.MaxDurationSeconds) * time.Second,
+		MaxToolCalls: int(b.MaxToolCalls),
+	}
+}
+
 func taskToProto(t *taskboard.Task) *pb.TaskInfo {
 	startedAt := ""
 	if !t.StartedAt.IsZero() {
@@ -578,6 +1521,10 @@ func taskToProto(t *taskboard.Task) *pb.TaskInfo {
 	if !t.CompletedAt.IsZero() {
 		completedAt = t.CompletedAt.Format("2006-01-02 15:04:05")
 	}
+	nextAttemptAt := ""
+	if t.Status == taskboard.StatusReady && t.NextAttemptAt.After(time.Now()) {
+		nextAttemptAt = t.NextAttemptAt.Format("2006-01-02 15:04:05")
+	}
 	return &pb.TaskInfo{
 		Id:              t.ID,
 		WorkspaceId:     t.WorkspaceID,
@@ -596,5 +1543,47 @@ func taskToProto(t *taskboard.Task) *pb.TaskInfo {
 		CreatedAt:       t.CreatedAt.Format("2006-01-02 15:04:05"),
 		StartedAt:       startedAt,
 		CompletedAt:     completedAt,
+		Branch:          t.Branch,
+		Diff:            t.Diff,
+		MergeStatus:     string(t.MergeStatus),
+		VerifyCommand:   t.VerifyCommand,
+		VerifyPrompt:    t.VerifyPrompt,
+		Verdict:         string(t.Verdict),
+		VerifyNotes:     t.VerifyNotes,
+		BlockedReason:   t.BlockedReason,
+		TimeoutSeconds:  int64(t.Timeout / time.Second),
+
+		RequiresApproval: t.RequiresApproval,
+		ReviewFeedback:   t.ReviewFeedback,
+
+		Model:         t.Model,
+		Temperature:   t.Temperature,
+		Tools:         t.AllowedTools,
+		MaxToolRounds: int32(t.MaxToolRounds),
+		Artifacts:     t.Artifacts,
+
+		NextAttemptAt:    nextAttemptAt,
+		ErrorClass:       string(t.ErrorClass),
+		TransientRetries: int32(t.TransientRetries),
+	}
+}
+
+func draftToProto(d *taskboard.PlanDraft) *pb.PlanDraftInfo {
+	planJSON, _ := json.Marshal(d.Plan)
+	return &pb.PlanDraftInfo{
+		Id:        d.ID,
+		Goal:      d.Goal,
+		WorkDir:   d.WorkDir,
+		PlanJson:  string(planJSON),
+		CreatedAt: d.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: d.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func intsFromProto(v []int32) []int {
+	out := make([]int, len(v))
+	for i, n := range v {
+		out[i] = int(n)
 	}
+	return out
 }
@@ -1,9 +1,9 @@
 package daemon
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -35,8 +35,15 @@ type SessionBrain struct {
 	cfg             *config.Config
 	injectedContext string // additional context prepended to system prompt
 	workspace       *workspace.Manager
-	currentWork     string      // 当前工作空间名
-	answerChan      chan string // ask_user 工具等待用户回答
+	currentWork     string              // 当前工作空间名
+	answerChan      chan string         // ask_user 工具等待用户回答
+	overrides       llm.StreamOverrides // 会话级模型/温度覆盖，零值使用全局配置
+	maxToolRounds   int                 // 最大工具轮数，0 使用全局配置
+	injected        []memory.Injected   // 本轮注入 system prompt 的记忆
+	sessionID       string              // 会话 ID，用于会话作用域的记忆
+	sessionName     string              // 会话名，记入对话归档
+	channel         string              // 对话来源渠道（cli/telegram/task），记入对话归档
+	workspaceID     string              // 所属 TaskBoard 工作区，用于工作区作用域的记忆
 }
 
 // SessionManager manages all active sessions.
@@ -65,6 +72,97 @@ func NewSessionManager(provider *llm.ProviderManager, executor *tools.Executor,
 
 // Create creates a new session and returns it.
 func (sm *SessionManager) Create(name string) *Session {
+	return sm.create(name, sm.executor)
+}
+
+// CreateWithWorkDir creates a session whose tools run in workDir.
+// The session gets its own forked executor so other sessions are unaffected.
+func (sm *SessionManager) CreateWithWorkDir(name, workDir string) *Session {
+	if workDir == "" {
+		return sm.Create(name)
+	}
+	return sm.create(name, sm.executor.Fork(workDir))
+}
+
+// CreateReadOnly creates a session that can only browse workDir: file
+// reading, code search, directory listing and read-only git commands.
+func (sm *SessionManager) CreateReadOnly(name, workDir string) *Session {
+	if workDir == "" {
+		workDir = sm.executor.GetWorkDir()
+	}
+	return sm.create(name, sm.executor.ForkReadOnly(workDir))
+}
+
+// SessionOptions configures a session created with CreateWithOptions.
+// Zero fields fall back to the daemon's global settings.
+type SessionOptions struct {
+	WorkDir       string   // tool working directory; empty uses the daemon's default
+	WorkspaceID   string   // TaskBoard workspace; scopes the session's memories
+	Channel       string   // origin recorded in the conversation archive; empty keeps "cli"
+	ReadOnly      bool     // only expose tools that cannot modify the working directory
+	Model         string   // model name or tier ("small"/"large")
+	Temperature   *float64 // sampling temperature
+	AllowedTools  []string // tool whitelist
+	MaxToolRounds int      // tool call rounds per chat turn
+}
+
+// CreateWithOptions creates a session with its own model, tool set and
+// round limit. A whitelist made only of read-only tools gets the read-only
+// executor, so e.g. "git" is restricted to query subcommands as well.
+func (sm *SessionManager) CreateWithOptions(name string, opts SessionOptions) *Session {
+	readOnly := opts.ReadOnly || (len(opts.AllowedTools) > 0 && onlyReadOnlyTools(opts.AllowedTools))
+	workDir := opts.WorkDir
+	var executor *tools.Executor
+	switch {
+	case readOnly:
+		if workDir == "" {
+			workDir = sm.executor.GetWorkDir()
+		}
+		executor = sm.executor.ForkReadOnly(workDir)
+	case workDir != "" || len(opts.AllowedTools) > 0:
+		if workDir == "" {
+			workDir = sm.executor.GetWorkDir()
+		}
+		executor = sm.executor.Fork(workDir)
+	default:
+		executor = sm.executor
+	}
+	if len(opts.AllowedTools) > 0 {
+		allowed := opts.AllowedTools
+		if readOnly {
+			allowed = intersectTools(allowed, tools.ReadOnlyTools)
+		}
+		executor.SetAllowedTools(allowed)
+	}
+
+	sess := sm.create(name, executor)
+	sess.brain.overrides = llm.StreamOverrides{Model: opts.Model, Temperature: opts.Temperature}
+	sess.brain.maxToolRounds = opts.MaxToolRounds
+	sess.brain.workspaceID = opts.WorkspaceID
+	if opts.Channel != "" {
+		sess.brain.channel = opts.Channel
+	}
+	return sess
+}
+
+func onlyReadOnlyTools(names []string) bool {
+	return len(intersectTools(names, tools.ReadOnlyTools)) == len(names)
+}
+
+func intersectTools(names, allowed []string) []string {
+	var out []string
+	for _, name := range names {
+		for _, a := range allowed {
+			if name == a {
+				out = append(out, name)
+				break
+			}
+		}
+	}
+	return out
+}
+
+func (sm *SessionManager) create(name string, executor *tools.Executor) *Session {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
@@ -78,13 +176,16 @@ func (sm *SessionManager) Create(name string) *Session {
 		ID:   id,
 		Name: name,
 		brain: &SessionBrain{
-			provider:   sm.provider,
-			executor:   sm.executor,
-			memory:     sm.memory,
-			history:    []llm.Message{},
-			cfg:        sm.cfg,
-			workspace:  sm.workspace,
-			answerChan: make(chan string, 1),
+			provider:    sm.provider,
+			executor:    executor,
+			memory:      sm.memory,
+			history:     []llm.Message{},
+			cfg:         sm.cfg,
+			workspace:   sm.workspace,
+			answerChan:  make(chan string, 1),
+			sessionID:   id,
+			sessionName: name,
+			channel:     "cli",
 		},
 	}
 	sm.sessions[id] = sess
@@ -98,11 +199,14 @@ func (sm *SessionManager) Get(id string) *Session {
 	return sm.sessions[id]
 }
 
-// Delete removes a session.
+// Delete removes a session and discards its session-scoped memories.
 func (sm *SessionManager) Delete(id string) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 	delete(sm.sessions, id)
+	if sm.memory != nil {
+		sm.memory.DeleteScope(memory.SessionScope(id))
+	}
 }
 
 // List returns all active sessions.
@@ -135,22 +239,51 @@ func (sb *SessionBrain) Answer(text string) {
 
 // ChatStream starts a streaming chat with tool auto-loop.
 func (sb *SessionBrain) ChatStream(userInput string) (<-chan ChatEvent, error) {
+	return sb.ChatStreamContext(context.Background(), userInput)
+}
+
+// ChatStreamContext is ChatStream bound to ctx: cancelling it aborts the
+// LLM request in flight, kills running bash/python tool processes and ends
+// the stream with an error event.
+func (sb *SessionBrain) ChatStreamContext(ctx context.Context, userInput string) (<-chan ChatEvent, error) {
 	eventChan := make(chan ChatEvent, 100)
 
+	// 记忆工具按会话可见的作用域读写
+	ctx = tools.WithMemoryScopes(ctx, sb.memoryScopes())
+
 	go func() {
 		defer close(eventChan)
 
 		sb.addMessage("user", userInput)
+		if injected := sb.selectMemories(userInput); len(injected) > 0 {
+			eventChan <- ChatEvent{Type: "memory", Content: formatInjected(injected)}
+		}
 
 		maxToolRounds := sb.cfg.LLM.MaxToolRounds
+		if sb.maxToolRounds > 0 {
+			maxToolRounds = sb.maxToolRounds
+		}
 		var finalContent string
 
 		for round := 0; round < maxToolRounds; round++ {
-			llmEvents := sb.provider.ChatStream(sb.getMessages(), sb.executor.GetTools())
+			if ctx.Err() != nil {
+				eventChan <- ChatEvent{Type: "error", Error: abortedError(ctx)}
+				return
+			}
+			promptTokens := sb.estimateTokens()
+			llmEvents := sb.provider.ChatStreamWith(ctx, sb.getMessages(), sb.executor.GetTools(), sb.overrides)
 
 			roundContent := ""
 			var pendingToolCalls []llm.ToolCall
 			gotToolCalls := false
+			// 每轮结束时上报估算用量（提示 + 输出），供任务预算统计
+			reportUsage := func() {
+				output := len(roundContent)
+				for _, tc := range pendingToolCalls {
+					output += len(tc.Function.Arguments)
+				}
+				eventChan <- ChatEvent{Type: "usage", Tokens: promptTokens + output/4}
+			}
 
 			for event := range llmEvents {
 				switch event.Type {
@@ -167,24 +300,24 @@ func (sb *SessionBrain) ChatStream(userInput string) (<-chan ChatEvent, error) {
 					if event.Error != nil {
 						errStr = event.Error.Error()
 					}
+					if ctx.Err() != nil {
+						errStr = abortedError(ctx)
+					}
 					eventChan <- ChatEvent{Type: "error", Error: errStr}
 					return
 				case "done":
+					reportUsage()
 					if roundContent != "" {
 						sb.addMessage("assistant", roundContent)
 						finalContent = roundContent
 					}
-					if sb.memory != nil {
-						sb.memory.SaveMessage("user", userInput)
-						if finalContent != "" {
-							sb.memory.SaveMessage("assistant", finalContent)
-						}
-					}
+					sb.archiveTurn(userInput, finalContent)
 					eventChan <- ChatEvent{Type: "done"}
 					return
 				}
 			}
 
+			reportUsage()
 			if gotToolCalls {
 				assistantMsg := llm.Message{
 					Role:      "assistant",
@@ -194,6 +327,10 @@ func (sb *SessionBrain) ChatStream(userInput string) (<-chan ChatEvent, error) {
 				sb.appendRawMessage(assistantMsg)
 
 				for _, tc := range pendingToolCalls {
+					if ctx.Err() != nil {
+						eventChan <- ChatEvent{Type: "error", Error: abortedError(ctx)}
+						return
+					}
 					// 拦截 ask_user 工具
 					if tc.Function.Name == "ask_user" {
 						result := sb.handleAskUser(tc, eventChan)
@@ -207,10 +344,11 @@ func (sb *SessionBrain) ChatStream(userInput string) (<-chan ChatEvent, error) {
 
 					eventChan <- ChatEvent{
 						Type:     "tool_call",
+						Content:  tc.Function.Arguments,
 						ToolName: tc.Function.Name,
 					}
 
-					result, err := sb.executor.Execute(tc)
+					result, err := sb.executor.ExecuteContext(ctx, tc)
 					if err != nil {
 						result = fmt.Sprintf("Error: %v", err)
 					}
@@ -235,17 +373,17 @@ func (sb *SessionBrain) ChatStream(userInput string) (<-chan ChatEvent, error) {
 				sb.addMessage("assistant", roundContent)
 				finalContent = roundContent
 			}
-			if sb.memory != nil {
-				sb.memory.SaveMessage("user", userInput)
-				if finalContent != "" {
-					sb.memory.SaveMessage("assistant", finalContent)
-				}
-			}
+			sb.archiveTurn(userInput, finalContent)
 			eventChan <- ChatEvent{Type: "done"}
 			return
 		}
 
 		sb.addMessage("assistant", "[reached max tool rounds]")
+		if sb.channel == "task" {
+			// 任务在轮数上限内没有完成，按失败上报，由调度器走重试流程
+			eventChan <- ChatEvent{Type: "error", Error: fmt.Sprintf("已达到最大工具轮数 (%d)，任务未完成", maxToolRounds)}
+			return
+		}
 		eventChan <- ChatEvent{Type: "done"}
 	}()
 
@@ -295,6 +433,15 @@ type ChatEvent struct {
 	ToolName   string
 	ToolResult string
 	Error      string
+	Tokens     int // usage 事件: 本轮 LLM 调用估算的 token 数
+}
+
+// abortedError describes why a context-bound chat stream stopped.
+func abortedError(ctx context.Context) string {
+	if ctx.Err() == context.DeadlineExceeded {
+		return "aborted: deadline exceeded"
+	}
+	return "aborted: cancelled"
 }
 
 // Complete performs AI input completion.
@@ -329,303 +476,6 @@ func (sb *SessionBrain) Complete(input string) (string, error) {
 	return sb.provider.Complete(messages, 60)
 }
 
-// RunCommand executes a slash command and returns formatted output.
-func (sb *SessionBrain) RunCommand(command string) (string, bool) {
-	parts := strings.Fields(command)
-	if len(parts) == 0 {
-		return "", false
-	}
-
-	cmd := parts[0]
-	args := parts[1:]
-
-	switch cmd {
-	case "/help":
-		return fmt.Sprintf(`Kele v%s 命令帮助
-
-对话控制
-  /clear, /reset   清空对话历史
-
-模型管理
-  /model <name>     切换大模型（自动匹配供应商）
-  /model-small <n>  切换小模型
-  /models           列出可用模型
-  /model-reset      重置为默认模型
-  /model-info       显示模型详细信息
-
-工具与记忆
-  /tools            列出所有可用工具
-  /remember <text>  添加到长期记忆
-  /search <query>   搜索记忆
-  /memory           查看记忆摘要
-
-工作空间
-  /works            列出所有工作空间
-  /works create <n> 创建并切换工作空间
-  /works use <n>    切换工作空间
-  /works delete <n> 删除工作空间
-  /works clear      清空所有工作空间
-
-定时任务
-  /cron             查看定时任务列表
-
-配置管理
-  /config           列出所有配置项
-  /config set k v   设置配置项
-  /config get k     获取配置项
-
-信息查看
-  /status           显示系统状态
-  /history          显示完整对话历史
-  /tokens           显示 token 估算
-
-会话导出
-  /save             保存当前会话
-  /export           导出对话为 Markdown`, config.Version), false
-
-	case "/clear", "/reset":
-		sb.mu.Lock()
-		sb.history = []llm.Message{}
-		sb.mu.Unlock()
-		return "对话已清空", false
-
-	case "/model":
-		if len(args) == 0 {
-			return fmt.Sprintf("当前大模型: %s\n供应商: %s\n默认模型: %s\n小模型: %s\n\n使用 /model <name> 切换",
-				sb.provider.GetModel(), sb.provider.GetActiveProviderName(),
-				sb.provider.GetDefaultModel(), sb.provider.GetSmallModel()), false
-		}
-		modelName := strings.Join(args, " ")
-		sb.provider.SetModel(modelName)
-		config.SetValue("llm.openai_model", modelName)
-		return fmt.Sprintf("已切换模型: %s (供应商: %s)", modelName, sb.provider.GetActiveProviderName()), false
-
-	case "/model-small":
-		if len(args) == 0 {
-			return fmt.Sprintf("当前小模型: %s\n\n使用 /model-small <name> 切换", sb.provider.GetSmallModel()), false
-		}
-		modelName := strings.Join(args, " ")
-		sb.provider.SetSmallModel(modelName)
-		config.SetValue("llm.small_model", modelName)
-		return fmt.Sprintf("已切换小模型: %s", modelName), false
-
-	case "/models":
-		providers := sb.provider.ListProviders()
-		var s strings.Builder
-		s.WriteString("可用模型列表\n\n")
-		s.WriteString(fmt.Sprintf("已注册供应商: %s\n", strings.Join(providers, ", ")))
-		s.WriteString(fmt.Sprintf("当前: %s (%s)\n\n", sb.provider.GetModel(), sb.provider.GetActiveProviderName()))
-		s.WriteString("OpenAI:\n  gpt-4o, gpt-4o-mini, gpt-4-turbo, o1-preview\n\n")
-		s.WriteString("Anthropic Claude:\n  claude-sonnet-4-5-20250929, claude-haiku-4-5-20251001\n\n")
-		s.WriteString("DeepSeek (OpenAI 兼容):\n  deepseek-chat, deepseek-reasoner\n\n")
-		s.WriteString("Ollama 本地模型 (名称含 :):\n  llama3:8b, qwen2:7b, codellama:13b")
-		return s.String(), false
-
-	case "/model-reset":
-		sb.provider.ResetModel()
-		config.DeleteValue("llm.openai_model")
-		return fmt.Sprintf("已重置为默认模型: %s (%s)", sb.provider.GetDefaultModel(), sb.provider.GetActiveProviderName()), false
-
-	case "/model-info":
-		var s strings.Builder
-		s.WriteString("模型详细信息\n\n")
-		s.WriteString(fmt.Sprintf("  供应商:       %s\n", sb.provider.GetActiveProviderName()))
-		s.WriteString(fmt.Sprintf("  当前模型:     %s\n", sb.provider.GetModel()))
-		s.WriteString(fmt.Sprintf("  默认模型:     %s\n", sb.provider.GetDefaultModel()))
-		s.WriteString(fmt.Sprintf("  小模型:       %s\n", sb.provider.GetSmallModel()))
-		s.WriteString(fmt.Sprintf("  工具支持:     %v\n", sb.provider.ActiveSupportsTools()))
-		s.WriteString(fmt.Sprintf("  已注册供应商: %s\n", strings.Join(sb.provider.ListProviders(), ", ")))
-		return s.String(), false
-
-	case "/tools":
-		toolNames := sb.executor.ListTools()
-		var s strings.Builder
-		s.WriteString(fmt.Sprintf("可用工具 (%d 个)\n\n", len(toolNames)))
-		for i, name := range toolNames {
-			s.WriteString(fmt.Sprintf("  %d. %s\n", i+1, name))
-		}
-		s.WriteString("\nAI 会根据对话内容自动调用工具")
-		return s.String(), false
-
-	case "/status":
-		return fmt.Sprintf(`系统状态
-
-版本: Kele v%s
-供应商: %s
-可用供应商: %s
-大模型: %s
-小模型: %s
-Token 估算: ~%d
-时间: %s`,
-			config.Version,
-			sb.provider.GetActiveProviderName(),
-			strings.Join(sb.provider.ListProviders(), ", "),
-			sb.provider.GetModel(), sb.provider.GetSmallModel(),
-			sb.estimateTokens(),
-			time.Now().Format("2006-01-02 15:04:05")), false
-
-	case "/config":
-		if len(args) == 0 {
-			all := config.AllSettings(sb.cfg)
-			dbValues, _ := config.ListValues()
-
-			keys := make([]string, 0, len(all))
-			for k := range all {
-				keys = append(keys, k)
-			}
-			sort.Strings(keys)
-
-			var s strings.Builder
-			s.WriteString(fmt.Sprintf("Kele v%s 配置\n\n", config.Version))
-			for _, k := range keys {
-				v := all[k]
-				if v == "" {
-					v = "(未设置)"
-				}
-				tag := ""
-				if _, ok := dbValues[k]; ok {
-					tag = " [db]"
-				}
-				s.WriteString(fmt.Sprintf("  %-28s %s%s\n", k, v, tag))
-			}
-			s.WriteString("\n/config set <key> <value>  设置配置")
-			s.WriteString("\n/config get <key>          获取配置")
-			return s.String(), false
-		}
-		subCmd := args[0]
-		subArgs := args[1:]
-		switch subCmd {
-		case "set":
-			if len(subArgs) < 2 {
-				return "用法: /config set <key> <value>", false
-			}
-			key := subArgs[0]
-			val := strings.Join(subArgs[1:], " ")
-			if err := config.SetValue(key, val); err != nil {
-				return fmt.Sprintf("设置失败: %v", err), false
-			}
-			return fmt.Sprintf("%s = %s", key, val), false
-		case "get":
-			if len(subArgs) < 1 {
-				return "用法: /config get <key>", false
-			}
-			val, err := config.GetValue(subArgs[0])
-			if err != nil {
-				return fmt.Sprintf("获取失败: %v", err), false
-			}
-			return fmt.Sprintf("%s = %s", subArgs[0], val), false
-		default:
-			return fmt.Sprintf("未知子命令: /config %s\n用法: /config [set|get]", subCmd), false
-		}
-
-	case "/history":
-		sb.mu.RLock()
-		historyCopy := make([]llm.Message, len(sb.history))
-		copy(historyCopy, sb.history)
-		sb.mu.RUnlock()
-
-		var s strings.Builder
-		s.WriteString("对话历史\n\n")
-		for i, msg := range historyCopy {
-			content := msg.Content
-			if len([]rune(content)) > 100 {
-				content = string([]rune(content)[:100]) + "..."
-			}
-			s.WriteString(fmt.Sprintf("%d. [%s] %s\n\n", i+1, msg.Role, content))
-		}
-		if len(historyCopy) == 0 {
-			s.WriteString("(暂无历史记录)")
-		}
-		return s.String(), false
-
-	case "/remember":
-		if len(args) == 0 {
-			return "用法: /remember <要记住的内容>", false
-		}
-		text := strings.Join(args, " ")
-		key := fmt.Sprintf("note_%d", time.Now().Unix())
-		if sb.memory == nil {
-			return "记忆系统未初始化", false
-		}
-		if err := sb.memory.UpdateMemory(key, text); err != nil {
-			return fmt.Sprintf("保存失败: %v", err), false
-		}
-		return "已添加到长期记忆", false
-
-	case "/search":
-		if len(args) == 0 {
-			return "用法: /search <搜索关键词>", false
-		}
-		query := strings.Join(args, " ")
-		if sb.memory == nil {
-			return "记忆系统未初始化", false
-		}
-		results, err := sb.memory.Search(query, 5)
-		if err != nil {
-			return fmt.Sprintf("搜索失败: %v", err), false
-		}
-		if len(results) == 0 {
-			return "未找到相关记忆", false
-		}
-		var s strings.Builder
-		s.WriteString(fmt.Sprintf("搜索结果 (%d 条):\n\n", len(results)))
-		for i, r := range results {
-			s.WriteString(fmt.Sprintf("%d. %s\n\n", i+1, r))
-		}
-		return s.String(), false
-
-	case "/memory":
-		return fmt.Sprintf("记忆系统\n\n命令:\n  /remember <text>  添加到长期记忆\n  /search <query>   搜索记忆\n\n存储: %s", sb.cfg.Memory.DBPath), false
-
-	case "/tokens":
-		tokens := sb.estimateTokens()
-		return fmt.Sprintf("Token 估算\n\n  历史消息数: %d\n  估算 Tokens: ~%d\n  模型: %s (%s)",
-			len(sb.history), tokens, sb.provider.GetModel(), sb.provider.GetActiveProviderName()), false
-
-	case "/cron":
-		jobs, err := sb.executor.ListCronJobs()
-		if err != nil {
-			return fmt.Sprintf("查询失败: %v", err), false
-		}
-		if len(jobs) == 0 {
-			return "暂无定时任务\n\n通过对话让 AI 帮你创建，例如：\n  \"每5分钟检查一次磁盘空间\"", false
-		}
-		var cs strings.Builder
-		cs.WriteString(fmt.Sprintf("定时任务 (%d 个)\n\n", len(jobs)))
-		for _, j := range jobs {
-			status := "启用"
-			if !j.Enabled {
-				status = "暂停"
-			}
-			nextStr := "-"
-			if j.NextRun != nil {
-				nextStr = j.NextRun.Format("01-02 15:04")
-			}
-			cs.WriteString(fmt.Sprintf("  %s  %s  [%s]  %s  下次: %s\n",
-				j.ID, j.Name, status, j.Schedule, nextStr))
-		}
-		cs.WriteString("\n通过对话管理: 创建/修改/删除/暂停")
-		return cs.String(), false
-
-	case "/works":
-		return sb.handleWorks(args), false
-
-	case "/answer":
-		if len(args) == 0 {
-			return "用法: /answer <回答内容>", false
-		}
-		answer := strings.Join(args, " ")
-		sb.Answer(answer)
-		return fmt.Sprintf("已回答: %s", answer), false
-
-	case "/exit", "/quit":
-		return "再见!", true
-
-	default:
-		return fmt.Sprintf("未知命令: %s\n输入 /help 查看可用命令", command), false
-	}
-}
-
 // --- TaskBoard integration ---
 
 // InjectContext prepends additional context to the session's system prompt.
@@ -645,12 +495,12 @@ func (s *Session) InjectContext(ctx string) {
 	s.brain.InjectContext(ctx)
 }
 
-// ChatStreamForTask wraps ChatStream, converting events to taskboard.SessionEvent.
-func (s *Session) ChatStreamForTask(input string) (<-chan TaskSessionEvent, error) {
+// ChatStreamForTask wraps ChatStreamContext, converting events to taskboard.SessionEvent.
+func (s *Session) ChatStreamForTask(ctx context.Context, input string) (<-chan TaskSessionEvent, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	eventChan, err := s.brain.ChatStream(input)
+	eventChan, err := s.brain.ChatStreamContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -665,6 +515,7 @@ func (s *Session) ChatStreamForTask(input string) (<-chan TaskSessionEvent, erro
 				ToolName:   ev.ToolName,
 				ToolResult: ev.ToolResult,
 				Error:      ev.Error,
+				Tokens:     ev.Tokens,
 			}
 		}
 	}()
@@ -678,6 +529,7 @@ type TaskSessionEvent struct {
 	ToolName   string
 	ToolResult string
 	Error      string
+	Tokens     int
 }
 
 // --- internal helpers ---
@@ -712,13 +564,9 @@ func (sb *SessionBrain) getMessages() []llm.Message {
 	copy(historyCopy, sb.history)
 	injected := sb.injectedContext
 	workName := sb.currentWork
+	memories := memory.FormatForPrompt(sb.injected)
 	sb.mu.RUnlock()
 
-	var memories []string
-	if sb.memory != nil {
-		memories, _ = sb.memory.GetRecentMemories(5)
-	}
-
 	systemContent := prompt.Build(prompt.BuildParams{
 		ToolNames:     sb.executor.ListTools(),
 		WorkDir:       sb.executor.GetWorkDir(),
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/BlakeLiAFK/kele/internal/config"
+	"github.com/BlakeLiAFK/kele/internal/cron"
 	"github.com/BlakeLiAFK/kele/internal/llm"
 )
 
@@ -38,7 +39,13 @@ func (sb *SessionBrain) RunCommand(command string) (string, bool) {
   /tools            列出所有可用工具
   /remember <text>  添加到长期记忆
   /search <query>   搜索记忆
-  /memory           查看记忆摘要
+  /recall <query>   搜索所有会话的历史对话
+  /memory           查看记忆摘要、最近注入的记忆与待确认候选
+  /memory pending   查看对话中提取的候选记忆
+  /memory pin <key> 置顶记忆，每轮都注入
+  /memory list      按作用域（全局/项目/工作区/会话）列出记忆
+  /memory move <key> <scope>  在作用域之间移动记忆
+  /memory conflicts 查看 MEMORY.md 同步冲突
 
 供应商管理
   /provider             列出所有供应商
@@ -73,9 +80,9 @@ func (sb *SessionBrain) RunCommand(command string) (string, bool) {
   /export           导出对话为 Markdown`, config.Version), false
 
 	case "/clear", "/reset":
-		sb.mu.Lock()
-		sb.history = []llm.Message{}
-		sb.mu.Unlock()
+		if sb.endConversation() {
+			return "对话已清空，正在后台提取候选记忆（/memory pending 查看）", false
+		}
 		return "对话已清空", false
 
 	case "/model":
@@ -249,7 +256,7 @@ Token 估算: ~%d
 		if sb.memory == nil {
 			return "记忆系统未初始化", false
 		}
-		results, err := sb.memory.Search(query, 5)
+		results, err := sb.memory.SearchEntriesIn(sb.memoryScopes().List(), query, 5)
 		if err != nil {
 			return fmt.Sprintf("搜索失败: %v", err), false
 		}
@@ -259,12 +266,15 @@ Token 估算: ~%d
 		var s strings.Builder
 		s.WriteString(fmt.Sprintf("搜索结果 (%d 条):\n\n", len(results)))
 		for i, r := range results {
-			s.WriteString(fmt.Sprintf("%d. %s\n\n", i+1, r))
+			s.WriteString(fmt.Sprintf("%d. %s\n\n", i+1, r.Value))
 		}
 		return s.String(), false
 
 	case "/memory":
-		return fmt.Sprintf("记忆系统\n\n命令:\n  /remember <text>  添加到长期记忆\n  /search <query>   搜索记忆\n\n存储: %s", sb.cfg.Memory.DBPath), false
+		return sb.handleMemory(args), false
+
+	case "/recall":
+		return sb.handleRecall(args), false
 
 	case "/tokens":
 		tokens := sb.estimateTokens()
@@ -290,10 +300,17 @@ Token 估算: ~%d
 			if j.NextRun != nil {
 				nextStr = j.NextRun.Format("01-02 15:04")
 			}
-			cs.WriteString(fmt.Sprintf("  %s  %s  [%s]  %s  下次: %s\n",
-				j.ID, j.Name, status, j.Schedule, nextStr))
+			kind := "命令"
+			if j.Type == cron.JobAgent {
+				kind = "agent"
+			}
+			if j.Deliver.Channel != "" {
+				kind += " → " + j.Deliver.Channel
+			}
+			cs.WriteString(fmt.Sprintf("  %s  %s  [%s]  %s  %s  下次: %s\n",
+				j.ID, j.Name, status, j.Schedule, kind, nextStr))
 		}
-		cs.WriteString("\n通过对话管理: 创建/修改/删除/暂停")
+		cs.WriteString("\n通过对话管理: 创建/修改/删除/暂停，agent 任务可定时让 AI 执行指令并把结果发到 Telegram")
 		return cs.String(), false
 
 	case "/provider":
@@ -1,6 +1,8 @@
 package daemon
 
 import (
+	"context"
+
 	"github.com/BlakeLiAFK/kele/internal/taskboard"
 )
 
@@ -15,8 +17,17 @@ func NewTaskSessionAdapter(sm *SessionManager) *TaskSessionAdapter {
 }
 
 // CreateTaskSession creates a session and returns a taskboard.TaskSession wrapper.
-func (a *TaskSessionAdapter) CreateTaskSession(name string) taskboard.TaskSession {
-	sess := a.sm.Create(name)
+func (a *TaskSessionAdapter) CreateTaskSession(name string, opts taskboard.TaskSessionOptions) taskboard.TaskSession {
+	sess := a.sm.CreateWithOptions(name, SessionOptions{
+		WorkDir:       opts.WorkDir,
+		ReadOnly:      opts.ReadOnly,
+		Model:         opts.Model,
+		Temperature:   opts.Temperature,
+		AllowedTools:  opts.AllowedTools,
+		MaxToolRounds: opts.MaxToolRounds,
+		WorkspaceID:   opts.WorkspaceID,
+		Channel:       "task",
+	})
 	return &sessionWrapper{sess: sess}
 }
 
@@ -38,8 +49,8 @@ func (w *sessionWrapper) InjectContext(ctx string) {
 	w.sess.InjectContext(ctx)
 }
 
-func (w *sessionWrapper) ChatStream(input string) (<-chan taskboard.SessionEvent, error) {
-	events, err := w.sess.ChatStreamForTask(input)
+func (w *sessionWrapper) ChatStream(ctx context.Context, input string) (<-chan taskboard.SessionEvent, error) {
+	events, err := w.sess.ChatStreamForTask(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -53,6 +64,7 @@ func (w *sessionWrapper) ChatStream(input string) (<-chan taskboard.SessionEvent
 				ToolName:   ev.ToolName,
 				ToolResult: ev.ToolResult,
 				Error:      ev.Error,
+				Tokens:     ev.Tokens,
 			}
 		}
 	}()
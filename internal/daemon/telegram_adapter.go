@@ -36,13 +36,16 @@ func (a *TelegramAdapter) GetOrCreateSession(chatID int64) (string, error) {
 		ID:   sessionID,
 		Name: fmt.Sprintf("Telegram %d", chatID),
 		brain: &SessionBrain{
-			provider:   a.sessions.provider,
-			executor:   a.sessions.executor,
-			memory:     a.sessions.memory,
-			history:    []llm.Message{},
-			cfg:        a.sessions.cfg,
-			workspace:  a.sessions.workspace,
-			answerChan: make(chan string, 1),
+			provider:    a.sessions.provider,
+			executor:    a.sessions.executor,
+			memory:      a.sessions.memory,
+			history:     []llm.Message{},
+			cfg:         a.sessions.cfg,
+			workspace:   a.sessions.workspace,
+			answerChan:  make(chan string, 1),
+			sessionID:   sessionID,
+			sessionName: fmt.Sprintf("Telegram %d", chatID),
+			channel:     "telegram",
 		},
 	}
 	a.sessions.sessions[sessionID] = sess
@@ -1,6 +1,7 @@
 package heartbeat
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -10,45 +11,95 @@ import (
 	"time"
 
 	"github.com/BlakeLiAFK/kele/internal/llm"
-	"github.com/BlakeLiAFK/kele/internal/tools"
 )
 
+// Heartbeat outcomes stored in Record.Status.
+const (
+	StatusOK        = "ok"         // the model finished with a reply
+	StatusMaxRounds = "max_rounds" // stopped at the round limit while still calling tools
+	StatusError     = "error"      // the LLM call failed
+)
+
+// maxToolResult caps each tool result fed back to the model and stored in the transcript.
+const maxToolResult = 4000
+
 // Record stores one heartbeat execution result.
 type Record struct {
-	Timestamp  time.Time
-	Decision   string
+	ID           int64
+	Timestamp    time.Time
+	Decision     string
 	ActionsTaken int
-	Duration   time.Duration
+	Rounds       int
+	Status       string
+	Error        string
+	Duration     time.Duration
+	Transcript   []llm.Message // prompt, model turns and tool results; only loaded by Store.Get
+}
+
+// Provider is the model a heartbeat talks to (satisfied by *llm.ProviderManager).
+type Provider interface {
+	ChatContext(ctx context.Context, messages []llm.Message, tools []llm.Tool) (*llm.ChatResponse, error)
+}
+
+// ToolExecutor runs the tools a heartbeat calls (satisfied by *tools.Executor).
+type ToolExecutor interface {
+	GetTools() []llm.Tool
+	ExecuteContext(ctx context.Context, toolCall llm.ToolCall) (string, error)
 }
 
 // Runner manages the heartbeat lifecycle.
 type Runner struct {
-	provider  *llm.ProviderManager
-	executor  *tools.Executor
-	interval  time.Duration
-	active    bool
-	done      chan struct{}
-	mu        sync.Mutex
+	provider Provider
+	executor ToolExecutor
+	opts     Options
+	store    *Store
+	active   bool
+	done     chan struct{}
+	ctx      context.Context
+	cancel   context.CancelFunc
+	mu       sync.Mutex
 
 	// State
 	totalHeartbeats int
 	totalActions    int
 	lastRun         time.Time
 	lastDecision    string
-	records         []Record
+	nextRun         time.Time
 
 	// SessionCounter func for snapshot
 	getSessionCount func() int
+
+	// readConfig returns the HEARTBEAT.md content; replaced in tests
+	readConfig func() string
 }
 
 // NewRunner creates a new heartbeat runner.
-func NewRunner(provider *llm.ProviderManager, executor *tools.Executor, getSessionCount func() int) *Runner {
+func NewRunner(provider Provider, executor ToolExecutor, getSessionCount func() int, opts Options) *Runner {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &Runner{
 		provider:        provider,
 		executor:        executor,
-		interval:        15 * time.Minute,
+		opts:            opts,
 		done:            make(chan struct{}),
+		ctx:             ctx,
+		cancel:          cancel,
 		getSessionCount: getSessionCount,
+		readConfig:      readHeartbeatConfig,
+	}
+}
+
+// SetStore persists heartbeat records in store and restores the counters from it.
+// Call before Start.
+func (r *Runner) SetStore(store *Store) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.store = store
+	if count, actions, err := store.totals(); err == nil {
+		r.totalHeartbeats, r.totalActions = count, actions
+	}
+	if recent, err := store.List(1); err == nil && len(recent) > 0 {
+		r.lastRun = recent[0].Timestamp
+		r.lastDecision = truncate(recent[0].Decision, 500)
 	}
 }
 
@@ -58,17 +109,23 @@ func (r *Runner) Start() {
 	r.active = true
 	r.mu.Unlock()
 
+	sched, _, err := r.schedule()
+	if err != nil {
+		log.Printf("Heartbeat: %v", err)
+	}
 	go r.loop()
-	log.Println("Heartbeat started (interval: 15m)")
+	log.Printf("Heartbeat started (interval: %v, quiet hours: %s, active days: %s, max rounds: %d)",
+		sched.interval, orNone(sched.opts.QuietHours), orEvery(sched.opts.ActiveDays), sched.maxRounds)
 }
 
-// Stop stops the heartbeat loop.
+// Stop stops the heartbeat loop and cancels a running heartbeat.
 func (r *Runner) Stop() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	if r.active {
 		r.active = false
 		close(r.done)
+		r.cancel()
 	}
 }
 
@@ -81,7 +138,21 @@ func (r *Runner) IsActive() bool {
 
 // IntervalMinutes returns the current interval in minutes.
 func (r *Runner) IntervalMinutes() int {
-	return int(r.interval.Minutes())
+	sched, _, _ := r.schedule()
+	return int(sched.interval.Minutes())
+}
+
+// Settings returns the effective schedule, with HEARTBEAT.md frontmatter applied.
+func (r *Runner) Settings() Options {
+	sched, _, _ := r.schedule()
+	return sched.opts
+}
+
+// NextRun returns when the next heartbeat is due; zero when the loop is not running.
+func (r *Runner) NextRun() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.nextRun
 }
 
 // LastRun returns the time of the last heartbeat.
@@ -112,52 +183,88 @@ func (r *Runner) TotalActions() int {
 	return r.totalActions
 }
 
-// loop runs the heartbeat on a dynamic interval.
+// History returns the most recent heartbeat records, newest first, without transcripts.
+func (r *Runner) History(limit int) ([]Record, error) {
+	r.mu.Lock()
+	store := r.store
+	r.mu.Unlock()
+	if store == nil {
+		return nil, fmt.Errorf("heartbeat history not available")
+	}
+	return store.List(limit)
+}
+
+// Record returns one heartbeat record with its full transcript.
+func (r *Runner) Record(id int64) (*Record, error) {
+	r.mu.Lock()
+	store := r.store
+	r.mu.Unlock()
+	if store == nil {
+		return nil, fmt.Errorf("heartbeat history not available")
+	}
+	return store.Get(id)
+}
+
+// loop runs the heartbeat on the configured schedule. The schedule is
+// re-read before every wait so HEARTBEAT.md edits apply without a restart.
 func (r *Runner) loop() {
+	last := time.Now()
 	for {
-		interval := r.getInterval()
+		sched, _, _ := r.schedule()
+		next := sched.next(last)
+		r.mu.Lock()
+		r.nextRun = next
+		r.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
 		select {
-		case <-time.After(interval):
+		case <-timer.C:
+			last = time.Now()
 			r.beat()
 		case <-r.done:
+			timer.Stop()
+			r.mu.Lock()
+			r.nextRun = time.Time{}
+			r.mu.Unlock()
 			log.Println("Heartbeat stopped")
 			return
 		}
 	}
 }
 
-// getInterval returns a dynamic interval based on time of day.
-func (r *Runner) getInterval() time.Duration {
-	hour := time.Now().Hour()
-
-	// Night (23:00 - 07:00): slow heartbeat
-	if hour >= 23 || hour < 7 {
-		return 60 * time.Minute
+// schedule parses the configured options merged with the HEARTBEAT.md
+// frontmatter and returns it with the HEARTBEAT.md body. Invalid settings
+// fall back to the configured options, then to the defaults; the returned
+// error says what was ignored.
+func (r *Runner) schedule() (schedule, string, error) {
+	fm, body, err := splitFrontmatter(r.readConfig())
+	opts := r.opts
+	if err == nil {
+		opts, err = opts.merge(fm)
 	}
-
-	// Work hours (09:00 - 18:00): normal
-	if hour >= 9 && hour < 18 {
-		return 15 * time.Minute
+	if err != nil {
+		opts = r.opts
 	}
-
-	// Other times: moderate
-	return 30 * time.Minute
+	sched, perr := opts.parse()
+	if perr != nil {
+		err = perr
+		if sched, perr = r.opts.parse(); perr != nil {
+			sched, _ = Options{}.parse()
+		}
+	}
+	return sched, strings.TrimSpace(body), err
 }
 
-// beat executes one heartbeat cycle.
+// beat executes one heartbeat cycle: the model gets a system snapshot and
+// HEARTBEAT.md and may call tools for up to MaxRounds rounds.
 func (r *Runner) beat() {
 	start := time.Now()
 
-	sessionCount := 0
-	if r.getSessionCount != nil {
-		sessionCount = r.getSessionCount()
+	sched, heartbeatConfig, err := r.schedule()
+	if err != nil {
+		log.Printf("Heartbeat: %v", err)
 	}
 
-	snapshot := TakeSnapshot(sessionCount)
-
-	// Read HEARTBEAT.md config
-	heartbeatConfig := readHeartbeatConfig()
-
 	// If no config, just record and skip
 	if heartbeatConfig == "" {
 		r.mu.Lock()
@@ -169,67 +276,109 @@ func (r *Runner) beat() {
 		return
 	}
 
-	// Build prompt
-	prompt := snapshot.FormatPrompt(heartbeatConfig)
+	sessionCount := 0
+	if r.getSessionCount != nil {
+		sessionCount = r.getSessionCount()
+	}
+	snapshot := TakeSnapshot(sessionCount)
+
+	rec := r.run(snapshot.FormatPrompt(heartbeatConfig), sched.maxRounds)
+	rec.Timestamp = start
+	rec.Duration = time.Since(start)
 
-	// Call LLM
-	messages := []llm.Message{
-		{Role: "user", Content: prompt},
+	r.mu.Lock()
+	r.totalHeartbeats++
+	r.totalActions += rec.ActionsTaken
+	r.lastRun = start
+	r.lastDecision = truncate(rec.Decision, 500)
+	if rec.Status == StatusError {
+		r.lastDecision = "error: " + rec.Error
 	}
+	store := r.store
+	r.mu.Unlock()
 
-	resp, err := r.provider.Chat(messages, r.executor.GetTools())
-	if err != nil {
-		log.Printf("Heartbeat LLM error: %v", err)
-		r.mu.Lock()
-		r.totalHeartbeats++
-		r.lastRun = start
-		r.lastDecision = fmt.Sprintf("error: %v", err)
-		r.mu.Unlock()
-		return
+	if store != nil {
+		if err := store.Save(&rec); err != nil {
+			log.Printf("Heartbeat: save record: %v", err)
+		}
 	}
+	log.Printf("Heartbeat completed in %v (status: %s, rounds: %d, actions: %d, decision: %s)",
+		rec.Duration, rec.Status, rec.Rounds, rec.ActionsTaken, truncate(rec.Decision, 100))
+}
 
-	decision := ""
-	actionsTaken := 0
-
-	if len(resp.Choices) > 0 {
-		choice := resp.Choices[0]
-		decision = choice.Message.Content
-
-		// Execute tool calls if any
-		if len(choice.Message.ToolCalls) > 0 {
-			for _, tc := range choice.Message.ToolCalls {
-				result, err := r.executor.Execute(tc)
-				if err != nil {
-					log.Printf("Heartbeat tool %s error: %v", tc.Function.Name, err)
-				} else {
-					log.Printf("Heartbeat tool %s: %s", tc.Function.Name, truncate(result, 200))
-					actionsTaken++
-				}
+// run drives the tool loop: each round the model either replies, which ends
+// the heartbeat, or calls tools whose results are fed back for the next round.
+func (r *Runner) run(prompt string, maxRounds int) Record {
+	messages := []llm.Message{{Role: "user", Content: prompt}}
+	tools := r.heartbeatTools()
+	rec := Record{Status: StatusMaxRounds}
+
+	for rec.Rounds < maxRounds {
+		if r.ctx.Err() != nil {
+			rec.Status, rec.Error = StatusError, "heartbeat stopped"
+			break
+		}
+		rec.Rounds++
+		resp, err := r.provider.ChatContext(r.ctx, messages, tools)
+		if err == nil && len(resp.Choices) == 0 {
+			err = fmt.Errorf("empty response")
+		}
+		if err != nil && r.ctx.Err() != nil {
+			rec.Status, rec.Error = StatusError, "heartbeat stopped"
+			break
+		}
+		if err != nil {
+			log.Printf("Heartbeat LLM error: %v", err)
+			rec.Status, rec.Error = StatusError, err.Error()
+			break
+		}
+
+		msg := resp.Choices[0].Message
+		if msg.Role == "" {
+			msg.Role = "assistant"
+		}
+		messages = append(messages, msg)
+		if strings.TrimSpace(msg.Content) != "" {
+			rec.Decision = msg.Content
+		}
+		if len(msg.ToolCalls) == 0 {
+			rec.Status = StatusOK
+			break
+		}
+
+		for _, tc := range msg.ToolCalls {
+			result, err := r.executor.ExecuteContext(r.ctx, tc)
+			if err != nil {
+				log.Printf("Heartbeat tool %s error: %v", tc.Function.Name, err)
+				result = fmt.Sprintf("Error: %v", err)
+			} else {
+				log.Printf("Heartbeat tool %s: %s", tc.Function.Name, truncate(result, 200))
+				rec.ActionsTaken++
 			}
+			messages = append(messages, llm.Message{
+				Role:       "tool",
+				Content:    truncate(result, maxToolResult),
+				ToolCallID: tc.ID,
+			})
 		}
 	}
 
-	duration := time.Since(start)
-
-	r.mu.Lock()
-	r.totalHeartbeats++
-	r.totalActions += actionsTaken
-	r.lastRun = start
-	r.lastDecision = truncate(decision, 500)
-	r.records = append(r.records, Record{
-		Timestamp:    start,
-		Decision:     decision,
-		ActionsTaken: actionsTaken,
-		Duration:     duration,
-	})
-	// Keep last 100 records
-	if len(r.records) > 100 {
-		r.records = r.records[len(r.records)-100:]
+	if rec.Status == StatusMaxRounds {
+		log.Printf("Heartbeat: stopped after %d rounds with tool calls pending", rec.Rounds)
 	}
-	r.mu.Unlock()
+	rec.Transcript = messages
+	return rec
+}
 
-	log.Printf("Heartbeat completed in %v (actions: %d, decision: %s)",
-		duration, actionsTaken, truncate(decision, 100))
+// heartbeatTools returns every tool except ask_user: nobody is there to answer.
+func (r *Runner) heartbeatTools() []llm.Tool {
+	var tools []llm.Tool
+	for _, t := range r.executor.GetTools() {
+		if t.Function.Name != "ask_user" {
+			tools = append(tools, t)
+		}
+	}
+	return tools
 }
 
 // readHeartbeatConfig reads HEARTBEAT.md from CWD or home dir.
@@ -256,6 +405,20 @@ func readHeartbeatConfig() string {
 	return ""
 }
 
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+func orEvery(s string) string {
+	if s == "" {
+		return "every day"
+	}
+	return s
+}
+
 func truncate(s string, maxLen int) string {
 	s = strings.TrimSpace(s)
 	runes := []rune(s)
@@ -0,0 +1,314 @@
+package heartbeat
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BlakeLiAFK/kele/internal/llm"
+)
+
+// scriptedProvider replies with the given messages in order and records what it was sent.
+type scriptedProvider struct {
+	replies []llm.Message
+	calls   [][]llm.Message
+	tools   []llm.Tool
+}
+
+func (p *scriptedProvider) ChatContext(_ context.Context, messages []llm.Message, tools []llm.Tool) (*llm.ChatResponse, error) {
+	p.calls = append(p.calls, append([]llm.Message(nil), messages...))
+	p.tools = tools
+	if len(p.calls) > len(p.replies) {
+		return nil, fmt.Errorf("unexpected call %d", len(p.calls))
+	}
+	return &llm.ChatResponse{Choices: []llm.ChatChoice{{Message: p.replies[len(p.calls)-1]}}}, nil
+}
+
+// blockingProvider holds every call until its context is cancelled.
+type blockingProvider struct {
+	started chan struct{}
+}
+
+func (p *blockingProvider) ChatContext(ctx context.Context, _ []llm.Message, _ []llm.Tool) (*llm.ChatResponse, error) {
+	close(p.started)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+type fakeExecutor struct {
+	executed []string
+}
+
+func (e *fakeExecutor) GetTools() []llm.Tool {
+	var tools []llm.Tool
+	for _, name := range []string{"bash", "ask_user", "remember"} {
+		tools = append(tools, llm.Tool{Type: "function", Function: llm.ToolFunction{Name: name}})
+	}
+	return tools
+}
+
+func (e *fakeExecutor) ExecuteContext(_ context.Context, tc llm.ToolCall) (string, error) {
+	e.executed = append(e.executed, tc.Function.Name)
+	if tc.Function.Name == "remember" {
+		return "", fmt.Errorf("store closed")
+	}
+	return "disk 42% used", nil
+}
+
+func toolCall(id, name string) llm.ToolCall {
+	tc := llm.ToolCall{ID: id, Type: "function"}
+	tc.Function.Name = name
+	tc.Function.Arguments = "{}"
+	return tc
+}
+
+func testRunner(p Provider, e ToolExecutor, config string) *Runner {
+	r := NewRunner(p, e, nil, Options{})
+	r.readConfig = func() string { return config }
+	return r
+}
+
+func TestRunFeedsToolResultsBack(t *testing.T) {
+	p := &scriptedProvider{replies: []llm.Message{
+		{Role: "assistant", Content: "checking disk", ToolCalls: []llm.ToolCall{toolCall("c1", "bash"), toolCall("c2", "remember")}},
+		{Role: "assistant", Content: "Disk is fine."},
+	}}
+	e := &fakeExecutor{}
+	r := testRunner(p, e, "")
+
+	rec := r.run("heartbeat prompt", 5)
+	if rec.Status != StatusOK || rec.Rounds != 2 || rec.Decision != "Disk is fine." {
+		t.Fatalf("unexpected record: %+v", rec)
+	}
+	if rec.ActionsTaken != 1 {
+		t.Errorf("only successful tool calls count as actions, got %d", rec.ActionsTaken)
+	}
+	if len(p.calls) != 2 {
+		t.Fatalf("expected 2 model calls, got %d", len(p.calls))
+	}
+	second := p.calls[1]
+	if len(second) != 4 || second[2].Role != "tool" || second[2].ToolCallID != "c1" || second[2].Content != "disk 42% used" {
+		t.Errorf("tool results should be fed back to the model: %+v", second)
+	}
+	if !strings.HasPrefix(second[3].Content, "Error: ") {
+		t.Errorf("tool errors should be fed back as text: %q", second[3].Content)
+	}
+	if len(rec.Transcript) != 5 {
+		t.Errorf("transcript should hold prompt, 2 replies and 2 tool results, got %d", len(rec.Transcript))
+	}
+	for _, tool := range p.tools {
+		if tool.Function.Name == "ask_user" {
+			t.Error("ask_user must not be offered to an unattended heartbeat")
+		}
+	}
+}
+
+func TestRunStopsAtMaxRounds(t *testing.T) {
+	loop := llm.Message{Role: "assistant", ToolCalls: []llm.ToolCall{toolCall("c", "bash")}}
+	p := &scriptedProvider{replies: []llm.Message{loop, loop, loop, loop}}
+	rec := testRunner(p, &fakeExecutor{}, "").run("prompt", 3)
+	if rec.Status != StatusMaxRounds || rec.Rounds != 3 || len(p.calls) != 3 {
+		t.Errorf("expected to stop after 3 rounds, got %+v with %d calls", rec, len(p.calls))
+	}
+}
+
+func TestRunRecordsLLMError(t *testing.T) {
+	rec := testRunner(&scriptedProvider{}, &fakeExecutor{}, "").run("prompt", 3)
+	if rec.Status != StatusError || !strings.Contains(rec.Error, "unexpected call") {
+		t.Errorf("expected an error record, got %+v", rec)
+	}
+}
+
+func TestSplitFrontmatter(t *testing.T) {
+	fm, body, err := splitFrontmatter("---\ninterval: 30\nquiet_hours: 22:00-08:00\nactive_days: mon-fri\nmax_rounds: 8\n---\n# Checks\n- disk\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fm.Interval != "30" || fm.QuietHours != "22:00-08:00" || fm.ActiveDays != "mon-fri" || fm.MaxRounds != 8 {
+		t.Errorf("unexpected frontmatter: %+v", fm)
+	}
+	if body != "# Checks\n- disk\n" {
+		t.Errorf("unexpected body: %q", body)
+	}
+
+	opts, err := Options{Interval: time.Hour, QuietHours: "23:00-07:00"}.merge(fm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Interval != 30*time.Minute || opts.QuietHours != "22:00-08:00" || opts.MaxRounds != 8 {
+		t.Errorf("frontmatter should override options: %+v", opts)
+	}
+
+	if _, body, _ := splitFrontmatter("# No header\n"); body != "# No header\n" {
+		t.Errorf("content without frontmatter should be unchanged: %q", body)
+	}
+	if _, _, err := splitFrontmatter("---\ninterval: 5m\n# never closed\n"); err == nil {
+		t.Error("unclosed frontmatter should be an error")
+	}
+}
+
+func TestParseDays(t *testing.T) {
+	cases := map[string][]time.Weekday{
+		"mon-fri":  {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		"sat,sun":  {time.Saturday, time.Sunday},
+		"1,3,5":    {time.Monday, time.Wednesday, time.Friday},
+		"fri-mon":  {time.Friday, time.Saturday, time.Sunday, time.Monday},
+		"Sunday":   {time.Sunday},
+		"7":        {time.Sunday},
+		"5-7, tue": {time.Friday, time.Saturday, time.Sunday, time.Tuesday},
+	}
+	for in, want := range cases {
+		days, err := parseDays(in)
+		if err != nil {
+			t.Errorf("%q: %v", in, err)
+			continue
+		}
+		var expect [7]bool
+		for _, d := range want {
+			expect[d] = true
+		}
+		if days != expect {
+			t.Errorf("%q: got %v, want %v", in, days, expect)
+		}
+	}
+	for _, bad := range []string{"", "funday", "8", ","} {
+		if _, err := parseDays(bad); err == nil {
+			t.Errorf("%q should be rejected", bad)
+		}
+	}
+}
+
+func TestParseRejectsBadOptions(t *testing.T) {
+	for _, o := range []Options{
+		{Interval: 10 * time.Second},
+		{QuietHours: "23:00"},
+		{QuietHours: "25:00-07:00"},
+		{QuietHours: "07:00-07:00"},
+		{ActiveDays: "someday"},
+	} {
+		if _, err := o.parse(); err == nil {
+			t.Errorf("%+v should be rejected", o)
+		}
+	}
+	s, err := Options{QuietHours: "none"}.parse()
+	if err != nil || s.quiet || s.interval != defaultInterval || s.maxRounds != defaultMaxRounds {
+		t.Errorf("defaults not applied: %+v, %v", s, err)
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	s, err := Options{Interval: 30 * time.Minute, QuietHours: "23:00-07:00", ActiveDays: "mon-fri"}.parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(day, hour, minute int) time.Time {
+		// 2026-03-02 is a Monday
+		return time.Date(2026, 3, day, hour, minute, 0, 0, time.Local)
+	}
+	cases := []struct {
+		from, want time.Time
+	}{
+		{at(2, 10, 0), at(2, 10, 30)}, // plain interval
+		{at(2, 22, 45), at(3, 7, 0)},  // lands in quiet hours, crossing midnight
+		{at(3, 2, 0), at(3, 7, 0)},    // already inside quiet hours
+		{at(6, 22, 40), at(9, 7, 0)},  // Friday night skips the weekend and Monday's quiet hours
+		{at(7, 12, 0), at(9, 7, 0)},   // Saturday
+		{at(2, 6, 40), at(2, 7, 10)},  // interval ends after quiet hours
+	}
+	for _, tc := range cases {
+		if got := s.next(tc.from); !got.Equal(tc.want) {
+			t.Errorf("next(%v) = %v, want %v", tc.from, got, tc.want)
+		}
+	}
+
+	day, _ := Options{QuietHours: "12:00-13:00"}.parse()
+	if got := day.next(at(2, 11, 50)); !got.Equal(at(2, 13, 0)) {
+		t.Errorf("daytime quiet hours: got %v", got)
+	}
+}
+
+func TestBeatPersistsRecords(t *testing.T) {
+	store, err := OpenStore(filepath.Join(t.TempDir(), "hb.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	p := &scriptedProvider{replies: []llm.Message{
+		{Role: "assistant", ToolCalls: []llm.ToolCall{toolCall("c1", "bash")}},
+		{Role: "assistant", Content: "All good."},
+	}}
+	r := testRunner(p, &fakeExecutor{}, "---\nmax_rounds: 4\n---\n- check disk\n")
+	r.SetStore(store)
+	r.beat()
+
+	if !strings.Contains(p.calls[0][0].Content, "- check disk") || strings.Contains(p.calls[0][0].Content, "max_rounds") {
+		t.Errorf("prompt should contain HEARTBEAT.md without frontmatter: %q", p.calls[0][0].Content)
+	}
+	if r.TotalHeartbeats() != 1 || r.TotalActions() != 1 || r.LastDecision() != "All good." {
+		t.Errorf("counters not updated: %d %d %q", r.TotalHeartbeats(), r.TotalActions(), r.LastDecision())
+	}
+
+	records, err := r.History(10)
+	if err != nil || len(records) != 1 {
+		t.Fatalf("expected one stored record, got %+v, %v", records, err)
+	}
+	got := records[0]
+	if got.Status != StatusOK || got.Rounds != 2 || got.ActionsTaken != 1 || got.Timestamp.IsZero() || got.Transcript != nil {
+		t.Errorf("unexpected listed record: %+v", got)
+	}
+	full, err := r.Record(got.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(full.Transcript) != 4 || full.Transcript[1].ToolCalls[0].Function.Name != "bash" || full.Transcript[2].Role != "tool" {
+		t.Errorf("transcript not stored: %+v", full.Transcript)
+	}
+	if _, err := r.Record(got.ID + 1); err == nil {
+		t.Error("missing record should be an error")
+	}
+
+	// a new runner restores the counters
+	restored := testRunner(p, &fakeExecutor{}, "")
+	restored.SetStore(store)
+	if restored.TotalHeartbeats() != 1 || restored.TotalActions() != 1 || !restored.LastRun().Equal(got.Timestamp) {
+		t.Errorf("counters not restored: %d %d %v", restored.TotalHeartbeats(), restored.TotalActions(), restored.LastRun())
+	}
+}
+
+func TestBeatWithoutConfigSkips(t *testing.T) {
+	p := &scriptedProvider{}
+	r := testRunner(p, &fakeExecutor{}, "---\ninterval: 20m\n---\n")
+	r.beat()
+	if len(p.calls) != 0 || r.LastDecision() != "no HEARTBEAT.md config found" {
+		t.Errorf("heartbeat with only frontmatter should skip the model: %d calls, %q", len(p.calls), r.LastDecision())
+	}
+	if r.IntervalMinutes() != 20 {
+		t.Errorf("frontmatter interval should apply, got %d", r.IntervalMinutes())
+	}
+	if _, err := r.History(1); err == nil {
+		t.Error("history without a store should be an error")
+	}
+}
+
+func TestStopInterruptsModelCall(t *testing.T) {
+	p := &blockingProvider{started: make(chan struct{})}
+	r := testRunner(p, &fakeExecutor{}, "")
+	r.Start()
+	done := make(chan Record, 1)
+	go func() { done <- r.run("prompt", 3) }()
+
+	<-p.started
+	r.Stop()
+	select {
+	case rec := <-done:
+		if rec.Status != StatusError || rec.Error != "heartbeat stopped" || rec.Rounds != 1 {
+			t.Errorf("stopped heartbeat should end as stopped after one round, got %+v", rec)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stop did not interrupt the in-flight model call")
+	}
+}
@@ -0,0 +1,282 @@
+package heartbeat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultInterval  = 15 * time.Minute
+	defaultMaxRounds = 5
+	minInterval      = time.Minute
+)
+
+// Options configures when heartbeats run and how many model rounds one
+// heartbeat may take. Zero fields fall back to the defaults; the frontmatter
+// of HEARTBEAT.md overrides them per file.
+type Options struct {
+	Interval   time.Duration
+	QuietHours string // local clock range with no heartbeats, e.g. "23:00-07:00"; empty = none
+	ActiveDays string // weekdays heartbeats run on, e.g. "mon-fri" or "1,3,5"; empty = every day
+	MaxRounds  int    // model calls per heartbeat, each may run tools
+}
+
+// frontmatter is the YAML header HEARTBEAT.md may start with:
+//
+//	---
+//	interval: 30m
+//	quiet_hours: 22:00-08:00
+//	active_days: mon-fri
+//	max_rounds: 8
+//	---
+type frontmatter struct {
+	Interval   string `yaml:"interval"` // duration, or plain minutes
+	QuietHours string `yaml:"quiet_hours"`
+	ActiveDays string `yaml:"active_days"`
+	MaxRounds  int    `yaml:"max_rounds"`
+}
+
+// schedule is the parsed form of Options.
+type schedule struct {
+	interval   time.Duration
+	quiet      bool
+	quietStart int // minutes after midnight
+	quietEnd   int
+	days       [7]bool // indexed by time.Weekday
+	maxRounds  int
+	opts       Options // normalized source, for status display
+}
+
+// splitFrontmatter separates a leading "---" YAML block from the body.
+func splitFrontmatter(content string) (frontmatter, string, error) {
+	var fm frontmatter
+	text := strings.TrimPrefix(content, "\ufeff")
+	if !strings.HasPrefix(text, "---\n") && !strings.HasPrefix(text, "---\r\n") {
+		return fm, content, nil
+	}
+	rest := text[strings.Index(text, "\n")+1:]
+	end := -1
+	for off := 0; off < len(rest); {
+		line := rest[off:]
+		if i := strings.IndexByte(line, '\n'); i >= 0 {
+			line = line[:i]
+		}
+		if strings.TrimRight(line, "\r ") == "---" {
+			end = off
+			break
+		}
+		off += len(line) + 1
+	}
+	if end < 0 {
+		return fm, content, fmt.Errorf("HEARTBEAT.md frontmatter is not closed with ---")
+	}
+	if err := yaml.Unmarshal([]byte(rest[:end]), &fm); err != nil {
+		return fm, content, fmt.Errorf("HEARTBEAT.md frontmatter: %w", err)
+	}
+	body := rest[end:]
+	if i := strings.IndexByte(body, '\n'); i >= 0 {
+		body = body[i+1:]
+	} else {
+		body = ""
+	}
+	return fm, body, nil
+}
+
+// merge returns o with the fields set in the frontmatter replaced.
+func (o Options) merge(fm frontmatter) (Options, error) {
+	if fm.Interval != "" {
+		d, err := parseInterval(fm.Interval)
+		if err != nil {
+			return o, err
+		}
+		o.Interval = d
+	}
+	if fm.QuietHours != "" {
+		o.QuietHours = fm.QuietHours
+	}
+	if fm.ActiveDays != "" {
+		o.ActiveDays = fm.ActiveDays
+	}
+	if fm.MaxRounds != 0 {
+		o.MaxRounds = fm.MaxRounds
+	}
+	return o, nil
+}
+
+// parse validates the options and fills in defaults.
+func (o Options) parse() (schedule, error) {
+	if o.Interval <= 0 {
+		o.Interval = defaultInterval
+	}
+	if o.Interval < minInterval {
+		return schedule{}, fmt.Errorf("heartbeat interval %v is below %v", o.Interval, minInterval)
+	}
+	if o.MaxRounds <= 0 {
+		o.MaxRounds = defaultMaxRounds
+	}
+	s := schedule{interval: o.Interval, maxRounds: o.MaxRounds}
+
+	if strings.EqualFold(strings.TrimSpace(o.QuietHours), "none") {
+		o.QuietHours = ""
+	}
+	if o.QuietHours != "" {
+		start, end, err := parseClockRange(o.QuietHours)
+		if err != nil {
+			return schedule{}, err
+		}
+		s.quiet, s.quietStart, s.quietEnd = true, start, end
+	}
+
+	if o.ActiveDays == "" {
+		for i := range s.days {
+			s.days[i] = true
+		}
+	} else {
+		days, err := parseDays(o.ActiveDays)
+		if err != nil {
+			return schedule{}, err
+		}
+		s.days = days
+	}
+	s.opts = o
+	return s, nil
+}
+
+// allowed reports whether a heartbeat may run at t.
+func (s schedule) allowed(t time.Time) bool {
+	return s.days[t.Weekday()] && !s.inQuiet(t)
+}
+
+func (s schedule) inQuiet(t time.Time) bool {
+	if !s.quiet {
+		return false
+	}
+	m := t.Hour()*60 + t.Minute()
+	if s.quietStart < s.quietEnd {
+		return m >= s.quietStart && m < s.quietEnd
+	}
+	return m >= s.quietStart || m < s.quietEnd // range crosses midnight
+}
+
+// next returns when the heartbeat after one at from should run: one interval
+// later, pushed out of quiet hours and inactive days.
+func (s schedule) next(from time.Time) time.Time {
+	t := from.Add(s.interval)
+	for i := 0; i < 16 && !s.allowed(t); i++ {
+		if !s.days[t.Weekday()] {
+			y, m, d := t.Date()
+			t = time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		y, m, d := t.Date()
+		end := time.Date(y, m, d, s.quietEnd/60, s.quietEnd%60, 0, 0, t.Location())
+		if !end.After(t) {
+			end = time.Date(y, m, d+1, s.quietEnd/60, s.quietEnd%60, 0, 0, t.Location())
+		}
+		t = end
+	}
+	return t
+}
+
+// parseInterval accepts a Go duration ("30m", "1h") or plain minutes ("30").
+func parseInterval(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.Atoi(s); err == nil {
+		return time.Duration(n) * time.Minute, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid heartbeat interval %q", s)
+	}
+	return d, nil
+}
+
+// parseClockRange parses "HH:MM-HH:MM" into minutes after midnight.
+func parseClockRange(s string) (int, int, error) {
+	from, to, ok := strings.Cut(strings.TrimSpace(s), "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid quiet hours %q, want HH:MM-HH:MM", s)
+	}
+	start, err := parseClock(from)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid quiet hours %q: %w", s, err)
+	}
+	end, err := parseClock(to)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid quiet hours %q: %w", s, err)
+	}
+	if start == end {
+		return 0, 0, fmt.Errorf("invalid quiet hours %q: start equals end", s)
+	}
+	return start, end, nil
+}
+
+func parseClock(s string) (int, error) {
+	h, m, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		m = "0"
+	}
+	hour, err1 := strconv.Atoi(h)
+	minute, err2 := strconv.Atoi(m)
+	if err1 != nil || err2 != nil || hour < 0 || hour > 24 || minute < 0 || minute > 59 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("bad time %q", s)
+	}
+	return (hour*60 + minute) % (24 * 60), nil
+}
+
+var dayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// parseDays parses a comma-separated list of weekdays and ranges, by name
+// ("mon-fri", "sat,sun") or number (0 or 7 = Sunday). Ranges may wrap ("fri-mon").
+func parseDays(s string) ([7]bool, error) {
+	var days [7]bool
+	found := false
+	for _, part := range strings.Split(strings.ToLower(s), ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		from, to, isRange := strings.Cut(part, "-")
+		start, err := parseDay(from)
+		if err != nil {
+			return days, err
+		}
+		end := start
+		if isRange {
+			if end, err = parseDay(to); err != nil {
+				return days, err
+			}
+		}
+		for d := start; ; d = (d + 1) % 7 {
+			days[d] = true
+			if d == end {
+				break
+			}
+		}
+		found = true
+	}
+	if !found {
+		return days, fmt.Errorf("invalid active days %q", s)
+	}
+	return days, nil
+}
+
+func parseDay(s string) (time.Weekday, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && n <= 7 {
+		return time.Weekday(n % 7), nil
+	}
+	if len(s) >= 3 {
+		if d, ok := dayNames[s[:3]]; ok {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday %q", s)
+}
@@ -89,7 +89,7 @@ func (s Snapshot) FormatPrompt(heartbeatConfig string) string {
 		b.WriteString("\n\n")
 	}
 
-	b.WriteString("如果有需要执行的任务，请说明并执行。如果没有，回复\"无需行动\"。")
+	b.WriteString("如果有需要执行的任务，请调用工具执行；可以根据工具结果继续调用工具完成多步检查，完成后用一段话总结做了什么。如果没有，回复\"无需行动\"。")
 
 	return b.String()
 }
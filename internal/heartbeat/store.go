@@ -0,0 +1,122 @@
+package heartbeat
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/BlakeLiAFK/kele/internal/llm"
+)
+
+// maxStoredRecords caps the heartbeat history; older records are pruned.
+const maxStoredRecords = 500
+
+// Store persists heartbeat records in SQLite.
+type Store struct {
+	db *sql.DB
+}
+
+// OpenStore opens (and creates if needed) the heartbeat history in dbPath.
+func OpenStore(dbPath string) (*Store, error) {
+	db, err := sql.Open("sqlite3", dbPath+"?_journal_mode=WAL&_busy_timeout=5000")
+	if err != nil {
+		return nil, err
+	}
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS heartbeat_runs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		started_at DATETIME NOT NULL,
+		duration_ms INTEGER NOT NULL DEFAULT 0,
+		status TEXT NOT NULL DEFAULT '',
+		decision TEXT NOT NULL DEFAULT '',
+		error TEXT NOT NULL DEFAULT '',
+		actions INTEGER NOT NULL DEFAULT 0,
+		rounds INTEGER NOT NULL DEFAULT 0,
+		transcript TEXT NOT NULL DEFAULT '[]'
+	);
+	CREATE INDEX IF NOT EXISTS idx_heartbeat_runs_started ON heartbeat_runs(started_at);`)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create heartbeat_runs: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Save inserts rec, sets its ID and prunes the oldest records.
+func (s *Store) Save(rec *Record) error {
+	transcript, err := json.Marshal(rec.Transcript)
+	if err != nil {
+		return err
+	}
+	res, err := s.db.Exec(`INSERT INTO heartbeat_runs
+		(started_at, duration_ms, status, decision, error, actions, rounds, transcript)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		rec.Timestamp, rec.Duration.Milliseconds(), rec.Status, rec.Decision, rec.Error,
+		rec.ActionsTaken, rec.Rounds, string(transcript))
+	if err != nil {
+		return err
+	}
+	rec.ID, _ = res.LastInsertId()
+	_, err = s.db.Exec(`DELETE FROM heartbeat_runs WHERE id NOT IN
+		(SELECT id FROM heartbeat_runs ORDER BY id DESC LIMIT ?)`, maxStoredRecords)
+	return err
+}
+
+// List returns the most recent records, newest first, without transcripts.
+func (s *Store) List(limit int) ([]Record, error) {
+	rows, err := s.db.Query(`SELECT id, started_at, duration_ms, status, decision, error, actions, rounds
+		FROM heartbeat_runs ORDER BY id DESC LIMIT ?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var rec Record
+		var durationMs int64
+		if err := rows.Scan(&rec.ID, &rec.Timestamp, &durationMs, &rec.Status, &rec.Decision,
+			&rec.Error, &rec.ActionsTaken, &rec.Rounds); err != nil {
+			return nil, err
+		}
+		rec.Duration = time.Duration(durationMs) * time.Millisecond
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
+
+// Get returns one record with its full transcript.
+func (s *Store) Get(id int64) (*Record, error) {
+	var rec Record
+	var durationMs int64
+	var transcript string
+	err := s.db.QueryRow(`SELECT id, started_at, duration_ms, status, decision, error, actions, rounds, transcript
+		FROM heartbeat_runs WHERE id = ?`, id).Scan(&rec.ID, &rec.Timestamp, &durationMs, &rec.Status,
+		&rec.Decision, &rec.Error, &rec.ActionsTaken, &rec.Rounds, &transcript)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("heartbeat record %d not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	rec.Duration = time.Duration(durationMs) * time.Millisecond
+	var messages []llm.Message
+	if err := json.Unmarshal([]byte(transcript), &messages); err != nil {
+		return nil, fmt.Errorf("decode transcript of heartbeat %d: %w", id, err)
+	}
+	rec.Transcript = messages
+	return &rec, nil
+}
+
+// totals returns the number of stored records and the actions they took.
+func (s *Store) totals() (count, actions int, err error) {
+	err = s.db.QueryRow("SELECT COUNT(*), COALESCE(SUM(actions), 0) FROM heartbeat_runs").Scan(&count, &actions)
+	return count, actions, err
+}
@@ -0,0 +1,279 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/BlakeLiAFK/kele/internal/config"
+)
+
+// Embedder 把文本转换为向量，用于语义检索
+type Embedder interface {
+	// Name 标识向量来源（供应商:模型），更换后旧向量需要重建
+	Name() string
+	// Embed 为每段文本返回一个向量，顺序与输入一致
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// 默认嵌入模型
+const (
+	DefaultOllamaEmbeddingModel = "nomic-embed-text"
+	DefaultOpenAIEmbeddingModel = "text-embedding-3-small"
+	DefaultHashDimensions       = 256
+)
+
+// NewEmbedder 按 memory.embedder 配置创建嵌入器，未配置时返回 nil
+func NewEmbedder(cfg *config.Config) (Embedder, error) {
+	model := cfg.Memory.EmbeddingModel
+	switch cfg.Memory.Embedder {
+	case "", "none":
+		return nil, nil
+	case "ollama":
+		if model == "" {
+			model = DefaultOllamaEmbeddingModel
+		}
+		return NewOllamaEmbedder(cfg.LLM.OllamaHost, model), nil
+	case "openai":
+		if cfg.LLM.OpenAIAPIKey == "" {
+			return nil, fmt.Errorf("openai 嵌入需要配置 llm.openai_api_key")
+		}
+		if model == "" {
+			model = DefaultOpenAIEmbeddingModel
+		}
+		return NewOpenAIEmbedder(cfg.LLM.OpenAIAPIBase, cfg.LLM.OpenAIAPIKey, model), nil
+	case "hash":
+		return NewHashEmbedder(DefaultHashDimensions), nil
+	default:
+		return nil, fmt.Errorf("未知的嵌入器: %s（可选 none, ollama, openai, hash）", cfg.Memory.Embedder)
+	}
+}
+
+// --- Ollama ---
+
+// OllamaEmbedder 调用 Ollama /api/embeddings 接口
+type OllamaEmbedder struct {
+	host   string
+	model  string
+	client *http.Client
+}
+
+// NewOllamaEmbedder 创建 Ollama 嵌入器
+func NewOllamaEmbedder(host, model string) *OllamaEmbedder {
+	return &OllamaEmbedder{host: host, model: model, client: &http.Client{Timeout: 2 * time.Minute}}
+}
+
+func (e *OllamaEmbedder) Name() string { return "ollama:" + e.model }
+
+// Embed 逐条请求（/api/embeddings 每次只接受一段文本）
+func (e *OllamaEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		var result struct {
+			Embedding []float32 `json:"embedding"`
+		}
+		err := postJSON(ctx, e.client, e.host+"/api/embeddings", "", map[string]string{
+			"model":  e.model,
+			"prompt": text,
+		}, &result)
+		if err != nil {
+			return nil, fmt.Errorf("Ollama 嵌入失败: %w (确认 Ollama 已运行且已拉取 %s)", err, e.model)
+		}
+		if len(result.Embedding) == 0 {
+			return nil, fmt.Errorf("Ollama 返回空向量 (模型 %s)", e.model)
+		}
+		vectors = append(vectors, result.Embedding)
+	}
+	return vectors, nil
+}
+
+// --- OpenAI 兼容 ---
+
+// OpenAIEmbedder 调用 OpenAI 兼容的 /embeddings 接口
+type OpenAIEmbedder struct {
+	apiBase string
+	apiKey  string
+	model   string
+	client  *http.Client
+}
+
+// NewOpenAIEmbedder 创建 OpenAI 兼容嵌入器
+func NewOpenAIEmbedder(apiBase, apiKey, model string) *OpenAIEmbedder {
+	return &OpenAIEmbedder{apiBase: apiBase, apiKey: apiKey, model: model, client: &http.Client{Timeout: 2 * time.Minute}}
+}
+
+func (e *OpenAIEmbedder) Name() string { return "openai:" + e.model }
+
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	var result struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	err := postJSON(ctx, e.client, e.apiBase+"/embeddings", e.apiKey, map[string]interface{}{
+		"model": e.model,
+		"input": texts,
+	}, &result)
+	if err != nil {
+		return nil, fmt.Errorf("嵌入失败: %w", err)
+	}
+	vectors := make([][]float32, len(texts))
+	for _, d := range result.Data {
+		if d.Index >= 0 && d.Index < len(vectors) {
+			vectors[d.Index] = d.Embedding
+		}
+	}
+	for i, v := range vectors {
+		if len(v) == 0 {
+			return nil, fmt.Errorf("嵌入结果缺少第 %d 条", i+1)
+		}
+	}
+	return vectors, nil
+}
+
+// postJSON 发送 JSON 请求并解析 JSON 响应
+func postJSON(ctx context.Context, client *http.Client, url, apiKey string, body, out interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("网络错误: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return classifyAPIError(resp.StatusCode, string(bodyBytes))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("解析响应失败: %w", err)
+	}
+	return nil
+}
+
+// --- 本地哈希 ---
+
+// HashEmbedder 不依赖模型的确定性嵌入：把词和中文二元组哈希到固定维度。
+// 只反映字面重合，没有语义能力，用于测试和离线环境。
+type HashEmbedder struct {
+	dim int
+}
+
+// NewHashEmbedder 创建哈希嵌入器
+func NewHashEmbedder(dim int) *HashEmbedder {
+	if dim <= 0 {
+		dim = DefaultHashDimensions
+	}
+	return &HashEmbedder{dim: dim}
+}
+
+func (e *HashEmbedder) Name() string { return fmt.Sprintf("hash:%d", e.dim) }
+
+func (e *HashEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		v := make([]float32, e.dim)
+		for _, tok := range hashTokens(text) {
+			h := fnv.New32a()
+			h.Write([]byte(tok))
+			sum := h.Sum32()
+			// 最高位决定符号，减少哈希冲突带来的偏差
+			if sum&0x80000000 != 0 {
+				v[int(sum%uint32(e.dim))]--
+			} else {
+				v[int(sum%uint32(e.dim))]++
+			}
+		}
+		vectors[i] = Normalize(v)
+	}
+	return vectors, nil
+}
+
+// hashTokens 拆分出小写的字母数字词，以及中文等无空格文字的单字和二元组
+func hashTokens(text string) []string {
+	var tokens []string
+	var word, cjk []rune
+	flushWord := func() {
+		if len(word) > 0 {
+			tokens = append(tokens, string(word))
+			word = word[:0]
+		}
+	}
+	flushCJK := func() {
+		for i, r := range cjk {
+			tokens = append(tokens, string(r))
+			if i+1 < len(cjk) {
+				tokens = append(tokens, string(cjk[i:i+2]))
+			}
+		}
+		cjk = cjk[:0]
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+	return tokens
+}
+
+// Normalize 把向量缩放到单位长度（零向量原样返回）
+func Normalize(v []float32) []float32 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 {
+		return v
+	}
+	norm := float32(math.Sqrt(sum))
+	out := make([]float32, len(v))
+	for i, x := range v {
+		out[i] = x / norm
+	}
+	return out
+}
+
+// Cosine 计算两个向量的余弦相似度，维度不同时返回 0
+func Cosine(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
@@ -0,0 +1,99 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHashEmbedder(t *testing.T) {
+	e := NewHashEmbedder(64)
+	vecs, err := e.Embed(context.Background(), []string{
+		"daemon 启动失败",
+		"daemon 启动失败",
+		"daemon 无法启动",
+		"用户喜欢喝咖啡",
+	})
+	if err != nil {
+		t.Fatalf("Embed 失败: %v", err)
+	}
+	if len(vecs[0]) != 64 {
+		t.Fatalf("维度应为 64, 实际 %d", len(vecs[0]))
+	}
+	if Cosine(vecs[0], vecs[1]) < 0.999 {
+		t.Error("相同文本应得到相同向量")
+	}
+	var norm float64
+	for _, x := range vecs[0] {
+		norm += float64(x) * float64(x)
+	}
+	if math.Abs(norm-1) > 1e-5 {
+		t.Errorf("向量应归一化, 模长平方 %f", norm)
+	}
+	if Cosine(vecs[0], vecs[2]) <= Cosine(vecs[0], vecs[3]) {
+		t.Error("字面相近的文本应更相似")
+	}
+}
+
+func TestOllamaEmbedder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/embeddings" {
+			t.Errorf("路径错误: %s", r.URL.Path)
+		}
+		var req struct {
+			Model  string `json:"model"`
+			Prompt string `json:"prompt"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"embedding": []float32{float32(len(req.Prompt)), 1},
+		})
+	}))
+	defer srv.Close()
+
+	e := NewOllamaEmbedder(srv.URL, "nomic-embed-text")
+	vecs, err := e.Embed(context.Background(), []string{"a", "abc"})
+	if err != nil {
+		t.Fatalf("Embed 失败: %v", err)
+	}
+	if len(vecs) != 2 || vecs[0][0] != 1 || vecs[1][0] != 3 {
+		t.Errorf("向量顺序错误: %v", vecs)
+	}
+	if e.Name() != "ollama:nomic-embed-text" {
+		t.Errorf("Name 错误: %s", e.Name())
+	}
+}
+
+func TestOpenAIEmbedder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sk-test" {
+			t.Errorf("缺少鉴权头")
+		}
+		// 故意打乱顺序，按 index 还原
+		w.Write([]byte(`{"data": [
+			{"index": 1, "embedding": [0, 2]},
+			{"index": 0, "embedding": [1, 0]}
+		]}`))
+	}))
+	defer srv.Close()
+
+	e := NewOpenAIEmbedder(srv.URL, "sk-test", "text-embedding-3-small")
+	vecs, err := e.Embed(context.Background(), []string{"x", "y"})
+	if err != nil {
+		t.Fatalf("Embed 失败: %v", err)
+	}
+	if vecs[0][0] != 1 || vecs[1][1] != 2 {
+		t.Errorf("应按 index 排列: %v", vecs)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer failing.Close()
+	if _, err := NewOpenAIEmbedder(failing.URL, "bad", "m").Embed(context.Background(), []string{"x"}); err == nil {
+		t.Error("401 应返回错误")
+	}
+}
@@ -25,6 +25,18 @@ type ChatOptions struct {
 	MaxTokens   int
 }
 
+// 模型档位：可代替具体模型名使用
+const (
+	ModelTierSmall = "small" // 小模型（llm.small_model）
+	ModelTierLarge = "large" // 当前主模型
+)
+
+// StreamOverrides 单次调用覆盖的参数，零值沿用全局设置
+type StreamOverrides struct {
+	Model       string   // 模型名，或 ModelTierSmall / ModelTierLarge
+	Temperature *float64 // nil 使用配置中的温度
+}
+
 // ProviderManager 多供应商管理器
 type ProviderManager struct {
 	providers map[string]Provider
@@ -39,13 +51,30 @@ type ProviderManager struct {
 	smallModel         string
 	smallProvider      Provider
 
+	// 限流状态：供应商名 → 冷却结束时间，单独加锁避免与 mu 嵌套
+	rateMu      sync.Mutex
+	rateLimited map[string]rateLimit
+
 	cfg *config.Config
 }
 
+// rateLimit 供应商限流冷却
+type rateLimit struct {
+	until    time.Time
+	cooldown time.Duration
+}
+
+// 限流冷却时长：首次 30 秒，冷却期内再次限流则翻倍，最长 5 分钟
+const (
+	rateLimitCooldown    = 30 * time.Second
+	maxRateLimitCooldown = 5 * time.Minute
+)
+
 // NewProviderManager 创建供应商管理器
 func NewProviderManager(cfg *config.Config) *ProviderManager {
 	pm := &ProviderManager{
 		providers:    make(map[string]Provider),
+		rateLimited:  make(map[string]rateLimit),
 		model:        cfg.LLM.OpenAIModel,
 		defaultModel: cfg.LLM.OpenAIModel,
 		smallModel:   cfg.LLM.SmallModel,
@@ -126,6 +155,11 @@ func (pm *ProviderManager) loadCustomProviders() {
 
 // Chat 非流式聊天（带自动重试）
 func (pm *ProviderManager) Chat(messages []Message, tools []Tool) (*ChatResponse, error) {
+	return pm.ChatContext(context.Background(), messages, tools)
+}
+
+// ChatContext 同 Chat，ctx 取消时中断请求和重试等待
+func (pm *ProviderManager) ChatContext(ctx context.Context, messages []Message, tools []Tool) (*ChatResponse, error) {
 	pm.mu.RLock()
 	provider := pm.activeProvider
 	model := pm.model
@@ -144,27 +178,39 @@ func (pm *ProviderManager) Chat(messages []Message, tools []Tool) (*ChatResponse
 	// 自动重试：最多 3 次，指数退避
 	var lastErr error
 	for attempt := 0; attempt < 3; attempt++ {
-		resp, err := provider.Chat(context.Background(), messages, tools, opts)
+		resp, err := provider.Chat(ctx, messages, tools, opts)
 		if err == nil {
 			return resp, nil
 		}
 		lastErr = err
-		if !isRetryableError(err) {
+		pm.noteError(provider, err)
+		if ctx.Err() != nil || !isRetryableError(err) {
 			return nil, err
 		}
 		// 指数退避：1s, 2s, 4s
 		backoff := time.Duration(1<<uint(attempt)) * time.Second
-		time.Sleep(backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
 	}
 	return nil, fmt.Errorf("重试 3 次后仍失败: %w", lastErr)
 }
 
 // ChatStream 流式聊天（带自动重试）
 func (pm *ProviderManager) ChatStream(messages []Message, tools []Tool) <-chan StreamEvent {
-	pm.mu.RLock()
-	provider := pm.activeProvider
-	model := pm.model
-	pm.mu.RUnlock()
+	return pm.ChatStreamContext(context.Background(), messages, tools)
+}
+
+// ChatStreamContext 同 ChatStream，ctx 取消时中断请求和流式读取
+func (pm *ProviderManager) ChatStreamContext(ctx context.Context, messages []Message, tools []Tool) <-chan StreamEvent {
+	return pm.ChatStreamWith(ctx, messages, tools, StreamOverrides{})
+}
+
+// ChatStreamWith 同 ChatStreamContext，按 o 覆盖本次调用的模型和温度，不影响全局设置
+func (pm *ProviderManager) ChatStreamWith(ctx context.Context, messages []Message, tools []Tool, o StreamOverrides) <-chan StreamEvent {
+	provider, model := pm.resolveOverride(o.Model)
 
 	if provider == nil {
 		ch := make(chan StreamEvent, 1)
@@ -178,23 +224,30 @@ func (pm *ProviderManager) ChatStream(messages []Message, tools []Tool) <-chan S
 		Temperature: pm.cfg.LLM.Temperature,
 		MaxTokens:   pm.cfg.LLM.MaxTokens,
 	}
+	if o.Temperature != nil {
+		opts.Temperature = *o.Temperature
+	}
 
 	// 自动重试
 	var lastErr error
 	for attempt := 0; attempt < 3; attempt++ {
-		ch, err := provider.ChatStream(context.Background(), messages, tools, opts)
+		ch, err := provider.ChatStream(ctx, messages, tools, opts)
 		if err == nil {
 			return ch
 		}
 		lastErr = err
-		if !isRetryableError(err) {
+		pm.noteError(provider, err)
+		if ctx.Err() != nil || !isRetryableError(err) {
 			errCh := make(chan StreamEvent, 1)
 			errCh <- StreamEvent{Type: "error", Error: err}
 			close(errCh)
 			return errCh
 		}
 		backoff := time.Duration(1<<uint(attempt)) * time.Second
-		time.Sleep(backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+		}
 	}
 
 	errCh := make(chan StreamEvent, 1)
@@ -203,6 +256,29 @@ func (pm *ProviderManager) ChatStream(messages []Message, tools []Tool) <-chan S
 	return errCh
 }
 
+// resolveOverride 返回覆盖模型对应的供应商和模型名，空值使用当前主模型
+func (pm *ProviderManager) resolveOverride(model string) (Provider, string) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	switch model {
+	case "", ModelTierLarge:
+		return pm.activeProvider, pm.model
+	case ModelTierSmall:
+		if pm.smallModel == "" {
+			return pm.activeProvider, pm.model
+		}
+		if pm.smallProvider != nil {
+			return pm.smallProvider, pm.smallModel
+		}
+		return pm.resolveProvider(pm.smallModel), pm.smallModel
+	}
+	if pm.explicitProvider {
+		// 锁定供应商时只换模型，与 SetModel 一致
+		return pm.activeProvider, model
+	}
+	return pm.resolveProvider(model), model
+}
+
 // Complete 快速补全（使用小模型）
 func (pm *ProviderManager) Complete(messages []Message, maxTokens int) (string, error) {
 	pm.mu.RLock()
@@ -225,6 +301,7 @@ func (pm *ProviderManager) Complete(messages []Message, maxTokens int) (string,
 		MaxTokens:   maxTokens,
 	})
 	if err != nil {
+		pm.noteError(provider, err)
 		return "", err
 	}
 	if len(resp.Choices) == 0 {
@@ -435,6 +512,50 @@ func (pm *ProviderManager) resolveProvider(model string) Provider {
 	return nil
 }
 
+// RateLimitedUntil 返回 model 对应供应商的限流冷却结束时间，零值表示未限流。
+// model 可以是模型名或档位，空值表示当前主模型
+func (pm *ProviderManager) RateLimitedUntil(model string) time.Time {
+	provider, _ := pm.resolveOverride(model)
+	if provider == nil {
+		return time.Time{}
+	}
+	pm.rateMu.Lock()
+	defer pm.rateMu.Unlock()
+	rl := pm.rateLimited[provider.Name()]
+	if time.Now().After(rl.until) {
+		return time.Time{}
+	}
+	return rl.until
+}
+
+// noteError 记录供应商返回的限流错误，冷却期内的任务调度会避开该供应商
+func (pm *ProviderManager) noteError(provider Provider, err error) {
+	if !isRateLimitError(err) {
+		return
+	}
+	pm.rateMu.Lock()
+	defer pm.rateMu.Unlock()
+	now := time.Now()
+	rl := pm.rateLimited[provider.Name()]
+	if now.Before(rl.until) {
+		// 冷却期内再次限流，加倍冷却
+		rl.cooldown = min(rl.cooldown*2, maxRateLimitCooldown)
+	} else {
+		rl.cooldown = rateLimitCooldown
+	}
+	rl.until = now.Add(rl.cooldown)
+	pm.rateLimited[provider.Name()] = rl
+}
+
+// isRateLimitError 判断是否为 429 限流错误
+func isRateLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "频率超限") || strings.Contains(msg, "429")
+}
+
 // isRetryableError 判断错误是否可重试
 func isRetryableError(err error) bool {
 	if err == nil {
@@ -447,7 +568,7 @@ func isRetryableError(err error) bool {
 		return true
 	}
 	// 429 限流
-	if strings.Contains(msg, "频率超限") || strings.Contains(msg, "429") {
+	if isRateLimitError(err) {
 		return true
 	}
 	// 5xx 服务器错误
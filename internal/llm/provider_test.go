@@ -1,8 +1,10 @@
 package llm
 
 import (
+	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/BlakeLiAFK/kele/internal/config"
 )
@@ -88,6 +90,69 @@ func TestProviderManagerModelRouting(t *testing.T) {
 	}
 }
 
+// TestProviderManagerOverride 单次调用覆盖模型不影响全局设置
+func TestProviderManagerOverride(t *testing.T) {
+	os.Setenv("OPENAI_API_KEY", "sk-test")
+	os.Setenv("ANTHROPIC_API_KEY", "sk-ant-test")
+	defer func() {
+		os.Unsetenv("OPENAI_API_KEY")
+		os.Unsetenv("ANTHROPIC_API_KEY")
+	}()
+
+	cfg := config.Load()
+	pm := NewProviderManager(cfg)
+	pm.SetModel("gpt-4o")
+	pm.SetSmallModel("claude-3-5-haiku-20241022")
+
+	p, model := pm.resolveOverride(ModelTierSmall)
+	if p.Name() != "anthropic" || model != "claude-3-5-haiku-20241022" {
+		t.Errorf("small 档位应使用小模型, 实际 %s %s", p.Name(), model)
+	}
+	p, model = pm.resolveOverride(ModelTierLarge)
+	if p.Name() != "openai" || model != "gpt-4o" {
+		t.Errorf("large 档位应使用主模型, 实际 %s %s", p.Name(), model)
+	}
+	p, model = pm.resolveOverride("claude-3-5-sonnet-20241022")
+	if p.Name() != "anthropic" || model != "claude-3-5-sonnet-20241022" {
+		t.Errorf("指定模型应路由到对应供应商, 实际 %s %s", p.Name(), model)
+	}
+	if pm.GetModel() != "gpt-4o" || pm.GetActiveProviderName() != "openai" {
+		t.Errorf("覆盖不应修改全局模型, 实际 %s (%s)", pm.GetModel(), pm.GetActiveProviderName())
+	}
+}
+
+func TestProviderManagerRateLimit(t *testing.T) {
+	os.Setenv("OPENAI_API_KEY", "sk-test")
+	os.Setenv("ANTHROPIC_API_KEY", "sk-ant-test")
+	defer func() {
+		os.Unsetenv("OPENAI_API_KEY")
+		os.Unsetenv("ANTHROPIC_API_KEY")
+	}()
+
+	cfg := config.Load()
+	pm := NewProviderManager(cfg)
+	pm.SetModel("gpt-4o")
+	openai, _ := pm.resolveOverride("")
+
+	pm.noteError(openai, fmt.Errorf("API 错误 (HTTP 400): bad request"))
+	if !pm.RateLimitedUntil("").IsZero() {
+		t.Error("非限流错误不应触发冷却")
+	}
+
+	pm.noteError(openai, fmt.Errorf("请求频率超限: 请稍后重试"))
+	first := pm.RateLimitedUntil("gpt-4o-mini")
+	if d := time.Until(first); d <= 0 || d > rateLimitCooldown {
+		t.Errorf("首次限流应冷却 %s, 实际 %s", rateLimitCooldown, d)
+	}
+	pm.noteError(openai, fmt.Errorf("请求频率超限: 请稍后重试"))
+	if d := time.Until(pm.RateLimitedUntil("")); d <= rateLimitCooldown {
+		t.Errorf("冷却期内再次限流应加倍冷却, 实际 %s", d)
+	}
+	if !pm.RateLimitedUntil("claude-3-5-sonnet-20241022").IsZero() {
+		t.Error("限流只影响对应供应商")
+	}
+}
+
 func TestProviderManagerModelState(t *testing.T) {
 	cfg := config.Load()
 	pm := NewProviderManager(cfg)
@@ -0,0 +1,279 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/BlakeLiAFK/kele/internal/llm"
+)
+
+// CompleteFunc 调用小模型补全，签名与 llm.ProviderManager.Complete 一致
+type CompleteFunc func(messages []llm.Message, maxTokens int) (string, error)
+
+// Proposal 对话结束时提取出的候选记忆，等待用户确认
+type Proposal struct {
+	ID        int64
+	Key       string
+	Value     string
+	Reason    string
+	Scope     string // 接受后写入的作用域
+	CreatedAt time.Time
+}
+
+const (
+	maxTranscriptChars = 12000 // 提取时最多带入的对话长度
+	maxKnownMemories   = 50    // 提示中列出的已有记忆条数
+	similarThreshold   = 0.8   // 认定为重复的相似度
+)
+
+const extractPrompt = `你负责从一段对话中提取值得长期记住的事实。
+
+只提取今后的对话仍然有用的持久信息：
+- 用户的偏好与习惯（语言、风格、常用工具）
+- 项目约定（目录结构、命名、构建与测试方式、依赖选择）
+- 稳定的环境信息（操作系统、服务地址、账号名称，不含密码和密钥）
+- 长期目标与决定
+
+不要提取：一次性任务的细节、临时状态、对话中的闲聊、已有记忆里已经包含的内容。
+每条事实写成一句完整、独立可读的话。没有值得记住的内容时输出 []。
+scope 表示适用范围：只对当前项目成立的约定填 "project"，用户偏好等处处适用的填 "global"。
+
+只输出 JSON 数组，不要解释：
+[{"key": "简短标识，小写英文加下划线", "value": "事实", "reason": "为什么值得长期记住", "scope": "global 或 project"}]`
+
+// keyPattern 合法的记忆 key
+var keyPattern = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// ExtractProposals 用小模型从对话中提取候选记忆，去掉与 scopes 中已有记忆和
+// 待确认候选重复的条目后存为待确认候选，返回新增的候选。模型判定为项目约定
+// 的候选归入 scopes.Project（没有项目时归入全局）。
+func (s *Store) ExtractProposals(complete CompleteFunc, history []llm.Message, scopes Scopes) ([]Proposal, error) {
+	transcript := buildTranscript(history)
+	if transcript == "" {
+		return nil, nil
+	}
+	existing, err := s.ListMemoriesIn(scopes.List())
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.ListProposals()
+	if err != nil {
+		return nil, err
+	}
+
+	var user strings.Builder
+	if len(existing) > 0 {
+		user.WriteString("已有记忆:\n")
+		for i, e := range existing {
+			if i == maxKnownMemories {
+				break
+			}
+			fmt.Fprintf(&user, "- [%s] %s\n", e.Key, e.Value)
+		}
+		user.WriteString("\n")
+	}
+	user.WriteString("对话:\n")
+	user.WriteString(transcript)
+
+	reply, err := complete([]llm.Message{
+		{Role: "system", Content: extractPrompt},
+		{Role: "user", Content: user.String()},
+	}, 1000)
+	if err != nil {
+		return nil, fmt.Errorf("提取记忆失败: %w", err)
+	}
+	candidates, err := parseProposals(reply)
+	if err != nil {
+		return nil, err
+	}
+	for i := range candidates {
+		if candidates[i].Scope == "project" && scopes.Project != "" {
+			candidates[i].Scope = scopes.Project
+		} else {
+			candidates[i].Scope = ScopeGlobal
+		}
+	}
+
+	var added []Proposal
+	for _, c := range candidates {
+		if isDuplicate(c, existing, pending) {
+			continue
+		}
+		res, err := s.db.Exec("INSERT INTO memory_proposals (key, value, reason, scope) VALUES (?, ?, ?, ?)", c.Key, c.Value, c.Reason, c.Scope)
+		if err != nil {
+			return added, err
+		}
+		c.ID, _ = res.LastInsertId()
+		c.CreatedAt = time.Now()
+		added = append(added, c)
+		pending = append(pending, c)
+	}
+	return added, nil
+}
+
+// ListProposals 列出待确认的候选记忆
+func (s *Store) ListProposals() ([]Proposal, error) {
+	rows, err := s.db.Query("SELECT id, key, value, reason, scope, created_at FROM memory_proposals ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []Proposal
+	for rows.Next() {
+		var p Proposal
+		if err := rows.Scan(&p.ID, &p.Key, &p.Value, &p.Reason, &p.Scope, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+	return result, rows.Err()
+}
+
+// AcceptProposal 把候选写入其作用域的长期记忆（同 key 的记忆被覆盖）
+func (s *Store) AcceptProposal(id int64) (*Proposal, error) {
+	var p Proposal
+	err := s.db.QueryRow("SELECT id, key, value, reason, scope, created_at FROM memory_proposals WHERE id = ?", id).
+		Scan(&p.ID, &p.Key, &p.Value, &p.Reason, &p.Scope, &p.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("候选 %d 不存在", id)
+	}
+	if err := s.UpdateMemoryIn(p.Scope, p.Key, p.Value); err != nil {
+		return nil, err
+	}
+	s.db.Exec("DELETE FROM memory_proposals WHERE id = ?", id)
+	return &p, nil
+}
+
+// RejectProposal 丢弃候选
+func (s *Store) RejectProposal(id int64) error {
+	res, err := s.db.Exec("DELETE FROM memory_proposals WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("候选 %d 不存在", id)
+	}
+	return nil
+}
+
+// buildTranscript 把对话整理成文本，只保留用户与助手的发言，过长时保留结尾
+func buildTranscript(history []llm.Message) string {
+	var b strings.Builder
+	for _, m := range history {
+		if (m.Role != "user" && m.Role != "assistant") || strings.TrimSpace(m.Content) == "" {
+			continue
+		}
+		role := "用户"
+		if m.Role == "assistant" {
+			role = "助手"
+		}
+		fmt.Fprintf(&b, "%s: %s\n\n", role, strings.TrimSpace(m.Content))
+	}
+	text := []rune(b.String())
+	if len(text) > maxTranscriptChars {
+		text = text[len(text)-maxTranscriptChars:]
+	}
+	return strings.TrimSpace(string(text))
+}
+
+// parseProposals 解析模型输出的 JSON 数组，容忍代码块包裹和前后多余文字
+func parseProposals(reply string) ([]Proposal, error) {
+	start := strings.Index(reply, "[")
+	end := strings.LastIndex(reply, "]")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("无法解析提取结果: %s", truncate(reply, 200))
+	}
+	var raw []struct {
+		Key    string `json:"key"`
+		Value  string `json:"value"`
+		Reason string `json:"reason"`
+		Scope  string `json:"scope"`
+	}
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &raw); err != nil {
+		return nil, fmt.Errorf("无法解析提取结果: %w", err)
+	}
+	var result []Proposal
+	for i, r := range raw {
+		value := strings.TrimSpace(r.Value)
+		if value == "" {
+			continue
+		}
+		key := strings.Trim(keyPattern.ReplaceAllString(strings.ToLower(r.Key), "_"), "_")
+		if key == "" {
+			key = fmt.Sprintf("fact_%d_%d", time.Now().Unix(), i+1)
+		}
+		result = append(result, Proposal{Key: key, Value: value, Reason: strings.TrimSpace(r.Reason), Scope: strings.TrimSpace(r.Scope)})
+	}
+	return result, nil
+}
+
+// isDuplicate 判断候选是否与已有记忆或待确认候选内容重复。
+// 同 key 但内容不同的候选保留，接受后更新该记忆。
+func isDuplicate(p Proposal, existing []Entry, pending []Proposal) bool {
+	for _, e := range existing {
+		if similar(p.Value, e.Value) {
+			return true
+		}
+	}
+	for _, q := range pending {
+		if q.Key == p.Key || similar(p.Value, q.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// similar 比较两段文本：忽略大小写、空白和标点后，一方包含另一方
+// （较短的一方不少于 6 个字符），或字符二元组的 Dice 系数达到阈值即视为相同
+func similar(a, b string) bool {
+	na, nb := normalize(a), normalize(b)
+	if len(na) == 0 || len(nb) == 0 {
+		return false
+	}
+	if len(na) >= 6 && len(nb) >= 6 &&
+		(strings.Contains(string(na), string(nb)) || strings.Contains(string(nb), string(na))) {
+		return true
+	}
+	return dice(na, nb) >= similarThreshold
+}
+
+func normalize(s string) []rune {
+	var out []rune
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func dice(a, b []rune) float64 {
+	if len(a) < 2 || len(b) < 2 {
+		return 0
+	}
+	grams := make(map[[2]rune]int)
+	for i := 0; i+1 < len(a); i++ {
+		grams[[2]rune{a[i], a[i+1]}]++
+	}
+	common := 0
+	for i := 0; i+1 < len(b); i++ {
+		g := [2]rune{b[i], b[i+1]}
+		if grams[g] > 0 {
+			grams[g]--
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(a)-1+len(b)-1)
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) > n {
+		return string(r[:n]) + "..."
+	}
+	return s
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/BlakeLiAFK/kele/internal/llm"
+)
+
+func TestExtractProposals(t *testing.T) {
+	store := testStore(t)
+	defer store.Close()
+	store.UpdateMemory("language", "用户习惯用中文交流。")
+
+	var prompt string
+	complete := func(messages []llm.Message, maxTokens int) (string, error) {
+		prompt = messages[1].Content
+		return "```json\n" + `[
+			{"key": "Language", "value": "用户习惯用中文交流", "reason": "重复"},
+			{"key": "test-cmd", "value": "项目用 make test 运行测试", "reason": "项目约定"},
+			{"key": "test_cmd", "value": "项目使用 make test 运行全部测试", "reason": "同一条"},
+			{"key": "empty", "value": " "}
+		]` + "\n```", nil
+	}
+	history := []llm.Message{
+		{Role: "user", Content: "测试怎么跑？"},
+		{Role: "assistant", Content: "用 make test。"},
+		{Role: "tool", Content: "ignored tool output"},
+	}
+
+	added, err := store.ExtractProposals(complete, history, Scopes{})
+	if err != nil {
+		t.Fatalf("ExtractProposals 失败: %v", err)
+	}
+	if len(added) != 1 || added[0].Key != "test_cmd" {
+		t.Fatalf("应只新增 test_cmd 一条候选, 实际 %+v", added)
+	}
+	if !strings.Contains(prompt, "[language] 用户习惯用中文交流。") || !strings.Contains(prompt, "用户: 测试怎么跑？") {
+		t.Errorf("提示应包含已有记忆和对话:\n%s", prompt)
+	}
+	if strings.Contains(prompt, "ignored tool output") {
+		t.Error("提示不应包含工具输出")
+	}
+
+	// 再次提取时与待确认候选去重
+	again, _ := store.ExtractProposals(complete, history, Scopes{})
+	if len(again) != 0 {
+		t.Errorf("重复提取不应新增候选, 实际 %d 条", len(again))
+	}
+
+	p, err := store.AcceptProposal(added[0].ID)
+	if err != nil {
+		t.Fatalf("AcceptProposal 失败: %v", err)
+	}
+	if v, _ := store.GetMemory(p.Key); v != "项目用 make test 运行测试" {
+		t.Errorf("接受后应写入记忆, 实际 %q", v)
+	}
+	if pending, _ := store.ListProposals(); len(pending) != 0 {
+		t.Errorf("接受后候选应被移除, 剩余 %d 条", len(pending))
+	}
+	if err := store.RejectProposal(added[0].ID); err == nil {
+		t.Error("不存在的候选应返回错误")
+	}
+}
+
+func TestSimilar(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{"用户偏好 Go 语言", "用户偏好Go语言。", true},
+		{"The project uses PostgreSQL 15", "the project uses postgresql 15 in production", true},
+		{"用户使用 macOS", "用户使用 Linux", false},
+		{"go", "use go modules", false},
+	}
+	for _, c := range cases {
+		if got := similar(c.a, c.b); got != c.want {
+			t.Errorf("similar(%q, %q) = %v, 期望 %v", c.a, c.b, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package memory
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxConflicts 冲突记录最多保留的条数
+const maxConflicts = 200
+
+// SyncReport 一次 MEMORY.md 与数据库同步的结果
+type SyncReport struct {
+	Scope     string
+	Path      string
+	Added     []string // 从文件导入的新记忆
+	Updated   []string // 按文件内容更新的记忆
+	Deleted   []string // 因文件中删除而删除的记忆
+	Conflicts []Conflict
+	Written   bool // 是否用数据库内容重写了文件
+}
+
+// Changed 返回文件中的修改是否导入了数据库
+func (r SyncReport) Changed() bool {
+	return len(r.Added)+len(r.Updated)+len(r.Deleted)+len(r.Conflicts) > 0
+}
+
+// Conflict 文件和数据库自上次同步后都修改了同一条记忆，按修改时间较新的一方为准
+type Conflict struct {
+	ID        int64
+	Scope     string
+	Key       string
+	FileValue string // 文件中的内容，为空表示文件中删除了该记忆
+	DBValue   string // 数据库中的内容，为空表示数据库中删除了该记忆
+	Kept      string // 保留的一方: file 或 db
+	CreatedAt time.Time
+}
+
+// initFileSync 创建同步状态表和冲突记录表
+func (s *Store) initFileSync() error {
+	_, err := s.db.Exec(`
+	CREATE TABLE IF NOT EXISTS memory_files (
+		path TEXT PRIMARY KEY,
+		scope TEXT NOT NULL,
+		content TEXT NOT NULL,
+		synced_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE TABLE IF NOT EXISTS memory_conflicts (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		scope TEXT NOT NULL,
+		key TEXT NOT NULL,
+		file_value TEXT NOT NULL,
+		db_value TEXT NOT NULL,
+		kept TEXT NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+	`)
+	return err
+}
+
+// syncScopeFile 同步作用域的 MEMORY.md：先合并文件中的手工修改，再写回数据库内容
+func (s *Store) syncScopeFile(scope string) error {
+	_, err := s.SyncFile(scope)
+	return err
+}
+
+// SyncFile 双向同步作用域对应的 MEMORY.md。
+//
+// 上次同步时的文件内容作为合并基准：文件相对基准的修改导入数据库；
+// 数据库在此期间也改了同一条记忆时记为冲突，保留修改时间较新的一方。
+// 合并后文件与数据库不一致时，用数据库内容重写文件。
+func (s *Store) SyncFile(scope string) (SyncReport, error) {
+	report := SyncReport{Scope: scope, Path: s.scopeFile(scope)}
+	if report.Path == "" {
+		return report, nil
+	}
+	s.fileMu.Lock()
+	defer s.fileMu.Unlock()
+
+	data, err := os.ReadFile(report.Path)
+	exists := err == nil
+	if err != nil && !os.IsNotExist(err) {
+		return report, err
+	}
+	var base string
+	hasBase := true
+	if err := s.db.QueryRow("SELECT content FROM memory_files WHERE path = ?", report.Path).Scan(&base); err == sql.ErrNoRows {
+		hasBase = false
+	} else if err != nil {
+		return report, err
+	}
+
+	// 文件被删除时视为未修改，按数据库重新生成
+	if exists && (!hasBase || string(data) != base) {
+		var modTime time.Time
+		if info, err := os.Stat(report.Path); err == nil {
+			modTime = info.ModTime()
+		}
+		if err := s.mergeFile(&report, parseMemoryFile(string(data)), parseMemoryFile(base), modTime); err != nil {
+			return report, err
+		}
+	}
+
+	entries, err := s.ListMemoriesIn([]string{scope})
+	if err != nil {
+		return report, err
+	}
+	content := string(data)
+	if !sameEntries(parseMemoryFile(content), entries) && (exists || len(entries) > 0) {
+		content = renderMemoryFile(scope, entries)
+		os.MkdirAll(filepath.Dir(report.Path), 0755)
+		if err := os.WriteFile(report.Path, []byte(content), 0644); err != nil {
+			return report, err
+		}
+		report.Written = true
+	}
+	if !exists && !report.Written {
+		return report, nil
+	}
+	_, err = s.db.Exec(
+		`INSERT INTO memory_files (path, scope, content, synced_at) VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+		 ON CONFLICT(path) DO UPDATE SET scope=excluded.scope, content=excluded.content, synced_at=CURRENT_TIMESTAMP`,
+		report.Path, scope, content,
+	)
+	return report, err
+}
+
+// mergeFile 把文件相对基准的修改合并进数据库
+func (s *Store) mergeFile(report *SyncReport, file, base map[string]string, fileTime time.Time) error {
+	entries, err := s.ListMemoriesIn([]string{report.Scope})
+	if err != nil {
+		return err
+	}
+	db := make(map[string]Entry, len(entries))
+	for _, e := range entries {
+		db[e.Key] = e
+	}
+
+	keys := make(map[string]bool)
+	for k := range file {
+		keys[k] = true
+	}
+	for k := range base {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		fv, inFile := file[key]
+		bv, inBase := base[key]
+		if inFile == inBase && fv == bv {
+			continue // 文件中未修改
+		}
+		e, inDB := db[key]
+		dv := strings.TrimSpace(e.Value)
+		if inDB == inFile && dv == fv {
+			continue // 两边已一致
+		}
+
+		if inDB != inBase || dv != bv {
+			// 两边都改了：数据库删除的记忆没有修改时间，以文件为准
+			kept := "file"
+			if inDB && e.UpdatedAt.After(fileTime) {
+				kept = "db"
+			}
+			c := Conflict{Scope: report.Scope, Key: key, FileValue: fv, DBValue: dv, Kept: kept}
+			if err := s.recordConflict(c); err != nil {
+				return err
+			}
+			report.Conflicts = append(report.Conflicts, c)
+			if kept == "db" {
+				continue
+			}
+		}
+
+		switch {
+		case !inFile:
+			if err := s.deleteEntry(report.Scope, key); err != nil && err != sql.ErrNoRows {
+				return err
+			}
+			report.Deleted = append(report.Deleted, key)
+		case inDB:
+			if err := s.upsertEntry(report.Scope, key, fv); err != nil {
+				return err
+			}
+			report.Updated = append(report.Updated, key)
+		default:
+			if err := s.upsertEntry(report.Scope, key, fv); err != nil {
+				return err
+			}
+			report.Added = append(report.Added, key)
+		}
+	}
+	return nil
+}
+
+func (s *Store) recordConflict(c Conflict) error {
+	if _, err := s.db.Exec(
+		"INSERT INTO memory_conflicts (scope, key, file_value, db_value, kept) VALUES (?, ?, ?, ?, ?)",
+		c.Scope, c.Key, c.FileValue, c.DBValue, c.Kept,
+	); err != nil {
+		return err
+	}
+	_, err := s.db.Exec("DELETE FROM memory_conflicts WHERE id <= (SELECT MAX(id) FROM memory_conflicts) - ?", maxConflicts)
+	return err
+}
+
+// Conflicts 返回最近的同步冲突，最新的在前
+func (s *Store) Conflicts(limit int) ([]Conflict, error) {
+	rows, err := s.db.Query(
+		"SELECT id, scope, key, file_value, db_value, kept, created_at FROM memory_conflicts ORDER BY id DESC LIMIT ?", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var results []Conflict
+	for rows.Next() {
+		var c Conflict
+		if err := rows.Scan(&c.ID, &c.Scope, &c.Key, &c.FileValue, &c.DBValue, &c.Kept, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		results = append(results, c)
+	}
+	return results, rows.Err()
+}
+
+// SyncFiles 同步全局 MEMORY.md 以及所有已知项目的 .kele/MEMORY.md，返回有改动的结果
+func (s *Store) SyncFiles() ([]SyncReport, error) {
+	scopes := []string{ScopeGlobal}
+	rows, err := s.db.Query(
+		`SELECT scope FROM memory_entries WHERE scope LIKE ?
+		 UNION SELECT scope FROM memory_files WHERE scope LIKE ?`,
+		projectPrefix+"%", projectPrefix+"%")
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var scope string
+		if rows.Scan(&scope) == nil {
+			scopes = append(scopes, scope)
+		}
+	}
+	rows.Close()
+
+	var reports []SyncReport
+	for _, scope := range scopes {
+		report, err := s.SyncFile(scope)
+		if err != nil {
+			return reports, fmt.Errorf("同步 %s 失败: %w", report.Path, err)
+		}
+		if report.Changed() {
+			reports = append(reports, report)
+		}
+	}
+	return reports, nil
+}
+
+// TrackScope 首次用到某个项目时导入其 .kele/MEMORY.md（可能随仓库提交），
+// 之后由 SyncFiles 持续同步
+func (s *Store) TrackScope(scope string) (SyncReport, error) {
+	if ScopeKind(scope) != "project" {
+		return SyncReport{Scope: scope}, nil
+	}
+	s.trackMu.Lock()
+	if s.tracked[scope] {
+		s.trackMu.Unlock()
+		return SyncReport{Scope: scope}, nil
+	}
+	s.tracked[scope] = true
+	s.trackMu.Unlock()
+	return s.SyncFile(scope)
+}
+
+// parseMemoryFile 把 MEMORY.md 中的 "## key" 小节解析为 key → 内容，
+// 第一个小节之前的标题和说明被忽略
+func parseMemoryFile(content string) map[string]string {
+	entries := make(map[string]string)
+	key := ""
+	var body []string
+	flush := func() {
+		if value := strings.TrimSpace(strings.Join(body, "\n")); key != "" && value != "" {
+			entries[key] = value
+		}
+		body = body[:0]
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "## ") {
+			flush()
+			key = strings.TrimSpace(line[3:])
+			continue
+		}
+		body = append(body, line)
+	}
+	flush()
+	return entries
+}
+
+// sameEntries 判断文件解析结果与数据库中的记忆是否一致
+func sameEntries(file map[string]string, entries []Entry) bool {
+	if len(file) != len(entries) {
+		return false
+	}
+	for _, e := range entries {
+		if v, ok := file[e.Key]; !ok || v != strings.TrimSpace(e.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// renderMemoryFile 生成作用域的 MEMORY.md 内容
+func renderMemoryFile(scope string, entries []Entry) string {
+	var b strings.Builder
+	if ScopeKind(scope) == "project" {
+		fmt.Fprintf(&b, "# 项目记忆\n\n> 项目: %s\n", strings.TrimPrefix(scope, projectPrefix))
+	} else {
+		b.WriteString("# 长期记忆\n\n")
+	}
+	fmt.Fprintf(&b, "> 最后更新: %s\n", time.Now().Format("2006-01-02 15:04:05"))
+	b.WriteString("> 可直接编辑本文件：每个 \"## key\" 小节是一条记忆，修改会自动同步到 kele\n\n")
+	for _, e := range entries {
+		fmt.Fprintf(&b, "## %s\n\n%s\n\n", e.Key, strings.TrimSpace(e.Value))
+	}
+	return b.String()
+}
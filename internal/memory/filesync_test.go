@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSyncFileImportsEdits(t *testing.T) {
+	store := testStore(t)
+	defer store.Close()
+
+	store.UpdateMemory("editor", "用户使用 vim")
+	store.UpdateMemory("shell", "用户使用 zsh")
+
+	// 手工编辑：修改 editor，删除 shell，新增 coffee
+	edited := "# 我的记忆\n\n## editor\n\n用户使用 neovim\n\n## coffee\n\n用户喜欢 espresso coffee\n多行内容也保留\n"
+	if err := os.WriteFile(store.memoryFile, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+	report, err := store.SyncFile(ScopeGlobal)
+	if err != nil {
+		t.Fatalf("SyncFile 失败: %v", err)
+	}
+	if strings.Join(report.Added, ",") != "coffee" || strings.Join(report.Updated, ",") != "editor" ||
+		strings.Join(report.Deleted, ",") != "shell" || len(report.Conflicts) != 0 {
+		t.Fatalf("同步结果不正确: %+v", report)
+	}
+	if report.Written {
+		t.Error("文件与数据库一致时不应重写文件")
+	}
+
+	if v, _ := store.GetMemory("editor"); v != "用户使用 neovim" {
+		t.Errorf("editor 应更新为文件内容, 实际 %q", v)
+	}
+	if v, _ := store.GetMemory("coffee"); v != "用户喜欢 espresso coffee\n多行内容也保留" {
+		t.Errorf("coffee 应从文件导入, 实际 %q", v)
+	}
+	if _, err := store.GetMemory("shell"); err == nil {
+		t.Error("文件中删除的记忆应从数据库删除")
+	}
+	// 导入的记忆应能被检索到（FTS 索引同步）
+	if results, _ := store.SearchEntries("espresso", 5); len(results) != 1 || results[0].Key != "coffee" {
+		t.Errorf("导入的记忆应可检索, 实际 %+v", results)
+	}
+	if results, _ := store.SearchEntries("zsh", 5); len(results) != 0 {
+		t.Errorf("删除的记忆不应再被检索到, 实际 %+v", results)
+	}
+
+	// 未再修改时同步无改动，文件保持手写格式
+	report, _ = store.SyncFile(ScopeGlobal)
+	if report.Changed() || report.Written {
+		t.Errorf("再次同步不应有改动: %+v", report)
+	}
+	if data, _ := os.ReadFile(store.memoryFile); string(data) != edited {
+		t.Error("未改动时不应覆盖手写的文件")
+	}
+
+	// 写入新记忆时保留之前的手工编辑
+	os.WriteFile(store.memoryFile, []byte(edited+"\n## tz\n\n用户在东八区\n"), 0644)
+	store.UpdateMemory("lang", "用户偏好中文")
+	if v, _ := store.GetMemory("tz"); v != "用户在东八区" {
+		t.Errorf("写入前应先导入文件中的修改, 实际 %q", v)
+	}
+	data, _ := os.ReadFile(store.memoryFile)
+	for _, key := range []string{"## tz", "## lang", "## coffee"} {
+		if !strings.Contains(string(data), key) {
+			t.Errorf("重写后的文件应包含 %s:\n%s", key, data)
+		}
+	}
+}
+
+func TestSyncFileConflicts(t *testing.T) {
+	store := testStore(t)
+	defer store.Close()
+
+	store.UpdateMemory("editor", "用户使用 vim")
+	store.UpdateMemory("shell", "用户使用 zsh")
+
+	// 数据库在文件编辑之后又修改了 editor：数据库较新
+	os.WriteFile(store.memoryFile, []byte("## editor\n\n用户使用 emacs\n\n## shell\n\n用户使用 fish\n"), 0644)
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(store.memoryFile, old, old)
+	store.upsertEntry(ScopeGlobal, "editor", "用户使用 helix")
+
+	// 文件中的 shell 修改晚于数据库：先让数据库的 shell 变旧
+	store.db.Exec("UPDATE memory_entries SET value = '用户使用 bash', updated_at = datetime('now', '-2 hours') WHERE key = 'shell'")
+
+	report, err := store.SyncFile(ScopeGlobal)
+	if err != nil {
+		t.Fatalf("SyncFile 失败: %v", err)
+	}
+	if len(report.Conflicts) != 2 {
+		t.Fatalf("应有 2 个冲突, 实际 %+v", report.Conflicts)
+	}
+	if v, _ := store.GetMemory("editor"); v != "用户使用 helix" {
+		t.Errorf("数据库较新时应保留数据库内容, 实际 %q", v)
+	}
+	if v, _ := store.GetMemory("shell"); v != "用户使用 fish" {
+		t.Errorf("文件较新时应采用文件内容, 实际 %q", v)
+	}
+	data, _ := os.ReadFile(store.memoryFile)
+	if !strings.Contains(string(data), "helix") || strings.Contains(string(data), "emacs") {
+		t.Errorf("文件应按合并结果重写:\n%s", data)
+	}
+
+	conflicts, err := store.Conflicts(10)
+	if err != nil || len(conflicts) != 2 {
+		t.Fatalf("冲突记录应有 2 条: %+v, %v", conflicts, err)
+	}
+	kept := map[string]string{}
+	for _, c := range conflicts {
+		kept[c.Key] = c.Kept
+	}
+	if kept["editor"] != "db" || kept["shell"] != "file" {
+		t.Errorf("冲突保留方不正确: %v", kept)
+	}
+}
+
+func TestTrackScopeImportsProjectFile(t *testing.T) {
+	store := testStore(t)
+	defer store.Close()
+
+	root := t.TempDir()
+	os.Mkdir(filepath.Join(root, ".git"), 0755)
+	os.MkdirAll(filepath.Join(root, ".kele"), 0755)
+	os.WriteFile(filepath.Join(root, ".kele", "MEMORY.md"), []byte("# 项目记忆\n\n## test\n\n运行 make test 执行测试\n"), 0644)
+
+	scope := ProjectScope(root)
+	report, err := store.TrackScope(scope)
+	if err != nil {
+		t.Fatalf("TrackScope 失败: %v", err)
+	}
+	if strings.Join(report.Added, ",") != "test" {
+		t.Fatalf("应导入项目记忆, 实际 %+v", report)
+	}
+	if v, _ := store.GetMemoryIn(scope, "test"); v != "运行 make test 执行测试" {
+		t.Errorf("项目记忆内容不正确: %q", v)
+	}
+	if _, err := store.GetMemory("test"); err == nil {
+		t.Error("项目记忆不应导入全局作用域")
+	}
+
+	// 之后的修改由 SyncFiles 发现
+	os.WriteFile(filepath.Join(root, ".kele", "MEMORY.md"), []byte("## test\n\n运行 go test ./... 执行测试\n"), 0644)
+	reports, err := store.SyncFiles()
+	if err != nil || len(reports) != 1 || reports[0].Scope != scope {
+		t.Fatalf("SyncFiles 应同步项目文件: %+v, %v", reports, err)
+	}
+}
+
+func TestParseMemoryFile(t *testing.T) {
+	entries := parseMemoryFile("# 标题\n\n> 说明\n\n## a\n\nfirst\n\n## b\n\n\n## c \n\nline1\nline2\n")
+	if len(entries) != 2 || entries["a"] != "first" || entries["c"] != "line1\nline2" {
+		t.Errorf("解析结果不正确: %#v", entries)
+	}
+}
@@ -0,0 +1,234 @@
+package memory
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 检索结果摘要中标记命中文本
+const (
+	SnippetOpen  = "«"
+	SnippetClose = "»"
+)
+
+const (
+	defaultHistoryLimit = 20  // 未指定时返回的消息条数
+	maxHistoryLimit     = 100 // 单次检索最多返回的消息条数
+	historySnippetSize  = 80  // LIKE 检索时命中处前后保留的字符数
+)
+
+// MessageOrigin 消息的来源：会话和渠道
+type MessageOrigin struct {
+	SessionID   string
+	SessionName string
+	Channel     string // cli、telegram、task 等
+}
+
+// HistoryQuery 对话归档检索条件
+type HistoryQuery struct {
+	Text    string    // 关键词，多个用空格分隔，全部命中才算匹配
+	Session string    // 会话 ID 或名称，为空不限
+	Channel string    // 来源渠道，为空不限
+	Since   time.Time // 不早于此时间
+	Until   time.Time // 早于此时间
+	Limit   int       // 默认 20，最多 100
+}
+
+// HistoryHit 一条命中的历史消息
+type HistoryHit struct {
+	ID          int64
+	TurnID      int64 // 同一轮对话中的用户输入和回复共享 TurnID
+	SessionID   string
+	SessionName string
+	Channel     string
+	Role        string
+	Snippet     string // 命中处的摘要，命中文本用 SnippetOpen/SnippetClose 标出
+	Timestamp   time.Time
+}
+
+// initHistoryIndex 为旧库补充消息来源列，并建立消息内容的 FTS5 索引（trigram 分词，
+// 中文也能按子串匹配），由触发器保持同步；FTS5 不可用时检索退化为 LIKE
+func (s *Store) initHistoryIndex() error {
+	for _, col := range []struct{ name, def string }{
+		{"session_id", "TEXT NOT NULL DEFAULT ''"},
+		{"session_name", "TEXT NOT NULL DEFAULT ''"},
+		{"channel", "TEXT NOT NULL DEFAULT ''"},
+		{"turn_id", "INTEGER NOT NULL DEFAULT 0"},
+	} {
+		if err := s.addColumnIfMissing("messages", col.name, col.def); err != nil {
+			return err
+		}
+	}
+	if _, err := s.db.Exec("CREATE INDEX IF NOT EXISTS idx_messages_session ON messages(session_id)"); err != nil {
+		return err
+	}
+
+	var existing int
+	s.db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE name = 'messages_fts'").Scan(&existing)
+	_, err := s.db.Exec(`
+		CREATE VIRTUAL TABLE IF NOT EXISTS messages_fts USING fts5(
+			content, content='messages', content_rowid='id', tokenize='trigram'
+		);
+		CREATE TRIGGER IF NOT EXISTS messages_fts_ai AFTER INSERT ON messages BEGIN
+			INSERT INTO messages_fts(rowid, content) VALUES (new.id, new.content);
+		END;
+		CREATE TRIGGER IF NOT EXISTS messages_fts_ad AFTER DELETE ON messages BEGIN
+			INSERT INTO messages_fts(messages_fts, rowid, content) VALUES ('delete', old.id, old.content);
+		END;
+	`)
+	if err != nil {
+		return nil
+	}
+	s.hasHistoryFTS = true
+	// 索引建立之前写入的消息
+	if existing == 0 {
+		s.db.Exec("INSERT INTO messages_fts(messages_fts) VALUES ('rebuild')")
+	}
+	return nil
+}
+
+// SaveTurn 归档一轮对话：用户输入和回复（可为空）记为同一个 turn，返回 turn ID
+func (s *Store) SaveTurn(origin MessageOrigin, user, assistant string) (int64, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(
+		"INSERT INTO messages (role, content, session_id, session_name, channel) VALUES ('user', ?, ?, ?, ?)",
+		user, origin.SessionID, origin.SessionName, origin.Channel)
+	if err != nil {
+		return 0, err
+	}
+	turnID, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec("UPDATE messages SET turn_id = ? WHERE id = ?", turnID, turnID); err != nil {
+		return 0, err
+	}
+	if assistant != "" {
+		if _, err := tx.Exec(
+			"INSERT INTO messages (role, content, session_id, session_name, channel, turn_id) VALUES ('assistant', ?, ?, ?, ?, ?)",
+			assistant, origin.SessionID, origin.SessionName, origin.Channel, turnID); err != nil {
+			return 0, err
+		}
+	}
+	return turnID, tx.Commit()
+}
+
+// SearchHistory 在所有会话的对话归档中检索，FTS5 可用时按相关度排序，否则按时间倒序
+func (s *Store) SearchHistory(q HistoryQuery) ([]HistoryHit, error) {
+	words := strings.Fields(q.Text)
+	if len(words) == 0 {
+		return nil, fmt.Errorf("缺少检索关键词")
+	}
+	if q.Limit <= 0 {
+		q.Limit = defaultHistoryLimit
+	}
+	if q.Limit > maxHistoryLimit {
+		q.Limit = maxHistoryLimit
+	}
+	// trigram 索引无法匹配少于 3 个字符的词
+	useFTS := s.hasHistoryFTS
+	for _, w := range words {
+		if utf8.RuneCountInString(w) < 3 {
+			useFTS = false
+		}
+	}
+
+	var where []string
+	var args []interface{}
+	query := "SELECT m.id, m.turn_id, m.session_id, m.session_name, m.channel, m.role, m.content, m.timestamp FROM messages m"
+	if useFTS {
+		query = `SELECT m.id, m.turn_id, m.session_id, m.session_name, m.channel, m.role,
+			snippet(messages_fts, 0, ?, ?, '…', 32), m.timestamp
+			FROM messages_fts JOIN messages m ON m.id = messages_fts.rowid`
+		args = append(args, SnippetOpen, SnippetClose)
+		phrases := make([]string, len(words))
+		for i, w := range words {
+			phrases[i] = `"` + strings.ReplaceAll(w, `"`, `""`) + `"`
+		}
+		where = append(where, "messages_fts MATCH ?")
+		args = append(args, strings.Join(phrases, " AND "))
+	} else {
+		r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+		for _, w := range words {
+			where = append(where, `m.content LIKE ? ESCAPE '\'`)
+			args = append(args, "%"+r.Replace(w)+"%")
+		}
+	}
+	if q.Session != "" {
+		where = append(where, "(m.session_id = ? OR m.session_name = ?)")
+		args = append(args, q.Session, q.Session)
+	}
+	if q.Channel != "" {
+		where = append(where, "m.channel = ?")
+		args = append(args, q.Channel)
+	}
+	// timestamp 默认值 CURRENT_TIMESTAMP 为 UTC
+	if !q.Since.IsZero() {
+		where = append(where, "m.timestamp >= ?")
+		args = append(args, q.Since.UTC().Format("2006-01-02 15:04:05"))
+	}
+	if !q.Until.IsZero() {
+		where = append(where, "m.timestamp < ?")
+		args = append(args, q.Until.UTC().Format("2006-01-02 15:04:05"))
+	}
+	query += " WHERE " + strings.Join(where, " AND ")
+	if useFTS {
+		query += " ORDER BY rank"
+	} else {
+		query += " ORDER BY m.id DESC"
+	}
+	query += " LIMIT ?"
+	args = append(args, q.Limit)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("检索对话失败: %w", err)
+	}
+	defer rows.Close()
+	var hits []HistoryHit
+	for rows.Next() {
+		var h HistoryHit
+		var text string
+		if err := rows.Scan(&h.ID, &h.TurnID, &h.SessionID, &h.SessionName, &h.Channel, &h.Role, &text, &h.Timestamp); err != nil {
+			return nil, err
+		}
+		h.Snippet = text
+		if !useFTS {
+			h.Snippet = likeSnippet(text, words[0])
+		}
+		hits = append(hits, h)
+	}
+	return hits, rows.Err()
+}
+
+// likeSnippet 截取 s 中第一次出现 word（不区分大小写）处前后的内容并标出命中文本
+func likeSnippet(s, word string) string {
+	idx := strings.Index(strings.ToLower(s), strings.ToLower(word))
+	if idx < 0 || len(strings.ToLower(s)) != len(s) {
+		// ToLower 改变了字节偏移，退回精确匹配
+		idx = strings.Index(s, word)
+	}
+	if idx < 0 {
+		return truncate(s, 2*historySnippetSize)
+	}
+	end := idx + len(word)
+	before := []rune(s[:idx])
+	after := []rune(s[end:])
+	prefix, suffix := "", ""
+	if len(before) > historySnippetSize {
+		before = before[len(before)-historySnippetSize:]
+		prefix = "…"
+	}
+	if len(after) > historySnippetSize {
+		after = after[:historySnippetSize]
+		suffix = "…"
+	}
+	return prefix + string(before) + SnippetOpen + s[idx:end] + SnippetClose + string(after) + suffix
+}
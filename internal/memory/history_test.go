@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BlakeLiAFK/kele/internal/config"
+)
+
+func TestSaveTurnAndSearchHistory(t *testing.T) {
+	store := testStore(t)
+	defer store.Close()
+
+	cli := MessageOrigin{SessionID: "s1", SessionName: "Chat 1", Channel: "cli"}
+	tg := MessageOrigin{SessionID: "telegram-42", SessionName: "Telegram 42", Channel: "telegram"}
+	turn, err := store.SaveTurn(cli, "数据库迁移方案怎么定", "建议先用 goose 管理 PostgreSQL 迁移脚本")
+	if err != nil {
+		t.Fatalf("SaveTurn 失败: %v", err)
+	}
+	store.SaveTurn(tg, "部署到 kubernetes 集群", "可以用 helm chart 部署")
+	store.SaveTurn(cli, "只有提问没有回复", "")
+
+	hits, err := store.SearchHistory(HistoryQuery{Text: "PostgreSQL 迁移脚本"})
+	if err != nil {
+		t.Fatalf("SearchHistory 失败: %v", err)
+	}
+	if len(hits) != 1 {
+		t.Fatalf("应命中 1 条, 实际 %+v", hits)
+	}
+	h := hits[0]
+	if h.TurnID != turn || h.Role != "assistant" || h.SessionID != "s1" || h.SessionName != "Chat 1" || h.Channel != "cli" {
+		t.Errorf("命中消息的来源不正确: %+v", h)
+	}
+	if !strings.Contains(h.Snippet, SnippetOpen) {
+		t.Errorf("摘要应标出命中处: %q", h.Snippet)
+	}
+
+	// 同一轮的用户输入共享 turn ID
+	hits, _ = store.SearchHistory(HistoryQuery{Text: "迁移方案"})
+	if len(hits) != 1 || hits[0].TurnID != turn || hits[0].Role != "user" {
+		t.Errorf("用户输入应与回复同属一轮: %+v", hits)
+	}
+
+	// 短关键词走 LIKE
+	if hits, _ := store.SearchHistory(HistoryQuery{Text: "部署"}); len(hits) != 2 {
+		t.Errorf("短关键词应命中 2 条, 实际 %+v", hits)
+	}
+
+	// 会话、渠道和时间过滤
+	if hits, _ := store.SearchHistory(HistoryQuery{Text: "部署", Session: "Chat 1"}); len(hits) != 0 {
+		t.Errorf("按会话名过滤后不应命中, 实际 %+v", hits)
+	}
+	if hits, _ := store.SearchHistory(HistoryQuery{Text: "helm", Channel: "telegram"}); len(hits) != 1 {
+		t.Errorf("按渠道过滤应命中 1 条, 实际 %+v", hits)
+	}
+	if hits, _ := store.SearchHistory(HistoryQuery{Text: "helm", Since: time.Now().Add(time.Hour)}); len(hits) != 0 {
+		t.Errorf("since 晚于所有消息时不应命中, 实际 %+v", hits)
+	}
+	if hits, _ := store.SearchHistory(HistoryQuery{Text: "helm", Until: time.Now().Add(-time.Hour)}); len(hits) != 0 {
+		t.Errorf("until 早于所有消息时不应命中, 实际 %+v", hits)
+	}
+
+	if _, err := store.SearchHistory(HistoryQuery{Text: "  "}); err == nil {
+		t.Error("空关键词应报错")
+	}
+}
+
+func TestHistoryIndexBackfill(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "old.db")
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`CREATE TABLE messages (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		role TEXT NOT NULL,
+		content TEXT NOT NULL,
+		timestamp DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+	INSERT INTO messages (role, content) VALUES ('user', '旧版本保存的 nginx 配置问题');`)
+	db.Close()
+	if err != nil {
+		t.Fatalf("创建旧表失败: %v", err)
+	}
+
+	store, err := NewStore(&config.Config{Memory: config.MemoryConfig{
+		DBPath:     dbPath,
+		MemoryFile: filepath.Join(dir, "MEMORY.md"),
+		SessionDir: filepath.Join(dir, "sessions"),
+	}})
+	if err != nil {
+		t.Fatalf("打开旧库失败: %v", err)
+	}
+	defer store.Close()
+
+	hits, err := store.SearchHistory(HistoryQuery{Text: "nginx"})
+	if err != nil || len(hits) != 1 {
+		t.Fatalf("旧消息应可检索: %+v, %v", hits, err)
+	}
+	if hits[0].SessionID != "" || hits[0].TurnID != 0 {
+		t.Errorf("旧消息没有来源信息: %+v", hits[0])
+	}
+}
@@ -0,0 +1,226 @@
+package memory
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	DefaultInjectTokens = 600 // 未配置时每轮注入的记忆 token 预算
+	maxInjectRunes      = 300 // 单条记忆注入时的最大长度
+	maxInjectCandidates = 20  // 每一路参与融合的候选数
+	minKeywordRatio     = 0.3 // 关键词得分低于最高分此比例的结果不注入
+)
+
+// Injected 一条被注入 system prompt 的记忆及注入原因
+type Injected struct {
+	Entry
+	Reason string
+	Tokens int
+}
+
+// SelectForPrompt 从 scopes（由近到远）中为本轮对话挑选要注入的记忆：
+// 置顶记忆总是注入，其余记忆按与 query 的关键词重合度和语义相似度
+// （配置了嵌入器时）用 RRF 融合排序，在 budget 个 token 内依次加入。
+// 多个作用域有同 key 记忆时只使用最近作用域中的一条。
+func (s *Store) SelectForPrompt(scopes []string, query string, budget int) ([]Injected, error) {
+	if budget <= 0 {
+		budget = DefaultInjectTokens
+	}
+	entries, err := s.ListMemoriesIn(scopes)
+	if err != nil {
+		return nil, err
+	}
+	entries = visibleEntries(scopes, entries)
+	visible := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		visible[e.id()] = true
+	}
+
+	var selected []Injected
+	used := 0
+	add := func(e Entry, reason string) bool {
+		item := Injected{Entry: e, Reason: reason}
+		item.Value = truncate(e.Value, maxInjectRunes)
+		item.Tokens = estimateTokens(item.Key, item.Value)
+		if !e.Pinned && used+item.Tokens > budget {
+			return false
+		}
+		used += item.Tokens
+		selected = append(selected, item)
+		return true
+	}
+
+	var candidates []Entry
+	for _, e := range entries {
+		if e.Pinned {
+			add(e, "置顶")
+		} else {
+			candidates = append(candidates, e)
+		}
+	}
+	if strings.TrimSpace(query) == "" || len(candidates) == 0 {
+		return selected, nil
+	}
+
+	reasons := make(map[string][]string)
+	keywordHits := rankByKeywords(query, candidates, reasons)
+	var semanticHits []Entry
+	if s.embedder != nil {
+		hits, err := s.vectorHits(scopes, query, maxInjectCandidates)
+		if err == nil {
+			for _, h := range hits {
+				if h.Pinned || !visible[h.id()] {
+					continue
+				}
+				semanticHits = append(semanticHits, h.Entry)
+				reasons[h.id()] = append(reasons[h.id()], fmt.Sprintf("语义相似度 %.2f", h.Score))
+			}
+		}
+	}
+
+	for _, e := range fuseRRF(keywordHits, semanticHits) {
+		// 放不下时继续尝试更短的记忆
+		add(e, strings.Join(reasons[e.id()], "；"))
+	}
+	return selected, nil
+}
+
+// visibleEntries 去掉被更近作用域中同 key 记忆覆盖的条目
+func visibleEntries(scopes []string, entries []Entry) []Entry {
+	rank := make(map[string]int, len(scopes))
+	for i, s := range scopes {
+		rank[s] = i
+	}
+	nearest := make(map[string]string)
+	for _, e := range entries {
+		if cur, ok := nearest[e.Key]; !ok || rank[e.Scope] < rank[cur] {
+			nearest[e.Key] = e.Scope
+		}
+	}
+	var result []Entry
+	for _, e := range entries {
+		if nearest[e.Key] == e.Scope {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// FormatForPrompt 把注入的记忆整理为 prompt 中的条目
+func FormatForPrompt(items []Injected) []string {
+	lines := make([]string, len(items))
+	for i, m := range items {
+		lines[i] = fmt.Sprintf("[%s] %s", m.Key, m.Value)
+	}
+	return lines
+}
+
+// rankByKeywords 按 query 与记忆共有的词打分（稀有词权重更高），
+// 返回得分最高的候选，并把命中的词记入 reasons
+func rankByKeywords(query string, entries []Entry, reasons map[string][]string) []Entry {
+	queryTerms := termSet(query)
+	if len(queryTerms) == 0 {
+		return nil
+	}
+	entryTerms := make([]map[string]bool, len(entries))
+	df := make(map[string]int)
+	for i, e := range entries {
+		entryTerms[i] = termSet(e.Key + " " + e.Value)
+		for t := range entryTerms[i] {
+			if queryTerms[t] {
+				df[t]++
+			}
+		}
+	}
+
+	type scored struct {
+		entry   Entry
+		score   float64
+		matched []string
+	}
+	var hits []scored
+	n := float64(len(entries))
+	for i, e := range entries {
+		var h scored
+		for t := range queryTerms {
+			if entryTerms[i][t] {
+				h.score += math.Log(1 + (n-float64(df[t])+0.5)/(float64(df[t])+0.5))
+				h.matched = append(h.matched, t)
+			}
+		}
+		if h.score > 0 {
+			h.entry = e
+			hits = append(hits, h)
+		}
+	}
+	if len(hits) == 0 {
+		return nil
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+
+	var result []Entry
+	for i, h := range hits {
+		if i == maxInjectCandidates || h.score < hits[0].score*minKeywordRatio {
+			break
+		}
+		// 命中的词按权重排列，最多列出 3 个
+		sort.Slice(h.matched, func(a, b int) bool {
+			if df[h.matched[a]] != df[h.matched[b]] {
+				return df[h.matched[a]] < df[h.matched[b]]
+			}
+			return h.matched[a] < h.matched[b]
+		})
+		if len(h.matched) > 3 {
+			h.matched = h.matched[:3]
+		}
+		reasons[h.entry.id()] = append(reasons[h.entry.id()], "关键词: "+strings.Join(h.matched, ", "))
+		result = append(result, h.entry)
+	}
+	return result
+}
+
+// termSet 拆出小写的字母数字词（至少 2 个字符）和中文等无空格文字的二元组
+func termSet(text string) map[string]bool {
+	terms := make(map[string]bool)
+	var word, cjk []rune
+	flush := func() {
+		if len(word) >= 2 {
+			terms[string(word)] = true
+		}
+		word = word[:0]
+		if len(cjk) == 1 {
+			terms[string(cjk)] = true
+		}
+		for i := 0; i+1 < len(cjk); i++ {
+			terms[string(cjk[i:i+2])] = true
+		}
+		cjk = cjk[:0]
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			if len(word) > 0 {
+				flush()
+			}
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if len(cjk) > 0 {
+				flush()
+			}
+			word = append(word, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return terms
+}
+
+// estimateTokens 粗略估算一条记忆注入后的 token 数（与会话的估算方式一致）
+func estimateTokens(key, value string) int {
+	return (len(key)+len(value)+4)/4 + 1
+}
@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSelectForPrompt(t *testing.T) {
+	store := testStore(t)
+	defer store.Close()
+
+	store.UpdateMemory("name", "用户叫小王")
+	store.UpdateMemory("db", "项目数据库使用 PostgreSQL 15，连接串在 .env")
+	store.UpdateMemory("editor", "用户使用 neovim 编辑代码")
+	store.UpdateMemory("drink", "用户每天早上喝咖啡")
+	store.PinMemory(ScopeGlobal, "name", true)
+
+	injected, err := store.SelectForPrompt(nil, "postgresql 连接失败怎么办", 600)
+	if err != nil {
+		t.Fatalf("SelectForPrompt 失败: %v", err)
+	}
+	keys := injectedKeys(injected)
+	if keys != "name,db" {
+		t.Fatalf("应注入置顶记忆和数据库记忆, 实际 %s", keys)
+	}
+	if injected[0].Reason != "置顶" || !strings.Contains(injected[1].Reason, "postgresql") {
+		t.Errorf("注入原因不正确: %+v", injected)
+	}
+
+	// 无关输入只注入置顶记忆
+	injected, _ = store.SelectForPrompt(nil, "今天天气如何", 600)
+	if keys := injectedKeys(injected); keys != "name" {
+		t.Errorf("无关输入应只注入置顶记忆, 实际 %s", keys)
+	}
+
+	// 预算不足时置顶记忆仍然注入，其余跳过
+	injected, _ = store.SelectForPrompt(nil, "neovim postgresql", 1)
+	if keys := injectedKeys(injected); keys != "name" {
+		t.Errorf("预算不足时应只保留置顶记忆, 实际 %s", keys)
+	}
+
+	if err := store.PinMemory(ScopeGlobal, "missing", true); err == nil {
+		t.Error("置顶不存在的记忆应返回错误")
+	}
+}
+
+func TestSelectForPromptSemantic(t *testing.T) {
+	store := testStore(t)
+	defer store.Close()
+	store.SetEmbedder(&stubEmbedder{name: "stub:v1"})
+
+	store.UpdateMemory("daemon_hang", "上次 daemon 无响应是因为 socket 文件残留")
+	store.UpdateMemory("editor", "用户使用 neovim")
+
+	injected, _ := store.SelectForPrompt(nil, "服务又崩溃了", 600)
+	if len(injected) != 1 || injected[0].Key != "daemon_hang" {
+		t.Fatalf("应通过语义检索注入 daemon_hang, 实际 %+v", injected)
+	}
+	if !strings.HasPrefix(injected[0].Reason, "语义相似度") {
+		t.Errorf("注入原因应为语义相似, 实际 %q", injected[0].Reason)
+	}
+}
+
+func TestTermSet(t *testing.T) {
+	terms := termSet("Go 项目用PostgreSQL, a")
+	for _, want := range []string{"go", "项目", "目用", "postgresql"} {
+		if !terms[want] {
+			t.Errorf("应包含词 %q, 实际 %v", want, terms)
+		}
+	}
+	if terms["a"] {
+		t.Error("单个字母不应作为词")
+	}
+}
+
+func injectedKeys(items []Injected) string {
+	var keys []string
+	for _, m := range items {
+		keys = append(keys, m.Key)
+	}
+	return strings.Join(keys, ",")
+}
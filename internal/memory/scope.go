@@ -0,0 +1,368 @@
+package memory
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ScopeGlobal 全局作用域：对所有项目和会话可见
+const ScopeGlobal = "global"
+
+// 作用域前缀，后接项目根目录、TaskBoard 工作区 ID 或会话 ID
+const (
+	projectPrefix   = "project:"
+	workspacePrefix = "workspace:"
+	sessionPrefix   = "session:"
+)
+
+// ProjectScope 返回 dir 所在项目的作用域，项目以 git 仓库根目录标识
+// （git worktree 归属其主仓库），不在仓库中时以 dir 本身标识
+func ProjectScope(dir string) string {
+	if dir == "" {
+		return ""
+	}
+	return projectPrefix + ProjectRoot(dir)
+}
+
+// WorkspaceScope 返回 TaskBoard 工作区的作用域
+func WorkspaceScope(id string) string {
+	if id == "" {
+		return ""
+	}
+	return workspacePrefix + id
+}
+
+// SessionScope 返回会话的作用域，会话结束时其中的记忆被删除
+func SessionScope(id string) string {
+	if id == "" {
+		return ""
+	}
+	return sessionPrefix + id
+}
+
+// ProjectRoot 向上查找 dir 所在的 git 仓库根目录
+func ProjectRoot(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = dir
+	}
+	for d := abs; ; {
+		gitPath := filepath.Join(d, ".git")
+		if info, err := os.Stat(gitPath); err == nil {
+			if !info.IsDir() {
+				if root := worktreeMainRoot(gitPath); root != "" {
+					return root
+				}
+			}
+			return d
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return abs
+		}
+		d = parent
+	}
+}
+
+// worktreeMainRoot 解析 worktree 的 .git 文件（gitdir: <repo>/.git/worktrees/<name>），
+// 返回主仓库根目录
+func worktreeMainRoot(gitFile string) string {
+	data, err := os.ReadFile(gitFile)
+	if err != nil {
+		return ""
+	}
+	gitdir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return ""
+	}
+	gitdir = strings.TrimSpace(gitdir)
+	if !filepath.IsAbs(gitdir) {
+		gitdir = filepath.Join(filepath.Dir(gitFile), gitdir)
+	}
+	sep := string(filepath.Separator)
+	if i := strings.LastIndex(gitdir, sep+"worktrees"+sep); i >= 0 {
+		return filepath.Dir(gitdir[:i])
+	}
+	return ""
+}
+
+// ScopeKind 返回作用域类型：global、project、workspace 或 session
+func ScopeKind(scope string) string {
+	if i := strings.Index(scope, ":"); i >= 0 {
+		return scope[:i]
+	}
+	return scope
+}
+
+// DescribeScope 返回便于阅读的作用域名称
+func DescribeScope(scope string) string {
+	switch ScopeKind(scope) {
+	case "project":
+		return "项目 " + strings.TrimPrefix(scope, projectPrefix)
+	case "workspace":
+		return "工作区 " + strings.TrimPrefix(scope, workspacePrefix)
+	case "session":
+		return "会话 " + strings.TrimPrefix(scope, sessionPrefix)
+	default:
+		return "全局"
+	}
+}
+
+// Scopes 一次对话可见的记忆作用域，未设置的字段为空
+type Scopes struct {
+	Session   string
+	Workspace string
+	Project   string
+}
+
+// List 按由近到远的顺序返回可见作用域：会话、工作区、项目、全局。
+// 同 key 的记忆以靠前作用域中的为准。
+func (sc Scopes) List() []string {
+	var list []string
+	for _, s := range []string{sc.Session, sc.Workspace, sc.Project} {
+		if s != "" {
+			list = append(list, s)
+		}
+	}
+	return append(list, ScopeGlobal)
+}
+
+// Resolve 把作用域类型名（global/project/workspace/session）解析为
+// 当前对话中的具体作用域
+func (sc Scopes) Resolve(kind string) (string, error) {
+	var scope string
+	switch kind {
+	case "", "global":
+		return ScopeGlobal, nil
+	case "project":
+		scope = sc.Project
+	case "workspace":
+		scope = sc.Workspace
+	case "session":
+		scope = sc.Session
+	default:
+		return "", fmt.Errorf("未知的作用域: %s（可选 global, project, workspace, session）", kind)
+	}
+	if scope == "" {
+		return "", fmt.Errorf("当前对话没有 %s 作用域", kind)
+	}
+	return scope, nil
+}
+
+// Broader 返回比 scope 范围更大的下一个可见作用域，用于提升记忆
+func (sc Scopes) Broader(scope string) (string, error) {
+	list := sc.List()
+	for i, s := range list {
+		if s == scope && i+1 < len(list) {
+			return list[i+1], nil
+		}
+	}
+	if scope == ScopeGlobal {
+		return "", fmt.Errorf("全局记忆无法再提升")
+	}
+	return ScopeGlobal, nil
+}
+
+// migrateScopes 把旧库中按 key 唯一的 memory_entries 重建为按 (scope, key) 唯一，
+// 已有记忆归入全局作用域；id 保持不变，FTS 与向量无需重建
+func (s *Store) migrateScopes() error {
+	has, err := s.hasColumn("memory_entries", "scope")
+	if err != nil || has {
+		return err
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	stmts := []string{
+		`CREATE TABLE memory_entries_scoped (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			scope TEXT NOT NULL DEFAULT 'global',
+			key TEXT NOT NULL,
+			value TEXT NOT NULL,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			pinned INTEGER NOT NULL DEFAULT 0,
+			UNIQUE(scope, key)
+		)`,
+		`INSERT INTO memory_entries_scoped (id, key, value, updated_at, pinned)
+		 SELECT id, key, value, updated_at, pinned FROM memory_entries`,
+		`DROP TABLE memory_entries`,
+		`ALTER TABLE memory_entries_scoped RENAME TO memory_entries`,
+		`CREATE INDEX IF NOT EXISTS idx_memory_value ON memory_entries(value)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("迁移记忆作用域失败: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// UpdateMemoryIn 在指定作用域中新增或覆盖一条记忆
+func (s *Store) UpdateMemoryIn(scope, key, value string) error {
+	if err := s.upsertEntry(scope, key, value); err != nil {
+		return err
+	}
+	return s.syncScopeFile(scope)
+}
+
+// upsertEntry 写入记忆并更新 FTS 索引和向量，不同步文件
+func (s *Store) upsertEntry(scope, key, value string) error {
+	_, err := s.db.Exec(
+		`INSERT INTO memory_entries (scope, key, value, updated_at) VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+		 ON CONFLICT(scope, key) DO UPDATE SET value=excluded.value, updated_at=CURRENT_TIMESTAMP`,
+		scope, key, value,
+	)
+	if err != nil {
+		return err
+	}
+
+	// 获取新插入/更新行的 ID
+	var rowID int64
+	s.db.QueryRow("SELECT id FROM memory_entries WHERE scope = ? AND key = ?", scope, key).Scan(&rowID)
+
+	// 同步到 FTS5
+	if s.hasFTS5 {
+		// 删除旧的 FTS5 条目，再插入新的
+		s.db.Exec("DELETE FROM memory_fts WHERE rowid = ?", rowID)
+		s.db.Exec("INSERT INTO memory_fts(rowid, key, value) VALUES (?, ?, ?)", rowID, key, value)
+	}
+
+	// 更新向量；失败不影响写入，之后可用 kele memory reindex 补齐
+	s.embedEntry(rowID, value)
+	return nil
+}
+
+// GetMemoryIn 读取指定作用域中的记忆
+func (s *Store) GetMemoryIn(scope, key string) (string, error) {
+	var value string
+	err := s.db.QueryRow("SELECT value FROM memory_entries WHERE scope = ? AND key = ?", scope, key).Scan(&value)
+	return value, err
+}
+
+// DeleteMemoryIn 删除指定作用域中的记忆，不存在时返回 sql.ErrNoRows
+func (s *Store) DeleteMemoryIn(scope, key string) error {
+	if err := s.deleteEntry(scope, key); err != nil {
+		return err
+	}
+	return s.syncScopeFile(scope)
+}
+
+// deleteEntry 删除记忆及其 FTS 索引和向量，不同步文件
+func (s *Store) deleteEntry(scope, key string) error {
+	var rowID int64
+	if err := s.db.QueryRow("SELECT id FROM memory_entries WHERE scope = ? AND key = ?", scope, key).Scan(&rowID); err != nil {
+		return err
+	}
+	if _, err := s.db.Exec("DELETE FROM memory_entries WHERE id = ?", rowID); err != nil {
+		return err
+	}
+	if s.hasFTS5 {
+		s.db.Exec("DELETE FROM memory_fts WHERE rowid = ?", rowID)
+	}
+	s.db.Exec("DELETE FROM memory_vectors WHERE entry_id = ?", rowID)
+	return nil
+}
+
+// FindMemory 在 scopes 中按由近到远的顺序查找 key，返回最先找到的记忆
+func (s *Store) FindMemory(scopes []string, key string) (Entry, error) {
+	entries, err := s.ListMemoriesIn(scopes)
+	if err != nil {
+		return Entry{}, err
+	}
+	for _, scope := range scopes {
+		for _, e := range entries {
+			if e.Scope == scope && e.Key == key {
+				return e, nil
+			}
+		}
+	}
+	return Entry{}, sql.ErrNoRows
+}
+
+// ListMemoriesIn 列出 scopes 中的全部记忆，最近更新的在前；scopes 为空时列出所有作用域
+func (s *Store) ListMemoriesIn(scopes []string) ([]Entry, error) {
+	filter, args := scopeFilter("scope", scopes)
+	rows, err := s.db.Query("SELECT key, value, updated_at, pinned, scope FROM memory_entries WHERE 1=1"+filter+" ORDER BY updated_at DESC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanEntries(rows), nil
+}
+
+// MoveMemory 把记忆移到另一个作用域，目标作用域已有同 key 记忆时报错
+func (s *Store) MoveMemory(from, key, to string) error {
+	if from == to {
+		return nil
+	}
+	if _, err := s.GetMemoryIn(to, key); err == nil {
+		return fmt.Errorf("%s 中已有记忆 [%s]", DescribeScope(to), key)
+	}
+	res, err := s.db.Exec("UPDATE memory_entries SET scope = ?, updated_at = CURRENT_TIMESTAMP WHERE scope = ? AND key = ?", to, from, key)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	if err := s.syncScopeFile(from); err != nil {
+		return err
+	}
+	return s.syncScopeFile(to)
+}
+
+// DeleteScope 删除作用域中的全部记忆（会话结束时清理会话记忆）
+func (s *Store) DeleteScope(scope string) error {
+	return s.deleteWhere("scope = ?", scope)
+}
+
+// ClearSessionScopes 删除所有会话作用域的记忆，daemon 启动时调用：
+// 会话 ID 在重启后会被复用，遗留的会话记忆不能带入新会话
+func (s *Store) ClearSessionScopes() error {
+	return s.deleteWhere("scope LIKE ?", sessionPrefix+"%")
+}
+
+func (s *Store) deleteWhere(cond string, arg interface{}) error {
+	where := " WHERE " + cond
+	if s.hasFTS5 {
+		s.db.Exec("DELETE FROM memory_fts WHERE rowid IN (SELECT id FROM memory_entries"+where+")", arg)
+	}
+	s.db.Exec("DELETE FROM memory_vectors WHERE entry_id IN (SELECT id FROM memory_entries"+where+")", arg)
+	_, err := s.db.Exec("DELETE FROM memory_entries"+where, arg)
+	return err
+}
+
+// id 在所有作用域中唯一标识一条记忆
+func (e Entry) id() string {
+	return e.Scope + "\x00" + e.Key
+}
+
+// scopeFilter 生成限定作用域的 SQL 条件，scopes 为空时不限定
+func scopeFilter(column string, scopes []string) (string, []interface{}) {
+	if len(scopes) == 0 {
+		return "", nil
+	}
+	args := make([]interface{}, len(scopes))
+	for i, s := range scopes {
+		args[i] = s
+	}
+	return fmt.Sprintf(" AND %s IN (?%s)", column, strings.Repeat(", ?", len(scopes)-1)), args
+}
+
+// scopeFile 返回作用域对应的 MEMORY.md：全局记忆写入配置的 memory_file，
+// 项目记忆写入项目根目录下的 .kele/MEMORY.md，工作区和会话记忆不落盘
+func (s *Store) scopeFile(scope string) string {
+	switch ScopeKind(scope) {
+	case ScopeGlobal:
+		return s.memoryFile
+	case "project":
+		return filepath.Join(strings.TrimPrefix(scope, projectPrefix), ".kele", "MEMORY.md")
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,186 @@
+package memory
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/BlakeLiAFK/kele/internal/config"
+)
+
+func TestScopedMemories(t *testing.T) {
+	store := testStore(t)
+	defer store.Close()
+
+	projA := ProjectScope(t.TempDir())
+	projB := ProjectScope(t.TempDir())
+	store.UpdateMemory("lang", "用户偏好中文回复")
+	store.UpdateMemoryIn(projA, "db", "项目 A 使用 PostgreSQL")
+	store.UpdateMemoryIn(projB, "db", "项目 B 使用 MySQL")
+
+	scopesA := Scopes{Project: projA}
+	if e, err := store.FindMemory(scopesA.List(), "db"); err != nil || !strings.Contains(e.Value, "PostgreSQL") {
+		t.Fatalf("项目 A 应读到自己的 db 记忆: %+v, %v", e, err)
+	}
+	if _, err := store.FindMemory(nil, "db"); err == nil {
+		t.Error("仅全局作用域时不应读到项目记忆")
+	}
+
+	results, err := store.SearchEntriesIn(scopesA.List(), "MySQL PostgreSQL", 10)
+	if err != nil {
+		t.Fatalf("SearchEntriesIn 失败: %v", err)
+	}
+	for _, r := range results {
+		if r.Scope == projB {
+			t.Errorf("项目 A 的检索不应返回项目 B 的记忆: %+v", r)
+		}
+	}
+	if len(results) != 1 {
+		t.Errorf("应检索到 1 条记忆, 实际 %d", len(results))
+	}
+
+	entries, _ := store.ListMemoriesIn(scopesA.List())
+	if len(entries) != 2 {
+		t.Errorf("项目 A 应可见 2 条记忆, 实际 %d", len(entries))
+	}
+}
+
+func TestVisibleEntriesShadowing(t *testing.T) {
+	store := testStore(t)
+	defer store.Close()
+
+	scopes := Scopes{Session: SessionScope("s1"), Project: ProjectScope(t.TempDir())}
+	store.UpdateMemory("style", "代码使用 tab 缩进")
+	store.UpdateMemoryIn(scopes.Project, "style", "本项目代码使用两个空格缩进")
+	store.PinMemory(ScopeGlobal, "style", true)
+	store.PinMemory(scopes.Project, "style", true)
+
+	injected, err := store.SelectForPrompt(scopes.List(), "缩进", 600)
+	if err != nil {
+		t.Fatalf("SelectForPrompt 失败: %v", err)
+	}
+	if len(injected) != 1 || injected[0].Scope != scopes.Project {
+		t.Fatalf("同 key 记忆应只注入项目作用域的一条, 实际 %+v", injected)
+	}
+}
+
+func TestMoveMemory(t *testing.T) {
+	store := testStore(t)
+	defer store.Close()
+
+	root := t.TempDir()
+	scopes := Scopes{Session: SessionScope("s1"), Project: ProjectScope(root)}
+	store.UpdateMemoryIn(scopes.Session, "build", "构建命令是 make all")
+
+	target, err := scopes.Broader(scopes.Session)
+	if err != nil || target != scopes.Project {
+		t.Fatalf("会话记忆应提升到项目作用域, 实际 %q, %v", target, err)
+	}
+	if err := store.MoveMemory(scopes.Session, "build", target); err != nil {
+		t.Fatalf("MoveMemory 失败: %v", err)
+	}
+	if _, err := store.GetMemoryIn(scopes.Session, "build"); err == nil {
+		t.Error("移动后会话作用域中不应再有该记忆")
+	}
+	data, err := os.ReadFile(filepath.Join(ProjectRoot(root), ".kele", "MEMORY.md"))
+	if err != nil || !strings.Contains(string(data), "make all") {
+		t.Errorf("项目记忆应写入 .kele/MEMORY.md: %q, %v", data, err)
+	}
+
+	// 目标作用域已有同 key 记忆时拒绝覆盖
+	store.UpdateMemory("build", "全局构建说明")
+	if err := store.MoveMemory(scopes.Project, "build", ScopeGlobal); err == nil {
+		t.Error("目标作用域已有同 key 记忆时应报错")
+	}
+	if _, err := scopes.Broader(ScopeGlobal); err == nil {
+		t.Error("全局记忆不应能继续提升")
+	}
+}
+
+func TestClearSessionScopes(t *testing.T) {
+	store := testStore(t)
+	defer store.Close()
+
+	store.UpdateMemory("keep", "全局记忆")
+	store.UpdateMemoryIn(SessionScope("1"), "tmp", "会话 1 的临时记忆")
+	store.UpdateMemoryIn(SessionScope("2"), "tmp", "会话 2 的临时记忆")
+
+	if err := store.DeleteScope(SessionScope("1")); err != nil {
+		t.Fatalf("DeleteScope 失败: %v", err)
+	}
+	if _, err := store.GetMemoryIn(SessionScope("2"), "tmp"); err != nil {
+		t.Error("删除会话 1 不应影响会话 2")
+	}
+	if err := store.ClearSessionScopes(); err != nil {
+		t.Fatalf("ClearSessionScopes 失败: %v", err)
+	}
+	entries, _ := store.ListMemories()
+	if len(entries) != 1 || entries[0].Key != "keep" {
+		t.Errorf("应只剩全局记忆, 实际 %+v", entries)
+	}
+}
+
+func TestProjectRootWorktree(t *testing.T) {
+	main := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(main, ".git", "worktrees", "wt1"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	sub := filepath.Join(main, "pkg", "util")
+	os.MkdirAll(sub, 0755)
+	if got := ProjectRoot(sub); got != main {
+		t.Errorf("子目录应归属仓库根目录 %s, 实际 %s", main, got)
+	}
+
+	wt := t.TempDir()
+	gitdir := filepath.Join(main, ".git", "worktrees", "wt1")
+	os.WriteFile(filepath.Join(wt, ".git"), []byte("gitdir: "+gitdir+"\n"), 0644)
+	if got := ProjectRoot(wt); got != main {
+		t.Errorf("worktree 应归属主仓库 %s, 实际 %s", main, got)
+	}
+	if ProjectScope(wt) != ProjectScope(main) {
+		t.Error("worktree 与主仓库应共享项目作用域")
+	}
+}
+
+func TestMigrateScopes(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "old.db")
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`CREATE TABLE memory_entries (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		key TEXT UNIQUE NOT NULL,
+		value TEXT NOT NULL,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+	INSERT INTO memory_entries (key, value) VALUES ('lang', '用户偏好中文回复');`)
+	db.Close()
+	if err != nil {
+		t.Fatalf("创建旧表失败: %v", err)
+	}
+
+	store, err := NewStore(&config.Config{Memory: config.MemoryConfig{
+		DBPath:     dbPath,
+		MemoryFile: filepath.Join(dir, "MEMORY.md"),
+		SessionDir: filepath.Join(dir, "sessions"),
+	}})
+	if err != nil {
+		t.Fatalf("打开旧库失败: %v", err)
+	}
+	defer store.Close()
+
+	if v, err := store.GetMemory("lang"); err != nil || v != "用户偏好中文回复" {
+		t.Fatalf("旧记忆应迁移到全局作用域: %q, %v", v, err)
+	}
+	proj := ProjectScope(dir)
+	if err := store.UpdateMemoryIn(proj, "lang", "项目文档用英文"); err != nil {
+		t.Fatalf("迁移后不同作用域应允许同 key: %v", err)
+	}
+	if v, _ := store.GetMemory("lang"); v != "用户偏好中文回复" {
+		t.Errorf("写入项目记忆不应覆盖全局记忆, 实际 %q", v)
+	}
+}
@@ -7,18 +7,26 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/BlakeLiAFK/kele/internal/config"
+	"github.com/BlakeLiAFK/kele/internal/llm"
 	_ "github.com/mattn/go-sqlite3"
 )
 
 // Store 记忆存储
 type Store struct {
-	db         *sql.DB
-	memoryFile string
-	sessionDir string
-	hasFTS5    bool // FTS5 是否可用
+	db            *sql.DB
+	memoryFile    string
+	sessionDir    string
+	hasFTS5       bool         // FTS5 是否可用
+	hasHistoryFTS bool         // 对话归档的 FTS5 索引是否可用
+	embedder      llm.Embedder // 为空时只做关键词检索
+
+	fileMu  sync.Mutex // 串行化 MEMORY.md 的合并与写回
+	trackMu sync.Mutex
+	tracked map[string]bool // 已导入过文件的项目作用域
 }
 
 // NewStore 创建存储（返回 error 而非 panic）
@@ -39,6 +47,7 @@ func NewStore(cfg *config.Config) (*Store, error) {
 		db:         db,
 		memoryFile: memoryFile,
 		sessionDir: sessionDir,
+		tracked:    make(map[string]bool),
 	}
 	if err := store.initSchema(); err != nil {
 		db.Close()
@@ -58,9 +67,12 @@ func (s *Store) initSchema() error {
 
 	CREATE TABLE IF NOT EXISTS memory_entries (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		key TEXT NOT NULL UNIQUE,
+		scope TEXT NOT NULL DEFAULT 'global',
+		key TEXT NOT NULL,
 		value TEXT NOT NULL,
-		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		pinned INTEGER NOT NULL DEFAULT 0,
+		UNIQUE(scope, key)
 	);
 
 	CREATE INDEX IF NOT EXISTS idx_memory_value ON memory_entries(value);
@@ -74,16 +86,78 @@ func (s *Store) initSchema() error {
 		message_count INTEGER DEFAULT 0,
 		summary TEXT DEFAULT ''
 	);
+
+	CREATE TABLE IF NOT EXISTS memory_proposals (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		key TEXT NOT NULL,
+		value TEXT NOT NULL,
+		reason TEXT DEFAULT '',
+		scope TEXT NOT NULL DEFAULT 'global',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE TABLE IF NOT EXISTS memory_vectors (
+		entry_id INTEGER PRIMARY KEY,
+		model TEXT NOT NULL,
+		dim INTEGER NOT NULL,
+		vector BLOB NOT NULL,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
 	`
 	if _, err := s.db.Exec(schema); err != nil {
 		return err
 	}
+	if err := s.addColumnIfMissing("memory_entries", "pinned", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := s.addColumnIfMissing("memory_proposals", "scope", "TEXT NOT NULL DEFAULT 'global'"); err != nil {
+		return err
+	}
+	if err := s.migrateScopes(); err != nil {
+		return err
+	}
+	if err := s.initFileSync(); err != nil {
+		return err
+	}
+	if err := s.initHistoryIndex(); err != nil {
+		return err
+	}
 
 	// 尝试创建 FTS5 虚拟表（如果 FTS5 不可用则跳过）
 	s.initFTS5()
 	return nil
 }
 
+// addColumnIfMissing 为旧数据库补充新增的列
+func (s *Store) addColumnIfMissing(table, column, def string) error {
+	has, err := s.hasColumn(table, column)
+	if err != nil || has {
+		return err
+	}
+	_, err = s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, def))
+	return err
+}
+
+func (s *Store) hasColumn(table, column string) (bool, error) {
+	rows, err := s.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, typ string
+		var dflt sql.NullString
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &dflt, &pk); err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
+
 // initFTS5 尝试初始化 FTS5 全文搜索
 func (s *Store) initFTS5() {
 	_, err := s.db.Exec(`
@@ -107,6 +181,7 @@ func (s *Store) initFTS5() {
 	`)
 }
 
+// SaveMessage 写入一条不带来源的消息；对话请用 SaveTurn 归档
 func (s *Store) SaveMessage(role, content string) error {
 	_, err := s.db.Exec("INSERT INTO messages (role, content) VALUES (?, ?)", role, content)
 	return err
@@ -136,36 +211,17 @@ func (s *Store) GetRecentMessages(limit int) ([]Message, error) {
 	return messages, nil
 }
 
+// UpdateMemory 新增或覆盖一条全局记忆
 func (s *Store) UpdateMemory(key, value string) error {
-	_, err := s.db.Exec(
-		`INSERT INTO memory_entries (key, value, updated_at) VALUES (?, ?, CURRENT_TIMESTAMP)
-		 ON CONFLICT(key) DO UPDATE SET value=?, updated_at=CURRENT_TIMESTAMP`,
-		key, value, value,
-	)
-	if err != nil {
-		return err
-	}
-
-	// 同步到 FTS5
-	if s.hasFTS5 {
-		// 获取新插入/更新行的 ID
-		var rowID int64
-		s.db.QueryRow("SELECT id FROM memory_entries WHERE key = ?", key).Scan(&rowID)
-		// 删除旧的 FTS5 条目，再插入新的
-		s.db.Exec("DELETE FROM memory_fts WHERE rowid = ?", rowID)
-		s.db.Exec("INSERT INTO memory_fts(rowid, key, value) VALUES (?, ?, ?)", rowID, key, value)
-	}
-
-	return s.syncToFile()
+	return s.UpdateMemoryIn(ScopeGlobal, key, value)
 }
 
+// GetMemory 读取一条全局记忆
 func (s *Store) GetMemory(key string) (string, error) {
-	var value string
-	err := s.db.QueryRow("SELECT value FROM memory_entries WHERE key = ?", key).Scan(&value)
-	return value, err
+	return s.GetMemoryIn(ScopeGlobal, key)
 }
 
-// GetRecentMemories 获取最近的记忆条目（用于 system prompt 注入）
+// GetRecentMemories 获取最近更新的记忆条目
 func (s *Store) GetRecentMemories(limit int) ([]string, error) {
 	rows, err := s.db.Query(
 		"SELECT key, value FROM memory_entries ORDER BY updated_at DESC LIMIT ?", limit)
@@ -189,158 +245,165 @@ func (s *Store) GetRecentMemories(limit int) ([]string, error) {
 	return results, nil
 }
 
-// Search 搜索记忆（优先使用 FTS5 BM25 排序，降级使用 LIKE）
+// Search 搜索记忆（优先使用 FTS5 BM25 排序，降级使用 LIKE；配置嵌入器后混合语义检索）
 func (s *Store) Search(query string, limit int) ([]string, error) {
+	entries, err := s.SearchEntries(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	var results []string
+	for _, e := range entries {
+		results = append(results, e.Value)
+	}
+	return results, nil
+}
+
+// SearchEntries 与 Search 相同，但返回带 key 的完整条目
+func (s *Store) SearchEntries(query string, limit int) ([]Entry, error) {
+	return s.SearchEntriesIn(nil, query, limit)
+}
+
+// SearchEntriesIn 在 scopes 中搜索记忆，scopes 为空时搜索所有作用域。
+// 配置了嵌入器时同时做向量检索，用 RRF 与关键词结果融合。
+func (s *Store) SearchEntriesIn(scopes []string, query string, limit int) ([]Entry, error) {
 	keywords := strings.Fields(query)
 	if len(keywords) == 0 {
 		return nil, nil
 	}
+	if s.embedder == nil {
+		return s.searchKeywords(scopes, keywords, limit)
+	}
+
+	// 两路各多召回一些候选再融合
+	keywordHits, err := s.searchKeywords(scopes, keywords, limit*candidateFactor)
+	if err != nil {
+		return nil, err
+	}
+	semanticHits, err := s.searchVectors(scopes, query, limit*candidateFactor)
+	if err != nil {
+		// 嵌入服务不可用时退回纯关键词检索
+		return truncateEntries(keywordHits, limit), nil
+	}
+	return truncateEntries(fuseRRF(keywordHits, semanticHits), limit), nil
+}
 
-	// 优先使用 FTS5 搜索
+// searchKeywords 关键词检索（优先使用 FTS5 搜索）
+func (s *Store) searchKeywords(scopes, keywords []string, limit int) ([]Entry, error) {
 	if s.hasFTS5 {
-		return s.searchFTS5(query, keywords, limit)
+		return s.searchFTS5(scopes, keywords, limit)
 	}
-	return s.searchLike(keywords, limit)
+	return s.searchLike(scopes, keywords, limit)
 }
 
 // searchFTS5 使用 FTS5 全文搜索（BM25 排序）
-func (s *Store) searchFTS5(query string, keywords []string, limit int) ([]string, error) {
+func (s *Store) searchFTS5(scopes, keywords []string, limit int) ([]Entry, error) {
 	// 构建 FTS5 查询表达式：多词用 AND 连接
-	ftsQuery := strings.Join(keywords, " AND ")
+	results, err := s.queryFTS5(scopes, strings.Join(keywords, " AND "), limit)
+	if err != nil {
+		// FTS5 查询失败，降级到 LIKE
+		return s.searchLike(scopes, keywords, limit)
+	}
 
+	// FTS5 无结果时尝试 OR 查询
+	if len(results) == 0 && len(keywords) > 1 {
+		results, err = s.queryFTS5(scopes, strings.Join(keywords, " OR "), limit)
+		if err != nil {
+			return s.searchLike(scopes, keywords, limit)
+		}
+	}
+	return results, nil
+}
+
+func (s *Store) queryFTS5(scopes []string, ftsQuery string, limit int) ([]Entry, error) {
+	filter, scopeArgs := scopeFilter("e.scope", scopes)
+	args := append([]interface{}{ftsQuery}, scopeArgs...)
 	rows, err := s.db.Query(
-		`SELECT value, bm25(memory_fts) AS rank
-		 FROM memory_fts
-		 WHERE memory_fts MATCH ?
-		 ORDER BY rank
+		`SELECT e.key, e.value, e.updated_at, e.pinned, e.scope
+		 FROM memory_fts JOIN memory_entries e ON e.id = memory_fts.rowid
+		 WHERE memory_fts MATCH ?`+filter+`
+		 ORDER BY bm25(memory_fts)
 		 LIMIT ?`,
-		ftsQuery, limit)
+		append(args, limit)...)
 	if err != nil {
-		// FTS5 查询失败，降级到 LIKE
-		return s.searchLike(keywords, limit)
+		return nil, err
 	}
 	defer rows.Close()
+	return scanEntries(rows), nil
+}
 
-	var results []string
-	for rows.Next() {
-		var content string
-		var rank float64
-		if err := rows.Scan(&content, &rank); err != nil {
-			continue
-		}
-		results = append(results, content)
+// searchLike LIKE 降级搜索
+func (s *Store) searchLike(scopes, keywords []string, limit int) ([]Entry, error) {
+	results, err := s.queryLike(scopes, keywords, " AND ", limit)
+	if err != nil {
+		return nil, err
 	}
 
-	// FTS5 无结果时尝试 OR 查询
+	// 多关键词无结果时退化为 OR 搜索
 	if len(results) == 0 && len(keywords) > 1 {
-		orQuery := strings.Join(keywords, " OR ")
-		rows2, err := s.db.Query(
-			`SELECT value, bm25(memory_fts) AS rank
-			 FROM memory_fts
-			 WHERE memory_fts MATCH ?
-			 ORDER BY rank
-			 LIMIT ?`,
-			orQuery, limit)
-		if err != nil {
-			return s.searchLike(keywords, limit)
-		}
-		defer rows2.Close()
-		for rows2.Next() {
-			var content string
-			var rank float64
-			if err := rows2.Scan(&content, &rank); err != nil {
-				continue
-			}
-			results = append(results, content)
-		}
+		return s.queryLike(scopes, keywords, " OR ", limit)
 	}
-
 	return results, nil
 }
 
-// searchLike LIKE 降级搜索
-func (s *Store) searchLike(keywords []string, limit int) ([]string, error) {
+func (s *Store) queryLike(scopes, keywords []string, op string, limit int) ([]Entry, error) {
 	var conditions []string
 	var args []interface{}
 	for _, kw := range keywords {
 		conditions = append(conditions, "value LIKE ?")
 		args = append(args, "%"+kw+"%")
 	}
-	args = append(args, limit)
+	filter, scopeArgs := scopeFilter("scope", scopes)
+	args = append(append(args, scopeArgs...), limit)
 
 	sqlStr := fmt.Sprintf(
-		"SELECT value FROM memory_entries WHERE %s ORDER BY updated_at DESC LIMIT ?",
-		strings.Join(conditions, " AND "),
+		"SELECT key, value, updated_at, pinned, scope FROM memory_entries WHERE (%s)%s ORDER BY updated_at DESC LIMIT ?",
+		strings.Join(conditions, op), filter,
 	)
 	rows, err := s.db.Query(sqlStr, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
+	return scanEntries(rows), nil
+}
 
-	var results []string
-	for rows.Next() {
-		var content string
-		if err := rows.Scan(&content); err != nil {
-			continue
-		}
-		results = append(results, content)
-	}
-
-	// 多关键词无结果时退化为 OR 搜索
-	if len(results) == 0 && len(keywords) > 1 {
-		var orConditions []string
-		var orArgs []interface{}
-		for _, kw := range keywords {
-			orConditions = append(orConditions, "value LIKE ?")
-			orArgs = append(orArgs, "%"+kw+"%")
-		}
-		orArgs = append(orArgs, limit)
-		orSQL := fmt.Sprintf(
-			"SELECT value FROM memory_entries WHERE %s ORDER BY updated_at DESC LIMIT ?",
-			strings.Join(orConditions, " OR "),
-		)
-		rows2, err := s.db.Query(orSQL, orArgs...)
-		if err != nil {
-			return nil, err
-		}
-		defer rows2.Close()
-		for rows2.Next() {
-			var content string
-			if err := rows2.Scan(&content); err != nil {
-				continue
-			}
-			results = append(results, content)
-		}
-	}
-
-	return results, nil
+// ListMemories 列出所有作用域的全部记忆，最近更新的在前
+func (s *Store) ListMemories() ([]Entry, error) {
+	return s.ListMemoriesIn(nil)
 }
 
-// HasFTS5 返回 FTS5 是否可用
-func (s *Store) HasFTS5() bool {
-	return s.hasFTS5
+// DeleteMemory 删除一条全局记忆，key 不存在时返回 sql.ErrNoRows
+func (s *Store) DeleteMemory(key string) error {
+	return s.DeleteMemoryIn(ScopeGlobal, key)
 }
 
-func (s *Store) syncToFile() error {
-	rows, err := s.db.Query("SELECT key, value, updated_at FROM memory_entries ORDER BY updated_at DESC")
+// PinMemory 设置或取消置顶，记忆不存在时返回 sql.ErrNoRows
+func (s *Store) PinMemory(scope, key string, pinned bool) error {
+	res, err := s.db.Exec("UPDATE memory_entries SET pinned = ? WHERE scope = ? AND key = ?", pinned, scope, key)
 	if err != nil {
 		return err
 	}
-	defer rows.Close()
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
 
-	content := "# 长期记忆\n\n"
-	content += fmt.Sprintf("> 最后更新: %s\n\n", time.Now().Format("2006-01-02 15:04:05"))
+func scanEntries(rows *sql.Rows) []Entry {
+	var results []Entry
 	for rows.Next() {
-		var key, value, updatedAt string
-		if err := rows.Scan(&key, &value, &updatedAt); err != nil {
+		var e Entry
+		if err := rows.Scan(&e.Key, &e.Value, &e.UpdatedAt, &e.Pinned, &e.Scope); err != nil {
 			continue
 		}
-		content += fmt.Sprintf("## %s\n\n%s\n\n", key, value)
+		results = append(results, e)
 	}
+	return results
+}
 
-	os.MkdirAll(filepath.Dir(s.memoryFile), 0755)
-	return os.WriteFile(s.memoryFile, []byte(content), 0644)
+// HasFTS5 返回 FTS5 是否可用
+func (s *Store) HasFTS5() bool {
+	return s.hasFTS5
 }
 
 // --- 会话持久化 ---
@@ -443,6 +506,15 @@ type Message struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// Entry 一条长期记忆
+type Entry struct {
+	Key       string
+	Value     string
+	UpdatedAt time.Time
+	Pinned    bool   // 置顶记忆每轮都注入 system prompt
+	Scope     string // 所属作用域，见 ScopeGlobal、ProjectScope 等
+}
+
 // SessionInfo 会话信息
 type SessionInfo struct {
 	ID           string
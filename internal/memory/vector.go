@@ -0,0 +1,215 @@
+package memory
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/BlakeLiAFK/kele/internal/llm"
+)
+
+const (
+	rrfK            = 60  // RRF 平滑常数，越大排名靠后的结果权重越接近
+	minSimilarity   = 0.2 // 余弦相似度低于此值的向量结果不参与融合
+	candidateFactor = 3   // 混合检索时每一路召回 limit 的倍数
+	embedBatchSize  = 16  // 重建索引时每批嵌入的条数
+	embedTimeout    = 30 * time.Second
+)
+
+// SetEmbedder 设置嵌入器，开启语义检索；传 nil 关闭
+func (s *Store) SetEmbedder(e llm.Embedder) {
+	s.embedder = e
+}
+
+// Embedder 返回当前嵌入器，未配置时为 nil
+func (s *Store) Embedder() llm.Embedder {
+	return s.embedder
+}
+
+// embedEntry 为单条记忆生成并保存向量
+func (s *Store) embedEntry(entryID int64, value string) error {
+	if s.embedder == nil || entryID == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), embedTimeout)
+	defer cancel()
+	vectors, err := s.embedder.Embed(ctx, []string{value})
+	if err != nil {
+		return err
+	}
+	return s.saveVector(entryID, vectors[0])
+}
+
+func (s *Store) saveVector(entryID int64, vec []float32) error {
+	_, err := s.db.Exec(
+		`INSERT INTO memory_vectors (entry_id, model, dim, vector, updated_at) VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP)
+		 ON CONFLICT(entry_id) DO UPDATE SET model=excluded.model, dim=excluded.dim, vector=excluded.vector, updated_at=CURRENT_TIMESTAMP`,
+		entryID, s.embedder.Name(), len(vec), encodeVector(vec),
+	)
+	return err
+}
+
+// Reindex 为缺少向量（或向量来自其他模型）的记忆补齐向量，all 为 true 时全部重建。
+// 返回本次写入的条数和记忆总数。
+func (s *Store) Reindex(ctx context.Context, all bool) (indexed, total int, err error) {
+	if s.embedder == nil {
+		return 0, 0, fmt.Errorf("未配置嵌入器（memory.embedder）")
+	}
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM memory_entries").Scan(&total); err != nil {
+		return 0, 0, err
+	}
+
+	query := `SELECT e.id, e.value FROM memory_entries e
+		LEFT JOIN memory_vectors v ON v.entry_id = e.id
+		WHERE v.entry_id IS NULL OR v.model != ? ORDER BY e.id`
+	args := []interface{}{s.embedder.Name()}
+	if all {
+		query = "SELECT id, value FROM memory_entries ORDER BY id"
+		args = nil
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return 0, total, err
+	}
+	var ids []int64
+	var texts []string
+	for rows.Next() {
+		var id int64
+		var value string
+		if err := rows.Scan(&id, &value); err != nil {
+			rows.Close()
+			return 0, total, err
+		}
+		ids = append(ids, id)
+		texts = append(texts, value)
+	}
+	rows.Close()
+
+	for start := 0; start < len(ids); start += embedBatchSize {
+		end := start + embedBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		vectors, err := s.embedder.Embed(ctx, texts[start:end])
+		if err != nil {
+			return indexed, total, err
+		}
+		for i, vec := range vectors {
+			if err := s.saveVector(ids[start+i], vec); err != nil {
+				return indexed, total, err
+			}
+			indexed++
+		}
+	}
+	return indexed, total, nil
+}
+
+// vectorHit 向量检索结果及其余弦相似度
+type vectorHit struct {
+	Entry
+	Score float64
+}
+
+// searchVectors 按余弦相似度检索当前模型生成的向量
+func (s *Store) searchVectors(scopes []string, query string, limit int) ([]Entry, error) {
+	hits, err := s.vectorHits(scopes, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Entry, len(hits))
+	for i, h := range hits {
+		results[i] = h.Entry
+	}
+	return results, nil
+}
+
+// vectorHits 返回相似度不低于 minSimilarity 的前 limit 条，按相似度降序
+func (s *Store) vectorHits(scopes []string, query string, limit int) ([]vectorHit, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), embedTimeout)
+	defer cancel()
+	vectors, err := s.embedder.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, err
+	}
+	queryVec := vectors[0]
+
+	filter, scopeArgs := scopeFilter("e.scope", scopes)
+	rows, err := s.db.Query(
+		`SELECT e.key, e.value, e.updated_at, e.pinned, e.scope, v.vector
+		 FROM memory_vectors v JOIN memory_entries e ON e.id = v.entry_id
+		 WHERE v.model = ? AND v.dim = ?`+filter,
+		append([]interface{}{s.embedder.Name(), len(queryVec)}, scopeArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []vectorHit
+	for rows.Next() {
+		var e Entry
+		var blob []byte
+		if err := rows.Scan(&e.Key, &e.Value, &e.UpdatedAt, &e.Pinned, &e.Scope, &blob); err != nil {
+			continue
+		}
+		if score := llm.Cosine(queryVec, decodeVector(blob)); score >= minSimilarity {
+			hits = append(hits, vectorHit{e, score})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, rows.Err()
+}
+
+// fuseRRF 用 Reciprocal Rank Fusion 合并多路排序结果：
+// 每条结果得分为各路 1/(k+排名) 之和，同时出现在多路中的结果排在前面
+func fuseRRF(lists ...[]Entry) []Entry {
+	scores := make(map[string]float64)
+	entries := make(map[string]Entry)
+	var order []string
+	for _, list := range lists {
+		for rank, e := range list {
+			id := e.id()
+			if _, ok := entries[id]; !ok {
+				entries[id] = e
+				order = append(order, id)
+			}
+			scores[id] += 1 / float64(rrfK+rank+1)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool { return scores[order[i]] > scores[order[j]] })
+
+	results := make([]Entry, 0, len(order))
+	for _, id := range order {
+		results = append(results, entries[id])
+	}
+	return results
+}
+
+func truncateEntries(entries []Entry, limit int) []Entry {
+	if len(entries) > limit {
+		return entries[:limit]
+	}
+	return entries
+}
+
+// encodeVector 以小端 float32 序列存储向量
+func encodeVector(v []float32) []byte {
+	buf := make([]byte, 4*len(v))
+	for i, x := range v {
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(x))
+	}
+	return buf
+}
+
+func decodeVector(buf []byte) []float32 {
+	v := make([]float32, len(buf)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[4*i:]))
+	}
+	return v
+}
@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// stubEmbedder 按关键词映射到固定方向，模拟“没有字面重合但语义相同”
+type stubEmbedder struct {
+	name string
+	fail bool
+}
+
+func (e *stubEmbedder) Name() string { return e.name }
+
+func (e *stubEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	if e.fail {
+		return nil, errors.New("embedding service down")
+	}
+	vecs := make([][]float32, len(texts))
+	for i, text := range texts {
+		switch {
+		case containsAny(text, "崩溃", "无响应", "crash", "hang"):
+			vecs[i] = []float32{1, 0, 0}
+		case containsAny(text, "咖啡", "coffee"):
+			vecs[i] = []float32{0, 1, 0}
+		default:
+			vecs[i] = []float32{0, 0, 1}
+		}
+	}
+	return vecs, nil
+}
+
+func containsAny(s string, subs ...string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestHybridSearch(t *testing.T) {
+	store := testStore(t)
+	defer store.Close()
+	embedder := &stubEmbedder{name: "stub:v1"}
+	store.SetEmbedder(embedder)
+
+	store.UpdateMemory("daemon_hang", "上次 daemon 无响应是因为 socket 文件残留")
+	store.UpdateMemory("drink", "用户每天早上喝 coffee")
+	store.UpdateMemory("editor", "用户使用 neovim")
+
+	// 查询与记忆没有共同关键词，只能靠向量召回
+	entries, err := store.SearchEntries("服务崩溃了", 5)
+	if err != nil {
+		t.Fatalf("SearchEntries 失败: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Key != "daemon_hang" {
+		t.Fatalf("语义检索应找到 daemon_hang, 实际 %+v", entries)
+	}
+
+	// 关键词与语义同时命中的结果排在前面
+	entries, _ = store.SearchEntries("neovim coffee", 5)
+	if len(entries) != 2 || entries[0].Key != "drink" {
+		t.Errorf("两路都命中的记忆应排第一, 实际 %+v", entries)
+	}
+
+	// 嵌入服务不可用时退回关键词检索
+	embedder.fail = true
+	entries, err = store.SearchEntries("neovim", 5)
+	if err != nil || len(entries) != 1 || entries[0].Key != "editor" {
+		t.Errorf("嵌入失败时应退回关键词检索, 实际 %+v, %v", entries, err)
+	}
+
+	// 删除记忆同时删除向量
+	store.DeleteMemory("daemon_hang")
+	var n int
+	store.db.QueryRow("SELECT COUNT(*) FROM memory_vectors").Scan(&n)
+	if n != 2 {
+		t.Errorf("删除后应剩 2 个向量, 实际 %d", n)
+	}
+}
+
+func TestReindex(t *testing.T) {
+	store := testStore(t)
+	defer store.Close()
+
+	// 未配置嵌入器时写入的记忆没有向量
+	for _, k := range []string{"a", "b", "c"} {
+		store.UpdateMemory(k, "记忆 "+k)
+	}
+	if _, _, err := store.Reindex(context.Background(), false); err == nil {
+		t.Error("未配置嵌入器应返回错误")
+	}
+
+	store.SetEmbedder(&stubEmbedder{name: "stub:v1"})
+	indexed, total, err := store.Reindex(context.Background(), false)
+	if err != nil || indexed != 3 || total != 3 {
+		t.Fatalf("应补齐 3 条, 实际 %d/%d, %v", indexed, total, err)
+	}
+	if indexed, _, _ = store.Reindex(context.Background(), false); indexed != 0 {
+		t.Errorf("已有向量不应重复生成, 实际 %d", indexed)
+	}
+
+	// 更换模型后旧向量视为过期
+	store.SetEmbedder(&stubEmbedder{name: "stub:v2"})
+	if indexed, _, _ = store.Reindex(context.Background(), false); indexed != 3 {
+		t.Errorf("更换模型后应重建 3 条, 实际 %d", indexed)
+	}
+	if indexed, _, _ = store.Reindex(context.Background(), true); indexed != 3 {
+		t.Errorf("--all 应重建全部, 实际 %d", indexed)
+	}
+}
+
+func TestFuseRRF(t *testing.T) {
+	keyword := []Entry{{Key: "a"}, {Key: "b"}, {Key: "c"}}
+	semantic := []Entry{{Key: "c"}, {Key: "d"}}
+	got := fuseRRF(keyword, semantic)
+	// 同分时先出现的排在前面
+	want := []string{"c", "a", "b", "d"}
+	if len(got) != len(want) {
+		t.Fatalf("结果数量错误: %+v", got)
+	}
+	for i, k := range want {
+		if got[i].Key != k {
+			t.Errorf("第 %d 位应为 %s, 实际 %s", i, k, got[i].Key)
+		}
+	}
+}
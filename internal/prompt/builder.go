@@ -32,23 +32,27 @@ func Build(p BuildParams) string {
 
 	// 工具描述映射
 	toolDescriptions := map[string]string{
-		"bash":         "执行 shell 命令（查看目录、运行程序、安装依赖等）",
-		"read":         "读取文件内容（查看源代码、配置等），路径相对于工作目录",
-		"write":        "创建或覆盖文件（写代码、写配置等），路径相对于工作目录",
-		"http":         "发起 HTTP API 请求（GET/POST/PUT/DELETE），返回原始响应",
-		"web_fetch":    "抓取网页并提取可读正文（HTML 转 Markdown），适合阅读网页内容",
-		"git":          "执行 Git 操作（status/diff/log/add/commit 等）",
-		"python":       "执行 Python 代码片段，适合数据处理和计算",
-		"send_message": "发送消息到 Telegram。参数: channel=\"telegram\", message=\"内容\"",
-		"cron_create":  "创建定时任务。参数: name, schedule(cron 表达式), command(bash 命令)",
-		"cron_list":    "列出所有定时任务",
-		"cron_get":     "查看定时任务详情和执行日志",
-		"cron_update":  "更新定时任务（修改名称/表达式/命令/启停）",
-		"cron_delete":  "删除定时任务",
-		"spawn_agent":  "启动子 agent 并行执行任务。参数: task(任务描述)。返回 agent ID",
-		"agent_status": "查看子 agent 状态。参数: id(可选，不传列出全部)",
-		"agent_result": "等待子 agent 完成并获取结果。参数: id(agent ID)。会阻塞直到完成",
-		"ask_user":     "向用户提问，获取确认或选择。参数: question(问题), options(选项列表，可选)",
+		"bash":          "执行 shell 命令（查看目录、运行程序、安装依赖等）",
+		"read":          "读取文件内容（查看源代码、配置等），路径相对于工作目录",
+		"write":         "创建或覆盖文件（写代码、写配置等），路径相对于工作目录",
+		"http":          "发起 HTTP API 请求（GET/POST/PUT/DELETE），返回原始响应",
+		"web_fetch":     "抓取网页并提取可读正文（HTML 转 Markdown），适合阅读网页内容",
+		"git":           "执行 Git 操作（status/diff/log/add/commit 等）",
+		"python":        "执行 Python 代码片段，适合数据处理和计算",
+		"send_message":  "发送消息到 Telegram。参数: channel=\"telegram\", message=\"内容\"",
+		"cron_create":   "创建定时任务。参数: name, schedule(cron 表达式), command(bash 命令)",
+		"cron_list":     "列出所有定时任务",
+		"cron_get":      "查看定时任务详情和执行日志",
+		"cron_update":   "更新定时任务（修改名称/表达式/命令/启停）",
+		"cron_delete":   "删除定时任务",
+		"spawn_agent":   "启动子 agent 并行执行任务。参数: task(任务描述)。返回 agent ID",
+		"agent_status":  "查看子 agent 状态。参数: id(可选，不传列出全部)",
+		"agent_result":  "等待子 agent 完成并获取结果。参数: id(agent ID)。会阻塞直到完成",
+		"ask_user":      "向用户提问，获取确认或选择。参数: question(问题), options(选项列表，可选)",
+		"remember":      "保存长期记忆。参数: content(事实), key(可选)",
+		"recall":        "搜索长期记忆，返回记忆及其 key。参数: query(关键词)",
+		"update_memory": "修改已有记忆。参数: key, content",
+		"forget":        "删除一条记忆。参数: key",
 	}
 
 	for _, name := range p.ToolNames {
@@ -75,6 +79,20 @@ func Build(p BuildParams) string {
 - 并行执行多任务：spawn_agent 启动多个子 agent -> agent_status 查进度 -> agent_result 获取结果汇总
 `)
 
+	// 记忆工具使用说明
+	for _, name := range p.ToolNames {
+		if name == "remember" {
+			sb.WriteString(`
+## 长期记忆使用
+- 用户说明偏好、项目约定等持久信息，或要求你记住某事时，用 remember 保存
+- 回答依赖以往信息时，先用 recall 搜索
+- 记忆过时用 update_memory 修改；用户要求忘记时用 forget 删除
+- 不要保存密码、密钥等敏感信息，也不要保存一次性任务细节
+`)
+			break
+		}
+	}
+
 	// 工作目录信息
 	if p.WorkDir != "" {
 		sb.WriteString(fmt.Sprintf("\n## 工作目录\n当前工作目录: %s\n", p.WorkDir))
@@ -228,3 +228,16 @@ func TestBuild_AgentTools(t *testing.T) {
 		t.Error("输出应包含并行任务示例")
 	}
 }
+
+func TestBuild_MemoryTools(t *testing.T) {
+	result := Build(BuildParams{ToolNames: []string{"bash", "remember", "recall"}})
+	if !strings.Contains(result, "**remember**: 保存长期记忆") {
+		t.Error("输出应包含 remember 工具描述")
+	}
+	if !strings.Contains(result, "长期记忆使用") {
+		t.Error("有记忆工具时应包含使用说明")
+	}
+	if strings.Contains(Build(BuildParams{ToolNames: []string{"bash"}}), "长期记忆使用") {
+		t.Error("无记忆工具时不应包含使用说明")
+	}
+}
@@ -710,6 +710,10 @@ type HeartbeatStatusResponse struct {
 	LastDecision    string                 `protobuf:"bytes,4,opt,name=last_decision,json=lastDecision,proto3" json:"last_decision,omitempty"`
 	TotalHeartbeats int32                  `protobuf:"varint,5,opt,name=total_heartbeats,json=totalHeartbeats,proto3" json:"total_heartbeats,omitempty"`
 	ActionsTaken    int32                  `protobuf:"varint,6,opt,name=actions_taken,json=actionsTaken,proto3" json:"actions_taken,omitempty"`
+	NextRun         string                 `protobuf:"bytes,7,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"`
+	QuietHours      string                 `protobuf:"bytes,8,opt,name=quiet_hours,json=quietHours,proto3" json:"quiet_hours,omitempty"` // empty = none
+	ActiveDays      string                 `protobuf:"bytes,9,opt,name=active_days,json=activeDays,proto3" json:"active_days,omitempty"` // empty = every day
+	MaxRounds       int32                  `protobuf:"varint,10,opt,name=max_rounds,json=maxRounds,proto3" json:"max_rounds,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -786,38 +790,56 @@ func (x *HeartbeatStatusResponse) GetActionsTaken() int32 {
 	return 0
 }
 
-type WorkspaceInfo struct {
+func (x *HeartbeatStatusResponse) GetNextRun() string {
+	if x != nil {
+		return x.NextRun
+	}
+	return ""
+}
+
+func (x *HeartbeatStatusResponse) GetQuietHours() string {
+	if x != nil {
+		return x.QuietHours
+	}
+	return ""
+}
+
+func (x *HeartbeatStatusResponse) GetActiveDays() string {
+	if x != nil {
+		return x.ActiveDays
+	}
+	return ""
+}
+
+func (x *HeartbeatStatusResponse) GetMaxRounds() int32 {
+	if x != nil {
+		return x.MaxRounds
+	}
+	return 0
+}
+
+type GetHeartbeatHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Goal          string                 `protobuf:"bytes,4,opt,name=goal,proto3" json:"goal,omitempty"`
-	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
-	MaxConcurrent int32                  `protobuf:"varint,6,opt,name=max_concurrent,json=maxConcurrent,proto3" json:"max_concurrent,omitempty"`
-	Context       string                 `protobuf:"bytes,7,opt,name=context,proto3" json:"context,omitempty"`
-	WorkDir       string                 `protobuf:"bytes,8,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
-	Summary       string                 `protobuf:"bytes,9,opt,name=summary,proto3" json:"summary,omitempty"`
-	TaskCount     int32                  `protobuf:"varint,10,opt,name=task_count,json=taskCount,proto3" json:"task_count,omitempty"`
-	RunningCount  int32                  `protobuf:"varint,11,opt,name=running_count,json=runningCount,proto3" json:"running_count,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`       // return this record with its transcript
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // records to list when id is 0, default 20
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WorkspaceInfo) Reset() {
-	*x = WorkspaceInfo{}
+func (x *GetHeartbeatHistoryRequest) Reset() {
+	*x = GetHeartbeatHistoryRequest{}
 	mi := &file_proto_kele_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WorkspaceInfo) String() string {
+func (x *GetHeartbeatHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WorkspaceInfo) ProtoMessage() {}
+func (*GetHeartbeatHistoryRequest) ProtoMessage() {}
 
-func (x *WorkspaceInfo) ProtoReflect() protoreflect.Message {
+func (x *GetHeartbeatHistoryRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_kele_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -829,122 +851,115 @@ func (x *WorkspaceInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WorkspaceInfo.ProtoReflect.Descriptor instead.
-func (*WorkspaceInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetHeartbeatHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetHeartbeatHistoryRequest) Descriptor() ([]byte, []int) {
 	return file_proto_kele_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *WorkspaceInfo) GetId() string {
+func (x *GetHeartbeatHistoryRequest) GetId() int64 {
 	if x != nil {
 		return x.Id
 	}
-	return ""
+	return 0
 }
 
-func (x *WorkspaceInfo) GetName() string {
+func (x *GetHeartbeatHistoryRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Name
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-func (x *WorkspaceInfo) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
+type HeartbeatMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"` // user, assistant, tool
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	ToolCalls     []string               `protobuf:"bytes,3,rep,name=tool_calls,json=toolCalls,proto3" json:"tool_calls,omitempty"` // "name(arguments)" for assistant turns
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WorkspaceInfo) GetGoal() string {
-	if x != nil {
-		return x.Goal
-	}
-	return ""
+func (x *HeartbeatMessage) Reset() {
+	*x = HeartbeatMessage{}
+	mi := &file_proto_kele_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *WorkspaceInfo) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
+func (x *HeartbeatMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *WorkspaceInfo) GetMaxConcurrent() int32 {
-	if x != nil {
-		return x.MaxConcurrent
-	}
-	return 0
-}
+func (*HeartbeatMessage) ProtoMessage() {}
 
-func (x *WorkspaceInfo) GetContext() string {
+func (x *HeartbeatMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[14]
 	if x != nil {
-		return x.Context
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *WorkspaceInfo) GetWorkDir() string {
-	if x != nil {
-		return x.WorkDir
-	}
-	return ""
+// Deprecated: Use HeartbeatMessage.ProtoReflect.Descriptor instead.
+func (*HeartbeatMessage) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *WorkspaceInfo) GetSummary() string {
+func (x *HeartbeatMessage) GetRole() string {
 	if x != nil {
-		return x.Summary
+		return x.Role
 	}
 	return ""
 }
 
-func (x *WorkspaceInfo) GetTaskCount() int32 {
-	if x != nil {
-		return x.TaskCount
-	}
-	return 0
-}
-
-func (x *WorkspaceInfo) GetRunningCount() int32 {
+func (x *HeartbeatMessage) GetContent() string {
 	if x != nil {
-		return x.RunningCount
+		return x.Content
 	}
-	return 0
+	return ""
 }
 
-func (x *WorkspaceInfo) GetCreatedAt() string {
+func (x *HeartbeatMessage) GetToolCalls() []string {
 	if x != nil {
-		return x.CreatedAt
+		return x.ToolCalls
 	}
-	return ""
+	return nil
 }
 
-type CreateWorkspaceRequest struct {
+type HeartbeatRecord struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Goal          string                 `protobuf:"bytes,3,opt,name=goal,proto3" json:"goal,omitempty"`
-	MaxConcurrent int32                  `protobuf:"varint,4,opt,name=max_concurrent,json=maxConcurrent,proto3" json:"max_concurrent,omitempty"`
-	Context       string                 `protobuf:"bytes,5,opt,name=context,proto3" json:"context,omitempty"`
-	WorkDir       string                 `protobuf:"bytes,6,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Timestamp     string                 `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // ok, max_rounds, error
+	Decision      string                 `protobuf:"bytes,4,opt,name=decision,proto3" json:"decision,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	ActionsTaken  int32                  `protobuf:"varint,6,opt,name=actions_taken,json=actionsTaken,proto3" json:"actions_taken,omitempty"`
+	Rounds        int32                  `protobuf:"varint,7,opt,name=rounds,proto3" json:"rounds,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,8,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Transcript    []*HeartbeatMessage    `protobuf:"bytes,9,rep,name=transcript,proto3" json:"transcript,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateWorkspaceRequest) Reset() {
-	*x = CreateWorkspaceRequest{}
-	mi := &file_proto_kele_proto_msgTypes[14]
+func (x *HeartbeatRecord) Reset() {
+	*x = HeartbeatRecord{}
+	mi := &file_proto_kele_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateWorkspaceRequest) String() string {
+func (x *HeartbeatRecord) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateWorkspaceRequest) ProtoMessage() {}
+func (*HeartbeatRecord) ProtoMessage() {}
 
-func (x *CreateWorkspaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[14]
+func (x *HeartbeatRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -955,75 +970,96 @@ func (x *CreateWorkspaceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateWorkspaceRequest.ProtoReflect.Descriptor instead.
-func (*CreateWorkspaceRequest) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use HeartbeatRecord.ProtoReflect.Descriptor instead.
+func (*HeartbeatRecord) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *CreateWorkspaceRequest) GetName() string {
+func (x *HeartbeatRecord) GetId() int64 {
 	if x != nil {
-		return x.Name
+		return x.Id
+	}
+	return 0
+}
+
+func (x *HeartbeatRecord) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
 	}
 	return ""
 }
 
-func (x *CreateWorkspaceRequest) GetDescription() string {
+func (x *HeartbeatRecord) GetStatus() string {
 	if x != nil {
-		return x.Description
+		return x.Status
 	}
 	return ""
 }
 
-func (x *CreateWorkspaceRequest) GetGoal() string {
+func (x *HeartbeatRecord) GetDecision() string {
 	if x != nil {
-		return x.Goal
+		return x.Decision
 	}
 	return ""
 }
 
-func (x *CreateWorkspaceRequest) GetMaxConcurrent() int32 {
+func (x *HeartbeatRecord) GetError() string {
 	if x != nil {
-		return x.MaxConcurrent
+		return x.Error
+	}
+	return ""
+}
+
+func (x *HeartbeatRecord) GetActionsTaken() int32 {
+	if x != nil {
+		return x.ActionsTaken
 	}
 	return 0
 }
 
-func (x *CreateWorkspaceRequest) GetContext() string {
+func (x *HeartbeatRecord) GetRounds() int32 {
 	if x != nil {
-		return x.Context
+		return x.Rounds
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateWorkspaceRequest) GetWorkDir() string {
+func (x *HeartbeatRecord) GetDurationMs() int64 {
 	if x != nil {
-		return x.WorkDir
+		return x.DurationMs
 	}
-	return ""
+	return 0
 }
 
-type GetWorkspaceRequest struct {
+func (x *HeartbeatRecord) GetTranscript() []*HeartbeatMessage {
+	if x != nil {
+		return x.Transcript
+	}
+	return nil
+}
+
+type GetHeartbeatHistoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Records       []*HeartbeatRecord     `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetWorkspaceRequest) Reset() {
-	*x = GetWorkspaceRequest{}
-	mi := &file_proto_kele_proto_msgTypes[15]
+func (x *GetHeartbeatHistoryResponse) Reset() {
+	*x = GetHeartbeatHistoryResponse{}
+	mi := &file_proto_kele_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetWorkspaceRequest) String() string {
+func (x *GetHeartbeatHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetWorkspaceRequest) ProtoMessage() {}
+func (*GetHeartbeatHistoryResponse) ProtoMessage() {}
 
-func (x *GetWorkspaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[15]
+func (x *GetHeartbeatHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1034,45 +1070,40 @@ func (x *GetWorkspaceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetWorkspaceRequest.ProtoReflect.Descriptor instead.
-func (*GetWorkspaceRequest) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use GetHeartbeatHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetHeartbeatHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *GetWorkspaceRequest) GetId() string {
+func (x *GetHeartbeatHistoryResponse) GetRecords() []*HeartbeatRecord {
 	if x != nil {
-		return x.Id
+		return x.Records
 	}
-	return ""
+	return nil
 }
 
-type UpdateWorkspaceRequest struct {
+type ReindexMemoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	MaxConcurrent int32                  `protobuf:"varint,4,opt,name=max_concurrent,json=maxConcurrent,proto3" json:"max_concurrent,omitempty"`
-	Context       string                 `protobuf:"bytes,5,opt,name=context,proto3" json:"context,omitempty"`
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	All           bool                   `protobuf:"varint,1,opt,name=all,proto3" json:"all,omitempty"` // re-embed every memory, not only missing or stale vectors
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateWorkspaceRequest) Reset() {
-	*x = UpdateWorkspaceRequest{}
-	mi := &file_proto_kele_proto_msgTypes[16]
+func (x *ReindexMemoryRequest) Reset() {
+	*x = ReindexMemoryRequest{}
+	mi := &file_proto_kele_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateWorkspaceRequest) String() string {
+func (x *ReindexMemoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateWorkspaceRequest) ProtoMessage() {}
+func (*ReindexMemoryRequest) ProtoMessage() {}
 
-func (x *UpdateWorkspaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[16]
+func (x *ReindexMemoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1083,75 +1114,105 @@ func (x *UpdateWorkspaceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateWorkspaceRequest.ProtoReflect.Descriptor instead.
-func (*UpdateWorkspaceRequest) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use ReindexMemoryRequest.ProtoReflect.Descriptor instead.
+func (*ReindexMemoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *UpdateWorkspaceRequest) GetId() string {
+func (x *ReindexMemoryRequest) GetAll() bool {
 	if x != nil {
-		return x.Id
+		return x.All
 	}
-	return ""
+	return false
 }
 
-func (x *UpdateWorkspaceRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+type ReindexMemoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Indexed       int32                  `protobuf:"varint,1,opt,name=indexed,proto3" json:"indexed,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Embedder      string                 `protobuf:"bytes,3,opt,name=embedder,proto3" json:"embedder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateWorkspaceRequest) GetDescription() string {
+func (x *ReindexMemoryResponse) Reset() {
+	*x = ReindexMemoryResponse{}
+	mi := &file_proto_kele_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReindexMemoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexMemoryResponse) ProtoMessage() {}
+
+func (x *ReindexMemoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[18]
 	if x != nil {
-		return x.Description
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *UpdateWorkspaceRequest) GetMaxConcurrent() int32 {
+// Deprecated: Use ReindexMemoryResponse.ProtoReflect.Descriptor instead.
+func (*ReindexMemoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReindexMemoryResponse) GetIndexed() int32 {
 	if x != nil {
-		return x.MaxConcurrent
+		return x.Indexed
 	}
 	return 0
 }
 
-func (x *UpdateWorkspaceRequest) GetContext() string {
+func (x *ReindexMemoryResponse) GetTotal() int32 {
 	if x != nil {
-		return x.Context
+		return x.Total
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateWorkspaceRequest) GetStatus() string {
+func (x *ReindexMemoryResponse) GetEmbedder() string {
 	if x != nil {
-		return x.Status
+		return x.Embedder
 	}
 	return ""
 }
 
-type DeleteWorkspaceRequest struct {
+type SearchHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Session       string                 `protobuf:"bytes,2,opt,name=session,proto3" json:"session,omitempty"` // session ID or name
+	Channel       string                 `protobuf:"bytes,3,opt,name=channel,proto3" json:"channel,omitempty"` // cli, telegram, task
+	Since         string                 `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"`     // RFC 3339; only messages at or after this time
+	Until         string                 `protobuf:"bytes,5,opt,name=until,proto3" json:"until,omitempty"`     // RFC 3339; only messages before this time
+	Limit         int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`    // max messages, default 20
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteWorkspaceRequest) Reset() {
-	*x = DeleteWorkspaceRequest{}
-	mi := &file_proto_kele_proto_msgTypes[17]
+func (x *SearchHistoryRequest) Reset() {
+	*x = SearchHistoryRequest{}
+	mi := &file_proto_kele_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteWorkspaceRequest) String() string {
+func (x *SearchHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteWorkspaceRequest) ProtoMessage() {}
+func (*SearchHistoryRequest) ProtoMessage() {}
 
-func (x *DeleteWorkspaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[17]
+func (x *SearchHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1162,40 +1223,83 @@ func (x *DeleteWorkspaceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteWorkspaceRequest.ProtoReflect.Descriptor instead.
-func (*DeleteWorkspaceRequest) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use SearchHistoryRequest.ProtoReflect.Descriptor instead.
+func (*SearchHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *DeleteWorkspaceRequest) GetId() string {
+func (x *SearchHistoryRequest) GetQuery() string {
 	if x != nil {
-		return x.Id
+		return x.Query
 	}
 	return ""
 }
 
-type ListWorkspacesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Workspaces    []*WorkspaceInfo       `protobuf:"bytes,1,rep,name=workspaces,proto3" json:"workspaces,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListWorkspacesResponse) Reset() {
-	*x = ListWorkspacesResponse{}
-	mi := &file_proto_kele_proto_msgTypes[18]
+func (x *SearchHistoryRequest) GetSession() string {
+	if x != nil {
+		return x.Session
+	}
+	return ""
+}
+
+func (x *SearchHistoryRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *SearchHistoryRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *SearchHistoryRequest) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *SearchHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// HistoryHit snippets wrap the matched text in « and ».
+type HistoryHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TurnId        int64                  `protobuf:"varint,2,opt,name=turn_id,json=turnId,proto3" json:"turn_id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	SessionName   string                 `protobuf:"bytes,4,opt,name=session_name,json=sessionName,proto3" json:"session_name,omitempty"`
+	Channel       string                 `protobuf:"bytes,5,opt,name=channel,proto3" json:"channel,omitempty"`
+	Role          string                 `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
+	Snippet       string                 `protobuf:"bytes,7,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	Timestamp     string                 `protobuf:"bytes,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryHit) Reset() {
+	*x = HistoryHit{}
+	mi := &file_proto_kele_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListWorkspacesResponse) String() string {
+func (x *HistoryHit) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListWorkspacesResponse) ProtoMessage() {}
+func (*HistoryHit) ProtoMessage() {}
 
-func (x *ListWorkspacesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[18]
+func (x *HistoryHit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1206,56 +1310,153 @@ func (x *ListWorkspacesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListWorkspacesResponse.ProtoReflect.Descriptor instead.
-func (*ListWorkspacesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use HistoryHit.ProtoReflect.Descriptor instead.
+func (*HistoryHit) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *ListWorkspacesResponse) GetWorkspaces() []*WorkspaceInfo {
+func (x *HistoryHit) GetId() int64 {
 	if x != nil {
-		return x.Workspaces
+		return x.Id
+	}
+	return 0
+}
+
+func (x *HistoryHit) GetTurnId() int64 {
+	if x != nil {
+		return x.TurnId
+	}
+	return 0
+}
+
+func (x *HistoryHit) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *HistoryHit) GetSessionName() string {
+	if x != nil {
+		return x.SessionName
+	}
+	return ""
+}
+
+func (x *HistoryHit) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *HistoryHit) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *HistoryHit) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *HistoryHit) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+type SearchHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hits          []*HistoryHit          `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHistoryResponse) Reset() {
+	*x = SearchHistoryResponse{}
+	mi := &file_proto_kele_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHistoryResponse) ProtoMessage() {}
+
+func (x *SearchHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHistoryResponse.ProtoReflect.Descriptor instead.
+func (*SearchHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SearchHistoryResponse) GetHits() []*HistoryHit {
+	if x != nil {
+		return x.Hits
 	}
 	return nil
 }
 
-type TaskInfo struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	WorkspaceId     string                 `protobuf:"bytes,2,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
-	Title           string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	Description     string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Prompt          string                 `protobuf:"bytes,5,opt,name=prompt,proto3" json:"prompt,omitempty"`
-	Status          string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
-	Priority        int32                  `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"`
-	DependsOn       []string               `protobuf:"bytes,8,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
-	AssignedSession string                 `protobuf:"bytes,9,opt,name=assigned_session,json=assignedSession,proto3" json:"assigned_session,omitempty"`
-	Result          string                 `protobuf:"bytes,10,opt,name=result,proto3" json:"result,omitempty"`
-	Error           string                 `protobuf:"bytes,11,opt,name=error,proto3" json:"error,omitempty"`
-	RetryCount      int32                  `protobuf:"varint,12,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
-	MaxRetries      int32                  `protobuf:"varint,13,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
-	Tags            []string               `protobuf:"bytes,14,rep,name=tags,proto3" json:"tags,omitempty"`
-	CreatedAt       string                 `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	StartedAt       string                 `protobuf:"bytes,16,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
-	CompletedAt     string                 `protobuf:"bytes,17,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type CronJobInfo struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Schedule       string                 `protobuf:"bytes,3,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Type           string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"` // command or agent
+	Command        string                 `protobuf:"bytes,5,opt,name=command,proto3" json:"command,omitempty"`
+	Prompt         string                 `protobuf:"bytes,6,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	Model          string                 `protobuf:"bytes,7,opt,name=model,proto3" json:"model,omitempty"`
+	Tools          []string               `protobuf:"bytes,8,rep,name=tools,proto3" json:"tools,omitempty"`
+	WorkDir        string                 `protobuf:"bytes,9,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	DeliverChannel string                 `protobuf:"bytes,10,opt,name=deliver_channel,json=deliverChannel,proto3" json:"deliver_channel,omitempty"`
+	DeliverTarget  string                 `protobuf:"bytes,11,opt,name=deliver_target,json=deliverTarget,proto3" json:"deliver_target,omitempty"`
+	DeliverWhen    string                 `protobuf:"bytes,12,opt,name=deliver_when,json=deliverWhen,proto3" json:"deliver_when,omitempty"` // always, failure, non_empty
+	Timezone       string                 `protobuf:"bytes,13,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Overlap        string                 `protobuf:"bytes,14,opt,name=overlap,proto3" json:"overlap,omitempty"`                // skip, queue, allow
+	CatchUp        string                 `protobuf:"bytes,15,opt,name=catch_up,json=catchUp,proto3" json:"catch_up,omitempty"` // none, once, all; empty = daemon default
+	Enabled        bool                   `protobuf:"varint,16,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	LastRun        string                 `protobuf:"bytes,17,opt,name=last_run,json=lastRun,proto3" json:"last_run,omitempty"`
+	NextRun        string                 `protobuf:"bytes,18,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"`
+	LastResult     string                 `protobuf:"bytes,19,opt,name=last_result,json=lastResult,proto3" json:"last_result,omitempty"`
+	LastError      string                 `protobuf:"bytes,20,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,21,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *TaskInfo) Reset() {
-	*x = TaskInfo{}
-	mi := &file_proto_kele_proto_msgTypes[19]
+func (x *CronJobInfo) Reset() {
+	*x = CronJobInfo{}
+	mi := &file_proto_kele_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TaskInfo) String() string {
+func (x *CronJobInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskInfo) ProtoMessage() {}
+func (*CronJobInfo) ProtoMessage() {}
 
-func (x *TaskInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[19]
+func (x *CronJobInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1266,160 +1467,3801 @@ func (x *TaskInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskInfo.ProtoReflect.Descriptor instead.
-func (*TaskInfo) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use CronJobInfo.ProtoReflect.Descriptor instead.
+func (*CronJobInfo) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *TaskInfo) GetId() string {
+func (x *CronJobInfo) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *TaskInfo) GetWorkspaceId() string {
+func (x *CronJobInfo) GetName() string {
 	if x != nil {
-		return x.WorkspaceId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *TaskInfo) GetTitle() string {
+func (x *CronJobInfo) GetSchedule() string {
 	if x != nil {
-		return x.Title
+		return x.Schedule
 	}
 	return ""
 }
 
-func (x *TaskInfo) GetDescription() string {
+func (x *CronJobInfo) GetType() string {
 	if x != nil {
-		return x.Description
+		return x.Type
 	}
 	return ""
 }
 
-func (x *TaskInfo) GetPrompt() string {
+func (x *CronJobInfo) GetCommand() string {
 	if x != nil {
-		return x.Prompt
+		return x.Command
 	}
 	return ""
 }
 
-func (x *TaskInfo) GetStatus() string {
+func (x *CronJobInfo) GetPrompt() string {
 	if x != nil {
-		return x.Status
+		return x.Prompt
 	}
 	return ""
 }
 
-func (x *TaskInfo) GetPriority() int32 {
+func (x *CronJobInfo) GetModel() string {
 	if x != nil {
-		return x.Priority
+		return x.Model
 	}
-	return 0
+	return ""
 }
 
-func (x *TaskInfo) GetDependsOn() []string {
+func (x *CronJobInfo) GetTools() []string {
 	if x != nil {
-		return x.DependsOn
+		return x.Tools
 	}
 	return nil
 }
 
-func (x *TaskInfo) GetAssignedSession() string {
+func (x *CronJobInfo) GetWorkDir() string {
 	if x != nil {
-		return x.AssignedSession
+		return x.WorkDir
 	}
 	return ""
 }
 
-func (x *TaskInfo) GetResult() string {
+func (x *CronJobInfo) GetDeliverChannel() string {
 	if x != nil {
-		return x.Result
+		return x.DeliverChannel
 	}
 	return ""
 }
 
-func (x *TaskInfo) GetError() string {
+func (x *CronJobInfo) GetDeliverTarget() string {
 	if x != nil {
-		return x.Error
+		return x.DeliverTarget
 	}
 	return ""
 }
 
-func (x *TaskInfo) GetRetryCount() int32 {
+func (x *CronJobInfo) GetDeliverWhen() string {
 	if x != nil {
-		return x.RetryCount
+		return x.DeliverWhen
 	}
-	return 0
+	return ""
 }
 
-func (x *TaskInfo) GetMaxRetries() int32 {
+func (x *CronJobInfo) GetTimezone() string {
 	if x != nil {
-		return x.MaxRetries
+		return x.Timezone
 	}
-	return 0
+	return ""
 }
 
-func (x *TaskInfo) GetTags() []string {
+func (x *CronJobInfo) GetOverlap() string {
 	if x != nil {
-		return x.Tags
+		return x.Overlap
 	}
-	return nil
+	return ""
 }
 
-func (x *TaskInfo) GetCreatedAt() string {
+func (x *CronJobInfo) GetCatchUp() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.CatchUp
 	}
 	return ""
 }
 
-func (x *TaskInfo) GetStartedAt() string {
+func (x *CronJobInfo) GetEnabled() bool {
 	if x != nil {
-		return x.StartedAt
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *CronJobInfo) GetLastRun() string {
+	if x != nil {
+		return x.LastRun
 	}
 	return ""
 }
 
-func (x *TaskInfo) GetCompletedAt() string {
+func (x *CronJobInfo) GetNextRun() string {
 	if x != nil {
-		return x.CompletedAt
+		return x.NextRun
 	}
 	return ""
 }
 
-type CreateTaskRequest struct {
+func (x *CronJobInfo) GetLastResult() string {
+	if x != nil {
+		return x.LastResult
+	}
+	return ""
+}
+
+func (x *CronJobInfo) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *CronJobInfo) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ListCronJobsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Prompt        string                 `protobuf:"bytes,4,opt,name=prompt,proto3" json:"prompt,omitempty"`
-	Priority      int32                  `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
-	DependsOn     []string               `protobuf:"bytes,6,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
-	MaxRetries    int32                  `protobuf:"varint,7,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
-	Tags          []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
-	AutoReady     bool                   `protobuf:"varint,9,opt,name=auto_ready,json=autoReady,proto3" json:"auto_ready,omitempty"`
+	Jobs          []*CronJobInfo         `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTaskRequest) Reset() {
-	*x = CreateTaskRequest{}
-	mi := &file_proto_kele_proto_msgTypes[20]
+func (x *ListCronJobsResponse) Reset() {
+	*x = ListCronJobsResponse{}
+	mi := &file_proto_kele_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCronJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCronJobsResponse) ProtoMessage() {}
+
+func (x *ListCronJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCronJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListCronJobsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListCronJobsResponse) GetJobs() []*CronJobInfo {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+type CronJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CronJobRequest) Reset() {
+	*x = CronJobRequest{}
+	mi := &file_proto_kele_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CronJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CronJobRequest) ProtoMessage() {}
+
+func (x *CronJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CronJobRequest.ProtoReflect.Descriptor instead.
+func (*CronJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CronJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateCronJobRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Schedule       string                 `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"` // 5/6-field cron expression, @daily, @every 30m ...
+	Type           string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`         // command (default) or agent
+	Command        string                 `protobuf:"bytes,4,opt,name=command,proto3" json:"command,omitempty"`
+	Prompt         string                 `protobuf:"bytes,5,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	Model          string                 `protobuf:"bytes,6,opt,name=model,proto3" json:"model,omitempty"`
+	Tools          []string               `protobuf:"bytes,7,rep,name=tools,proto3" json:"tools,omitempty"`
+	WorkDir        string                 `protobuf:"bytes,8,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	DeliverChannel string                 `protobuf:"bytes,9,opt,name=deliver_channel,json=deliverChannel,proto3" json:"deliver_channel,omitempty"` // e.g. telegram; empty = no delivery
+	DeliverTarget  string                 `protobuf:"bytes,10,opt,name=deliver_target,json=deliverTarget,proto3" json:"deliver_target,omitempty"`
+	DeliverWhen    string                 `protobuf:"bytes,11,opt,name=deliver_when,json=deliverWhen,proto3" json:"deliver_when,omitempty"`
+	Timezone       string                 `protobuf:"bytes,12,opt,name=timezone,proto3" json:"timezone,omitempty"` // IANA name; empty = daemon local time
+	Overlap        string                 `protobuf:"bytes,13,opt,name=overlap,proto3" json:"overlap,omitempty"`
+	CatchUp        string                 `protobuf:"bytes,14,opt,name=catch_up,json=catchUp,proto3" json:"catch_up,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateCronJobRequest) Reset() {
+	*x = CreateCronJobRequest{}
+	mi := &file_proto_kele_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCronJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCronJobRequest) ProtoMessage() {}
+
+func (x *CreateCronJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCronJobRequest.ProtoReflect.Descriptor instead.
+func (*CreateCronJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreateCronJobRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCronJobRequest) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *CreateCronJobRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateCronJobRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *CreateCronJobRequest) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *CreateCronJobRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *CreateCronJobRequest) GetTools() []string {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *CreateCronJobRequest) GetWorkDir() string {
+	if x != nil {
+		return x.WorkDir
+	}
+	return ""
+}
+
+func (x *CreateCronJobRequest) GetDeliverChannel() string {
+	if x != nil {
+		return x.DeliverChannel
+	}
+	return ""
+}
+
+func (x *CreateCronJobRequest) GetDeliverTarget() string {
+	if x != nil {
+		return x.DeliverTarget
+	}
+	return ""
+}
+
+func (x *CreateCronJobRequest) GetDeliverWhen() string {
+	if x != nil {
+		return x.DeliverWhen
+	}
+	return ""
+}
+
+func (x *CreateCronJobRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *CreateCronJobRequest) GetOverlap() string {
+	if x != nil {
+		return x.Overlap
+	}
+	return ""
+}
+
+func (x *CreateCronJobRequest) GetCatchUp() string {
+	if x != nil {
+		return x.CatchUp
+	}
+	return ""
+}
+
+// UpdateCronJobRequest changes only the fields that are set.
+type UpdateCronJobRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Schedule       *string                `protobuf:"bytes,3,opt,name=schedule,proto3,oneof" json:"schedule,omitempty"`
+	Type           *string                `protobuf:"bytes,4,opt,name=type,proto3,oneof" json:"type,omitempty"`
+	Command        *string                `protobuf:"bytes,5,opt,name=command,proto3,oneof" json:"command,omitempty"`
+	Prompt         *string                `protobuf:"bytes,6,opt,name=prompt,proto3,oneof" json:"prompt,omitempty"`
+	Model          *string                `protobuf:"bytes,7,opt,name=model,proto3,oneof" json:"model,omitempty"`
+	Tools          []string               `protobuf:"bytes,8,rep,name=tools,proto3" json:"tools,omitempty"`
+	SetTools       bool                   `protobuf:"varint,9,opt,name=set_tools,json=setTools,proto3" json:"set_tools,omitempty"` // replace tools (allows clearing it)
+	WorkDir        *string                `protobuf:"bytes,10,opt,name=work_dir,json=workDir,proto3,oneof" json:"work_dir,omitempty"`
+	DeliverChannel *string                `protobuf:"bytes,11,opt,name=deliver_channel,json=deliverChannel,proto3,oneof" json:"deliver_channel,omitempty"`
+	DeliverTarget  *string                `protobuf:"bytes,12,opt,name=deliver_target,json=deliverTarget,proto3,oneof" json:"deliver_target,omitempty"`
+	DeliverWhen    *string                `protobuf:"bytes,13,opt,name=deliver_when,json=deliverWhen,proto3,oneof" json:"deliver_when,omitempty"`
+	Timezone       *string                `protobuf:"bytes,14,opt,name=timezone,proto3,oneof" json:"timezone,omitempty"`
+	Overlap        *string                `protobuf:"bytes,15,opt,name=overlap,proto3,oneof" json:"overlap,omitempty"`
+	CatchUp        *string                `protobuf:"bytes,16,opt,name=catch_up,json=catchUp,proto3,oneof" json:"catch_up,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateCronJobRequest) Reset() {
+	*x = UpdateCronJobRequest{}
+	mi := &file_proto_kele_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCronJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCronJobRequest) ProtoMessage() {}
+
+func (x *UpdateCronJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCronJobRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCronJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateCronJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateCronJobRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateCronJobRequest) GetSchedule() string {
+	if x != nil && x.Schedule != nil {
+		return *x.Schedule
+	}
+	return ""
+}
+
+func (x *UpdateCronJobRequest) GetType() string {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ""
+}
+
+func (x *UpdateCronJobRequest) GetCommand() string {
+	if x != nil && x.Command != nil {
+		return *x.Command
+	}
+	return ""
+}
+
+func (x *UpdateCronJobRequest) GetPrompt() string {
+	if x != nil && x.Prompt != nil {
+		return *x.Prompt
+	}
+	return ""
+}
+
+func (x *UpdateCronJobRequest) GetModel() string {
+	if x != nil && x.Model != nil {
+		return *x.Model
+	}
+	return ""
+}
+
+func (x *UpdateCronJobRequest) GetTools() []string {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *UpdateCronJobRequest) GetSetTools() bool {
+	if x != nil {
+		return x.SetTools
+	}
+	return false
+}
+
+func (x *UpdateCronJobRequest) GetWorkDir() string {
+	if x != nil && x.WorkDir != nil {
+		return *x.WorkDir
+	}
+	return ""
+}
+
+func (x *UpdateCronJobRequest) GetDeliverChannel() string {
+	if x != nil && x.DeliverChannel != nil {
+		return *x.DeliverChannel
+	}
+	return ""
+}
+
+func (x *UpdateCronJobRequest) GetDeliverTarget() string {
+	if x != nil && x.DeliverTarget != nil {
+		return *x.DeliverTarget
+	}
+	return ""
+}
+
+func (x *UpdateCronJobRequest) GetDeliverWhen() string {
+	if x != nil && x.DeliverWhen != nil {
+		return *x.DeliverWhen
+	}
+	return ""
+}
+
+func (x *UpdateCronJobRequest) GetTimezone() string {
+	if x != nil && x.Timezone != nil {
+		return *x.Timezone
+	}
+	return ""
+}
+
+func (x *UpdateCronJobRequest) GetOverlap() string {
+	if x != nil && x.Overlap != nil {
+		return *x.Overlap
+	}
+	return ""
+}
+
+func (x *UpdateCronJobRequest) GetCatchUp() string {
+	if x != nil && x.CatchUp != nil {
+		return *x.CatchUp
+	}
+	return ""
+}
+
+type SetCronJobEnabledRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCronJobEnabledRequest) Reset() {
+	*x = SetCronJobEnabledRequest{}
+	mi := &file_proto_kele_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCronJobEnabledRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCronJobEnabledRequest) ProtoMessage() {}
+
+func (x *SetCronJobEnabledRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCronJobEnabledRequest.ProtoReflect.Descriptor instead.
+func (*SetCronJobEnabledRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SetCronJobEnabledRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SetCronJobEnabledRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type CronLogEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	RunAt         string                 `protobuf:"bytes,3,opt,name=run_at,json=runAt,proto3" json:"run_at,omitempty"`
+	Output        string                 `protobuf:"bytes,4,opt,name=output,proto3" json:"output,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,6,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CronLogEntry) Reset() {
+	*x = CronLogEntry{}
+	mi := &file_proto_kele_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CronLogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CronLogEntry) ProtoMessage() {}
+
+func (x *CronLogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CronLogEntry.ProtoReflect.Descriptor instead.
+func (*CronLogEntry) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CronLogEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CronLogEntry) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *CronLogEntry) GetRunAt() string {
+	if x != nil {
+		return x.RunAt
+	}
+	return ""
+}
+
+func (x *CronLogEntry) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *CronLogEntry) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CronLogEntry) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+type GetCronLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // default 20
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCronLogsRequest) Reset() {
+	*x = GetCronLogsRequest{}
+	mi := &file_proto_kele_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCronLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCronLogsRequest) ProtoMessage() {}
+
+func (x *GetCronLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCronLogsRequest.ProtoReflect.Descriptor instead.
+func (*GetCronLogsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetCronLogsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetCronLogsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetCronLogsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Logs          []*CronLogEntry        `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCronLogsResponse) Reset() {
+	*x = GetCronLogsResponse{}
+	mi := &file_proto_kele_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCronLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCronLogsResponse) ProtoMessage() {}
+
+func (x *GetCronLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCronLogsResponse.ProtoReflect.Descriptor instead.
+func (*GetCronLogsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetCronLogsResponse) GetLogs() []*CronLogEntry {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+type PreviewCronScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // preview a saved job; or give schedule and timezone
+	Schedule      string                 `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Timezone      string                 `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Count         int32                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"` // default 5, max 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewCronScheduleRequest) Reset() {
+	*x = PreviewCronScheduleRequest{}
+	mi := &file_proto_kele_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewCronScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewCronScheduleRequest) ProtoMessage() {}
+
+func (x *PreviewCronScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewCronScheduleRequest.ProtoReflect.Descriptor instead.
+func (*PreviewCronScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PreviewCronScheduleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PreviewCronScheduleRequest) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *PreviewCronScheduleRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *PreviewCronScheduleRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type PreviewCronScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Times         []string               `protobuf:"bytes,1,rep,name=times,proto3" json:"times,omitempty"` // RFC 3339, in the job's time zone
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewCronScheduleResponse) Reset() {
+	*x = PreviewCronScheduleResponse{}
+	mi := &file_proto_kele_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewCronScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewCronScheduleResponse) ProtoMessage() {}
+
+func (x *PreviewCronScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewCronScheduleResponse.ProtoReflect.Descriptor instead.
+func (*PreviewCronScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PreviewCronScheduleResponse) GetTimes() []string {
+	if x != nil {
+		return x.Times
+	}
+	return nil
+}
+
+type WorkspaceInfo struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name               string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description        string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Goal               string                 `protobuf:"bytes,4,opt,name=goal,proto3" json:"goal,omitempty"`
+	Status             string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	MaxConcurrent      int32                  `protobuf:"varint,6,opt,name=max_concurrent,json=maxConcurrent,proto3" json:"max_concurrent,omitempty"`
+	Context            string                 `protobuf:"bytes,7,opt,name=context,proto3" json:"context,omitempty"`
+	WorkDir            string                 `protobuf:"bytes,8,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	Summary            string                 `protobuf:"bytes,9,opt,name=summary,proto3" json:"summary,omitempty"`
+	TaskCount          int32                  `protobuf:"varint,10,opt,name=task_count,json=taskCount,proto3" json:"task_count,omitempty"`
+	RunningCount       int32                  `protobuf:"varint,11,opt,name=running_count,json=runningCount,proto3" json:"running_count,omitempty"`
+	CreatedAt          string                 `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	MergePolicy        string                 `protobuf:"bytes,13,opt,name=merge_policy,json=mergePolicy,proto3" json:"merge_policy,omitempty"`                         // manual, auto
+	TaskTimeoutSeconds int64                  `protobuf:"varint,14,opt,name=task_timeout_seconds,json=taskTimeoutSeconds,proto3" json:"task_timeout_seconds,omitempty"` // default limit per task run, 0 = none
+	ApprovalTags       []string               `protobuf:"bytes,15,rep,name=approval_tags,json=approvalTags,proto3" json:"approval_tags,omitempty"`                      // tasks with these tags wait for approval
+	Budget             *BudgetInfo            `protobuf:"bytes,16,opt,name=budget,proto3" json:"budget,omitempty"`
+	ScheduleId         string                 `protobuf:"bytes,17,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"` // schedule that created this workspace
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *WorkspaceInfo) Reset() {
+	*x = WorkspaceInfo{}
+	mi := &file_proto_kele_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceInfo) ProtoMessage() {}
+
+func (x *WorkspaceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceInfo.ProtoReflect.Descriptor instead.
+func (*WorkspaceInfo) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *WorkspaceInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WorkspaceInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkspaceInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *WorkspaceInfo) GetGoal() string {
+	if x != nil {
+		return x.Goal
+	}
+	return ""
+}
+
+func (x *WorkspaceInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WorkspaceInfo) GetMaxConcurrent() int32 {
+	if x != nil {
+		return x.MaxConcurrent
+	}
+	return 0
+}
+
+func (x *WorkspaceInfo) GetContext() string {
+	if x != nil {
+		return x.Context
+	}
+	return ""
+}
+
+func (x *WorkspaceInfo) GetWorkDir() string {
+	if x != nil {
+		return x.WorkDir
+	}
+	return ""
+}
+
+func (x *WorkspaceInfo) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *WorkspaceInfo) GetTaskCount() int32 {
+	if x != nil {
+		return x.TaskCount
+	}
+	return 0
+}
+
+func (x *WorkspaceInfo) GetRunningCount() int32 {
+	if x != nil {
+		return x.RunningCount
+	}
+	return 0
+}
+
+func (x *WorkspaceInfo) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *WorkspaceInfo) GetMergePolicy() string {
+	if x != nil {
+		return x.MergePolicy
+	}
+	return ""
+}
+
+func (x *WorkspaceInfo) GetTaskTimeoutSeconds() int64 {
+	if x != nil {
+		return x.TaskTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *WorkspaceInfo) GetApprovalTags() []string {
+	if x != nil {
+		return x.ApprovalTags
+	}
+	return nil
+}
+
+func (x *WorkspaceInfo) GetBudget() *BudgetInfo {
+	if x != nil {
+		return x.Budget
+	}
+	return nil
+}
+
+func (x *WorkspaceInfo) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+// BudgetInfo holds a workspace's limits (0 = unlimited) and what it has used.
+// Requests only read the limit fields.
+type BudgetInfo struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	MaxTokens          int64                  `protobuf:"varint,1,opt,name=max_tokens,json=maxTokens,proto3" json:"max_tokens,omitempty"`
+	MaxCost            float64                `protobuf:"fixed64,2,opt,name=max_cost,json=maxCost,proto3" json:"max_cost,omitempty"`                                   // USD, estimated with token_price
+	TokenPrice         float64                `protobuf:"fixed64,3,opt,name=token_price,json=tokenPrice,proto3" json:"token_price,omitempty"`                          // USD per million tokens
+	MaxDurationSeconds int64                  `protobuf:"varint,4,opt,name=max_duration_seconds,json=maxDurationSeconds,proto3" json:"max_duration_seconds,omitempty"` // wall-clock since the first task started
+	MaxToolCalls       int32                  `protobuf:"varint,5,opt,name=max_tool_calls,json=maxToolCalls,proto3" json:"max_tool_calls,omitempty"`                   // per task run
+	UsedTokens         int64                  `protobuf:"varint,6,opt,name=used_tokens,json=usedTokens,proto3" json:"used_tokens,omitempty"`
+	UsedToolCalls      int64                  `protobuf:"varint,7,opt,name=used_tool_calls,json=usedToolCalls,proto3" json:"used_tool_calls,omitempty"`
+	UsedCost           float64                `protobuf:"fixed64,8,opt,name=used_cost,json=usedCost,proto3" json:"used_cost,omitempty"`
+	ElapsedSeconds     int64                  `protobuf:"varint,9,opt,name=elapsed_seconds,json=elapsedSeconds,proto3" json:"elapsed_seconds,omitempty"`
+	Exceeded           string                 `protobuf:"bytes,10,opt,name=exceeded,proto3" json:"exceeded,omitempty"` // the exhausted limit, empty while within budget
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *BudgetInfo) Reset() {
+	*x = BudgetInfo{}
+	mi := &file_proto_kele_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BudgetInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BudgetInfo) ProtoMessage() {}
+
+func (x *BudgetInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BudgetInfo.ProtoReflect.Descriptor instead.
+func (*BudgetInfo) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *BudgetInfo) GetMaxTokens() int64 {
+	if x != nil {
+		return x.MaxTokens
+	}
+	return 0
+}
+
+func (x *BudgetInfo) GetMaxCost() float64 {
+	if x != nil {
+		return x.MaxCost
+	}
+	return 0
+}
+
+func (x *BudgetInfo) GetTokenPrice() float64 {
+	if x != nil {
+		return x.TokenPrice
+	}
+	return 0
+}
+
+func (x *BudgetInfo) GetMaxDurationSeconds() int64 {
+	if x != nil {
+		return x.MaxDurationSeconds
+	}
+	return 0
+}
+
+func (x *BudgetInfo) GetMaxToolCalls() int32 {
+	if x != nil {
+		return x.MaxToolCalls
+	}
+	return 0
+}
+
+func (x *BudgetInfo) GetUsedTokens() int64 {
+	if x != nil {
+		return x.UsedTokens
+	}
+	return 0
+}
+
+func (x *BudgetInfo) GetUsedToolCalls() int64 {
+	if x != nil {
+		return x.UsedToolCalls
+	}
+	return 0
+}
+
+func (x *BudgetInfo) GetUsedCost() float64 {
+	if x != nil {
+		return x.UsedCost
+	}
+	return 0
+}
+
+func (x *BudgetInfo) GetElapsedSeconds() int64 {
+	if x != nil {
+		return x.ElapsedSeconds
+	}
+	return 0
+}
+
+func (x *BudgetInfo) GetExceeded() string {
+	if x != nil {
+		return x.Exceeded
+	}
+	return ""
+}
+
+type CreateWorkspaceRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Name               string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description        string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Goal               string                 `protobuf:"bytes,3,opt,name=goal,proto3" json:"goal,omitempty"`
+	MaxConcurrent      int32                  `protobuf:"varint,4,opt,name=max_concurrent,json=maxConcurrent,proto3" json:"max_concurrent,omitempty"`
+	Context            string                 `protobuf:"bytes,5,opt,name=context,proto3" json:"context,omitempty"`
+	WorkDir            string                 `protobuf:"bytes,6,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	MergePolicy        string                 `protobuf:"bytes,7,opt,name=merge_policy,json=mergePolicy,proto3" json:"merge_policy,omitempty"`
+	TaskTimeoutSeconds int64                  `protobuf:"varint,8,opt,name=task_timeout_seconds,json=taskTimeoutSeconds,proto3" json:"task_timeout_seconds,omitempty"`
+	ApprovalTags       []string               `protobuf:"bytes,9,rep,name=approval_tags,json=approvalTags,proto3" json:"approval_tags,omitempty"`
+	Budget             *BudgetInfo            `protobuf:"bytes,10,opt,name=budget,proto3" json:"budget,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CreateWorkspaceRequest) Reset() {
+	*x = CreateWorkspaceRequest{}
+	mi := &file_proto_kele_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorkspaceRequest) ProtoMessage() {}
+
+func (x *CreateWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CreateWorkspaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateWorkspaceRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateWorkspaceRequest) GetGoal() string {
+	if x != nil {
+		return x.Goal
+	}
+	return ""
+}
+
+func (x *CreateWorkspaceRequest) GetMaxConcurrent() int32 {
+	if x != nil {
+		return x.MaxConcurrent
+	}
+	return 0
+}
+
+func (x *CreateWorkspaceRequest) GetContext() string {
+	if x != nil {
+		return x.Context
+	}
+	return ""
+}
+
+func (x *CreateWorkspaceRequest) GetWorkDir() string {
+	if x != nil {
+		return x.WorkDir
+	}
+	return ""
+}
+
+func (x *CreateWorkspaceRequest) GetMergePolicy() string {
+	if x != nil {
+		return x.MergePolicy
+	}
+	return ""
+}
+
+func (x *CreateWorkspaceRequest) GetTaskTimeoutSeconds() int64 {
+	if x != nil {
+		return x.TaskTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *CreateWorkspaceRequest) GetApprovalTags() []string {
+	if x != nil {
+		return x.ApprovalTags
+	}
+	return nil
+}
+
+func (x *CreateWorkspaceRequest) GetBudget() *BudgetInfo {
+	if x != nil {
+		return x.Budget
+	}
+	return nil
+}
+
+type GetWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWorkspaceRequest) Reset() {
+	*x = GetWorkspaceRequest{}
+	mi := &file_proto_kele_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWorkspaceRequest) ProtoMessage() {}
+
+func (x *GetWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*GetWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetWorkspaceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UpdateWorkspaceRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name               string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description        string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	MaxConcurrent      int32                  `protobuf:"varint,4,opt,name=max_concurrent,json=maxConcurrent,proto3" json:"max_concurrent,omitempty"`
+	Context            string                 `protobuf:"bytes,5,opt,name=context,proto3" json:"context,omitempty"`
+	Status             string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	MergePolicy        string                 `protobuf:"bytes,7,opt,name=merge_policy,json=mergePolicy,proto3" json:"merge_policy,omitempty"`
+	TaskTimeoutSeconds *int64                 `protobuf:"varint,8,opt,name=task_timeout_seconds,json=taskTimeoutSeconds,proto3,oneof" json:"task_timeout_seconds,omitempty"` // 0 removes the limit
+	ApprovalTags       []string               `protobuf:"bytes,9,rep,name=approval_tags,json=approvalTags,proto3" json:"approval_tags,omitempty"`
+	SetApprovalTags    bool                   `protobuf:"varint,10,opt,name=set_approval_tags,json=setApprovalTags,proto3" json:"set_approval_tags,omitempty"` // replace approval_tags (allows clearing it)
+	Budget             *BudgetInfo            `protobuf:"bytes,11,opt,name=budget,proto3" json:"budget,omitempty"`                                             // replaces the limits when set
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *UpdateWorkspaceRequest) Reset() {
+	*x = UpdateWorkspaceRequest{}
+	mi := &file_proto_kele_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWorkspaceRequest) ProtoMessage() {}
+
+func (x *UpdateWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UpdateWorkspaceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateWorkspaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateWorkspaceRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateWorkspaceRequest) GetMaxConcurrent() int32 {
+	if x != nil {
+		return x.MaxConcurrent
+	}
+	return 0
+}
+
+func (x *UpdateWorkspaceRequest) GetContext() string {
+	if x != nil {
+		return x.Context
+	}
+	return ""
+}
+
+func (x *UpdateWorkspaceRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateWorkspaceRequest) GetMergePolicy() string {
+	if x != nil {
+		return x.MergePolicy
+	}
+	return ""
+}
+
+func (x *UpdateWorkspaceRequest) GetTaskTimeoutSeconds() int64 {
+	if x != nil && x.TaskTimeoutSeconds != nil {
+		return *x.TaskTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *UpdateWorkspaceRequest) GetApprovalTags() []string {
+	if x != nil {
+		return x.ApprovalTags
+	}
+	return nil
+}
+
+func (x *UpdateWorkspaceRequest) GetSetApprovalTags() bool {
+	if x != nil {
+		return x.SetApprovalTags
+	}
+	return false
+}
+
+func (x *UpdateWorkspaceRequest) GetBudget() *BudgetInfo {
+	if x != nil {
+		return x.Budget
+	}
+	return nil
+}
+
+type DeleteWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWorkspaceRequest) Reset() {
+	*x = DeleteWorkspaceRequest{}
+	mi := &file_proto_kele_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWorkspaceRequest) ProtoMessage() {}
+
+func (x *DeleteWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DeleteWorkspaceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListWorkspacesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workspaces    []*WorkspaceInfo       `protobuf:"bytes,1,rep,name=workspaces,proto3" json:"workspaces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspacesResponse) Reset() {
+	*x = ListWorkspacesResponse{}
+	mi := &file_proto_kele_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspacesResponse) ProtoMessage() {}
+
+func (x *ListWorkspacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspacesResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkspacesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListWorkspacesResponse) GetWorkspaces() []*WorkspaceInfo {
+	if x != nil {
+		return x.Workspaces
+	}
+	return nil
+}
+
+type TaskInfo struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WorkspaceId      string                 `protobuf:"bytes,2,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Title            string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description      string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Prompt           string                 `protobuf:"bytes,5,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	Status           string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Priority         int32                  `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	DependsOn        []string               `protobuf:"bytes,8,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	AssignedSession  string                 `protobuf:"bytes,9,opt,name=assigned_session,json=assignedSession,proto3" json:"assigned_session,omitempty"`
+	Result           string                 `protobuf:"bytes,10,opt,name=result,proto3" json:"result,omitempty"`
+	Error            string                 `protobuf:"bytes,11,opt,name=error,proto3" json:"error,omitempty"`
+	RetryCount       int32                  `protobuf:"varint,12,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
+	MaxRetries       int32                  `protobuf:"varint,13,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
+	Tags             []string               `protobuf:"bytes,14,rep,name=tags,proto3" json:"tags,omitempty"`
+	CreatedAt        string                 `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt        string                 `protobuf:"bytes,16,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt      string                 `protobuf:"bytes,17,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	Branch           string                 `protobuf:"bytes,18,opt,name=branch,proto3" json:"branch,omitempty"` // git branch the task ran on (worktree mode)
+	Diff             string                 `protobuf:"bytes,19,opt,name=diff,proto3" json:"diff,omitempty"`
+	MergeStatus      string                 `protobuf:"bytes,20,opt,name=merge_status,json=mergeStatus,proto3" json:"merge_status,omitempty"` // pending, merged, failed
+	VerifyCommand    string                 `protobuf:"bytes,21,opt,name=verify_command,json=verifyCommand,proto3" json:"verify_command,omitempty"`
+	VerifyPrompt     string                 `protobuf:"bytes,22,opt,name=verify_prompt,json=verifyPrompt,proto3" json:"verify_prompt,omitempty"`
+	Verdict          string                 `protobuf:"bytes,23,opt,name=verdict,proto3" json:"verdict,omitempty"` // passed, failed
+	VerifyNotes      string                 `protobuf:"bytes,24,opt,name=verify_notes,json=verifyNotes,proto3" json:"verify_notes,omitempty"`
+	BlockedReason    string                 `protobuf:"bytes,25,opt,name=blocked_reason,json=blockedReason,proto3" json:"blocked_reason,omitempty"`
+	TimeoutSeconds   int64                  `protobuf:"varint,26,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"` // 0 = workspace default
+	RequiresApproval bool                   `protobuf:"varint,27,opt,name=requires_approval,json=requiresApproval,proto3" json:"requires_approval,omitempty"`
+	ReviewFeedback   string                 `protobuf:"bytes,28,opt,name=review_feedback,json=reviewFeedback,proto3" json:"review_feedback,omitempty"` // feedback from the last rejection
+	Model            string                 `protobuf:"bytes,29,opt,name=model,proto3" json:"model,omitempty"`                                         // model name or tier (small/large); empty = daemon model
+	Temperature      *float64               `protobuf:"fixed64,30,opt,name=temperature,proto3,oneof" json:"temperature,omitempty"`
+	Tools            []string               `protobuf:"bytes,31,rep,name=tools,proto3" json:"tools,omitempty"`                                                // tool whitelist; empty = all tools
+	MaxToolRounds    int32                  `protobuf:"varint,32,opt,name=max_tool_rounds,json=maxToolRounds,proto3" json:"max_tool_rounds,omitempty"`        // 0 = configured limit
+	Artifacts        []string               `protobuf:"bytes,33,rep,name=artifacts,proto3" json:"artifacts,omitempty"`                                        // declared artifact globs, relative to the work dir
+	NextAttemptAt    string                 `protobuf:"bytes,34,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`         // a retry does not start before this time
+	ErrorClass       string                 `protobuf:"bytes,35,opt,name=error_class,json=errorClass,proto3" json:"error_class,omitempty"`                    // transient, task
+	TransientRetries int32                  `protobuf:"varint,36,opt,name=transient_retries,json=transientRetries,proto3" json:"transient_retries,omitempty"` // retries after transient errors, not counted in retry_count
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TaskInfo) Reset() {
+	*x = TaskInfo{}
+	mi := &file_proto_kele_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskInfo) ProtoMessage() {}
+
+func (x *TaskInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskInfo.ProtoReflect.Descriptor instead.
+func (*TaskInfo) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *TaskInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *TaskInfo) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *TaskInfo) GetAssignedSession() string {
+	if x != nil {
+		return x.AssignedSession
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetRetryCount() int32 {
+	if x != nil {
+		return x.RetryCount
+	}
+	return 0
+}
+
+func (x *TaskInfo) GetMaxRetries() int32 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+func (x *TaskInfo) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TaskInfo) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetCompletedAt() string {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetDiff() string {
+	if x != nil {
+		return x.Diff
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetMergeStatus() string {
+	if x != nil {
+		return x.MergeStatus
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetVerifyCommand() string {
+	if x != nil {
+		return x.VerifyCommand
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetVerifyPrompt() string {
+	if x != nil {
+		return x.VerifyPrompt
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetVerdict() string {
+	if x != nil {
+		return x.Verdict
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetVerifyNotes() string {
+	if x != nil {
+		return x.VerifyNotes
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetBlockedReason() string {
+	if x != nil {
+		return x.BlockedReason
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetTimeoutSeconds() int64 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *TaskInfo) GetRequiresApproval() bool {
+	if x != nil {
+		return x.RequiresApproval
+	}
+	return false
+}
+
+func (x *TaskInfo) GetReviewFeedback() string {
+	if x != nil {
+		return x.ReviewFeedback
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetTemperature() float64 {
+	if x != nil && x.Temperature != nil {
+		return *x.Temperature
+	}
+	return 0
+}
+
+func (x *TaskInfo) GetTools() []string {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *TaskInfo) GetMaxToolRounds() int32 {
+	if x != nil {
+		return x.MaxToolRounds
+	}
+	return 0
+}
+
+func (x *TaskInfo) GetArtifacts() []string {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *TaskInfo) GetNextAttemptAt() string {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetErrorClass() string {
+	if x != nil {
+		return x.ErrorClass
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetTransientRetries() int32 {
+	if x != nil {
+		return x.TransientRetries
+	}
+	return 0
+}
+
+type CreateTaskRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId      string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Title            string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description      string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Prompt           string                 `protobuf:"bytes,4,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	Priority         int32                  `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	DependsOn        []string               `protobuf:"bytes,6,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	MaxRetries       int32                  `protobuf:"varint,7,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
+	Tags             []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	AutoReady        bool                   `protobuf:"varint,9,opt,name=auto_ready,json=autoReady,proto3" json:"auto_ready,omitempty"`
+	VerifyCommand    string                 `protobuf:"bytes,10,opt,name=verify_command,json=verifyCommand,proto3" json:"verify_command,omitempty"`
+	VerifyPrompt     string                 `protobuf:"bytes,11,opt,name=verify_prompt,json=verifyPrompt,proto3" json:"verify_prompt,omitempty"`
+	TimeoutSeconds   int64                  `protobuf:"varint,12,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	RequiresApproval bool                   `protobuf:"varint,13,opt,name=requires_approval,json=requiresApproval,proto3" json:"requires_approval,omitempty"`
+	Model            string                 `protobuf:"bytes,14,opt,name=model,proto3" json:"model,omitempty"`
+	Temperature      *float64               `protobuf:"fixed64,15,opt,name=temperature,proto3,oneof" json:"temperature,omitempty"`
+	Tools            []string               `protobuf:"bytes,16,rep,name=tools,proto3" json:"tools,omitempty"`
+	MaxToolRounds    int32                  `protobuf:"varint,17,opt,name=max_tool_rounds,json=maxToolRounds,proto3" json:"max_tool_rounds,omitempty"`
+	Artifacts        []string               `protobuf:"bytes,18,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
+	mi := &file_proto_kele_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskRequest) ProtoMessage() {}
+
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CreateTaskRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *CreateTaskRequest) GetMaxRetries() int32 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateTaskRequest) GetAutoReady() bool {
+	if x != nil {
+		return x.AutoReady
+	}
+	return false
+}
+
+func (x *CreateTaskRequest) GetVerifyCommand() string {
+	if x != nil {
+		return x.VerifyCommand
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetVerifyPrompt() string {
+	if x != nil {
+		return x.VerifyPrompt
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetTimeoutSeconds() int64 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetRequiresApproval() bool {
+	if x != nil {
+		return x.RequiresApproval
+	}
+	return false
+}
+
+func (x *CreateTaskRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetTemperature() float64 {
+	if x != nil && x.Temperature != nil {
+		return *x.Temperature
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetTools() []string {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *CreateTaskRequest) GetMaxToolRounds() int32 {
+	if x != nil {
+		return x.MaxToolRounds
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetArtifacts() []string {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_proto_kele_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UpdateTaskRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title            string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description      string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Prompt           string                 `protobuf:"bytes,4,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	Priority         int32                  `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	Tags             []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	VerifyCommand    string                 `protobuf:"bytes,7,opt,name=verify_command,json=verifyCommand,proto3" json:"verify_command,omitempty"`
+	VerifyPrompt     string                 `protobuf:"bytes,8,opt,name=verify_prompt,json=verifyPrompt,proto3" json:"verify_prompt,omitempty"`
+	DependsOn        []string               `protobuf:"bytes,9,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	SetDependsOn     bool                   `protobuf:"varint,10,opt,name=set_depends_on,json=setDependsOn,proto3" json:"set_depends_on,omitempty"`           // replace depends_on (allows clearing it)
+	TimeoutSeconds   *int64                 `protobuf:"varint,11,opt,name=timeout_seconds,json=timeoutSeconds,proto3,oneof" json:"timeout_seconds,omitempty"` // 0 falls back to the workspace default
+	RequiresApproval *bool                  `protobuf:"varint,12,opt,name=requires_approval,json=requiresApproval,proto3,oneof" json:"requires_approval,omitempty"`
+	Model            *string                `protobuf:"bytes,13,opt,name=model,proto3,oneof" json:"model,omitempty"`
+	Temperature      *float64               `protobuf:"fixed64,14,opt,name=temperature,proto3,oneof" json:"temperature,omitempty"`
+	Tools            []string               `protobuf:"bytes,15,rep,name=tools,proto3" json:"tools,omitempty"`
+	SetTools         bool                   `protobuf:"varint,16,opt,name=set_tools,json=setTools,proto3" json:"set_tools,omitempty"` // replace tools (allows clearing it)
+	MaxToolRounds    *int32                 `protobuf:"varint,17,opt,name=max_tool_rounds,json=maxToolRounds,proto3,oneof" json:"max_tool_rounds,omitempty"`
+	Artifacts        []string               `protobuf:"bytes,18,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	SetArtifacts     bool                   `protobuf:"varint,19,opt,name=set_artifacts,json=setArtifacts,proto3" json:"set_artifacts,omitempty"` // replace artifacts (allows clearing it)
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
+	mi := &file_proto_kele_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *UpdateTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetVerifyCommand() string {
+	if x != nil {
+		return x.VerifyCommand
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetVerifyPrompt() string {
+	if x != nil {
+		return x.VerifyPrompt
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetSetDependsOn() bool {
+	if x != nil {
+		return x.SetDependsOn
+	}
+	return false
+}
+
+func (x *UpdateTaskRequest) GetTimeoutSeconds() int64 {
+	if x != nil && x.TimeoutSeconds != nil {
+		return *x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetRequiresApproval() bool {
+	if x != nil && x.RequiresApproval != nil {
+		return *x.RequiresApproval
+	}
+	return false
+}
+
+func (x *UpdateTaskRequest) GetModel() string {
+	if x != nil && x.Model != nil {
+		return *x.Model
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetTemperature() float64 {
+	if x != nil && x.Temperature != nil {
+		return *x.Temperature
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetTools() []string {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetSetTools() bool {
+	if x != nil {
+		return x.SetTools
+	}
+	return false
+}
+
+func (x *UpdateTaskRequest) GetMaxToolRounds() int32 {
+	if x != nil && x.MaxToolRounds != nil {
+		return *x.MaxToolRounds
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetArtifacts() []string {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetSetArtifacts() bool {
+	if x != nil {
+		return x.SetArtifacts
+	}
+	return false
+}
+
+type DeleteTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_proto_kele_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *DeleteTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	StatusFilter  string                 `protobuf:"bytes,2,opt,name=status_filter,json=statusFilter,proto3" json:"status_filter,omitempty"`
+	TagFilter     string                 `protobuf:"bytes,3,opt,name=tag_filter,json=tagFilter,proto3" json:"tag_filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_proto_kele_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListTasksRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetStatusFilter() string {
+	if x != nil {
+		return x.StatusFilter
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetTagFilter() string {
+	if x != nil {
+		return x.TagFilter
+	}
+	return ""
+}
+
+type ListTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*TaskInfo            `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_proto_kele_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListTasksResponse) GetTasks() []*TaskInfo {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type StartTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartTaskRequest) Reset() {
+	*x = StartTaskRequest{}
+	mi := &file_proto_kele_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartTaskRequest) ProtoMessage() {}
+
+func (x *StartTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartTaskRequest.ProtoReflect.Descriptor instead.
+func (*StartTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *StartTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTaskRequest) Reset() {
+	*x = CancelTaskRequest{}
+	mi := &file_proto_kele_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTaskRequest) ProtoMessage() {}
+
+func (x *CancelTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTaskRequest.ProtoReflect.Descriptor instead.
+func (*CancelTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CancelTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RetryTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryTaskRequest) Reset() {
+	*x = RetryTaskRequest{}
+	mi := &file_proto_kele_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryTaskRequest) ProtoMessage() {}
+
+func (x *RetryTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryTaskRequest.ProtoReflect.Descriptor instead.
+func (*RetryTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *RetryTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type MergeTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeTaskRequest) Reset() {
+	*x = MergeTaskRequest{}
+	mi := &file_proto_kele_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeTaskRequest) ProtoMessage() {}
+
+func (x *MergeTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeTaskRequest.ProtoReflect.Descriptor instead.
+func (*MergeTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *MergeTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReviewTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Feedback      string                 `protobuf:"bytes,2,opt,name=feedback,proto3" json:"feedback,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewTaskRequest) Reset() {
+	*x = ReviewTaskRequest{}
+	mi := &file_proto_kele_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewTaskRequest) ProtoMessage() {}
+
+func (x *ReviewTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewTaskRequest.ProtoReflect.Descriptor instead.
+func (*ReviewTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ReviewTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReviewTaskRequest) GetFeedback() string {
+	if x != nil {
+		return x.Feedback
+	}
+	return ""
+}
+
+type PlanWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Goal          string                 `protobuf:"bytes,1,opt,name=goal,proto3" json:"goal,omitempty"`
+	WorkDir       string                 `protobuf:"bytes,2,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	MaxConcurrent int32                  `protobuf:"varint,3,opt,name=max_concurrent,json=maxConcurrent,proto3" json:"max_concurrent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanWorkspaceRequest) Reset() {
+	*x = PlanWorkspaceRequest{}
+	mi := &file_proto_kele_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanWorkspaceRequest) ProtoMessage() {}
+
+func (x *PlanWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*PlanWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *PlanWorkspaceRequest) GetGoal() string {
+	if x != nil {
+		return x.Goal
+	}
+	return ""
+}
+
+func (x *PlanWorkspaceRequest) GetWorkDir() string {
+	if x != nil {
+		return x.WorkDir
+	}
+	return ""
+}
+
+func (x *PlanWorkspaceRequest) GetMaxConcurrent() int32 {
+	if x != nil {
+		return x.MaxConcurrent
+	}
+	return 0
+}
+
+type PlanEventMsg struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	PlanJson      string                 `protobuf:"bytes,3,opt,name=plan_json,json=planJson,proto3" json:"plan_json,omitempty"`
+	DraftId       string                 `protobuf:"bytes,4,opt,name=draft_id,json=draftId,proto3" json:"draft_id,omitempty"` // set on plan_ready
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanEventMsg) Reset() {
+	*x = PlanEventMsg{}
+	mi := &file_proto_kele_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanEventMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanEventMsg) ProtoMessage() {}
+
+func (x *PlanEventMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanEventMsg.ProtoReflect.Descriptor instead.
+func (*PlanEventMsg) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *PlanEventMsg) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PlanEventMsg) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *PlanEventMsg) GetPlanJson() string {
+	if x != nil {
+		return x.PlanJson
+	}
+	return ""
+}
+
+func (x *PlanEventMsg) GetDraftId() string {
+	if x != nil {
+		return x.DraftId
+	}
+	return ""
+}
+
+type ApprovePlanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlanJson      string                 `protobuf:"bytes,1,opt,name=plan_json,json=planJson,proto3" json:"plan_json,omitempty"`
+	Goal          string                 `protobuf:"bytes,2,opt,name=goal,proto3" json:"goal,omitempty"`
+	WorkDir       string                 `protobuf:"bytes,3,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	AutoStart     bool                   `protobuf:"varint,4,opt,name=auto_start,json=autoStart,proto3" json:"auto_start,omitempty"`
+	DraftId       string                 `protobuf:"bytes,5,opt,name=draft_id,json=draftId,proto3" json:"draft_id,omitempty"` // approve a stored draft instead of plan_json
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApprovePlanRequest) Reset() {
+	*x = ApprovePlanRequest{}
+	mi := &file_proto_kele_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovePlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovePlanRequest) ProtoMessage() {}
+
+func (x *ApprovePlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovePlanRequest.ProtoReflect.Descriptor instead.
+func (*ApprovePlanRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ApprovePlanRequest) GetPlanJson() string {
+	if x != nil {
+		return x.PlanJson
+	}
+	return ""
+}
+
+func (x *ApprovePlanRequest) GetGoal() string {
+	if x != nil {
+		return x.Goal
+	}
+	return ""
+}
+
+func (x *ApprovePlanRequest) GetWorkDir() string {
+	if x != nil {
+		return x.WorkDir
+	}
+	return ""
+}
+
+func (x *ApprovePlanRequest) GetAutoStart() bool {
+	if x != nil {
+		return x.AutoStart
+	}
+	return false
+}
+
+func (x *ApprovePlanRequest) GetDraftId() string {
+	if x != nil {
+		return x.DraftId
+	}
+	return ""
+}
+
+type ApprovePlanResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workspace     *WorkspaceInfo         `protobuf:"bytes,1,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	Tasks         []*TaskInfo            `protobuf:"bytes,2,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApprovePlanResponse) Reset() {
+	*x = ApprovePlanResponse{}
+	mi := &file_proto_kele_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovePlanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovePlanResponse) ProtoMessage() {}
+
+func (x *ApprovePlanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovePlanResponse.ProtoReflect.Descriptor instead.
+func (*ApprovePlanResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ApprovePlanResponse) GetWorkspace() *WorkspaceInfo {
+	if x != nil {
+		return x.Workspace
+	}
+	return nil
+}
+
+func (x *ApprovePlanResponse) GetTasks() []*TaskInfo {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+// PlanDraftInfo task indices (depends_on, index, from, to) are 0-based
+// positions in the plan's tasks array.
+type PlanDraftInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Goal          string                 `protobuf:"bytes,2,opt,name=goal,proto3" json:"goal,omitempty"`
+	WorkDir       string                 `protobuf:"bytes,3,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	PlanJson      string                 `protobuf:"bytes,4,opt,name=plan_json,json=planJson,proto3" json:"plan_json,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanDraftInfo) Reset() {
+	*x = PlanDraftInfo{}
+	mi := &file_proto_kele_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanDraftInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanDraftInfo) ProtoMessage() {}
+
+func (x *PlanDraftInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanDraftInfo.ProtoReflect.Descriptor instead.
+func (*PlanDraftInfo) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *PlanDraftInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PlanDraftInfo) GetGoal() string {
+	if x != nil {
+		return x.Goal
+	}
+	return ""
+}
+
+func (x *PlanDraftInfo) GetWorkDir() string {
+	if x != nil {
+		return x.WorkDir
+	}
+	return ""
+}
+
+func (x *PlanDraftInfo) GetPlanJson() string {
+	if x != nil {
+		return x.PlanJson
+	}
+	return ""
+}
+
+func (x *PlanDraftInfo) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *PlanDraftInfo) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type ListPlanDraftsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Drafts        []*PlanDraftInfo       `protobuf:"bytes,1,rep,name=drafts,proto3" json:"drafts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPlanDraftsResponse) Reset() {
+	*x = ListPlanDraftsResponse{}
+	mi := &file_proto_kele_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPlanDraftsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlanDraftsResponse) ProtoMessage() {}
+
+func (x *ListPlanDraftsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlanDraftsResponse.ProtoReflect.Descriptor instead.
+func (*ListPlanDraftsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListPlanDraftsResponse) GetDrafts() []*PlanDraftInfo {
+	if x != nil {
+		return x.Drafts
+	}
+	return nil
+}
+
+type GetPlanDraftRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlanDraftRequest) Reset() {
+	*x = GetPlanDraftRequest{}
+	mi := &file_proto_kele_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlanDraftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlanDraftRequest) ProtoMessage() {}
+
+func (x *GetPlanDraftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlanDraftRequest.ProtoReflect.Descriptor instead.
+func (*GetPlanDraftRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetPlanDraftRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeletePlanDraftRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePlanDraftRequest) Reset() {
+	*x = DeletePlanDraftRequest{}
+	mi := &file_proto_kele_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePlanDraftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePlanDraftRequest) ProtoMessage() {}
+
+func (x *DeletePlanDraftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePlanDraftRequest.ProtoReflect.Descriptor instead.
+func (*DeletePlanDraftRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *DeletePlanDraftRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type AddPlanTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DraftId       string                 `protobuf:"bytes,1,opt,name=draft_id,json=draftId,proto3" json:"draft_id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Prompt        string                 `protobuf:"bytes,4,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	Priority      *int32                 `protobuf:"varint,5,opt,name=priority,proto3,oneof" json:"priority,omitempty"` // 0-3; 2 (normal) when unset
+	DependsOn     []int32                `protobuf:"varint,6,rep,packed,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	Tags          []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	Position      *int32                 `protobuf:"varint,8,opt,name=position,proto3,oneof" json:"position,omitempty"` // insert position; appended when unset
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddPlanTaskRequest) Reset() {
+	*x = AddPlanTaskRequest{}
+	mi := &file_proto_kele_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddPlanTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPlanTaskRequest) ProtoMessage() {}
+
+func (x *AddPlanTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPlanTaskRequest.ProtoReflect.Descriptor instead.
+func (*AddPlanTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *AddPlanTaskRequest) GetDraftId() string {
+	if x != nil {
+		return x.DraftId
+	}
+	return ""
+}
+
+func (x *AddPlanTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *AddPlanTaskRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AddPlanTaskRequest) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *AddPlanTaskRequest) GetPriority() int32 {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return 0
+}
+
+func (x *AddPlanTaskRequest) GetDependsOn() []int32 {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *AddPlanTaskRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *AddPlanTaskRequest) GetPosition() int32 {
+	if x != nil && x.Position != nil {
+		return *x.Position
+	}
+	return 0
+}
+
+type RemovePlanTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DraftId       string                 `protobuf:"bytes,1,opt,name=draft_id,json=draftId,proto3" json:"draft_id,omitempty"`
+	Index         int32                  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemovePlanTaskRequest) Reset() {
+	*x = RemovePlanTaskRequest{}
+	mi := &file_proto_kele_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemovePlanTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePlanTaskRequest) ProtoMessage() {}
+
+func (x *RemovePlanTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePlanTaskRequest.ProtoReflect.Descriptor instead.
+func (*RemovePlanTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *RemovePlanTaskRequest) GetDraftId() string {
+	if x != nil {
+		return x.DraftId
+	}
+	return ""
+}
+
+func (x *RemovePlanTaskRequest) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type MovePlanTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DraftId       string                 `protobuf:"bytes,1,opt,name=draft_id,json=draftId,proto3" json:"draft_id,omitempty"`
+	From          int32                  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            int32                  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MovePlanTaskRequest) Reset() {
+	*x = MovePlanTaskRequest{}
+	mi := &file_proto_kele_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MovePlanTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovePlanTaskRequest) ProtoMessage() {}
+
+func (x *MovePlanTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovePlanTaskRequest.ProtoReflect.Descriptor instead.
+func (*MovePlanTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *MovePlanTaskRequest) GetDraftId() string {
+	if x != nil {
+		return x.DraftId
+	}
+	return ""
+}
+
+func (x *MovePlanTaskRequest) GetFrom() int32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *MovePlanTaskRequest) GetTo() int32 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type UpdatePlanTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DraftId       string                 `protobuf:"bytes,1,opt,name=draft_id,json=draftId,proto3" json:"draft_id,omitempty"`
+	Index         int32                  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Prompt        string                 `protobuf:"bytes,5,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	Priority      *int32                 `protobuf:"varint,6,opt,name=priority,proto3,oneof" json:"priority,omitempty"`
+	DependsOn     []int32                `protobuf:"varint,7,rep,packed,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	SetDependsOn  bool                   `protobuf:"varint,8,opt,name=set_depends_on,json=setDependsOn,proto3" json:"set_depends_on,omitempty"` // replace depends_on (allows clearing it)
+	Tags          []string               `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePlanTaskRequest) Reset() {
+	*x = UpdatePlanTaskRequest{}
+	mi := &file_proto_kele_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePlanTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePlanTaskRequest) ProtoMessage() {}
+
+func (x *UpdatePlanTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePlanTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePlanTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *UpdatePlanTaskRequest) GetDraftId() string {
+	if x != nil {
+		return x.DraftId
+	}
+	return ""
+}
+
+func (x *UpdatePlanTaskRequest) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *UpdatePlanTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdatePlanTaskRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdatePlanTaskRequest) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *UpdatePlanTaskRequest) GetPriority() int32 {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return 0
+}
+
+func (x *UpdatePlanTaskRequest) GetDependsOn() []int32 {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *UpdatePlanTaskRequest) GetSetDependsOn() bool {
+	if x != nil {
+		return x.SetDependsOn
+	}
+	return false
+}
+
+func (x *UpdatePlanTaskRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type RevisePlanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DraftId       string                 `protobuf:"bytes,1,opt,name=draft_id,json=draftId,proto3" json:"draft_id,omitempty"`
+	Feedback      string                 `protobuf:"bytes,2,opt,name=feedback,proto3" json:"feedback,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevisePlanRequest) Reset() {
+	*x = RevisePlanRequest{}
+	mi := &file_proto_kele_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevisePlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevisePlanRequest) ProtoMessage() {}
+
+func (x *RevisePlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevisePlanRequest.ProtoReflect.Descriptor instead.
+func (*RevisePlanRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *RevisePlanRequest) GetDraftId() string {
+	if x != nil {
+		return x.DraftId
+	}
+	return ""
+}
+
+func (x *RevisePlanRequest) GetFeedback() string {
+	if x != nil {
+		return x.Feedback
+	}
+	return ""
+}
+
+type BoardOverviewMsg struct {
+	state          protoimpl.MessageState  `protogen:"open.v1"`
+	Workspaces     []*WorkspaceOverviewMsg `protobuf:"bytes,1,rep,name=workspaces,proto3" json:"workspaces,omitempty"`
+	TotalTasks     int32                   `protobuf:"varint,2,opt,name=total_tasks,json=totalTasks,proto3" json:"total_tasks,omitempty"`
+	RunningTasks   int32                   `protobuf:"varint,3,opt,name=running_tasks,json=runningTasks,proto3" json:"running_tasks,omitempty"`
+	PendingTasks   int32                   `protobuf:"varint,4,opt,name=pending_tasks,json=pendingTasks,proto3" json:"pending_tasks,omitempty"`
+	CompletedTasks int32                   `protobuf:"varint,5,opt,name=completed_tasks,json=completedTasks,proto3" json:"completed_tasks,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BoardOverviewMsg) Reset() {
+	*x = BoardOverviewMsg{}
+	mi := &file_proto_kele_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BoardOverviewMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoardOverviewMsg) ProtoMessage() {}
+
+func (x *BoardOverviewMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoardOverviewMsg.ProtoReflect.Descriptor instead.
+func (*BoardOverviewMsg) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *BoardOverviewMsg) GetWorkspaces() []*WorkspaceOverviewMsg {
+	if x != nil {
+		return x.Workspaces
+	}
+	return nil
+}
+
+func (x *BoardOverviewMsg) GetTotalTasks() int32 {
+	if x != nil {
+		return x.TotalTasks
+	}
+	return 0
+}
+
+func (x *BoardOverviewMsg) GetRunningTasks() int32 {
+	if x != nil {
+		return x.RunningTasks
+	}
+	return 0
+}
+
+func (x *BoardOverviewMsg) GetPendingTasks() int32 {
+	if x != nil {
+		return x.PendingTasks
+	}
+	return 0
+}
+
+func (x *BoardOverviewMsg) GetCompletedTasks() int32 {
+	if x != nil {
+		return x.CompletedTasks
+	}
+	return 0
+}
+
+type WorkspaceOverviewMsg struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Backlog       int32                  `protobuf:"varint,4,opt,name=backlog,proto3" json:"backlog,omitempty"`
+	Ready         int32                  `protobuf:"varint,5,opt,name=ready,proto3" json:"ready,omitempty"`
+	Running       int32                  `protobuf:"varint,6,opt,name=running,proto3" json:"running,omitempty"`
+	Done          int32                  `protobuf:"varint,7,opt,name=done,proto3" json:"done,omitempty"`
+	Failed        int32                  `protobuf:"varint,8,opt,name=failed,proto3" json:"failed,omitempty"`
+	MaxConcurrent int32                  `protobuf:"varint,9,opt,name=max_concurrent,json=maxConcurrent,proto3" json:"max_concurrent,omitempty"`
+	Blocked       int32                  `protobuf:"varint,10,opt,name=blocked,proto3" json:"blocked,omitempty"`
+	Review        int32                  `protobuf:"varint,11,opt,name=review,proto3" json:"review,omitempty"`
+	Budget        *BudgetInfo            `protobuf:"bytes,12,opt,name=budget,proto3" json:"budget,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkspaceOverviewMsg) Reset() {
+	*x = WorkspaceOverviewMsg{}
+	mi := &file_proto_kele_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceOverviewMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceOverviewMsg) ProtoMessage() {}
+
+func (x *WorkspaceOverviewMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceOverviewMsg.ProtoReflect.Descriptor instead.
+func (*WorkspaceOverviewMsg) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *WorkspaceOverviewMsg) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WorkspaceOverviewMsg) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkspaceOverviewMsg) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WorkspaceOverviewMsg) GetBacklog() int32 {
+	if x != nil {
+		return x.Backlog
+	}
+	return 0
+}
+
+func (x *WorkspaceOverviewMsg) GetReady() int32 {
+	if x != nil {
+		return x.Ready
+	}
+	return 0
+}
+
+func (x *WorkspaceOverviewMsg) GetRunning() int32 {
+	if x != nil {
+		return x.Running
+	}
+	return 0
+}
+
+func (x *WorkspaceOverviewMsg) GetDone() int32 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *WorkspaceOverviewMsg) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *WorkspaceOverviewMsg) GetMaxConcurrent() int32 {
+	if x != nil {
+		return x.MaxConcurrent
+	}
+	return 0
+}
+
+func (x *WorkspaceOverviewMsg) GetBlocked() int32 {
+	if x != nil {
+		return x.Blocked
+	}
+	return 0
+}
+
+func (x *WorkspaceOverviewMsg) GetReview() int32 {
+	if x != nil {
+		return x.Review
+	}
+	return 0
+}
+
+func (x *WorkspaceOverviewMsg) GetBudget() *BudgetInfo {
+	if x != nil {
+		return x.Budget
+	}
+	return nil
+}
+
+type WatchBoardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchBoardRequest) Reset() {
+	*x = WatchBoardRequest{}
+	mi := &file_proto_kele_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchBoardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBoardRequest) ProtoMessage() {}
+
+func (x *WatchBoardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBoardRequest.ProtoReflect.Descriptor instead.
+func (*WatchBoardRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *WatchBoardRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+type BoardEventMsg struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	WorkspaceId   string                 `protobuf:"bytes,2,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	TaskId        string                 `protobuf:"bytes,3,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Detail        string                 `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
+	Timestamp     string                 `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BoardEventMsg) Reset() {
+	*x = BoardEventMsg{}
+	mi := &file_proto_kele_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTaskRequest) String() string {
+func (x *BoardEventMsg) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTaskRequest) ProtoMessage() {}
+func (*BoardEventMsg) ProtoMessage() {}
 
-func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[20]
+func (x *BoardEventMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1430,96 +5272,69 @@ func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
-func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use BoardEventMsg.ProtoReflect.Descriptor instead.
+func (*BoardEventMsg) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *CreateTaskRequest) GetWorkspaceId() string {
+func (x *BoardEventMsg) GetType() string {
 	if x != nil {
-		return x.WorkspaceId
+		return x.Type
 	}
 	return ""
 }
 
-func (x *CreateTaskRequest) GetTitle() string {
+func (x *BoardEventMsg) GetWorkspaceId() string {
 	if x != nil {
-		return x.Title
+		return x.WorkspaceId
 	}
 	return ""
 }
 
-func (x *CreateTaskRequest) GetDescription() string {
+func (x *BoardEventMsg) GetTaskId() string {
 	if x != nil {
-		return x.Description
+		return x.TaskId
 	}
 	return ""
 }
 
-func (x *CreateTaskRequest) GetPrompt() string {
+func (x *BoardEventMsg) GetDetail() string {
 	if x != nil {
-		return x.Prompt
+		return x.Detail
 	}
 	return ""
 }
 
-func (x *CreateTaskRequest) GetPriority() int32 {
-	if x != nil {
-		return x.Priority
-	}
-	return 0
-}
-
-func (x *CreateTaskRequest) GetDependsOn() []string {
-	if x != nil {
-		return x.DependsOn
-	}
-	return nil
-}
-
-func (x *CreateTaskRequest) GetMaxRetries() int32 {
-	if x != nil {
-		return x.MaxRetries
-	}
-	return 0
-}
-
-func (x *CreateTaskRequest) GetTags() []string {
-	if x != nil {
-		return x.Tags
-	}
-	return nil
-}
-
-func (x *CreateTaskRequest) GetAutoReady() bool {
+func (x *BoardEventMsg) GetTimestamp() string {
 	if x != nil {
-		return x.AutoReady
+		return x.Timestamp
 	}
-	return false
+	return ""
 }
 
-type GetTaskRequest struct {
+type GetTaskLogRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskRequest) Reset() {
-	*x = GetTaskRequest{}
-	mi := &file_proto_kele_proto_msgTypes[21]
+func (x *GetTaskLogRequest) Reset() {
+	*x = GetTaskLogRequest{}
+	mi := &file_proto_kele_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskRequest) String() string {
+func (x *GetTaskLogRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskRequest) ProtoMessage() {}
+func (*GetTaskLogRequest) ProtoMessage() {}
 
-func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[21]
+func (x *GetTaskLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1530,45 +5345,50 @@ func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
-func (*GetTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use GetTaskLogRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskLogRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *GetTaskRequest) GetId() string {
+func (x *GetTaskLogRequest) GetTaskId() string {
 	if x != nil {
-		return x.Id
+		return x.TaskId
 	}
 	return ""
 }
 
-type UpdateTaskRequest struct {
+func (x *GetTaskLogRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TaskLogEntry struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Prompt        string                 `protobuf:"bytes,4,opt,name=prompt,proto3" json:"prompt,omitempty"`
-	Priority      int32                  `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
-	Tags          []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	EventType     string                 `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	ToolName      string                 `protobuf:"bytes,3,opt,name=tool_name,json=toolName,proto3" json:"tool_name,omitempty"`
+	Timestamp     string                 `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateTaskRequest) Reset() {
-	*x = UpdateTaskRequest{}
-	mi := &file_proto_kele_proto_msgTypes[22]
+func (x *TaskLogEntry) Reset() {
+	*x = TaskLogEntry{}
+	mi := &file_proto_kele_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTaskRequest) String() string {
+func (x *TaskLogEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTaskRequest) ProtoMessage() {}
+func (*TaskLogEntry) ProtoMessage() {}
 
-func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[22]
+func (x *TaskLogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1579,75 +5399,61 @@ func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
-func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use TaskLogEntry.ProtoReflect.Descriptor instead.
+func (*TaskLogEntry) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *UpdateTaskRequest) GetId() string {
+func (x *TaskLogEntry) GetEventType() string {
 	if x != nil {
-		return x.Id
+		return x.EventType
 	}
 	return ""
 }
 
-func (x *UpdateTaskRequest) GetTitle() string {
+func (x *TaskLogEntry) GetContent() string {
 	if x != nil {
-		return x.Title
+		return x.Content
 	}
 	return ""
 }
 
-func (x *UpdateTaskRequest) GetDescription() string {
+func (x *TaskLogEntry) GetToolName() string {
 	if x != nil {
-		return x.Description
+		return x.ToolName
 	}
 	return ""
 }
 
-func (x *UpdateTaskRequest) GetPrompt() string {
+func (x *TaskLogEntry) GetTimestamp() string {
 	if x != nil {
-		return x.Prompt
+		return x.Timestamp
 	}
 	return ""
 }
 
-func (x *UpdateTaskRequest) GetPriority() int32 {
-	if x != nil {
-		return x.Priority
-	}
-	return 0
-}
-
-func (x *UpdateTaskRequest) GetTags() []string {
-	if x != nil {
-		return x.Tags
-	}
-	return nil
-}
-
-type DeleteTaskRequest struct {
+type TaskLogResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Entries       []*TaskLogEntry        `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteTaskRequest) Reset() {
-	*x = DeleteTaskRequest{}
-	mi := &file_proto_kele_proto_msgTypes[23]
+func (x *TaskLogResponse) Reset() {
+	*x = TaskLogResponse{}
+	mi := &file_proto_kele_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTaskRequest) String() string {
+func (x *TaskLogResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTaskRequest) ProtoMessage() {}
+func (*TaskLogResponse) ProtoMessage() {}
 
-func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[23]
+func (x *TaskLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1658,42 +5464,44 @@ func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
-func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use TaskLogResponse.ProtoReflect.Descriptor instead.
+func (*TaskLogResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *DeleteTaskRequest) GetId() string {
+func (x *TaskLogResponse) GetEntries() []*TaskLogEntry {
 	if x != nil {
-		return x.Id
+		return x.Entries
 	}
-	return ""
+	return nil
 }
 
-type ListTasksRequest struct {
+type SearchTasksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
-	StatusFilter  string                 `protobuf:"bytes,2,opt,name=status_filter,json=statusFilter,proto3" json:"status_filter,omitempty"`
-	TagFilter     string                 `protobuf:"bytes,3,opt,name=tag_filter,json=tagFilter,proto3" json:"tag_filter,omitempty"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	WorkspaceId   string                 `protobuf:"bytes,2,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Tool          string                 `protobuf:"bytes,3,opt,name=tool,proto3" json:"tool,omitempty"`    // only log entries of this tool
+	Since         string                 `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"`  // RFC 3339; only activity at or after this time
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"` // max tasks, default 20
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTasksRequest) Reset() {
-	*x = ListTasksRequest{}
-	mi := &file_proto_kele_proto_msgTypes[24]
+func (x *SearchTasksRequest) Reset() {
+	*x = SearchTasksRequest{}
+	mi := &file_proto_kele_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTasksRequest) String() string {
+func (x *SearchTasksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTasksRequest) ProtoMessage() {}
+func (*SearchTasksRequest) ProtoMessage() {}
 
-func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[24]
+func (x *SearchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1704,54 +5512,73 @@ func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
-func (*ListTasksRequest) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use SearchTasksRequest.ProtoReflect.Descriptor instead.
+func (*SearchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *ListTasksRequest) GetWorkspaceId() string {
+func (x *SearchTasksRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchTasksRequest) GetWorkspaceId() string {
 	if x != nil {
 		return x.WorkspaceId
 	}
 	return ""
 }
 
-func (x *ListTasksRequest) GetStatusFilter() string {
+func (x *SearchTasksRequest) GetTool() string {
 	if x != nil {
-		return x.StatusFilter
+		return x.Tool
 	}
 	return ""
 }
 
-func (x *ListTasksRequest) GetTagFilter() string {
+func (x *SearchTasksRequest) GetSince() string {
 	if x != nil {
-		return x.TagFilter
+		return x.Since
 	}
 	return ""
 }
 
-type ListTasksResponse struct {
+func (x *SearchTasksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// TaskSearchMatch snippets wrap the matched text in « and ».
+type TaskSearchMatch struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tasks         []*TaskInfo            `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"` // log, result, error
+	EventType     string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	ToolName      string                 `protobuf:"bytes,3,opt,name=tool_name,json=toolName,proto3" json:"tool_name,omitempty"`
+	Snippet       string                 `protobuf:"bytes,4,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	Timestamp     string                 `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTasksResponse) Reset() {
-	*x = ListTasksResponse{}
-	mi := &file_proto_kele_proto_msgTypes[25]
+func (x *TaskSearchMatch) Reset() {
+	*x = TaskSearchMatch{}
+	mi := &file_proto_kele_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTasksResponse) String() string {
+func (x *TaskSearchMatch) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTasksResponse) ProtoMessage() {}
+func (*TaskSearchMatch) ProtoMessage() {}
 
-func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[25]
+func (x *TaskSearchMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1762,40 +5589,73 @@ func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
-func (*ListTasksResponse) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use TaskSearchMatch.ProtoReflect.Descriptor instead.
+func (*TaskSearchMatch) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *ListTasksResponse) GetTasks() []*TaskInfo {
+func (x *TaskSearchMatch) GetField() string {
 	if x != nil {
-		return x.Tasks
+		return x.Field
 	}
-	return nil
+	return ""
 }
 
-type StartTaskRequest struct {
+func (x *TaskSearchMatch) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *TaskSearchMatch) GetToolName() string {
+	if x != nil {
+		return x.ToolName
+	}
+	return ""
+}
+
+func (x *TaskSearchMatch) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *TaskSearchMatch) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+type TaskSearchHit struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	WorkspaceId   string                 `protobuf:"bytes,2,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	TotalMatches  int32                  `protobuf:"varint,5,opt,name=total_matches,json=totalMatches,proto3" json:"total_matches,omitempty"`
+	Matches       []*TaskSearchMatch     `protobuf:"bytes,6,rep,name=matches,proto3" json:"matches,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartTaskRequest) Reset() {
-	*x = StartTaskRequest{}
-	mi := &file_proto_kele_proto_msgTypes[26]
+func (x *TaskSearchHit) Reset() {
+	*x = TaskSearchHit{}
+	mi := &file_proto_kele_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartTaskRequest) String() string {
+func (x *TaskSearchHit) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartTaskRequest) ProtoMessage() {}
+func (*TaskSearchHit) ProtoMessage() {}
 
-func (x *StartTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[26]
+func (x *TaskSearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1806,84 +5666,75 @@ func (x *StartTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartTaskRequest.ProtoReflect.Descriptor instead.
-func (*StartTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use TaskSearchHit.ProtoReflect.Descriptor instead.
+func (*TaskSearchHit) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *StartTaskRequest) GetId() string {
+func (x *TaskSearchHit) GetTaskId() string {
 	if x != nil {
-		return x.Id
+		return x.TaskId
 	}
 	return ""
 }
 
-type CancelTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CancelTaskRequest) Reset() {
-	*x = CancelTaskRequest{}
-	mi := &file_proto_kele_proto_msgTypes[27]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *TaskSearchHit) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
 }
 
-func (x *CancelTaskRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *TaskSearchHit) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
 }
 
-func (*CancelTaskRequest) ProtoMessage() {}
-
-func (x *CancelTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[27]
+func (x *TaskSearchHit) GetStatus() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Status
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use CancelTaskRequest.ProtoReflect.Descriptor instead.
-func (*CancelTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{27}
+func (x *TaskSearchHit) GetTotalMatches() int32 {
+	if x != nil {
+		return x.TotalMatches
+	}
+	return 0
 }
 
-func (x *CancelTaskRequest) GetId() string {
+func (x *TaskSearchHit) GetMatches() []*TaskSearchMatch {
 	if x != nil {
-		return x.Id
+		return x.Matches
 	}
-	return ""
+	return nil
 }
 
-type RetryTaskRequest struct {
+type SearchTasksResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Hits          []*TaskSearchHit       `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RetryTaskRequest) Reset() {
-	*x = RetryTaskRequest{}
-	mi := &file_proto_kele_proto_msgTypes[28]
+func (x *SearchTasksResponse) Reset() {
+	*x = SearchTasksResponse{}
+	mi := &file_proto_kele_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RetryTaskRequest) String() string {
+func (x *SearchTasksResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RetryTaskRequest) ProtoMessage() {}
+func (*SearchTasksResponse) ProtoMessage() {}
 
-func (x *RetryTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[28]
+func (x *SearchTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1894,42 +5745,51 @@ func (x *RetryTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RetryTaskRequest.ProtoReflect.Descriptor instead.
-func (*RetryTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use SearchTasksResponse.ProtoReflect.Descriptor instead.
+func (*SearchTasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *RetryTaskRequest) GetId() string {
+func (x *SearchTasksResponse) GetHits() []*TaskSearchHit {
 	if x != nil {
-		return x.Id
+		return x.Hits
 	}
-	return ""
+	return nil
 }
 
-type PlanWorkspaceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Goal          string                 `protobuf:"bytes,1,opt,name=goal,proto3" json:"goal,omitempty"`
-	WorkDir       string                 `protobuf:"bytes,2,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
-	MaxConcurrent int32                  `protobuf:"varint,3,opt,name=max_concurrent,json=maxConcurrent,proto3" json:"max_concurrent,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type WorkspaceScheduleInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TemplateId      string                 `protobuf:"bytes,2,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	TemplateName    string                 `protobuf:"bytes,3,opt,name=template_name,json=templateName,proto3" json:"template_name,omitempty"`
+	Cron            string                 `protobuf:"bytes,4,opt,name=cron,proto3" json:"cron,omitempty"`
+	Channel         string                 `protobuf:"bytes,5,opt,name=channel,proto3" json:"channel,omitempty"`
+	Target          string                 `protobuf:"bytes,6,opt,name=target,proto3" json:"target,omitempty"`
+	Enabled         bool                   `protobuf:"varint,7,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	LastRun         string                 `protobuf:"bytes,8,opt,name=last_run,json=lastRun,proto3" json:"last_run,omitempty"`
+	NextRun         string                 `protobuf:"bytes,9,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"`
+	LastWorkspaceId string                 `protobuf:"bytes,10,opt,name=last_workspace_id,json=lastWorkspaceId,proto3" json:"last_workspace_id,omitempty"`
+	LastError       string                 `protobuf:"bytes,11,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	LastSummary     string                 `protobuf:"bytes,12,opt,name=last_summary,json=lastSummary,proto3" json:"last_summary,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *PlanWorkspaceRequest) Reset() {
-	*x = PlanWorkspaceRequest{}
-	mi := &file_proto_kele_proto_msgTypes[29]
+func (x *WorkspaceScheduleInfo) Reset() {
+	*x = WorkspaceScheduleInfo{}
+	mi := &file_proto_kele_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlanWorkspaceRequest) String() string {
+func (x *WorkspaceScheduleInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlanWorkspaceRequest) ProtoMessage() {}
+func (*WorkspaceScheduleInfo) ProtoMessage() {}
 
-func (x *PlanWorkspaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[29]
+func (x *WorkspaceScheduleInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1940,117 +5800,120 @@ func (x *PlanWorkspaceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlanWorkspaceRequest.ProtoReflect.Descriptor instead.
-func (*PlanWorkspaceRequest) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use WorkspaceScheduleInfo.ProtoReflect.Descriptor instead.
+func (*WorkspaceScheduleInfo) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *PlanWorkspaceRequest) GetGoal() string {
+func (x *WorkspaceScheduleInfo) GetId() string {
 	if x != nil {
-		return x.Goal
+		return x.Id
 	}
 	return ""
 }
 
-func (x *PlanWorkspaceRequest) GetWorkDir() string {
+func (x *WorkspaceScheduleInfo) GetTemplateId() string {
 	if x != nil {
-		return x.WorkDir
+		return x.TemplateId
 	}
 	return ""
 }
 
-func (x *PlanWorkspaceRequest) GetMaxConcurrent() int32 {
+func (x *WorkspaceScheduleInfo) GetTemplateName() string {
 	if x != nil {
-		return x.MaxConcurrent
+		return x.TemplateName
 	}
-	return 0
+	return ""
 }
 
-type PlanEventMsg struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	PlanJson      string                 `protobuf:"bytes,3,opt,name=plan_json,json=planJson,proto3" json:"plan_json,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *WorkspaceScheduleInfo) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
 }
 
-func (x *PlanEventMsg) Reset() {
-	*x = PlanEventMsg{}
-	mi := &file_proto_kele_proto_msgTypes[30]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *WorkspaceScheduleInfo) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
 }
 
-func (x *PlanEventMsg) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *WorkspaceScheduleInfo) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
 }
 
-func (*PlanEventMsg) ProtoMessage() {}
+func (x *WorkspaceScheduleInfo) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
 
-func (x *PlanEventMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[30]
+func (x *WorkspaceScheduleInfo) GetLastRun() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.LastRun
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use PlanEventMsg.ProtoReflect.Descriptor instead.
-func (*PlanEventMsg) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{30}
+func (x *WorkspaceScheduleInfo) GetNextRun() string {
+	if x != nil {
+		return x.NextRun
+	}
+	return ""
 }
 
-func (x *PlanEventMsg) GetType() string {
+func (x *WorkspaceScheduleInfo) GetLastWorkspaceId() string {
 	if x != nil {
-		return x.Type
+		return x.LastWorkspaceId
 	}
 	return ""
 }
 
-func (x *PlanEventMsg) GetContent() string {
+func (x *WorkspaceScheduleInfo) GetLastError() string {
 	if x != nil {
-		return x.Content
+		return x.LastError
 	}
 	return ""
 }
 
-func (x *PlanEventMsg) GetPlanJson() string {
+func (x *WorkspaceScheduleInfo) GetLastSummary() string {
 	if x != nil {
-		return x.PlanJson
+		return x.LastSummary
 	}
 	return ""
 }
 
-type ApprovePlanRequest struct {
+type CreateWorkspaceScheduleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlanJson      string                 `protobuf:"bytes,1,opt,name=plan_json,json=planJson,proto3" json:"plan_json,omitempty"`
-	Goal          string                 `protobuf:"bytes,2,opt,name=goal,proto3" json:"goal,omitempty"`
-	WorkDir       string                 `protobuf:"bytes,3,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
-	AutoStart     bool                   `protobuf:"varint,4,opt,name=auto_start,json=autoStart,proto3" json:"auto_start,omitempty"`
+	TemplateId    string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Cron          string                 `protobuf:"bytes,2,opt,name=cron,proto3" json:"cron,omitempty"`       // 5-field cron expression
+	Channel       string                 `protobuf:"bytes,3,opt,name=channel,proto3" json:"channel,omitempty"` // e.g. telegram; empty = no report
+	Target        string                 `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`   // recipient on the channel; empty = channel default
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ApprovePlanRequest) Reset() {
-	*x = ApprovePlanRequest{}
-	mi := &file_proto_kele_proto_msgTypes[31]
+func (x *CreateWorkspaceScheduleRequest) Reset() {
+	*x = CreateWorkspaceScheduleRequest{}
+	mi := &file_proto_kele_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ApprovePlanRequest) String() string {
+func (x *CreateWorkspaceScheduleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ApprovePlanRequest) ProtoMessage() {}
+func (*CreateWorkspaceScheduleRequest) ProtoMessage() {}
 
-func (x *ApprovePlanRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[31]
+func (x *CreateWorkspaceScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2061,62 +5924,61 @@ func (x *ApprovePlanRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ApprovePlanRequest.ProtoReflect.Descriptor instead.
-func (*ApprovePlanRequest) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use CreateWorkspaceScheduleRequest.ProtoReflect.Descriptor instead.
+func (*CreateWorkspaceScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *ApprovePlanRequest) GetPlanJson() string {
+func (x *CreateWorkspaceScheduleRequest) GetTemplateId() string {
 	if x != nil {
-		return x.PlanJson
+		return x.TemplateId
 	}
 	return ""
 }
 
-func (x *ApprovePlanRequest) GetGoal() string {
+func (x *CreateWorkspaceScheduleRequest) GetCron() string {
 	if x != nil {
-		return x.Goal
+		return x.Cron
 	}
 	return ""
 }
 
-func (x *ApprovePlanRequest) GetWorkDir() string {
+func (x *CreateWorkspaceScheduleRequest) GetChannel() string {
 	if x != nil {
-		return x.WorkDir
+		return x.Channel
 	}
 	return ""
 }
 
-func (x *ApprovePlanRequest) GetAutoStart() bool {
+func (x *CreateWorkspaceScheduleRequest) GetTarget() string {
 	if x != nil {
-		return x.AutoStart
+		return x.Target
 	}
-	return false
+	return ""
 }
 
-type ApprovePlanResponse struct {
+type WorkspaceScheduleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Workspace     *WorkspaceInfo         `protobuf:"bytes,1,opt,name=workspace,proto3" json:"workspace,omitempty"`
-	Tasks         []*TaskInfo            `protobuf:"bytes,2,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ApprovePlanResponse) Reset() {
-	*x = ApprovePlanResponse{}
-	mi := &file_proto_kele_proto_msgTypes[32]
+func (x *WorkspaceScheduleRequest) Reset() {
+	*x = WorkspaceScheduleRequest{}
+	mi := &file_proto_kele_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ApprovePlanResponse) String() string {
+func (x *WorkspaceScheduleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ApprovePlanResponse) ProtoMessage() {}
+func (*WorkspaceScheduleRequest) ProtoMessage() {}
 
-func (x *ApprovePlanResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[32]
+func (x *WorkspaceScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2127,51 +5989,41 @@ func (x *ApprovePlanResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ApprovePlanResponse.ProtoReflect.Descriptor instead.
-func (*ApprovePlanResponse) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{32}
-}
-
-func (x *ApprovePlanResponse) GetWorkspace() *WorkspaceInfo {
-	if x != nil {
-		return x.Workspace
-	}
-	return nil
+// Deprecated: Use WorkspaceScheduleRequest.ProtoReflect.Descriptor instead.
+func (*WorkspaceScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *ApprovePlanResponse) GetTasks() []*TaskInfo {
+func (x *WorkspaceScheduleRequest) GetId() string {
 	if x != nil {
-		return x.Tasks
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type BoardOverviewMsg struct {
-	state          protoimpl.MessageState  `protogen:"open.v1"`
-	Workspaces     []*WorkspaceOverviewMsg `protobuf:"bytes,1,rep,name=workspaces,proto3" json:"workspaces,omitempty"`
-	TotalTasks     int32                   `protobuf:"varint,2,opt,name=total_tasks,json=totalTasks,proto3" json:"total_tasks,omitempty"`
-	RunningTasks   int32                   `protobuf:"varint,3,opt,name=running_tasks,json=runningTasks,proto3" json:"running_tasks,omitempty"`
-	PendingTasks   int32                   `protobuf:"varint,4,opt,name=pending_tasks,json=pendingTasks,proto3" json:"pending_tasks,omitempty"`
-	CompletedTasks int32                   `protobuf:"varint,5,opt,name=completed_tasks,json=completedTasks,proto3" json:"completed_tasks,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type SetWorkspaceScheduleEnabledRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BoardOverviewMsg) Reset() {
-	*x = BoardOverviewMsg{}
-	mi := &file_proto_kele_proto_msgTypes[33]
+func (x *SetWorkspaceScheduleEnabledRequest) Reset() {
+	*x = SetWorkspaceScheduleEnabledRequest{}
+	mi := &file_proto_kele_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BoardOverviewMsg) String() string {
+func (x *SetWorkspaceScheduleEnabledRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BoardOverviewMsg) ProtoMessage() {}
+func (*SetWorkspaceScheduleEnabledRequest) ProtoMessage() {}
 
-func (x *BoardOverviewMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[33]
+func (x *SetWorkspaceScheduleEnabledRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2182,76 +6034,94 @@ func (x *BoardOverviewMsg) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BoardOverviewMsg.ProtoReflect.Descriptor instead.
-func (*BoardOverviewMsg) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use SetWorkspaceScheduleEnabledRequest.ProtoReflect.Descriptor instead.
+func (*SetWorkspaceScheduleEnabledRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{79}
 }
 
-func (x *BoardOverviewMsg) GetWorkspaces() []*WorkspaceOverviewMsg {
+func (x *SetWorkspaceScheduleEnabledRequest) GetId() string {
 	if x != nil {
-		return x.Workspaces
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *BoardOverviewMsg) GetTotalTasks() int32 {
+func (x *SetWorkspaceScheduleEnabledRequest) GetEnabled() bool {
 	if x != nil {
-		return x.TotalTasks
+		return x.Enabled
 	}
-	return 0
+	return false
 }
 
-func (x *BoardOverviewMsg) GetRunningTasks() int32 {
+type ListWorkspaceSchedulesResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Schedules     []*WorkspaceScheduleInfo `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspaceSchedulesResponse) Reset() {
+	*x = ListWorkspaceSchedulesResponse{}
+	mi := &file_proto_kele_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspaceSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspaceSchedulesResponse) ProtoMessage() {}
+
+func (x *ListWorkspaceSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[80]
 	if x != nil {
-		return x.RunningTasks
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *BoardOverviewMsg) GetPendingTasks() int32 {
-	if x != nil {
-		return x.PendingTasks
-	}
-	return 0
+// Deprecated: Use ListWorkspaceSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkspaceSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *BoardOverviewMsg) GetCompletedTasks() int32 {
+func (x *ListWorkspaceSchedulesResponse) GetSchedules() []*WorkspaceScheduleInfo {
 	if x != nil {
-		return x.CompletedTasks
+		return x.Schedules
 	}
-	return 0
+	return nil
 }
 
-type WorkspaceOverviewMsg struct {
+type ExportWorkspaceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	Backlog       int32                  `protobuf:"varint,4,opt,name=backlog,proto3" json:"backlog,omitempty"`
-	Ready         int32                  `protobuf:"varint,5,opt,name=ready,proto3" json:"ready,omitempty"`
-	Running       int32                  `protobuf:"varint,6,opt,name=running,proto3" json:"running,omitempty"`
-	Done          int32                  `protobuf:"varint,7,opt,name=done,proto3" json:"done,omitempty"`
-	Failed        int32                  `protobuf:"varint,8,opt,name=failed,proto3" json:"failed,omitempty"`
-	MaxConcurrent int32                  `protobuf:"varint,9,opt,name=max_concurrent,json=maxConcurrent,proto3" json:"max_concurrent,omitempty"`
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`                               // yaml (default) or json
+	WithResults   bool                   `protobuf:"varint,3,opt,name=with_results,json=withResults,proto3" json:"with_results,omitempty"` // include task status, results and the summary
+	LogLimit      int32                  `protobuf:"varint,4,opt,name=log_limit,json=logLimit,proto3" json:"log_limit,omitempty"`          // task log entries per task; 0 = none
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WorkspaceOverviewMsg) Reset() {
-	*x = WorkspaceOverviewMsg{}
-	mi := &file_proto_kele_proto_msgTypes[34]
+func (x *ExportWorkspaceRequest) Reset() {
+	*x = ExportWorkspaceRequest{}
+	mi := &file_proto_kele_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WorkspaceOverviewMsg) String() string {
+func (x *ExportWorkspaceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WorkspaceOverviewMsg) ProtoMessage() {}
+func (*ExportWorkspaceRequest) ProtoMessage() {}
 
-func (x *WorkspaceOverviewMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[34]
+func (x *ExportWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2262,96 +6132,108 @@ func (x *WorkspaceOverviewMsg) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WorkspaceOverviewMsg.ProtoReflect.Descriptor instead.
-func (*WorkspaceOverviewMsg) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use ExportWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*ExportWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{81}
 }
 
-func (x *WorkspaceOverviewMsg) GetId() string {
+func (x *ExportWorkspaceRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *WorkspaceOverviewMsg) GetName() string {
+func (x *ExportWorkspaceRequest) GetFormat() string {
 	if x != nil {
-		return x.Name
+		return x.Format
 	}
 	return ""
 }
 
-func (x *WorkspaceOverviewMsg) GetStatus() string {
+func (x *ExportWorkspaceRequest) GetWithResults() bool {
 	if x != nil {
-		return x.Status
+		return x.WithResults
 	}
-	return ""
+	return false
 }
 
-func (x *WorkspaceOverviewMsg) GetBacklog() int32 {
+func (x *ExportWorkspaceRequest) GetLogLimit() int32 {
 	if x != nil {
-		return x.Backlog
+		return x.LogLimit
 	}
 	return 0
 }
 
-func (x *WorkspaceOverviewMsg) GetReady() int32 {
-	if x != nil {
-		return x.Ready
-	}
-	return 0
+type ExportWorkspaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WorkspaceOverviewMsg) GetRunning() int32 {
-	if x != nil {
-		return x.Running
-	}
-	return 0
+func (x *ExportWorkspaceResponse) Reset() {
+	*x = ExportWorkspaceResponse{}
+	mi := &file_proto_kele_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *WorkspaceOverviewMsg) GetDone() int32 {
-	if x != nil {
-		return x.Done
-	}
-	return 0
+func (x *ExportWorkspaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *WorkspaceOverviewMsg) GetFailed() int32 {
+func (*ExportWorkspaceResponse) ProtoMessage() {}
+
+func (x *ExportWorkspaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[82]
 	if x != nil {
-		return x.Failed
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *WorkspaceOverviewMsg) GetMaxConcurrent() int32 {
+// Deprecated: Use ExportWorkspaceResponse.ProtoReflect.Descriptor instead.
+func (*ExportWorkspaceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ExportWorkspaceResponse) GetData() []byte {
 	if x != nil {
-		return x.MaxConcurrent
+		return x.Data
 	}
-	return 0
+	return nil
 }
 
-type WatchBoardRequest struct {
+type ImportWorkspaceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`                                                                           // YAML or JSON plan file
+	Vars          map[string]string      `protobuf:"bytes,2,rep,name=vars,proto3" json:"vars,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // values for {{name}} placeholders
+	WorkDir       string                 `protobuf:"bytes,3,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`                                                      // overrides the file's work_dir
+	Paused        bool                   `protobuf:"varint,4,opt,name=paused,proto3" json:"paused,omitempty"`                                                                      // create the workspace paused
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WatchBoardRequest) Reset() {
-	*x = WatchBoardRequest{}
-	mi := &file_proto_kele_proto_msgTypes[35]
+func (x *ImportWorkspaceRequest) Reset() {
+	*x = ImportWorkspaceRequest{}
+	mi := &file_proto_kele_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WatchBoardRequest) String() string {
+func (x *ImportWorkspaceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WatchBoardRequest) ProtoMessage() {}
+func (*ImportWorkspaceRequest) ProtoMessage() {}
 
-func (x *WatchBoardRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[35]
+func (x *ImportWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2362,44 +6244,66 @@ func (x *WatchBoardRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WatchBoardRequest.ProtoReflect.Descriptor instead.
-func (*WatchBoardRequest) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use ImportWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*ImportWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *WatchBoardRequest) GetWorkspaceId() string {
+func (x *ImportWorkspaceRequest) GetData() []byte {
 	if x != nil {
-		return x.WorkspaceId
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportWorkspaceRequest) GetVars() map[string]string {
+	if x != nil {
+		return x.Vars
+	}
+	return nil
+}
+
+func (x *ImportWorkspaceRequest) GetWorkDir() string {
+	if x != nil {
+		return x.WorkDir
 	}
 	return ""
 }
 
-type BoardEventMsg struct {
+func (x *ImportWorkspaceRequest) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+type ArtifactInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	WorkspaceId   string                 `protobuf:"bytes,2,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
-	TaskId        string                 `protobuf:"bytes,3,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	Detail        string                 `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
-	Timestamp     string                 `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"` // file, diff, result
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Path          string                 `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"` // location of the stored copy on the daemon host
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BoardEventMsg) Reset() {
-	*x = BoardEventMsg{}
-	mi := &file_proto_kele_proto_msgTypes[36]
+func (x *ArtifactInfo) Reset() {
+	*x = ArtifactInfo{}
+	mi := &file_proto_kele_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BoardEventMsg) String() string {
+func (x *ArtifactInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BoardEventMsg) ProtoMessage() {}
+func (*ArtifactInfo) ProtoMessage() {}
 
-func (x *BoardEventMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[36]
+func (x *ArtifactInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2410,69 +6314,75 @@ func (x *BoardEventMsg) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BoardEventMsg.ProtoReflect.Descriptor instead.
-func (*BoardEventMsg) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use ArtifactInfo.ProtoReflect.Descriptor instead.
+func (*ArtifactInfo) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{84}
 }
 
-func (x *BoardEventMsg) GetType() string {
+func (x *ArtifactInfo) GetTaskId() string {
 	if x != nil {
-		return x.Type
+		return x.TaskId
 	}
 	return ""
 }
 
-func (x *BoardEventMsg) GetWorkspaceId() string {
+func (x *ArtifactInfo) GetName() string {
 	if x != nil {
-		return x.WorkspaceId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *BoardEventMsg) GetTaskId() string {
+func (x *ArtifactInfo) GetKind() string {
 	if x != nil {
-		return x.TaskId
+		return x.Kind
 	}
 	return ""
 }
 
-func (x *BoardEventMsg) GetDetail() string {
+func (x *ArtifactInfo) GetSize() int64 {
 	if x != nil {
-		return x.Detail
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ArtifactInfo) GetPath() string {
+	if x != nil {
+		return x.Path
 	}
 	return ""
 }
 
-func (x *BoardEventMsg) GetTimestamp() string {
+func (x *ArtifactInfo) GetCreatedAt() string {
 	if x != nil {
-		return x.Timestamp
+		return x.CreatedAt
 	}
 	return ""
 }
 
-type GetTaskLogRequest struct {
+type ListTaskArtifactsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Artifacts     []*ArtifactInfo        `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskLogRequest) Reset() {
-	*x = GetTaskLogRequest{}
-	mi := &file_proto_kele_proto_msgTypes[37]
+func (x *ListTaskArtifactsResponse) Reset() {
+	*x = ListTaskArtifactsResponse{}
+	mi := &file_proto_kele_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskLogRequest) String() string {
+func (x *ListTaskArtifactsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskLogRequest) ProtoMessage() {}
+func (*ListTaskArtifactsResponse) ProtoMessage() {}
 
-func (x *GetTaskLogRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[37]
+func (x *ListTaskArtifactsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2483,50 +6393,41 @@ func (x *GetTaskLogRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskLogRequest.ProtoReflect.Descriptor instead.
-func (*GetTaskLogRequest) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{37}
-}
-
-func (x *GetTaskLogRequest) GetTaskId() string {
-	if x != nil {
-		return x.TaskId
-	}
-	return ""
+// Deprecated: Use ListTaskArtifactsResponse.ProtoReflect.Descriptor instead.
+func (*ListTaskArtifactsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{85}
 }
 
-func (x *GetTaskLogRequest) GetLimit() int32 {
+func (x *ListTaskArtifactsResponse) GetArtifacts() []*ArtifactInfo {
 	if x != nil {
-		return x.Limit
+		return x.Artifacts
 	}
-	return 0
+	return nil
 }
 
-type TaskLogEntry struct {
+type GetTaskArtifactRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	EventType     string                 `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
-	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	ToolName      string                 `protobuf:"bytes,3,opt,name=tool_name,json=toolName,proto3" json:"tool_name,omitempty"`
-	Timestamp     string                 `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TaskLogEntry) Reset() {
-	*x = TaskLogEntry{}
-	mi := &file_proto_kele_proto_msgTypes[38]
+func (x *GetTaskArtifactRequest) Reset() {
+	*x = GetTaskArtifactRequest{}
+	mi := &file_proto_kele_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TaskLogEntry) String() string {
+func (x *GetTaskArtifactRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskLogEntry) ProtoMessage() {}
+func (*GetTaskArtifactRequest) ProtoMessage() {}
 
-func (x *TaskLogEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[38]
+func (x *GetTaskArtifactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2537,61 +6438,48 @@ func (x *TaskLogEntry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskLogEntry.ProtoReflect.Descriptor instead.
-func (*TaskLogEntry) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{38}
-}
-
-func (x *TaskLogEntry) GetEventType() string {
-	if x != nil {
-		return x.EventType
-	}
-	return ""
-}
-
-func (x *TaskLogEntry) GetContent() string {
-	if x != nil {
-		return x.Content
-	}
-	return ""
+// Deprecated: Use GetTaskArtifactRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskArtifactRequest) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{86}
 }
 
-func (x *TaskLogEntry) GetToolName() string {
+func (x *GetTaskArtifactRequest) GetTaskId() string {
 	if x != nil {
-		return x.ToolName
+		return x.TaskId
 	}
 	return ""
 }
 
-func (x *TaskLogEntry) GetTimestamp() string {
+func (x *GetTaskArtifactRequest) GetName() string {
 	if x != nil {
-		return x.Timestamp
+		return x.Name
 	}
 	return ""
 }
 
-type TaskLogResponse struct {
+type TaskArtifact struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Entries       []*TaskLogEntry        `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Info          *ArtifactInfo          `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TaskLogResponse) Reset() {
-	*x = TaskLogResponse{}
-	mi := &file_proto_kele_proto_msgTypes[39]
+func (x *TaskArtifact) Reset() {
+	*x = TaskArtifact{}
+	mi := &file_proto_kele_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TaskLogResponse) String() string {
+func (x *TaskArtifact) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskLogResponse) ProtoMessage() {}
+func (*TaskArtifact) ProtoMessage() {}
 
-func (x *TaskLogResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_kele_proto_msgTypes[39]
+func (x *TaskArtifact) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_kele_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2602,14 +6490,21 @@ func (x *TaskLogResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskLogResponse.ProtoReflect.Descriptor instead.
-func (*TaskLogResponse) Descriptor() ([]byte, []int) {
-	return file_proto_kele_proto_rawDescGZIP(), []int{39}
+// Deprecated: Use TaskArtifact.ProtoReflect.Descriptor instead.
+func (*TaskArtifact) Descriptor() ([]byte, []int) {
+	return file_proto_kele_proto_rawDescGZIP(), []int{87}
 }
 
-func (x *TaskLogResponse) GetEntries() []*TaskLogEntry {
+func (x *TaskArtifact) GetInfo() *ArtifactInfo {
 	if x != nil {
-		return x.Entries
+		return x.Info
+	}
+	return nil
+}
+
+func (x *TaskArtifact) GetContent() []byte {
+	if x != nil {
+		return x.Content
 	}
 	return nil
 }
@@ -2669,14 +6564,174 @@ const file_proto_kele_proto_rawDesc = "" +
 	"smallModel\x12'\n" +
 	"\x0factive_sessions\x18\x06 \x01(\x05R\x0eactiveSessions\x12%\n" +
 	"\x0euptime_seconds\x18\a \x01(\x05R\ruptimeSeconds\x12)\n" +
-	"\x10heartbeat_active\x18\b \x01(\bR\x0fheartbeatActive\"\xec\x01\n" +
+	"\x10heartbeat_active\x18\b \x01(\bR\x0fheartbeatActive\"\xe8\x02\n" +
 	"\x17HeartbeatStatusResponse\x12\x16\n" +
 	"\x06active\x18\x01 \x01(\bR\x06active\x12)\n" +
 	"\x10interval_minutes\x18\x02 \x01(\x05R\x0fintervalMinutes\x12\x19\n" +
 	"\blast_run\x18\x03 \x01(\tR\alastRun\x12#\n" +
 	"\rlast_decision\x18\x04 \x01(\tR\flastDecision\x12)\n" +
 	"\x10total_heartbeats\x18\x05 \x01(\x05R\x0ftotalHeartbeats\x12#\n" +
-	"\ractions_taken\x18\x06 \x01(\x05R\factionsTaken\"\xda\x02\n" +
+	"\ractions_taken\x18\x06 \x01(\x05R\factionsTaken\x12\x19\n" +
+	"\bnext_run\x18\a \x01(\tR\anextRun\x12\x1f\n" +
+	"\vquiet_hours\x18\b \x01(\tR\n" +
+	"quietHours\x12\x1f\n" +
+	"\vactive_days\x18\t \x01(\tR\n" +
+	"activeDays\x12\x1d\n" +
+	"\n" +
+	"max_rounds\x18\n" +
+	" \x01(\x05R\tmaxRounds\"B\n" +
+	"\x1aGetHeartbeatHistoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"_\n" +
+	"\x10HeartbeatMessage\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1d\n" +
+	"\n" +
+	"tool_calls\x18\x03 \x03(\tR\ttoolCalls\"\x9f\x02\n" +
+	"\x0fHeartbeatRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\tR\ttimestamp\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1a\n" +
+	"\bdecision\x18\x04 \x01(\tR\bdecision\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12#\n" +
+	"\ractions_taken\x18\x06 \x01(\x05R\factionsTaken\x12\x16\n" +
+	"\x06rounds\x18\a \x01(\x05R\x06rounds\x12\x1f\n" +
+	"\vduration_ms\x18\b \x01(\x03R\n" +
+	"durationMs\x126\n" +
+	"\n" +
+	"transcript\x18\t \x03(\v2\x16.kele.HeartbeatMessageR\n" +
+	"transcript\"N\n" +
+	"\x1bGetHeartbeatHistoryResponse\x12/\n" +
+	"\arecords\x18\x01 \x03(\v2\x15.kele.HeartbeatRecordR\arecords\"(\n" +
+	"\x14ReindexMemoryRequest\x12\x10\n" +
+	"\x03all\x18\x01 \x01(\bR\x03all\"c\n" +
+	"\x15ReindexMemoryResponse\x12\x18\n" +
+	"\aindexed\x18\x01 \x01(\x05R\aindexed\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x1a\n" +
+	"\bembedder\x18\x03 \x01(\tR\bembedder\"\xa2\x01\n" +
+	"\x14SearchHistoryRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x18\n" +
+	"\asession\x18\x02 \x01(\tR\asession\x12\x18\n" +
+	"\achannel\x18\x03 \x01(\tR\achannel\x12\x14\n" +
+	"\x05since\x18\x04 \x01(\tR\x05since\x12\x14\n" +
+	"\x05until\x18\x05 \x01(\tR\x05until\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\"\xdd\x01\n" +
+	"\n" +
+	"HistoryHit\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\aturn_id\x18\x02 \x01(\x03R\x06turnId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tR\tsessionId\x12!\n" +
+	"\fsession_name\x18\x04 \x01(\tR\vsessionName\x12\x18\n" +
+	"\achannel\x18\x05 \x01(\tR\achannel\x12\x12\n" +
+	"\x04role\x18\x06 \x01(\tR\x04role\x12\x18\n" +
+	"\asnippet\x18\a \x01(\tR\asnippet\x12\x1c\n" +
+	"\ttimestamp\x18\b \x01(\tR\ttimestamp\"=\n" +
+	"\x15SearchHistoryResponse\x12$\n" +
+	"\x04hits\x18\x01 \x03(\v2\x10.kele.HistoryHitR\x04hits\"\xcd\x04\n" +
+	"\vCronJobInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bschedule\x18\x03 \x01(\tR\bschedule\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x18\n" +
+	"\acommand\x18\x05 \x01(\tR\acommand\x12\x16\n" +
+	"\x06prompt\x18\x06 \x01(\tR\x06prompt\x12\x14\n" +
+	"\x05model\x18\a \x01(\tR\x05model\x12\x14\n" +
+	"\x05tools\x18\b \x03(\tR\x05tools\x12\x19\n" +
+	"\bwork_dir\x18\t \x01(\tR\aworkDir\x12'\n" +
+	"\x0fdeliver_channel\x18\n" +
+	" \x01(\tR\x0edeliverChannel\x12%\n" +
+	"\x0edeliver_target\x18\v \x01(\tR\rdeliverTarget\x12!\n" +
+	"\fdeliver_when\x18\f \x01(\tR\vdeliverWhen\x12\x1a\n" +
+	"\btimezone\x18\r \x01(\tR\btimezone\x12\x18\n" +
+	"\aoverlap\x18\x0e \x01(\tR\aoverlap\x12\x19\n" +
+	"\bcatch_up\x18\x0f \x01(\tR\acatchUp\x12\x18\n" +
+	"\aenabled\x18\x10 \x01(\bR\aenabled\x12\x19\n" +
+	"\blast_run\x18\x11 \x01(\tR\alastRun\x12\x19\n" +
+	"\bnext_run\x18\x12 \x01(\tR\anextRun\x12\x1f\n" +
+	"\vlast_result\x18\x13 \x01(\tR\n" +
+	"lastResult\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x14 \x01(\tR\tlastError\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x15 \x01(\tR\tcreatedAt\"=\n" +
+	"\x14ListCronJobsResponse\x12%\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x11.kele.CronJobInfoR\x04jobs\" \n" +
+	"\x0eCronJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x97\x03\n" +
+	"\x14CreateCronJobRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bschedule\x18\x02 \x01(\tR\bschedule\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x18\n" +
+	"\acommand\x18\x04 \x01(\tR\acommand\x12\x16\n" +
+	"\x06prompt\x18\x05 \x01(\tR\x06prompt\x12\x14\n" +
+	"\x05model\x18\x06 \x01(\tR\x05model\x12\x14\n" +
+	"\x05tools\x18\a \x03(\tR\x05tools\x12\x19\n" +
+	"\bwork_dir\x18\b \x01(\tR\aworkDir\x12'\n" +
+	"\x0fdeliver_channel\x18\t \x01(\tR\x0edeliverChannel\x12%\n" +
+	"\x0edeliver_target\x18\n" +
+	" \x01(\tR\rdeliverTarget\x12!\n" +
+	"\fdeliver_when\x18\v \x01(\tR\vdeliverWhen\x12\x1a\n" +
+	"\btimezone\x18\f \x01(\tR\btimezone\x12\x18\n" +
+	"\aoverlap\x18\r \x01(\tR\aoverlap\x12\x19\n" +
+	"\bcatch_up\x18\x0e \x01(\tR\acatchUp\"\xb0\x05\n" +
+	"\x14UpdateCronJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x1f\n" +
+	"\bschedule\x18\x03 \x01(\tH\x01R\bschedule\x88\x01\x01\x12\x17\n" +
+	"\x04type\x18\x04 \x01(\tH\x02R\x04type\x88\x01\x01\x12\x1d\n" +
+	"\acommand\x18\x05 \x01(\tH\x03R\acommand\x88\x01\x01\x12\x1b\n" +
+	"\x06prompt\x18\x06 \x01(\tH\x04R\x06prompt\x88\x01\x01\x12\x19\n" +
+	"\x05model\x18\a \x01(\tH\x05R\x05model\x88\x01\x01\x12\x14\n" +
+	"\x05tools\x18\b \x03(\tR\x05tools\x12\x1b\n" +
+	"\tset_tools\x18\t \x01(\bR\bsetTools\x12\x1e\n" +
+	"\bwork_dir\x18\n" +
+	" \x01(\tH\x06R\aworkDir\x88\x01\x01\x12,\n" +
+	"\x0fdeliver_channel\x18\v \x01(\tH\aR\x0edeliverChannel\x88\x01\x01\x12*\n" +
+	"\x0edeliver_target\x18\f \x01(\tH\bR\rdeliverTarget\x88\x01\x01\x12&\n" +
+	"\fdeliver_when\x18\r \x01(\tH\tR\vdeliverWhen\x88\x01\x01\x12\x1f\n" +
+	"\btimezone\x18\x0e \x01(\tH\n" +
+	"R\btimezone\x88\x01\x01\x12\x1d\n" +
+	"\aoverlap\x18\x0f \x01(\tH\vR\aoverlap\x88\x01\x01\x12\x1e\n" +
+	"\bcatch_up\x18\x10 \x01(\tH\fR\acatchUp\x88\x01\x01B\a\n" +
+	"\x05_nameB\v\n" +
+	"\t_scheduleB\a\n" +
+	"\x05_typeB\n" +
+	"\n" +
+	"\b_commandB\t\n" +
+	"\a_promptB\b\n" +
+	"\x06_modelB\v\n" +
+	"\t_work_dirB\x12\n" +
+	"\x10_deliver_channelB\x11\n" +
+	"\x0f_deliver_targetB\x0f\n" +
+	"\r_deliver_whenB\v\n" +
+	"\t_timezoneB\n" +
+	"\n" +
+	"\b_overlapB\v\n" +
+	"\t_catch_up\"D\n" +
+	"\x18SetCronJobEnabledRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\"\x9b\x01\n" +
+	"\fCronLogEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x15\n" +
+	"\x06run_at\x18\x03 \x01(\tR\x05runAt\x12\x16\n" +
+	"\x06output\x18\x04 \x01(\tR\x06output\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12\x1f\n" +
+	"\vduration_ms\x18\x06 \x01(\x03R\n" +
+	"durationMs\":\n" +
+	"\x12GetCronLogsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"=\n" +
+	"\x13GetCronLogsResponse\x12&\n" +
+	"\x04logs\x18\x01 \x03(\v2\x12.kele.CronLogEntryR\x04logs\"z\n" +
+	"\x1aPreviewCronScheduleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bschedule\x18\x02 \x01(\tR\bschedule\x12\x1a\n" +
+	"\btimezone\x18\x03 \x01(\tR\btimezone\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\"3\n" +
+	"\x1bPreviewCronScheduleResponse\x12\x14\n" +
+	"\x05times\x18\x01 \x03(\tR\x05times\"\x9f\x04\n" +
 	"\rWorkspaceInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -2692,29 +6747,63 @@ const file_proto_kele_proto_rawDesc = "" +
 	" \x01(\x05R\ttaskCount\x12#\n" +
 	"\rrunning_count\x18\v \x01(\x05R\frunningCount\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\f \x01(\tR\tcreatedAt\"\xbe\x01\n" +
+	"created_at\x18\f \x01(\tR\tcreatedAt\x12!\n" +
+	"\fmerge_policy\x18\r \x01(\tR\vmergePolicy\x120\n" +
+	"\x14task_timeout_seconds\x18\x0e \x01(\x03R\x12taskTimeoutSeconds\x12#\n" +
+	"\rapproval_tags\x18\x0f \x03(\tR\fapprovalTags\x12(\n" +
+	"\x06budget\x18\x10 \x01(\v2\x10.kele.BudgetInfoR\x06budget\x12\x1f\n" +
+	"\vschedule_id\x18\x11 \x01(\tR\n" +
+	"scheduleId\"\xea\x02\n" +
+	"\n" +
+	"BudgetInfo\x12\x1d\n" +
+	"\n" +
+	"max_tokens\x18\x01 \x01(\x03R\tmaxTokens\x12\x19\n" +
+	"\bmax_cost\x18\x02 \x01(\x01R\amaxCost\x12\x1f\n" +
+	"\vtoken_price\x18\x03 \x01(\x01R\n" +
+	"tokenPrice\x120\n" +
+	"\x14max_duration_seconds\x18\x04 \x01(\x03R\x12maxDurationSeconds\x12$\n" +
+	"\x0emax_tool_calls\x18\x05 \x01(\x05R\fmaxToolCalls\x12\x1f\n" +
+	"\vused_tokens\x18\x06 \x01(\x03R\n" +
+	"usedTokens\x12&\n" +
+	"\x0fused_tool_calls\x18\a \x01(\x03R\rusedToolCalls\x12\x1b\n" +
+	"\tused_cost\x18\b \x01(\x01R\busedCost\x12'\n" +
+	"\x0felapsed_seconds\x18\t \x01(\x03R\x0eelapsedSeconds\x12\x1a\n" +
+	"\bexceeded\x18\n" +
+	" \x01(\tR\bexceeded\"\xe2\x02\n" +
 	"\x16CreateWorkspaceRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x12\n" +
 	"\x04goal\x18\x03 \x01(\tR\x04goal\x12%\n" +
 	"\x0emax_concurrent\x18\x04 \x01(\x05R\rmaxConcurrent\x12\x18\n" +
 	"\acontext\x18\x05 \x01(\tR\acontext\x12\x19\n" +
-	"\bwork_dir\x18\x06 \x01(\tR\aworkDir\"%\n" +
+	"\bwork_dir\x18\x06 \x01(\tR\aworkDir\x12!\n" +
+	"\fmerge_policy\x18\a \x01(\tR\vmergePolicy\x120\n" +
+	"\x14task_timeout_seconds\x18\b \x01(\x03R\x12taskTimeoutSeconds\x12#\n" +
+	"\rapproval_tags\x18\t \x03(\tR\fapprovalTags\x12(\n" +
+	"\x06budget\x18\n" +
+	" \x01(\v2\x10.kele.BudgetInfoR\x06budget\"%\n" +
 	"\x13GetWorkspaceRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xb7\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xa5\x03\n" +
 	"\x16UpdateWorkspaceRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12%\n" +
 	"\x0emax_concurrent\x18\x04 \x01(\x05R\rmaxConcurrent\x12\x18\n" +
 	"\acontext\x18\x05 \x01(\tR\acontext\x12\x16\n" +
-	"\x06status\x18\x06 \x01(\tR\x06status\"(\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12!\n" +
+	"\fmerge_policy\x18\a \x01(\tR\vmergePolicy\x125\n" +
+	"\x14task_timeout_seconds\x18\b \x01(\x03H\x00R\x12taskTimeoutSeconds\x88\x01\x01\x12#\n" +
+	"\rapproval_tags\x18\t \x03(\tR\fapprovalTags\x12*\n" +
+	"\x11set_approval_tags\x18\n" +
+	" \x01(\bR\x0fsetApprovalTags\x12(\n" +
+	"\x06budget\x18\v \x01(\v2\x10.kele.BudgetInfoR\x06budgetB\x17\n" +
+	"\x15_task_timeout_seconds\"(\n" +
 	"\x16DeleteWorkspaceRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"M\n" +
 	"\x16ListWorkspacesResponse\x123\n" +
 	"\n" +
 	"workspaces\x18\x01 \x03(\v2\x13.kele.WorkspaceInfoR\n" +
-	"workspaces\"\xf0\x03\n" +
+	"workspaces\"\x8d\t\n" +
 	"\bTaskInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
 	"\fworkspace_id\x18\x02 \x01(\tR\vworkspaceId\x12\x14\n" +
@@ -2738,7 +6827,28 @@ const file_proto_kele_proto_rawDesc = "" +
 	"created_at\x18\x0f \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
 	"started_at\x18\x10 \x01(\tR\tstartedAt\x12!\n" +
-	"\fcompleted_at\x18\x11 \x01(\tR\vcompletedAt\"\x95\x02\n" +
+	"\fcompleted_at\x18\x11 \x01(\tR\vcompletedAt\x12\x16\n" +
+	"\x06branch\x18\x12 \x01(\tR\x06branch\x12\x12\n" +
+	"\x04diff\x18\x13 \x01(\tR\x04diff\x12!\n" +
+	"\fmerge_status\x18\x14 \x01(\tR\vmergeStatus\x12%\n" +
+	"\x0everify_command\x18\x15 \x01(\tR\rverifyCommand\x12#\n" +
+	"\rverify_prompt\x18\x16 \x01(\tR\fverifyPrompt\x12\x18\n" +
+	"\averdict\x18\x17 \x01(\tR\averdict\x12!\n" +
+	"\fverify_notes\x18\x18 \x01(\tR\vverifyNotes\x12%\n" +
+	"\x0eblocked_reason\x18\x19 \x01(\tR\rblockedReason\x12'\n" +
+	"\x0ftimeout_seconds\x18\x1a \x01(\x03R\x0etimeoutSeconds\x12+\n" +
+	"\x11requires_approval\x18\x1b \x01(\bR\x10requiresApproval\x12'\n" +
+	"\x0freview_feedback\x18\x1c \x01(\tR\x0ereviewFeedback\x12\x14\n" +
+	"\x05model\x18\x1d \x01(\tR\x05model\x12%\n" +
+	"\vtemperature\x18\x1e \x01(\x01H\x00R\vtemperature\x88\x01\x01\x12\x14\n" +
+	"\x05tools\x18\x1f \x03(\tR\x05tools\x12&\n" +
+	"\x0fmax_tool_rounds\x18  \x01(\x05R\rmaxToolRounds\x12\x1c\n" +
+	"\tartifacts\x18! \x03(\tR\tartifacts\x12&\n" +
+	"\x0fnext_attempt_at\x18\" \x01(\tR\rnextAttemptAt\x12\x1f\n" +
+	"\verror_class\x18# \x01(\tR\n" +
+	"errorClass\x12+\n" +
+	"\x11transient_retries\x18$ \x01(\x05R\x10transientRetriesB\x0e\n" +
+	"\f_temperature\"\xe0\x04\n" +
 	"\x11CreateTaskRequest\x12!\n" +
 	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -2751,16 +6861,47 @@ const file_proto_kele_proto_rawDesc = "" +
 	"maxRetries\x12\x12\n" +
 	"\x04tags\x18\b \x03(\tR\x04tags\x12\x1d\n" +
 	"\n" +
-	"auto_ready\x18\t \x01(\bR\tautoReady\" \n" +
+	"auto_ready\x18\t \x01(\bR\tautoReady\x12%\n" +
+	"\x0everify_command\x18\n" +
+	" \x01(\tR\rverifyCommand\x12#\n" +
+	"\rverify_prompt\x18\v \x01(\tR\fverifyPrompt\x12'\n" +
+	"\x0ftimeout_seconds\x18\f \x01(\x03R\x0etimeoutSeconds\x12+\n" +
+	"\x11requires_approval\x18\r \x01(\bR\x10requiresApproval\x12\x14\n" +
+	"\x05model\x18\x0e \x01(\tR\x05model\x12%\n" +
+	"\vtemperature\x18\x0f \x01(\x01H\x00R\vtemperature\x88\x01\x01\x12\x14\n" +
+	"\x05tools\x18\x10 \x03(\tR\x05tools\x12&\n" +
+	"\x0fmax_tool_rounds\x18\x11 \x01(\x05R\rmaxToolRounds\x12\x1c\n" +
+	"\tartifacts\x18\x12 \x03(\tR\tartifactsB\x0e\n" +
+	"\f_temperature\" \n" +
 	"\x0eGetTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xa3\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xd1\x05\n" +
 	"\x11UpdateTaskRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
 	"\x06prompt\x18\x04 \x01(\tR\x06prompt\x12\x1a\n" +
 	"\bpriority\x18\x05 \x01(\x05R\bpriority\x12\x12\n" +
-	"\x04tags\x18\x06 \x03(\tR\x04tags\"#\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12%\n" +
+	"\x0everify_command\x18\a \x01(\tR\rverifyCommand\x12#\n" +
+	"\rverify_prompt\x18\b \x01(\tR\fverifyPrompt\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\t \x03(\tR\tdependsOn\x12$\n" +
+	"\x0eset_depends_on\x18\n" +
+	" \x01(\bR\fsetDependsOn\x12,\n" +
+	"\x0ftimeout_seconds\x18\v \x01(\x03H\x00R\x0etimeoutSeconds\x88\x01\x01\x120\n" +
+	"\x11requires_approval\x18\f \x01(\bH\x01R\x10requiresApproval\x88\x01\x01\x12\x19\n" +
+	"\x05model\x18\r \x01(\tH\x02R\x05model\x88\x01\x01\x12%\n" +
+	"\vtemperature\x18\x0e \x01(\x01H\x03R\vtemperature\x88\x01\x01\x12\x14\n" +
+	"\x05tools\x18\x0f \x03(\tR\x05tools\x12\x1b\n" +
+	"\tset_tools\x18\x10 \x01(\bR\bsetTools\x12+\n" +
+	"\x0fmax_tool_rounds\x18\x11 \x01(\x05H\x04R\rmaxToolRounds\x88\x01\x01\x12\x1c\n" +
+	"\tartifacts\x18\x12 \x03(\tR\tartifacts\x12#\n" +
+	"\rset_artifacts\x18\x13 \x01(\bR\fsetArtifactsB\x12\n" +
+	"\x10_timeout_secondsB\x14\n" +
+	"\x12_requires_approvalB\b\n" +
+	"\x06_modelB\x0e\n" +
+	"\f_temperatureB\x12\n" +
+	"\x10_max_tool_rounds\"#\n" +
 	"\x11DeleteTaskRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"y\n" +
 	"\x10ListTasksRequest\x12!\n" +
@@ -2775,24 +6916,80 @@ const file_proto_kele_proto_rawDesc = "" +
 	"\x11CancelTaskRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\"\n" +
 	"\x10RetryTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"l\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\"\n" +
+	"\x10MergeTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"?\n" +
+	"\x11ReviewTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bfeedback\x18\x02 \x01(\tR\bfeedback\"l\n" +
 	"\x14PlanWorkspaceRequest\x12\x12\n" +
 	"\x04goal\x18\x01 \x01(\tR\x04goal\x12\x19\n" +
 	"\bwork_dir\x18\x02 \x01(\tR\aworkDir\x12%\n" +
-	"\x0emax_concurrent\x18\x03 \x01(\x05R\rmaxConcurrent\"Y\n" +
+	"\x0emax_concurrent\x18\x03 \x01(\x05R\rmaxConcurrent\"t\n" +
 	"\fPlanEventMsg\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1b\n" +
-	"\tplan_json\x18\x03 \x01(\tR\bplanJson\"\x7f\n" +
+	"\tplan_json\x18\x03 \x01(\tR\bplanJson\x12\x19\n" +
+	"\bdraft_id\x18\x04 \x01(\tR\adraftId\"\x9a\x01\n" +
 	"\x12ApprovePlanRequest\x12\x1b\n" +
 	"\tplan_json\x18\x01 \x01(\tR\bplanJson\x12\x12\n" +
 	"\x04goal\x18\x02 \x01(\tR\x04goal\x12\x19\n" +
 	"\bwork_dir\x18\x03 \x01(\tR\aworkDir\x12\x1d\n" +
 	"\n" +
-	"auto_start\x18\x04 \x01(\bR\tautoStart\"n\n" +
+	"auto_start\x18\x04 \x01(\bR\tautoStart\x12\x19\n" +
+	"\bdraft_id\x18\x05 \x01(\tR\adraftId\"n\n" +
 	"\x13ApprovePlanResponse\x121\n" +
 	"\tworkspace\x18\x01 \x01(\v2\x13.kele.WorkspaceInfoR\tworkspace\x12$\n" +
-	"\x05tasks\x18\x02 \x03(\v2\x0e.kele.TaskInfoR\x05tasks\"\xe2\x01\n" +
+	"\x05tasks\x18\x02 \x03(\v2\x0e.kele.TaskInfoR\x05tasks\"\xa9\x01\n" +
+	"\rPlanDraftInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04goal\x18\x02 \x01(\tR\x04goal\x12\x19\n" +
+	"\bwork_dir\x18\x03 \x01(\tR\aworkDir\x12\x1b\n" +
+	"\tplan_json\x18\x04 \x01(\tR\bplanJson\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\tR\tupdatedAt\"E\n" +
+	"\x16ListPlanDraftsResponse\x12+\n" +
+	"\x06drafts\x18\x01 \x03(\v2\x13.kele.PlanDraftInfoR\x06drafts\"%\n" +
+	"\x13GetPlanDraftRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"(\n" +
+	"\x16DeletePlanDraftRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x8e\x02\n" +
+	"\x12AddPlanTaskRequest\x12\x19\n" +
+	"\bdraft_id\x18\x01 \x01(\tR\adraftId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06prompt\x18\x04 \x01(\tR\x06prompt\x12\x1f\n" +
+	"\bpriority\x18\x05 \x01(\x05H\x00R\bpriority\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\x06 \x03(\x05R\tdependsOn\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12\x1f\n" +
+	"\bposition\x18\b \x01(\x05H\x01R\bposition\x88\x01\x01B\v\n" +
+	"\t_priorityB\v\n" +
+	"\t_position\"H\n" +
+	"\x15RemovePlanTaskRequest\x12\x19\n" +
+	"\bdraft_id\x18\x01 \x01(\tR\adraftId\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x05R\x05index\"T\n" +
+	"\x13MovePlanTaskRequest\x12\x19\n" +
+	"\bdraft_id\x18\x01 \x01(\tR\adraftId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x05R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x05R\x02to\"\x9f\x02\n" +
+	"\x15UpdatePlanTaskRequest\x12\x19\n" +
+	"\bdraft_id\x18\x01 \x01(\tR\adraftId\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x05R\x05index\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06prompt\x18\x05 \x01(\tR\x06prompt\x12\x1f\n" +
+	"\bpriority\x18\x06 \x01(\x05H\x00R\bpriority\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\a \x03(\x05R\tdependsOn\x12$\n" +
+	"\x0eset_depends_on\x18\b \x01(\bR\fsetDependsOn\x12\x12\n" +
+	"\x04tags\x18\t \x03(\tR\x04tagsB\v\n" +
+	"\t_priority\"J\n" +
+	"\x11RevisePlanRequest\x12\x19\n" +
+	"\bdraft_id\x18\x01 \x01(\tR\adraftId\x12\x1a\n" +
+	"\bfeedback\x18\x02 \x01(\tR\bfeedback\"\xe2\x01\n" +
 	"\x10BoardOverviewMsg\x12:\n" +
 	"\n" +
 	"workspaces\x18\x01 \x03(\v2\x1a.kele.WorkspaceOverviewMsgR\n" +
@@ -2801,7 +6998,7 @@ const file_proto_kele_proto_rawDesc = "" +
 	"totalTasks\x12#\n" +
 	"\rrunning_tasks\x18\x03 \x01(\x05R\frunningTasks\x12#\n" +
 	"\rpending_tasks\x18\x04 \x01(\x05R\fpendingTasks\x12'\n" +
-	"\x0fcompleted_tasks\x18\x05 \x01(\x05R\x0ecompletedTasks\"\xef\x01\n" +
+	"\x0fcompleted_tasks\x18\x05 \x01(\x05R\x0ecompletedTasks\"\xcb\x02\n" +
 	"\x14WorkspaceOverviewMsg\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
@@ -2811,7 +7008,11 @@ const file_proto_kele_proto_rawDesc = "" +
 	"\arunning\x18\x06 \x01(\x05R\arunning\x12\x12\n" +
 	"\x04done\x18\a \x01(\x05R\x04done\x12\x16\n" +
 	"\x06failed\x18\b \x01(\x05R\x06failed\x12%\n" +
-	"\x0emax_concurrent\x18\t \x01(\x05R\rmaxConcurrent\"6\n" +
+	"\x0emax_concurrent\x18\t \x01(\x05R\rmaxConcurrent\x12\x18\n" +
+	"\ablocked\x18\n" +
+	" \x01(\x05R\ablocked\x12\x16\n" +
+	"\x06review\x18\v \x01(\x05R\x06review\x12(\n" +
+	"\x06budget\x18\f \x01(\v2\x10.kele.BudgetInfoR\x06budget\"6\n" +
 	"\x11WatchBoardRequest\x12!\n" +
 	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\"\x95\x01\n" +
 	"\rBoardEventMsg\x12\x12\n" +
@@ -2830,7 +7031,89 @@ const file_proto_kele_proto_rawDesc = "" +
 	"\ttool_name\x18\x03 \x01(\tR\btoolName\x12\x1c\n" +
 	"\ttimestamp\x18\x04 \x01(\tR\ttimestamp\"?\n" +
 	"\x0fTaskLogResponse\x12,\n" +
-	"\aentries\x18\x01 \x03(\v2\x12.kele.TaskLogEntryR\aentries2\x94\f\n" +
+	"\aentries\x18\x01 \x03(\v2\x12.kele.TaskLogEntryR\aentries\"\x8d\x01\n" +
+	"\x12SearchTasksRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12!\n" +
+	"\fworkspace_id\x18\x02 \x01(\tR\vworkspaceId\x12\x12\n" +
+	"\x04tool\x18\x03 \x01(\tR\x04tool\x12\x14\n" +
+	"\x05since\x18\x04 \x01(\tR\x05since\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"\x9b\x01\n" +
+	"\x0fTaskSearchMatch\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12\x1b\n" +
+	"\ttool_name\x18\x03 \x01(\tR\btoolName\x12\x18\n" +
+	"\asnippet\x18\x04 \x01(\tR\asnippet\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\tR\ttimestamp\"\xcf\x01\n" +
+	"\rTaskSearchHit\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12!\n" +
+	"\fworkspace_id\x18\x02 \x01(\tR\vworkspaceId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12#\n" +
+	"\rtotal_matches\x18\x05 \x01(\x05R\ftotalMatches\x12/\n" +
+	"\amatches\x18\x06 \x03(\v2\x15.kele.TaskSearchMatchR\amatches\">\n" +
+	"\x13SearchTasksResponse\x12'\n" +
+	"\x04hits\x18\x01 \x03(\v2\x13.kele.TaskSearchHitR\x04hits\"\xf1\x02\n" +
+	"\x15WorkspaceScheduleInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vtemplate_id\x18\x02 \x01(\tR\n" +
+	"templateId\x12#\n" +
+	"\rtemplate_name\x18\x03 \x01(\tR\ftemplateName\x12\x12\n" +
+	"\x04cron\x18\x04 \x01(\tR\x04cron\x12\x18\n" +
+	"\achannel\x18\x05 \x01(\tR\achannel\x12\x16\n" +
+	"\x06target\x18\x06 \x01(\tR\x06target\x12\x18\n" +
+	"\aenabled\x18\a \x01(\bR\aenabled\x12\x19\n" +
+	"\blast_run\x18\b \x01(\tR\alastRun\x12\x19\n" +
+	"\bnext_run\x18\t \x01(\tR\anextRun\x12*\n" +
+	"\x11last_workspace_id\x18\n" +
+	" \x01(\tR\x0flastWorkspaceId\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\v \x01(\tR\tlastError\x12!\n" +
+	"\flast_summary\x18\f \x01(\tR\vlastSummary\"\x87\x01\n" +
+	"\x1eCreateWorkspaceScheduleRequest\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\tR\n" +
+	"templateId\x12\x12\n" +
+	"\x04cron\x18\x02 \x01(\tR\x04cron\x12\x18\n" +
+	"\achannel\x18\x03 \x01(\tR\achannel\x12\x16\n" +
+	"\x06target\x18\x04 \x01(\tR\x06target\"*\n" +
+	"\x18WorkspaceScheduleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"N\n" +
+	"\"SetWorkspaceScheduleEnabledRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\"[\n" +
+	"\x1eListWorkspaceSchedulesResponse\x129\n" +
+	"\tschedules\x18\x01 \x03(\v2\x1b.kele.WorkspaceScheduleInfoR\tschedules\"\x80\x01\n" +
+	"\x16ExportWorkspaceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x12!\n" +
+	"\fwith_results\x18\x03 \x01(\bR\vwithResults\x12\x1b\n" +
+	"\tlog_limit\x18\x04 \x01(\x05R\blogLimit\"-\n" +
+	"\x17ExportWorkspaceResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\xd4\x01\n" +
+	"\x16ImportWorkspaceRequest\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12:\n" +
+	"\x04vars\x18\x02 \x03(\v2&.kele.ImportWorkspaceRequest.VarsEntryR\x04vars\x12\x19\n" +
+	"\bwork_dir\x18\x03 \x01(\tR\aworkDir\x12\x16\n" +
+	"\x06paused\x18\x04 \x01(\bR\x06paused\x1a7\n" +
+	"\tVarsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x96\x01\n" +
+	"\fArtifactInfo\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x12\n" +
+	"\x04path\x18\x05 \x01(\tR\x04path\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\"M\n" +
+	"\x19ListTaskArtifactsResponse\x120\n" +
+	"\tartifacts\x18\x01 \x03(\v2\x12.kele.ArtifactInfoR\tartifacts\"E\n" +
+	"\x16GetTaskArtifactRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"P\n" +
+	"\fTaskArtifact\x12&\n" +
+	"\x04info\x18\x01 \x01(\v2\x12.kele.ArtifactInfoR\x04info\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent2\xc4\x1e\n" +
 	"\vKeleService\x12,\n" +
 	"\x04Chat\x12\x11.kele.ChatRequest\x1a\x0f.kele.ChatEvent0\x01\x129\n" +
 	"\bComplete\x12\x15.kele.CompleteRequest\x1a\x16.kele.CompleteResponse\x12?\n" +
@@ -2840,12 +7123,34 @@ const file_proto_kele_proto_rawDesc = "" +
 	"\rDeleteSession\x12\x1a.kele.DeleteSessionRequest\x1a\v.kele.Empty\x127\n" +
 	"\fListSessions\x12\v.kele.Empty\x1a\x1a.kele.ListSessionsResponse\x12.\n" +
 	"\tGetStatus\x12\v.kele.Empty\x1a\x14.kele.StatusResponse\x12@\n" +
-	"\x12GetHeartbeatStatus\x12\v.kele.Empty\x1a\x1d.kele.HeartbeatStatusResponse\x12D\n" +
+	"\x12GetHeartbeatStatus\x12\v.kele.Empty\x1a\x1d.kele.HeartbeatStatusResponse\x12Z\n" +
+	"\x13GetHeartbeatHistory\x12 .kele.GetHeartbeatHistoryRequest\x1a!.kele.GetHeartbeatHistoryResponse\x12H\n" +
+	"\rReindexMemory\x12\x1a.kele.ReindexMemoryRequest\x1a\x1b.kele.ReindexMemoryResponse\x12H\n" +
+	"\rSearchHistory\x12\x1a.kele.SearchHistoryRequest\x1a\x1b.kele.SearchHistoryResponse\x127\n" +
+	"\fListCronJobs\x12\v.kele.Empty\x1a\x1a.kele.ListCronJobsResponse\x125\n" +
+	"\n" +
+	"GetCronJob\x12\x14.kele.CronJobRequest\x1a\x11.kele.CronJobInfo\x12>\n" +
+	"\rCreateCronJob\x12\x1a.kele.CreateCronJobRequest\x1a\x11.kele.CronJobInfo\x12>\n" +
+	"\rUpdateCronJob\x12\x1a.kele.UpdateCronJobRequest\x1a\x11.kele.CronJobInfo\x122\n" +
+	"\rDeleteCronJob\x12\x14.kele.CronJobRequest\x1a\v.kele.Empty\x12F\n" +
+	"\x11SetCronJobEnabled\x12\x1e.kele.SetCronJobEnabledRequest\x1a\x11.kele.CronJobInfo\x126\n" +
+	"\n" +
+	"RunCronJob\x12\x14.kele.CronJobRequest\x1a\x12.kele.CronLogEntry\x12B\n" +
+	"\vGetCronLogs\x12\x18.kele.GetCronLogsRequest\x1a\x19.kele.GetCronLogsResponse\x12Z\n" +
+	"\x13PreviewCronSchedule\x12 .kele.PreviewCronScheduleRequest\x1a!.kele.PreviewCronScheduleResponse\x12D\n" +
 	"\x0fCreateWorkspace\x12\x1c.kele.CreateWorkspaceRequest\x1a\x13.kele.WorkspaceInfo\x12>\n" +
 	"\fGetWorkspace\x12\x19.kele.GetWorkspaceRequest\x1a\x13.kele.WorkspaceInfo\x12D\n" +
 	"\x0fUpdateWorkspace\x12\x1c.kele.UpdateWorkspaceRequest\x1a\x13.kele.WorkspaceInfo\x12<\n" +
 	"\x0fDeleteWorkspace\x12\x1c.kele.DeleteWorkspaceRequest\x1a\v.kele.Empty\x12;\n" +
-	"\x0eListWorkspaces\x12\v.kele.Empty\x1a\x1c.kele.ListWorkspacesResponse\x125\n" +
+	"\x0eListWorkspaces\x12\v.kele.Empty\x1a\x1c.kele.ListWorkspacesResponse\x12G\n" +
+	"\x15MakeWorkspaceTemplate\x12\x19.kele.GetWorkspaceRequest\x1a\x13.kele.WorkspaceInfo\x12\\\n" +
+	"\x17CreateWorkspaceSchedule\x12$.kele.CreateWorkspaceScheduleRequest\x1a\x1b.kele.WorkspaceScheduleInfo\x12K\n" +
+	"\x16ListWorkspaceSchedules\x12\v.kele.Empty\x1a$.kele.ListWorkspaceSchedulesResponse\x12F\n" +
+	"\x17DeleteWorkspaceSchedule\x12\x1e.kele.WorkspaceScheduleRequest\x1a\v.kele.Empty\x12d\n" +
+	"\x1bSetWorkspaceScheduleEnabled\x12(.kele.SetWorkspaceScheduleEnabledRequest\x1a\x1b.kele.WorkspaceScheduleInfo\x12K\n" +
+	"\x14RunWorkspaceSchedule\x12\x1e.kele.WorkspaceScheduleRequest\x1a\x13.kele.WorkspaceInfo\x12N\n" +
+	"\x0fExportWorkspace\x12\x1c.kele.ExportWorkspaceRequest\x1a\x1d.kele.ExportWorkspaceResponse\x12D\n" +
+	"\x0fImportWorkspace\x12\x1c.kele.ImportWorkspaceRequest\x1a\x13.kele.WorkspaceInfo\x125\n" +
 	"\n" +
 	"CreateTask\x12\x17.kele.CreateTaskRequest\x1a\x0e.kele.TaskInfo\x12/\n" +
 	"\aGetTask\x12\x14.kele.GetTaskRequest\x1a\x0e.kele.TaskInfo\x128\n" +
@@ -2856,14 +7161,30 @@ const file_proto_kele_proto_rawDesc = "" +
 	"\tStartTask\x12\x16.kele.StartTaskRequest\x1a\x0e.kele.TaskInfo\x125\n" +
 	"\n" +
 	"CancelTask\x12\x17.kele.CancelTaskRequest\x1a\x0e.kele.TaskInfo\x123\n" +
-	"\tRetryTask\x12\x16.kele.RetryTaskRequest\x1a\x0e.kele.TaskInfo\x12A\n" +
+	"\tRetryTask\x12\x16.kele.RetryTaskRequest\x1a\x0e.kele.TaskInfo\x123\n" +
+	"\tMergeTask\x12\x16.kele.MergeTaskRequest\x1a\x0e.kele.TaskInfo\x126\n" +
+	"\vApproveTask\x12\x17.kele.ReviewTaskRequest\x1a\x0e.kele.TaskInfo\x125\n" +
+	"\n" +
+	"RejectTask\x12\x17.kele.ReviewTaskRequest\x1a\x0e.kele.TaskInfo\x12J\n" +
+	"\x11ListTaskArtifacts\x12\x14.kele.GetTaskRequest\x1a\x1f.kele.ListTaskArtifactsResponse\x12C\n" +
+	"\x0fGetTaskArtifact\x12\x1c.kele.GetTaskArtifactRequest\x1a\x12.kele.TaskArtifact\x12A\n" +
 	"\rPlanWorkspace\x12\x1a.kele.PlanWorkspaceRequest\x1a\x12.kele.PlanEventMsg0\x01\x12B\n" +
-	"\vApprovePlan\x12\x18.kele.ApprovePlanRequest\x1a\x19.kele.ApprovePlanResponse\x127\n" +
+	"\vApprovePlan\x12\x18.kele.ApprovePlanRequest\x1a\x19.kele.ApprovePlanResponse\x12;\n" +
+	"\x0eListPlanDrafts\x12\v.kele.Empty\x1a\x1c.kele.ListPlanDraftsResponse\x12>\n" +
+	"\fGetPlanDraft\x12\x19.kele.GetPlanDraftRequest\x1a\x13.kele.PlanDraftInfo\x12<\n" +
+	"\x0fDeletePlanDraft\x12\x1c.kele.DeletePlanDraftRequest\x1a\v.kele.Empty\x12<\n" +
+	"\vAddPlanTask\x12\x18.kele.AddPlanTaskRequest\x1a\x13.kele.PlanDraftInfo\x12B\n" +
+	"\x0eRemovePlanTask\x12\x1b.kele.RemovePlanTaskRequest\x1a\x13.kele.PlanDraftInfo\x12>\n" +
+	"\fMovePlanTask\x12\x19.kele.MovePlanTaskRequest\x1a\x13.kele.PlanDraftInfo\x12B\n" +
+	"\x0eUpdatePlanTask\x12\x1b.kele.UpdatePlanTaskRequest\x1a\x13.kele.PlanDraftInfo\x12;\n" +
+	"\n" +
+	"RevisePlan\x12\x17.kele.RevisePlanRequest\x1a\x12.kele.PlanEventMsg0\x01\x127\n" +
 	"\x10GetBoardOverview\x12\v.kele.Empty\x1a\x16.kele.BoardOverviewMsg\x12<\n" +
 	"\n" +
 	"WatchBoard\x12\x17.kele.WatchBoardRequest\x1a\x13.kele.BoardEventMsg0\x01\x12<\n" +
 	"\n" +
-	"GetTaskLog\x12\x17.kele.GetTaskLogRequest\x1a\x15.kele.TaskLogResponseB+Z)github.com/BlakeLiAFK/kele/internal/protob\x06proto3"
+	"GetTaskLog\x12\x17.kele.GetTaskLogRequest\x1a\x15.kele.TaskLogResponse\x12B\n" +
+	"\vSearchTasks\x12\x18.kele.SearchTasksRequest\x1a\x19.kele.SearchTasksResponseB+Z)github.com/BlakeLiAFK/kele/internal/protob\x06proto3"
 
 var (
 	file_proto_kele_proto_rawDescOnce sync.Once
@@ -2877,114 +7198,247 @@ func file_proto_kele_proto_rawDescGZIP() []byte {
 	return file_proto_kele_proto_rawDescData
 }
 
-var file_proto_kele_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
+var file_proto_kele_proto_msgTypes = make([]protoimpl.MessageInfo, 89)
 var file_proto_kele_proto_goTypes = []any{
-	(*Empty)(nil),                   // 0: kele.Empty
-	(*ChatRequest)(nil),             // 1: kele.ChatRequest
-	(*ChatEvent)(nil),               // 2: kele.ChatEvent
-	(*CompleteRequest)(nil),         // 3: kele.CompleteRequest
-	(*CompleteResponse)(nil),        // 4: kele.CompleteResponse
-	(*RunCommandRequest)(nil),       // 5: kele.RunCommandRequest
-	(*RunCommandResponse)(nil),      // 6: kele.RunCommandResponse
-	(*CreateSessionRequest)(nil),    // 7: kele.CreateSessionRequest
-	(*DeleteSessionRequest)(nil),    // 8: kele.DeleteSessionRequest
-	(*SessionInfo)(nil),             // 9: kele.SessionInfo
-	(*ListSessionsResponse)(nil),    // 10: kele.ListSessionsResponse
-	(*StatusResponse)(nil),          // 11: kele.StatusResponse
-	(*HeartbeatStatusResponse)(nil), // 12: kele.HeartbeatStatusResponse
-	(*WorkspaceInfo)(nil),           // 13: kele.WorkspaceInfo
-	(*CreateWorkspaceRequest)(nil),  // 14: kele.CreateWorkspaceRequest
-	(*GetWorkspaceRequest)(nil),     // 15: kele.GetWorkspaceRequest
-	(*UpdateWorkspaceRequest)(nil),  // 16: kele.UpdateWorkspaceRequest
-	(*DeleteWorkspaceRequest)(nil),  // 17: kele.DeleteWorkspaceRequest
-	(*ListWorkspacesResponse)(nil),  // 18: kele.ListWorkspacesResponse
-	(*TaskInfo)(nil),                // 19: kele.TaskInfo
-	(*CreateTaskRequest)(nil),       // 20: kele.CreateTaskRequest
-	(*GetTaskRequest)(nil),          // 21: kele.GetTaskRequest
-	(*UpdateTaskRequest)(nil),       // 22: kele.UpdateTaskRequest
-	(*DeleteTaskRequest)(nil),       // 23: kele.DeleteTaskRequest
-	(*ListTasksRequest)(nil),        // 24: kele.ListTasksRequest
-	(*ListTasksResponse)(nil),       // 25: kele.ListTasksResponse
-	(*StartTaskRequest)(nil),        // 26: kele.StartTaskRequest
-	(*CancelTaskRequest)(nil),       // 27: kele.CancelTaskRequest
-	(*RetryTaskRequest)(nil),        // 28: kele.RetryTaskRequest
-	(*PlanWorkspaceRequest)(nil),    // 29: kele.PlanWorkspaceRequest
-	(*PlanEventMsg)(nil),            // 30: kele.PlanEventMsg
-	(*ApprovePlanRequest)(nil),      // 31: kele.ApprovePlanRequest
-	(*ApprovePlanResponse)(nil),     // 32: kele.ApprovePlanResponse
-	(*BoardOverviewMsg)(nil),        // 33: kele.BoardOverviewMsg
-	(*WorkspaceOverviewMsg)(nil),    // 34: kele.WorkspaceOverviewMsg
-	(*WatchBoardRequest)(nil),       // 35: kele.WatchBoardRequest
-	(*BoardEventMsg)(nil),           // 36: kele.BoardEventMsg
-	(*GetTaskLogRequest)(nil),       // 37: kele.GetTaskLogRequest
-	(*TaskLogEntry)(nil),            // 38: kele.TaskLogEntry
-	(*TaskLogResponse)(nil),         // 39: kele.TaskLogResponse
+	(*Empty)(nil),                              // 0: kele.Empty
+	(*ChatRequest)(nil),                        // 1: kele.ChatRequest
+	(*ChatEvent)(nil),                          // 2: kele.ChatEvent
+	(*CompleteRequest)(nil),                    // 3: kele.CompleteRequest
+	(*CompleteResponse)(nil),                   // 4: kele.CompleteResponse
+	(*RunCommandRequest)(nil),                  // 5: kele.RunCommandRequest
+	(*RunCommandResponse)(nil),                 // 6: kele.RunCommandResponse
+	(*CreateSessionRequest)(nil),               // 7: kele.CreateSessionRequest
+	(*DeleteSessionRequest)(nil),               // 8: kele.DeleteSessionRequest
+	(*SessionInfo)(nil),                        // 9: kele.SessionInfo
+	(*ListSessionsResponse)(nil),               // 10: kele.ListSessionsResponse
+	(*StatusResponse)(nil),                     // 11: kele.StatusResponse
+	(*HeartbeatStatusResponse)(nil),            // 12: kele.HeartbeatStatusResponse
+	(*GetHeartbeatHistoryRequest)(nil),         // 13: kele.GetHeartbeatHistoryRequest
+	(*HeartbeatMessage)(nil),                   // 14: kele.HeartbeatMessage
+	(*HeartbeatRecord)(nil),                    // 15: kele.HeartbeatRecord
+	(*GetHeartbeatHistoryResponse)(nil),        // 16: kele.GetHeartbeatHistoryResponse
+	(*ReindexMemoryRequest)(nil),               // 17: kele.ReindexMemoryRequest
+	(*ReindexMemoryResponse)(nil),              // 18: kele.ReindexMemoryResponse
+	(*SearchHistoryRequest)(nil),               // 19: kele.SearchHistoryRequest
+	(*HistoryHit)(nil),                         // 20: kele.HistoryHit
+	(*SearchHistoryResponse)(nil),              // 21: kele.SearchHistoryResponse
+	(*CronJobInfo)(nil),                        // 22: kele.CronJobInfo
+	(*ListCronJobsResponse)(nil),               // 23: kele.ListCronJobsResponse
+	(*CronJobRequest)(nil),                     // 24: kele.CronJobRequest
+	(*CreateCronJobRequest)(nil),               // 25: kele.CreateCronJobRequest
+	(*UpdateCronJobRequest)(nil),               // 26: kele.UpdateCronJobRequest
+	(*SetCronJobEnabledRequest)(nil),           // 27: kele.SetCronJobEnabledRequest
+	(*CronLogEntry)(nil),                       // 28: kele.CronLogEntry
+	(*GetCronLogsRequest)(nil),                 // 29: kele.GetCronLogsRequest
+	(*GetCronLogsResponse)(nil),                // 30: kele.GetCronLogsResponse
+	(*PreviewCronScheduleRequest)(nil),         // 31: kele.PreviewCronScheduleRequest
+	(*PreviewCronScheduleResponse)(nil),        // 32: kele.PreviewCronScheduleResponse
+	(*WorkspaceInfo)(nil),                      // 33: kele.WorkspaceInfo
+	(*BudgetInfo)(nil),                         // 34: kele.BudgetInfo
+	(*CreateWorkspaceRequest)(nil),             // 35: kele.CreateWorkspaceRequest
+	(*GetWorkspaceRequest)(nil),                // 36: kele.GetWorkspaceRequest
+	(*UpdateWorkspaceRequest)(nil),             // 37: kele.UpdateWorkspaceRequest
+	(*DeleteWorkspaceRequest)(nil),             // 38: kele.DeleteWorkspaceRequest
+	(*ListWorkspacesResponse)(nil),             // 39: kele.ListWorkspacesResponse
+	(*TaskInfo)(nil),                           // 40: kele.TaskInfo
+	(*CreateTaskRequest)(nil),                  // 41: kele.CreateTaskRequest
+	(*GetTaskRequest)(nil),                     // 42: kele.GetTaskRequest
+	(*UpdateTaskRequest)(nil),                  // 43: kele.UpdateTaskRequest
+	(*DeleteTaskRequest)(nil),                  // 44: kele.DeleteTaskRequest
+	(*ListTasksRequest)(nil),                   // 45: kele.ListTasksRequest
+	(*ListTasksResponse)(nil),                  // 46: kele.ListTasksResponse
+	(*StartTaskRequest)(nil),                   // 47: kele.StartTaskRequest
+	(*CancelTaskRequest)(nil),                  // 48: kele.CancelTaskRequest
+	(*RetryTaskRequest)(nil),                   // 49: kele.RetryTaskRequest
+	(*MergeTaskRequest)(nil),                   // 50: kele.MergeTaskRequest
+	(*ReviewTaskRequest)(nil),                  // 51: kele.ReviewTaskRequest
+	(*PlanWorkspaceRequest)(nil),               // 52: kele.PlanWorkspaceRequest
+	(*PlanEventMsg)(nil),                       // 53: kele.PlanEventMsg
+	(*ApprovePlanRequest)(nil),                 // 54: kele.ApprovePlanRequest
+	(*ApprovePlanResponse)(nil),                // 55: kele.ApprovePlanResponse
+	(*PlanDraftInfo)(nil),                      // 56: kele.PlanDraftInfo
+	(*ListPlanDraftsResponse)(nil),             // 57: kele.ListPlanDraftsResponse
+	(*GetPlanDraftRequest)(nil),                // 58: kele.GetPlanDraftRequest
+	(*DeletePlanDraftRequest)(nil),             // 59: kele.DeletePlanDraftRequest
+	(*AddPlanTaskRequest)(nil),                 // 60: kele.AddPlanTaskRequest
+	(*RemovePlanTaskRequest)(nil),              // 61: kele.RemovePlanTaskRequest
+	(*MovePlanTaskRequest)(nil),                // 62: kele.MovePlanTaskRequest
+	(*UpdatePlanTaskRequest)(nil),              // 63: kele.UpdatePlanTaskRequest
+	(*RevisePlanRequest)(nil),                  // 64: kele.RevisePlanRequest
+	(*BoardOverviewMsg)(nil),                   // 65: kele.BoardOverviewMsg
+	(*WorkspaceOverviewMsg)(nil),               // 66: kele.WorkspaceOverviewMsg
+	(*WatchBoardRequest)(nil),                  // 67: kele.WatchBoardRequest
+	(*BoardEventMsg)(nil),                      // 68: kele.BoardEventMsg
+	(*GetTaskLogRequest)(nil),                  // 69: kele.GetTaskLogRequest
+	(*TaskLogEntry)(nil),                       // 70: kele.TaskLogEntry
+	(*TaskLogResponse)(nil),                    // 71: kele.TaskLogResponse
+	(*SearchTasksRequest)(nil),                 // 72: kele.SearchTasksRequest
+	(*TaskSearchMatch)(nil),                    // 73: kele.TaskSearchMatch
+	(*TaskSearchHit)(nil),                      // 74: kele.TaskSearchHit
+	(*SearchTasksResponse)(nil),                // 75: kele.SearchTasksResponse
+	(*WorkspaceScheduleInfo)(nil),              // 76: kele.WorkspaceScheduleInfo
+	(*CreateWorkspaceScheduleRequest)(nil),     // 77: kele.CreateWorkspaceScheduleRequest
+	(*WorkspaceScheduleRequest)(nil),           // 78: kele.WorkspaceScheduleRequest
+	(*SetWorkspaceScheduleEnabledRequest)(nil), // 79: kele.SetWorkspaceScheduleEnabledRequest
+	(*ListWorkspaceSchedulesResponse)(nil),     // 80: kele.ListWorkspaceSchedulesResponse
+	(*ExportWorkspaceRequest)(nil),             // 81: kele.ExportWorkspaceRequest
+	(*ExportWorkspaceResponse)(nil),            // 82: kele.ExportWorkspaceResponse
+	(*ImportWorkspaceRequest)(nil),             // 83: kele.ImportWorkspaceRequest
+	(*ArtifactInfo)(nil),                       // 84: kele.ArtifactInfo
+	(*ListTaskArtifactsResponse)(nil),          // 85: kele.ListTaskArtifactsResponse
+	(*GetTaskArtifactRequest)(nil),             // 86: kele.GetTaskArtifactRequest
+	(*TaskArtifact)(nil),                       // 87: kele.TaskArtifact
+	nil,                                        // 88: kele.ImportWorkspaceRequest.VarsEntry
 }
 var file_proto_kele_proto_depIdxs = []int32{
 	9,  // 0: kele.ListSessionsResponse.sessions:type_name -> kele.SessionInfo
-	13, // 1: kele.ListWorkspacesResponse.workspaces:type_name -> kele.WorkspaceInfo
-	19, // 2: kele.ListTasksResponse.tasks:type_name -> kele.TaskInfo
-	13, // 3: kele.ApprovePlanResponse.workspace:type_name -> kele.WorkspaceInfo
-	19, // 4: kele.ApprovePlanResponse.tasks:type_name -> kele.TaskInfo
-	34, // 5: kele.BoardOverviewMsg.workspaces:type_name -> kele.WorkspaceOverviewMsg
-	38, // 6: kele.TaskLogResponse.entries:type_name -> kele.TaskLogEntry
-	1,  // 7: kele.KeleService.Chat:input_type -> kele.ChatRequest
-	3,  // 8: kele.KeleService.Complete:input_type -> kele.CompleteRequest
-	5,  // 9: kele.KeleService.RunCommand:input_type -> kele.RunCommandRequest
-	7,  // 10: kele.KeleService.CreateSession:input_type -> kele.CreateSessionRequest
-	8,  // 11: kele.KeleService.DeleteSession:input_type -> kele.DeleteSessionRequest
-	0,  // 12: kele.KeleService.ListSessions:input_type -> kele.Empty
-	0,  // 13: kele.KeleService.GetStatus:input_type -> kele.Empty
-	0,  // 14: kele.KeleService.GetHeartbeatStatus:input_type -> kele.Empty
-	14, // 15: kele.KeleService.CreateWorkspace:input_type -> kele.CreateWorkspaceRequest
-	15, // 16: kele.KeleService.GetWorkspace:input_type -> kele.GetWorkspaceRequest
-	16, // 17: kele.KeleService.UpdateWorkspace:input_type -> kele.UpdateWorkspaceRequest
-	17, // 18: kele.KeleService.DeleteWorkspace:input_type -> kele.DeleteWorkspaceRequest
-	0,  // 19: kele.KeleService.ListWorkspaces:input_type -> kele.Empty
-	20, // 20: kele.KeleService.CreateTask:input_type -> kele.CreateTaskRequest
-	21, // 21: kele.KeleService.GetTask:input_type -> kele.GetTaskRequest
-	22, // 22: kele.KeleService.UpdateTaskRPC:input_type -> kele.UpdateTaskRequest
-	23, // 23: kele.KeleService.DeleteTask:input_type -> kele.DeleteTaskRequest
-	24, // 24: kele.KeleService.ListTasks:input_type -> kele.ListTasksRequest
-	26, // 25: kele.KeleService.StartTask:input_type -> kele.StartTaskRequest
-	27, // 26: kele.KeleService.CancelTask:input_type -> kele.CancelTaskRequest
-	28, // 27: kele.KeleService.RetryTask:input_type -> kele.RetryTaskRequest
-	29, // 28: kele.KeleService.PlanWorkspace:input_type -> kele.PlanWorkspaceRequest
-	31, // 29: kele.KeleService.ApprovePlan:input_type -> kele.ApprovePlanRequest
-	0,  // 30: kele.KeleService.GetBoardOverview:input_type -> kele.Empty
-	35, // 31: kele.KeleService.WatchBoard:input_type -> kele.WatchBoardRequest
-	37, // 32: kele.KeleService.GetTaskLog:input_type -> kele.GetTaskLogRequest
-	2,  // 33: kele.KeleService.Chat:output_type -> kele.ChatEvent
-	4,  // 34: kele.KeleService.Complete:output_type -> kele.CompleteResponse
-	6,  // 35: kele.KeleService.RunCommand:output_type -> kele.RunCommandResponse
-	9,  // 36: kele.KeleService.CreateSession:output_type -> kele.SessionInfo
-	0,  // 37: kele.KeleService.DeleteSession:output_type -> kele.Empty
-	10, // 38: kele.KeleService.ListSessions:output_type -> kele.ListSessionsResponse
-	11, // 39: kele.KeleService.GetStatus:output_type -> kele.StatusResponse
-	12, // 40: kele.KeleService.GetHeartbeatStatus:output_type -> kele.HeartbeatStatusResponse
-	13, // 41: kele.KeleService.CreateWorkspace:output_type -> kele.WorkspaceInfo
-	13, // 42: kele.KeleService.GetWorkspace:output_type -> kele.WorkspaceInfo
-	13, // 43: kele.KeleService.UpdateWorkspace:output_type -> kele.WorkspaceInfo
-	0,  // 44: kele.KeleService.DeleteWorkspace:output_type -> kele.Empty
-	18, // 45: kele.KeleService.ListWorkspaces:output_type -> kele.ListWorkspacesResponse
-	19, // 46: kele.KeleService.CreateTask:output_type -> kele.TaskInfo
-	19, // 47: kele.KeleService.GetTask:output_type -> kele.TaskInfo
-	19, // 48: kele.KeleService.UpdateTaskRPC:output_type -> kele.TaskInfo
-	0,  // 49: kele.KeleService.DeleteTask:output_type -> kele.Empty
-	25, // 50: kele.KeleService.ListTasks:output_type -> kele.ListTasksResponse
-	19, // 51: kele.KeleService.StartTask:output_type -> kele.TaskInfo
-	19, // 52: kele.KeleService.CancelTask:output_type -> kele.TaskInfo
-	19, // 53: kele.KeleService.RetryTask:output_type -> kele.TaskInfo
-	30, // 54: kele.KeleService.PlanWorkspace:output_type -> kele.PlanEventMsg
-	32, // 55: kele.KeleService.ApprovePlan:output_type -> kele.ApprovePlanResponse
-	33, // 56: kele.KeleService.GetBoardOverview:output_type -> kele.BoardOverviewMsg
-	36, // 57: kele.KeleService.WatchBoard:output_type -> kele.BoardEventMsg
-	39, // 58: kele.KeleService.GetTaskLog:output_type -> kele.TaskLogResponse
-	33, // [33:59] is the sub-list for method output_type
-	7,  // [7:33] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	14, // 1: kele.HeartbeatRecord.transcript:type_name -> kele.HeartbeatMessage
+	15, // 2: kele.GetHeartbeatHistoryResponse.records:type_name -> kele.HeartbeatRecord
+	20, // 3: kele.SearchHistoryResponse.hits:type_name -> kele.HistoryHit
+	22, // 4: kele.ListCronJobsResponse.jobs:type_name -> kele.CronJobInfo
+	28, // 5: kele.GetCronLogsResponse.logs:type_name -> kele.CronLogEntry
+	34, // 6: kele.WorkspaceInfo.budget:type_name -> kele.BudgetInfo
+	34, // 7: kele.CreateWorkspaceRequest.budget:type_name -> kele.BudgetInfo
+	34, // 8: kele.UpdateWorkspaceRequest.budget:type_name -> kele.BudgetInfo
+	33, // 9: kele.ListWorkspacesResponse.workspaces:type_name -> kele.WorkspaceInfo
+	40, // 10: kele.ListTasksResponse.tasks:type_name -> kele.TaskInfo
+	33, // 11: kele.ApprovePlanResponse.workspace:type_name -> kele.WorkspaceInfo
+	40, // 12: kele.ApprovePlanResponse.tasks:type_name -> kele.TaskInfo
+	56, // 13: kele.ListPlanDraftsResponse.drafts:type_name -> kele.PlanDraftInfo
+	66, // 14: kele.BoardOverviewMsg.workspaces:type_name -> kele.WorkspaceOverviewMsg
+	34, // 15: kele.WorkspaceOverviewMsg.budget:type_name -> kele.BudgetInfo
+	70, // 16: kele.TaskLogResponse.entries:type_name -> kele.TaskLogEntry
+	73, // 17: kele.TaskSearchHit.matches:type_name -> kele.TaskSearchMatch
+	74, // 18: kele.SearchTasksResponse.hits:type_name -> kele.TaskSearchHit
+	76, // 19: kele.ListWorkspaceSchedulesResponse.schedules:type_name -> kele.WorkspaceScheduleInfo
+	88, // 20: kele.ImportWorkspaceRequest.vars:type_name -> kele.ImportWorkspaceRequest.VarsEntry
+	84, // 21: kele.ListTaskArtifactsResponse.artifacts:type_name -> kele.ArtifactInfo
+	84, // 22: kele.TaskArtifact.info:type_name -> kele.ArtifactInfo
+	1,  // 23: kele.KeleService.Chat:input_type -> kele.ChatRequest
+	3,  // 24: kele.KeleService.Complete:input_type -> kele.CompleteRequest
+	5,  // 25: kele.KeleService.RunCommand:input_type -> kele.RunCommandRequest
+	7,  // 26: kele.KeleService.CreateSession:input_type -> kele.CreateSessionRequest
+	8,  // 27: kele.KeleService.DeleteSession:input_type -> kele.DeleteSessionRequest
+	0,  // 28: kele.KeleService.ListSessions:input_type -> kele.Empty
+	0,  // 29: kele.KeleService.GetStatus:input_type -> kele.Empty
+	0,  // 30: kele.KeleService.GetHeartbeatStatus:input_type -> kele.Empty
+	13, // 31: kele.KeleService.GetHeartbeatHistory:input_type -> kele.GetHeartbeatHistoryRequest
+	17, // 32: kele.KeleService.ReindexMemory:input_type -> kele.ReindexMemoryRequest
+	19, // 33: kele.KeleService.SearchHistory:input_type -> kele.SearchHistoryRequest
+	0,  // 34: kele.KeleService.ListCronJobs:input_type -> kele.Empty
+	24, // 35: kele.KeleService.GetCronJob:input_type -> kele.CronJobRequest
+	25, // 36: kele.KeleService.CreateCronJob:input_type -> kele.CreateCronJobRequest
+	26, // 37: kele.KeleService.UpdateCronJob:input_type -> kele.UpdateCronJobRequest
+	24, // 38: kele.KeleService.DeleteCronJob:input_type -> kele.CronJobRequest
+	27, // 39: kele.KeleService.SetCronJobEnabled:input_type -> kele.SetCronJobEnabledRequest
+	24, // 40: kele.KeleService.RunCronJob:input_type -> kele.CronJobRequest
+	29, // 41: kele.KeleService.GetCronLogs:input_type -> kele.GetCronLogsRequest
+	31, // 42: kele.KeleService.PreviewCronSchedule:input_type -> kele.PreviewCronScheduleRequest
+	35, // 43: kele.KeleService.CreateWorkspace:input_type -> kele.CreateWorkspaceRequest
+	36, // 44: kele.KeleService.GetWorkspace:input_type -> kele.GetWorkspaceRequest
+	37, // 45: kele.KeleService.UpdateWorkspace:input_type -> kele.UpdateWorkspaceRequest
+	38, // 46: kele.KeleService.DeleteWorkspace:input_type -> kele.DeleteWorkspaceRequest
+	0,  // 47: kele.KeleService.ListWorkspaces:input_type -> kele.Empty
+	36, // 48: kele.KeleService.MakeWorkspaceTemplate:input_type -> kele.GetWorkspaceRequest
+	77, // 49: kele.KeleService.CreateWorkspaceSchedule:input_type -> kele.CreateWorkspaceScheduleRequest
+	0,  // 50: kele.KeleService.ListWorkspaceSchedules:input_type -> kele.Empty
+	78, // 51: kele.KeleService.DeleteWorkspaceSchedule:input_type -> kele.WorkspaceScheduleRequest
+	79, // 52: kele.KeleService.SetWorkspaceScheduleEnabled:input_type -> kele.SetWorkspaceScheduleEnabledRequest
+	78, // 53: kele.KeleService.RunWorkspaceSchedule:input_type -> kele.WorkspaceScheduleRequest
+	81, // 54: kele.KeleService.ExportWorkspace:input_type -> kele.ExportWorkspaceRequest
+	83, // 55: kele.KeleService.ImportWorkspace:input_type -> kele.ImportWorkspaceRequest
+	41, // 56: kele.KeleService.CreateTask:input_type -> kele.CreateTaskRequest
+	42, // 57: kele.KeleService.GetTask:input_type -> kele.GetTaskRequest
+	43, // 58: kele.KeleService.UpdateTaskRPC:input_type -> kele.UpdateTaskRequest
+	44, // 59: kele.KeleService.DeleteTask:input_type -> kele.DeleteTaskRequest
+	45, // 60: kele.KeleService.ListTasks:input_type -> kele.ListTasksRequest
+	47, // 61: kele.KeleService.StartTask:input_type -> kele.StartTaskRequest
+	48, // 62: kele.KeleService.CancelTask:input_type -> kele.CancelTaskRequest
+	49, // 63: kele.KeleService.RetryTask:input_type -> kele.RetryTaskRequest
+	50, // 64: kele.KeleService.MergeTask:input_type -> kele.MergeTaskRequest
+	51, // 65: kele.KeleService.ApproveTask:input_type -> kele.ReviewTaskRequest
+	51, // 66: kele.KeleService.RejectTask:input_type -> kele.ReviewTaskRequest
+	42, // 67: kele.KeleService.ListTaskArtifacts:input_type -> kele.GetTaskRequest
+	86, // 68: kele.KeleService.GetTaskArtifact:input_type -> kele.GetTaskArtifactRequest
+	52, // 69: kele.KeleService.PlanWorkspace:input_type -> kele.PlanWorkspaceRequest
+	54, // 70: kele.KeleService.ApprovePlan:input_type -> kele.ApprovePlanRequest
+	0,  // 71: kele.KeleService.ListPlanDrafts:input_type -> kele.Empty
+	58, // 72: kele.KeleService.GetPlanDraft:input_type -> kele.GetPlanDraftRequest
+	59, // 73: kele.KeleService.DeletePlanDraft:input_type -> kele.DeletePlanDraftRequest
+	60, // 74: kele.KeleService.AddPlanTask:input_type -> kele.AddPlanTaskRequest
+	61, // 75: kele.KeleService.RemovePlanTask:input_type -> kele.RemovePlanTaskRequest
+	62, // 76: kele.KeleService.MovePlanTask:input_type -> kele.MovePlanTaskRequest
+	63, // 77: kele.KeleService.UpdatePlanTask:input_type -> kele.UpdatePlanTaskRequest
+	64, // 78: kele.KeleService.RevisePlan:input_type -> kele.RevisePlanRequest
+	0,  // 79: kele.KeleService.GetBoardOverview:input_type -> kele.Empty
+	67, // 80: kele.KeleService.WatchBoard:input_type -> kele.WatchBoardRequest
+	69, // 81: kele.KeleService.GetTaskLog:input_type -> kele.GetTaskLogRequest
+	72, // 82: kele.KeleService.SearchTasks:input_type -> kele.SearchTasksRequest
+	2,  // 83: kele.KeleService.Chat:output_type -> kele.ChatEvent
+	4,  // 84: kele.KeleService.Complete:output_type -> kele.CompleteResponse
+	6,  // 85: kele.KeleService.RunCommand:output_type -> kele.RunCommandResponse
+	9,  // 86: kele.KeleService.CreateSession:output_type -> kele.SessionInfo
+	0,  // 87: kele.KeleService.DeleteSession:output_type -> kele.Empty
+	10, // 88: kele.KeleService.ListSessions:output_type -> kele.ListSessionsResponse
+	11, // 89: kele.KeleService.GetStatus:output_type -> kele.StatusResponse
+	12, // 90: kele.KeleService.GetHeartbeatStatus:output_type -> kele.HeartbeatStatusResponse
+	16, // 91: kele.KeleService.GetHeartbeatHistory:output_type -> kele.GetHeartbeatHistoryResponse
+	18, // 92: kele.KeleService.ReindexMemory:output_type -> kele.ReindexMemoryResponse
+	21, // 93: kele.KeleService.SearchHistory:output_type -> kele.SearchHistoryResponse
+	23, // 94: kele.KeleService.ListCronJobs:output_type -> kele.ListCronJobsResponse
+	22, // 95: kele.KeleService.GetCronJob:output_type -> kele.CronJobInfo
+	22, // 96: kele.KeleService.CreateCronJob:output_type -> kele.CronJobInfo
+	22, // 97: kele.KeleService.UpdateCronJob:output_type -> kele.CronJobInfo
+	0,  // 98: kele.KeleService.DeleteCronJob:output_type -> kele.Empty
+	22, // 99: kele.KeleService.SetCronJobEnabled:output_type -> kele.CronJobInfo
+	28, // 100: kele.KeleService.RunCronJob:output_type -> kele.CronLogEntry
+	30, // 101: kele.KeleService.GetCronLogs:output_type -> kele.GetCronLogsResponse
+	32, // 102: kele.KeleService.PreviewCronSchedule:output_type -> kele.PreviewCronScheduleResponse
+	33, // 103: kele.KeleService.CreateWorkspace:output_type -> kele.WorkspaceInfo
+	33, // 104: kele.KeleService.GetWorkspace:output_type -> kele.WorkspaceInfo
+	33, // 105: kele.KeleService.UpdateWorkspace:output_type -> kele.WorkspaceInfo
+	0,  // 106: kele.KeleService.DeleteWorkspace:output_type -> kele.Empty
+	39, // 107: kele.KeleService.ListWorkspaces:output_type -> kele.ListWorkspacesResponse
+	33, // 108: kele.KeleService.MakeWorkspaceTemplate:output_type -> kele.WorkspaceInfo
+	76, // 109: kele.KeleService.CreateWorkspaceSchedule:output_type -> kele.WorkspaceScheduleInfo
+	80, // 110: kele.KeleService.ListWorkspaceSchedules:output_type -> kele.ListWorkspaceSchedulesResponse
+	0,  // 111: kele.KeleService.DeleteWorkspaceSchedule:output_type -> kele.Empty
+	76, // 112: kele.KeleService.SetWorkspaceScheduleEnabled:output_type -> kele.WorkspaceScheduleInfo
+	33, // 113: kele.KeleService.RunWorkspaceSchedule:output_type -> kele.WorkspaceInfo
+	82, // 114: kele.KeleService.ExportWorkspace:output_type -> kele.ExportWorkspaceResponse
+	33, // 115: kele.KeleService.ImportWorkspace:output_type -> kele.WorkspaceInfo
+	40, // 116: kele.KeleService.CreateTask:output_type -> kele.TaskInfo
+	40, // 117: kele.KeleService.GetTask:output_type -> kele.TaskInfo
+	40, // 118: kele.KeleService.UpdateTaskRPC:output_type -> kele.TaskInfo
+	0,  // 119: kele.KeleService.DeleteTask:output_type -> kele.Empty
+	46, // 120: kele.KeleService.ListTasks:output_type -> kele.ListTasksResponse
+	40, // 121: kele.KeleService.StartTask:output_type -> kele.TaskInfo
+	40, // 122: kele.KeleService.CancelTask:output_type -> kele.TaskInfo
+	40, // 123: kele.KeleService.RetryTask:output_type -> kele.TaskInfo
+	40, // 124: kele.KeleService.MergeTask:output_type -> kele.TaskInfo
+	40, // 125: kele.KeleService.ApproveTask:output_type -> kele.TaskInfo
+	40, // 126: kele.KeleService.RejectTask:output_type -> kele.TaskInfo
+	85, // 127: kele.KeleService.ListTaskArtifacts:output_type -> kele.ListTaskArtifactsResponse
+	87, // 128: kele.KeleService.GetTaskArtifact:output_type -> kele.TaskArtifact
+	53, // 129: kele.KeleService.PlanWorkspace:output_type -> kele.PlanEventMsg
+	55, // 130: kele.KeleService.ApprovePlan:output_type -> kele.ApprovePlanResponse
+	57, // 131: kele.KeleService.ListPlanDrafts:output_type -> kele.ListPlanDraftsResponse
+	56, // 132: kele.KeleService.GetPlanDraft:output_type -> kele.PlanDraftInfo
+	0,  // 133: kele.KeleService.DeletePlanDraft:output_type -> kele.Empty
+	56, // 134: kele.KeleService.AddPlanTask:output_type -> kele.PlanDraftInfo
+	56, // 135: kele.KeleService.RemovePlanTask:output_type -> kele.PlanDraftInfo
+	56, // 136: kele.KeleService.MovePlanTask:output_type -> kele.PlanDraftInfo
+	56, // 137: kele.KeleService.UpdatePlanTask:output_type -> kele.PlanDraftInfo
+	53, // 138: kele.KeleService.RevisePlan:output_type -> kele.PlanEventMsg
+	65, // 139: kele.KeleService.GetBoardOverview:output_type -> kele.BoardOverviewMsg
+	68, // 140: kele.KeleService.WatchBoard:output_type -> kele.BoardEventMsg
+	71, // 141: kele.KeleService.GetTaskLog:output_type -> kele.TaskLogResponse
+	75, // 142: kele.KeleService.SearchTasks:output_type -> kele.SearchTasksResponse
+	83, // [83:143] is the sub-list for method output_type
+	23, // [23:83] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_proto_kele_proto_init() }
@@ -2992,13 +7446,20 @@ func file_proto_kele_proto_init() {
 	if File_proto_kele_proto != nil {
 		return
 	}
+	file_proto_kele_proto_msgTypes[26].OneofWrappers = []any{}
+	file_proto_kele_proto_msgTypes[37].OneofWrappers = []any{}
+	file_proto_kele_proto_msgTypes[40].OneofWrappers = []any{}
+	file_proto_kele_proto_msgTypes[41].OneofWrappers = []any{}
+	file_proto_kele_proto_msgTypes[43].OneofWrappers = []any{}
+	file_proto_kele_proto_msgTypes[60].OneofWrappers = []any{}
+	file_proto_kele_proto_msgTypes[63].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_kele_proto_rawDesc), len(file_proto_kele_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   40,
+			NumMessages:   89,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
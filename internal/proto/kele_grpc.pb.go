@@ -19,32 +19,66 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	KeleService_Chat_FullMethodName               = "/kele.KeleService/Chat"
-	KeleService_Complete_FullMethodName           = "/kele.KeleService/Complete"
-	KeleService_RunCommand_FullMethodName         = "/kele.KeleService/RunCommand"
-	KeleService_CreateSession_FullMethodName      = "/kele.KeleService/CreateSession"
-	KeleService_DeleteSession_FullMethodName      = "/kele.KeleService/DeleteSession"
-	KeleService_ListSessions_FullMethodName       = "/kele.KeleService/ListSessions"
-	KeleService_GetStatus_FullMethodName          = "/kele.KeleService/GetStatus"
-	KeleService_GetHeartbeatStatus_FullMethodName = "/kele.KeleService/GetHeartbeatStatus"
-	KeleService_CreateWorkspace_FullMethodName    = "/kele.KeleService/CreateWorkspace"
-	KeleService_GetWorkspace_FullMethodName       = "/kele.KeleService/GetWorkspace"
-	KeleService_UpdateWorkspace_FullMethodName    = "/kele.KeleService/UpdateWorkspace"
-	KeleService_DeleteWorkspace_FullMethodName    = "/kele.KeleService/DeleteWorkspace"
-	KeleService_ListWorkspaces_FullMethodName     = "/kele.KeleService/ListWorkspaces"
-	KeleService_CreateTask_FullMethodName         = "/kele.KeleService/CreateTask"
-	KeleService_GetTask_FullMethodName            = "/kele.KeleService/GetTask"
-	KeleService_UpdateTaskRPC_FullMethodName      = "/kele.KeleService/UpdateTaskRPC"
-	KeleService_DeleteTask_FullMethodName         = "/kele.KeleService/DeleteTask"
-	KeleService_ListTasks_FullMethodName          = "/kele.KeleService/ListTasks"
-	KeleService_StartTask_FullMethodName          = "/kele.KeleService/StartTask"
-	KeleService_CancelTask_FullMethodName         = "/kele.KeleService/CancelTask"
-	KeleService_RetryTask_FullMethodName          = "/kele.KeleService/RetryTask"
-	KeleService_PlanWorkspace_FullMethodName      = "/kele.KeleService/PlanWorkspace"
-	KeleService_ApprovePlan_FullMethodName        = "/kele.KeleService/ApprovePlan"
-	KeleService_GetBoardOverview_FullMethodName   = "/kele.KeleService/GetBoardOverview"
-	KeleService_WatchBoard_FullMethodName         = "/kele.KeleService/WatchBoard"
-	KeleService_GetTaskLog_FullMethodName         = "/kele.KeleService/GetTaskLog"
+	KeleService_Chat_FullMethodName                        = "/kele.KeleService/Chat"
+	KeleService_Complete_FullMethodName                    = "/kele.KeleService/Complete"
+	KeleService_RunCommand_FullMethodName                  = "/kele.KeleService/RunCommand"
+	KeleService_CreateSession_FullMethodName               = "/kele.KeleService/CreateSession"
+	KeleService_DeleteSession_FullMethodName               = "/kele.KeleService/DeleteSession"
+	KeleService_ListSessions_FullMethodName                = "/kele.KeleService/ListSessions"
+	KeleService_GetStatus_FullMethodName                   = "/kele.KeleService/GetStatus"
+	KeleService_GetHeartbeatStatus_FullMethodName          = "/kele.KeleService/GetHeartbeatStatus"
+	KeleService_GetHeartbeatHistory_FullMethodName         = "/kele.KeleService/GetHeartbeatHistory"
+	KeleService_ReindexMemory_FullMethodName               = "/kele.KeleService/ReindexMemory"
+	KeleService_SearchHistory_FullMethodName               = "/kele.KeleService/SearchHistory"
+	KeleService_ListCronJobs_FullMethodName                = "/kele.KeleService/ListCronJobs"
+	KeleService_GetCronJob_FullMethodName                  = "/kele.KeleService/GetCronJob"
+	KeleService_CreateCronJob_FullMethodName               = "/kele.KeleService/CreateCronJob"
+	KeleService_UpdateCronJob_FullMethodName               = "/kele.KeleService/UpdateCronJob"
+	KeleService_DeleteCronJob_FullMethodName               = "/kele.KeleService/DeleteCronJob"
+	KeleService_SetCronJobEnabled_FullMethodName           = "/kele.KeleService/SetCronJobEnabled"
+	KeleService_RunCronJob_FullMethodName                  = "/kele.KeleService/RunCronJob"
+	KeleService_GetCronLogs_FullMethodName                 = "/kele.KeleService/GetCronLogs"
+	KeleService_PreviewCronSchedule_FullMethodName         = "/kele.KeleService/PreviewCronSchedule"
+	KeleService_CreateWorkspace_FullMethodName             = "/kele.KeleService/CreateWorkspace"
+	KeleService_GetWorkspace_FullMethodName                = "/kele.KeleService/GetWorkspace"
+	KeleService_UpdateWorkspace_FullMethodName             = "/kele.KeleService/UpdateWorkspace"
+	KeleService_DeleteWorkspace_FullMethodName             = "/kele.KeleService/DeleteWorkspace"
+	KeleService_ListWorkspaces_FullMethodName              = "/kele.KeleService/ListWorkspaces"
+	KeleService_MakeWorkspaceTemplate_FullMethodName       = "/kele.KeleService/MakeWorkspaceTemplate"
+	KeleService_CreateWorkspaceSchedule_FullMethodName     = "/kele.KeleService/CreateWorkspaceSchedule"
+	KeleService_ListWorkspaceSchedules_FullMethodName      = "/kele.KeleService/ListWorkspaceSchedules"
+	KeleService_DeleteWorkspaceSchedule_FullMethodName     = "/kele.KeleService/DeleteWorkspaceSchedule"
+	KeleService_SetWorkspaceScheduleEnabled_FullMethodName = "/kele.KeleService/SetWorkspaceScheduleEnabled"
+	KeleService_RunWorkspaceSchedule_FullMethodName        = "/kele.KeleService/RunWorkspaceSchedule"
+	KeleService_ExportWorkspace_FullMethodName             = "/kele.KeleService/ExportWorkspace"
+	KeleService_ImportWorkspace_FullMethodName             = "/kele.KeleService/ImportWorkspace"
+	KeleService_CreateTask_FullMethodName                  = "/kele.KeleService/CreateTask"
+	KeleService_GetTask_FullMethodName                     = "/kele.KeleService/GetTask"
+	KeleService_UpdateTaskRPC_FullMethodName               = "/kele.KeleService/UpdateTaskRPC"
+	KeleService_DeleteTask_FullMethodName                  = "/kele.KeleService/DeleteTask"
+	KeleService_ListTasks_FullMethodName                   = "/kele.KeleService/ListTasks"
+	KeleService_StartTask_FullMethodName                   = "/kele.KeleService/StartTask"
+	KeleService_CancelTask_FullMethodName                  = "/kele.KeleService/CancelTask"
+	KeleService_RetryTask_FullMethodName                   = "/kele.KeleService/RetryTask"
+	KeleService_MergeTask_FullMethodName                   = "/kele.KeleService/MergeTask"
+	KeleService_ApproveTask_FullMethodName                 = "/kele.KeleService/ApproveTask"
+	KeleService_RejectTask_FullMethodName                  = "/kele.KeleService/RejectTask"
+	KeleService_ListTaskArtifacts_FullMethodName           = "/kele.KeleService/ListTaskArtifacts"
+	KeleService_GetTaskArtifact_FullMethodName             = "/kele.KeleService/GetTaskArtifact"
+	KeleService_PlanWorkspace_FullMethodName               = "/kele.KeleService/PlanWorkspace"
+	KeleService_ApprovePlan_FullMethodName                 = "/kele.KeleService/ApprovePlan"
+	KeleService_ListPlanDrafts_FullMethodName              = "/kele.KeleService/ListPlanDrafts"
+	KeleService_GetPlanDraft_FullMethodName                = "/kele.KeleService/GetPlanDraft"
+	KeleService_DeletePlanDraft_FullMethodName             = "/kele.KeleService/DeletePlanDraft"
+	KeleService_AddPlanTask_FullMethodName                 = "/kele.KeleService/AddPlanTask"
+	KeleService_RemovePlanTask_FullMethodName              = "/kele.KeleService/RemovePlanTask"
+	KeleService_MovePlanTask_FullMethodName                = "/kele.KeleService/MovePlanTask"
+	KeleService_UpdatePlanTask_FullMethodName              = "/kele.KeleService/UpdatePlanTask"
+	KeleService_RevisePlan_FullMethodName                  = "/kele.KeleService/RevisePlan"
+	KeleService_GetBoardOverview_FullMethodName            = "/kele.KeleService/GetBoardOverview"
+	KeleService_WatchBoard_FullMethodName                  = "/kele.KeleService/WatchBoard"
+	KeleService_GetTaskLog_FullMethodName                  = "/kele.KeleService/GetTaskLog"
+	KeleService_SearchTasks_FullMethodName                 = "/kele.KeleService/SearchTasks"
 )
 
 // KeleServiceClient is the client API for KeleService service.
@@ -70,11 +104,39 @@ type KeleServiceClient interface {
 	GetStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusResponse, error)
 	// GetHeartbeatStatus returns heartbeat system status.
 	GetHeartbeatStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HeartbeatStatusResponse, error)
+	// GetHeartbeatHistory lists recent heartbeats, or one with its transcript.
+	GetHeartbeatHistory(ctx context.Context, in *GetHeartbeatHistoryRequest, opts ...grpc.CallOption) (*GetHeartbeatHistoryResponse, error)
+	// ReindexMemory backfills embedding vectors for long-term memories.
+	ReindexMemory(ctx context.Context, in *ReindexMemoryRequest, opts ...grpc.CallOption) (*ReindexMemoryResponse, error)
+	// SearchHistory searches archived conversations across all sessions.
+	SearchHistory(ctx context.Context, in *SearchHistoryRequest, opts ...grpc.CallOption) (*SearchHistoryResponse, error)
+	ListCronJobs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListCronJobsResponse, error)
+	GetCronJob(ctx context.Context, in *CronJobRequest, opts ...grpc.CallOption) (*CronJobInfo, error)
+	CreateCronJob(ctx context.Context, in *CreateCronJobRequest, opts ...grpc.CallOption) (*CronJobInfo, error)
+	UpdateCronJob(ctx context.Context, in *UpdateCronJobRequest, opts ...grpc.CallOption) (*CronJobInfo, error)
+	DeleteCronJob(ctx context.Context, in *CronJobRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetCronJobEnabled(ctx context.Context, in *SetCronJobEnabledRequest, opts ...grpc.CallOption) (*CronJobInfo, error)
+	// RunCronJob runs a job now and waits for it; the schedule is unchanged.
+	RunCronJob(ctx context.Context, in *CronJobRequest, opts ...grpc.CallOption) (*CronLogEntry, error)
+	GetCronLogs(ctx context.Context, in *GetCronLogsRequest, opts ...grpc.CallOption) (*GetCronLogsResponse, error)
+	// PreviewCronSchedule lists the upcoming fire times of a job or an expression.
+	PreviewCronSchedule(ctx context.Context, in *PreviewCronScheduleRequest, opts ...grpc.CallOption) (*PreviewCronScheduleResponse, error)
 	CreateWorkspace(ctx context.Context, in *CreateWorkspaceRequest, opts ...grpc.CallOption) (*WorkspaceInfo, error)
 	GetWorkspace(ctx context.Context, in *GetWorkspaceRequest, opts ...grpc.CallOption) (*WorkspaceInfo, error)
 	UpdateWorkspace(ctx context.Context, in *UpdateWorkspaceRequest, opts ...grpc.CallOption) (*WorkspaceInfo, error)
 	DeleteWorkspace(ctx context.Context, in *DeleteWorkspaceRequest, opts ...grpc.CallOption) (*Empty, error)
 	ListWorkspaces(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListWorkspacesResponse, error)
+	MakeWorkspaceTemplate(ctx context.Context, in *GetWorkspaceRequest, opts ...grpc.CallOption) (*WorkspaceInfo, error)
+	CreateWorkspaceSchedule(ctx context.Context, in *CreateWorkspaceScheduleRequest, opts ...grpc.CallOption) (*WorkspaceScheduleInfo, error)
+	ListWorkspaceSchedules(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListWorkspaceSchedulesResponse, error)
+	DeleteWorkspaceSchedule(ctx context.Context, in *WorkspaceScheduleRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetWorkspaceScheduleEnabled(ctx context.Context, in *SetWorkspaceScheduleEnabledRequest, opts ...grpc.CallOption) (*WorkspaceScheduleInfo, error)
+	// RunWorkspaceSchedule starts a run now, outside the cron schedule.
+	RunWorkspaceSchedule(ctx context.Context, in *WorkspaceScheduleRequest, opts ...grpc.CallOption) (*WorkspaceInfo, error)
+	// ExportWorkspace writes a workspace as a portable YAML/JSON plan file.
+	ExportWorkspace(ctx context.Context, in *ExportWorkspaceRequest, opts ...grpc.CallOption) (*ExportWorkspaceResponse, error)
+	// ImportWorkspace recreates a workspace from a plan file.
+	ImportWorkspace(ctx context.Context, in *ImportWorkspaceRequest, opts ...grpc.CallOption) (*WorkspaceInfo, error)
 	CreateTask(ctx context.Context, in *CreateTaskRequest, opts ...grpc.CallOption) (*TaskInfo, error)
 	GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*TaskInfo, error)
 	UpdateTaskRPC(ctx context.Context, in *UpdateTaskRequest, opts ...grpc.CallOption) (*TaskInfo, error)
@@ -83,11 +145,31 @@ type KeleServiceClient interface {
 	StartTask(ctx context.Context, in *StartTaskRequest, opts ...grpc.CallOption) (*TaskInfo, error)
 	CancelTask(ctx context.Context, in *CancelTaskRequest, opts ...grpc.CallOption) (*TaskInfo, error)
 	RetryTask(ctx context.Context, in *RetryTaskRequest, opts ...grpc.CallOption) (*TaskInfo, error)
+	// MergeTask fast-forwards the workspace repository to a done task's branch.
+	MergeTask(ctx context.Context, in *MergeTaskRequest, opts ...grpc.CallOption) (*TaskInfo, error)
+	// ApproveTask marks a task in review as done and releases its dependents.
+	ApproveTask(ctx context.Context, in *ReviewTaskRequest, opts ...grpc.CallOption) (*TaskInfo, error)
+	// RejectTask sends a task in review back to ready with the reviewer's feedback.
+	RejectTask(ctx context.Context, in *ReviewTaskRequest, opts ...grpc.CallOption) (*TaskInfo, error)
+	// ListTaskArtifacts lists the files, diff and long result kept from a task's runs.
+	ListTaskArtifacts(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*ListTaskArtifactsResponse, error)
+	// GetTaskArtifact returns one artifact with its content.
+	GetTaskArtifact(ctx context.Context, in *GetTaskArtifactRequest, opts ...grpc.CallOption) (*TaskArtifact, error)
 	PlanWorkspace(ctx context.Context, in *PlanWorkspaceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PlanEventMsg], error)
 	ApprovePlan(ctx context.Context, in *ApprovePlanRequest, opts ...grpc.CallOption) (*ApprovePlanResponse, error)
+	ListPlanDrafts(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListPlanDraftsResponse, error)
+	GetPlanDraft(ctx context.Context, in *GetPlanDraftRequest, opts ...grpc.CallOption) (*PlanDraftInfo, error)
+	DeletePlanDraft(ctx context.Context, in *DeletePlanDraftRequest, opts ...grpc.CallOption) (*Empty, error)
+	AddPlanTask(ctx context.Context, in *AddPlanTaskRequest, opts ...grpc.CallOption) (*PlanDraftInfo, error)
+	RemovePlanTask(ctx context.Context, in *RemovePlanTaskRequest, opts ...grpc.CallOption) (*PlanDraftInfo, error)
+	MovePlanTask(ctx context.Context, in *MovePlanTaskRequest, opts ...grpc.CallOption) (*PlanDraftInfo, error)
+	UpdatePlanTask(ctx context.Context, in *UpdatePlanTaskRequest, opts ...grpc.CallOption) (*PlanDraftInfo, error)
+	RevisePlan(ctx context.Context, in *RevisePlanRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PlanEventMsg], error)
 	GetBoardOverview(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BoardOverviewMsg, error)
 	WatchBoard(ctx context.Context, in *WatchBoardRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BoardEventMsg], error)
 	GetTaskLog(ctx context.Context, in *GetTaskLogRequest, opts ...grpc.CallOption) (*TaskLogResponse, error)
+	// SearchTasks finds tasks whose logs, result or error contain a text.
+	SearchTasks(ctx context.Context, in *SearchTasksRequest, opts ...grpc.CallOption) (*SearchTasksResponse, error)
 }
 
 type keleServiceClient struct {
@@ -187,6 +269,126 @@ func (c *keleServiceClient) GetHeartbeatStatus(ctx context.Context, in *Empty, o
 	return out, nil
 }
 
+func (c *keleServiceClient) GetHeartbeatHistory(ctx context.Context, in *GetHeartbeatHistoryRequest, opts ...grpc.CallOption) (*GetHeartbeatHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHeartbeatHistoryResponse)
+	err := c.cc.Invoke(ctx, KeleService_GetHeartbeatHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) ReindexMemory(ctx context.Context, in *ReindexMemoryRequest, opts ...grpc.CallOption) (*ReindexMemoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReindexMemoryResponse)
+	err := c.cc.Invoke(ctx, KeleService_ReindexMemory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) SearchHistory(ctx context.Context, in *SearchHistoryRequest, opts ...grpc.CallOption) (*SearchHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchHistoryResponse)
+	err := c.cc.Invoke(ctx, KeleService_SearchHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) ListCronJobs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListCronJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCronJobsResponse)
+	err := c.cc.Invoke(ctx, KeleService_ListCronJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) GetCronJob(ctx context.Context, in *CronJobRequest, opts ...grpc.CallOption) (*CronJobInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CronJobInfo)
+	err := c.cc.Invoke(ctx, KeleService_GetCronJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) CreateCronJob(ctx context.Context, in *CreateCronJobRequest, opts ...grpc.CallOption) (*CronJobInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CronJobInfo)
+	err := c.cc.Invoke(ctx, KeleService_CreateCronJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) UpdateCronJob(ctx context.Context, in *UpdateCronJobRequest, opts ...grpc.CallOption) (*CronJobInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CronJobInfo)
+	err := c.cc.Invoke(ctx, KeleService_UpdateCronJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) DeleteCronJob(ctx context.Context, in *CronJobRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, KeleService_DeleteCronJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) SetCronJobEnabled(ctx context.Context, in *SetCronJobEnabledRequest, opts ...grpc.CallOption) (*CronJobInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CronJobInfo)
+	err := c.cc.Invoke(ctx, KeleService_SetCronJobEnabled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) RunCronJob(ctx context.Context, in *CronJobRequest, opts ...grpc.CallOption) (*CronLogEntry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CronLogEntry)
+	err := c.cc.Invoke(ctx, KeleService_RunCronJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) GetCronLogs(ctx context.Context, in *GetCronLogsRequest, opts ...grpc.CallOption) (*GetCronLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCronLogsResponse)
+	err := c.cc.Invoke(ctx, KeleService_GetCronLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) PreviewCronSchedule(ctx context.Context, in *PreviewCronScheduleRequest, opts ...grpc.CallOption) (*PreviewCronScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewCronScheduleResponse)
+	err := c.cc.Invoke(ctx, KeleService_PreviewCronSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *keleServiceClient) CreateWorkspace(ctx context.Context, in *CreateWorkspaceRequest, opts ...grpc.CallOption) (*WorkspaceInfo, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(WorkspaceInfo)
@@ -237,6 +439,86 @@ func (c *keleServiceClient) ListWorkspaces(ctx context.Context, in *Empty, opts
 	return out, nil
 }
 
+func (c *keleServiceClient) MakeWorkspaceTemplate(ctx context.Context, in *GetWorkspaceRequest, opts ...grpc.CallOption) (*WorkspaceInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkspaceInfo)
+	err := c.cc.Invoke(ctx, KeleService_MakeWorkspaceTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) CreateWorkspaceSchedule(ctx context.Context, in *CreateWorkspaceScheduleRequest, opts ...grpc.CallOption) (*WorkspaceScheduleInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkspaceScheduleInfo)
+	err := c.cc.Invoke(ctx, KeleService_CreateWorkspaceSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) ListWorkspaceSchedules(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListWorkspaceSchedulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWorkspaceSchedulesResponse)
+	err := c.cc.Invoke(ctx, KeleService_ListWorkspaceSchedules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) DeleteWorkspaceSchedule(ctx context.Context, in *WorkspaceScheduleRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, KeleService_DeleteWorkspaceSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) SetWorkspaceScheduleEnabled(ctx context.Context, in *SetWorkspaceScheduleEnabledRequest, opts ...grpc.CallOption) (*WorkspaceScheduleInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkspaceScheduleInfo)
+	err := c.cc.Invoke(ctx, KeleService_SetWorkspaceScheduleEnabled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) RunWorkspaceSchedule(ctx context.Context, in *WorkspaceScheduleRequest, opts ...grpc.CallOption) (*WorkspaceInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkspaceInfo)
+	err := c.cc.Invoke(ctx, KeleService_RunWorkspaceSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) ExportWorkspace(ctx context.Context, in *ExportWorkspaceRequest, opts ...grpc.CallOption) (*ExportWorkspaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportWorkspaceResponse)
+	err := c.cc.Invoke(ctx, KeleService_ExportWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) ImportWorkspace(ctx context.Context, in *ImportWorkspaceRequest, opts ...grpc.CallOption) (*WorkspaceInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkspaceInfo)
+	err := c.cc.Invoke(ctx, KeleService_ImportWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *keleServiceClient) CreateTask(ctx context.Context, in *CreateTaskRequest, opts ...grpc.CallOption) (*TaskInfo, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TaskInfo)
@@ -317,6 +599,56 @@ func (c *keleServiceClient) RetryTask(ctx context.Context, in *RetryTaskRequest,
 	return out, nil
 }
 
+func (c *keleServiceClient) MergeTask(ctx context.Context, in *MergeTaskRequest, opts ...grpc.CallOption) (*TaskInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskInfo)
+	err := c.cc.Invoke(ctx, KeleService_MergeTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) ApproveTask(ctx context.Context, in *ReviewTaskRequest, opts ...grpc.CallOption) (*TaskInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskInfo)
+	err := c.cc.Invoke(ctx, KeleService_ApproveTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) RejectTask(ctx context.Context, in *ReviewTaskRequest, opts ...grpc.CallOption) (*TaskInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskInfo)
+	err := c.cc.Invoke(ctx, KeleService_RejectTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) ListTaskArtifacts(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*ListTaskArtifactsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTaskArtifactsResponse)
+	err := c.cc.Invoke(ctx, KeleService_ListTaskArtifacts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) GetTaskArtifact(ctx context.Context, in *GetTaskArtifactRequest, opts ...grpc.CallOption) (*TaskArtifact, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskArtifact)
+	err := c.cc.Invoke(ctx, KeleService_GetTaskArtifact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *keleServiceClient) PlanWorkspace(ctx context.Context, in *PlanWorkspaceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PlanEventMsg], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &KeleService_ServiceDesc.Streams[1], KeleService_PlanWorkspace_FullMethodName, cOpts...)
@@ -346,6 +678,95 @@ func (c *keleServiceClient) ApprovePlan(ctx context.Context, in *ApprovePlanRequ
 	return out, nil
 }
 
+func (c *keleServiceClient) ListPlanDrafts(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListPlanDraftsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPlanDraftsResponse)
+	err := c.cc.Invoke(ctx, KeleService_ListPlanDrafts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) GetPlanDraft(ctx context.Context, in *GetPlanDraftRequest, opts ...grpc.CallOption) (*PlanDraftInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlanDraftInfo)
+	err := c.cc.Invoke(ctx, KeleService_GetPlanDraft_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) DeletePlanDraft(ctx context.Context, in *DeletePlanDraftRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, KeleService_DeletePlanDraft_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) AddPlanTask(ctx context.Context, in *AddPlanTaskRequest, opts ...grpc.CallOption) (*PlanDraftInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlanDraftInfo)
+	err := c.cc.Invoke(ctx, KeleService_AddPlanTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) RemovePlanTask(ctx context.Context, in *RemovePlanTaskRequest, opts ...grpc.CallOption) (*PlanDraftInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlanDraftInfo)
+	err := c.cc.Invoke(ctx, KeleService_RemovePlanTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) MovePlanTask(ctx context.Context, in *MovePlanTaskRequest, opts ...grpc.CallOption) (*PlanDraftInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlanDraftInfo)
+	err := c.cc.Invoke(ctx, KeleService_MovePlanTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) UpdatePlanTask(ctx context.Context, in *UpdatePlanTaskRequest, opts ...grpc.CallOption) (*PlanDraftInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlanDraftInfo)
+	err := c.cc.Invoke(ctx, KeleService_UpdatePlanTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keleServiceClient) RevisePlan(ctx context.Context, in *RevisePlanRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PlanEventMsg], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KeleService_ServiceDesc.Streams[2], KeleService_RevisePlan_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RevisePlanRequest, PlanEventMsg]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KeleService_RevisePlanClient = grpc.ServerStreamingClient[PlanEventMsg]
+
 func (c *keleServiceClient) GetBoardOverview(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BoardOverviewMsg, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(BoardOverviewMsg)
@@ -358,7 +779,7 @@ func (c *keleServiceClient) GetBoardOverview(ctx context.Context, in *Empty, opt
 
 func (c *keleServiceClient) WatchBoard(ctx context.Context, in *WatchBoardRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BoardEventMsg], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &KeleService_ServiceDesc.Streams[2], KeleService_WatchBoard_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &KeleService_ServiceDesc.Streams[3], KeleService_WatchBoard_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -385,6 +806,16 @@ func (c *keleServiceClient) GetTaskLog(ctx context.Context, in *GetTaskLogReques
 	return out, nil
 }
 
+func (c *keleServiceClient) SearchTasks(ctx context.Context, in *SearchTasksRequest, opts ...grpc.CallOption) (*SearchTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchTasksResponse)
+	err := c.cc.Invoke(ctx, KeleService_SearchTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // KeleServiceServer is the server API for KeleService service.
 // All implementations must embed UnimplementedKeleServiceServer
 // for forward compatibility.
@@ -408,11 +839,39 @@ type KeleServiceServer interface {
 	GetStatus(context.Context, *Empty) (*StatusResponse, error)
 	// GetHeartbeatStatus returns heartbeat system status.
 	GetHeartbeatStatus(context.Context, *Empty) (*HeartbeatStatusResponse, error)
+	// GetHeartbeatHistory lists recent heartbeats, or one with its transcript.
+	GetHeartbeatHistory(context.Context, *GetHeartbeatHistoryRequest) (*GetHeartbeatHistoryResponse, error)
+	// ReindexMemory backfills embedding vectors for long-term memories.
+	ReindexMemory(context.Context, *ReindexMemoryRequest) (*ReindexMemoryResponse, error)
+	// SearchHistory searches archived conversations across all sessions.
+	SearchHistory(context.Context, *SearchHistoryRequest) (*SearchHistoryResponse, error)
+	ListCronJobs(context.Context, *Empty) (*ListCronJobsResponse, error)
+	GetCronJob(context.Context, *CronJobRequest) (*CronJobInfo, error)
+	CreateCronJob(context.Context, *CreateCronJobRequest) (*CronJobInfo, error)
+	UpdateCronJob(context.Context, *UpdateCronJobRequest) (*CronJobInfo, error)
+	DeleteCronJob(context.Context, *CronJobRequest) (*Empty, error)
+	SetCronJobEnabled(context.Context, *SetCronJobEnabledRequest) (*CronJobInfo, error)
+	// RunCronJob runs a job now and waits for it; the schedule is unchanged.
+	RunCronJob(context.Context, *CronJobRequest) (*CronLogEntry, error)
+	GetCronLogs(context.Context, *GetCronLogsRequest) (*GetCronLogsResponse, error)
+	// PreviewCronSchedule lists the upcoming fire times of a job or an expression.
+	PreviewCronSchedule(context.Context, *PreviewCronScheduleRequest) (*PreviewCronScheduleResponse, error)
 	CreateWorkspace(context.Context, *CreateWorkspaceRequest) (*WorkspaceInfo, error)
 	GetWorkspace(context.Context, *GetWorkspaceRequest) (*WorkspaceInfo, error)
 	UpdateWorkspace(context.Context, *UpdateWorkspaceRequest) (*WorkspaceInfo, error)
 	DeleteWorkspace(context.Context, *DeleteWorkspaceRequest) (*Empty, error)
 	ListWorkspaces(context.Context, *Empty) (*ListWorkspacesResponse, error)
+	MakeWorkspaceTemplate(context.Context, *GetWorkspaceRequest) (*WorkspaceInfo, error)
+	CreateWorkspaceSchedule(context.Context, *CreateWorkspaceScheduleRequest) (*WorkspaceScheduleInfo, error)
+	ListWorkspaceSchedules(context.Context, *Empty) (*ListWorkspaceSchedulesResponse, error)
+	DeleteWorkspaceSchedule(context.Context, *WorkspaceScheduleRequest) (*Empty, error)
+	SetWorkspaceScheduleEnabled(context.Context, *SetWorkspaceScheduleEnabledRequest) (*WorkspaceScheduleInfo, error)
+	// RunWorkspaceSchedule starts a run now, outside the cron schedule.
+	RunWorkspaceSchedule(context.Context, *WorkspaceScheduleRequest) (*WorkspaceInfo, error)
+	// ExportWorkspace writes a workspace as a portable YAML/JSON plan file.
+	ExportWorkspace(context.Context, *ExportWorkspaceRequest) (*ExportWorkspaceResponse, error)
+	// ImportWorkspace recreates a workspace from a plan file.
+	ImportWorkspace(context.Context, *ImportWorkspaceRequest) (*WorkspaceInfo, error)
 	CreateTask(context.Context, *CreateTaskRequest) (*TaskInfo, error)
 	GetTask(context.Context, *GetTaskRequest) (*TaskInfo, error)
 	UpdateTaskRPC(context.Context, *UpdateTaskRequest) (*TaskInfo, error)
@@ -421,11 +880,31 @@ type KeleServiceServer interface {
 	StartTask(context.Context, *StartTaskRequest) (*TaskInfo, error)
 	CancelTask(context.Context, *CancelTaskRequest) (*TaskInfo, error)
 	RetryTask(context.Context, *RetryTaskRequest) (*TaskInfo, error)
+	// MergeTask fast-forwards the workspace repository to a done task's branch.
+	MergeTask(context.Context, *MergeTaskRequest) (*TaskInfo, error)
+	// ApproveTask marks a task in review as done and releases its dependents.
+	ApproveTask(context.Context, *ReviewTaskRequest) (*TaskInfo, error)
+	// RejectTask sends a task in review back to ready with the reviewer's feedback.
+	RejectTask(context.Context, *ReviewTaskRequest) (*TaskInfo, error)
+	// ListTaskArtifacts lists the files, diff and long result kept from a task's runs.
+	ListTaskArtifacts(context.Context, *GetTaskRequest) (*ListTaskArtifactsResponse, error)
+	// GetTaskArtifact returns one artifact with its content.
+	GetTaskArtifact(context.Context, *GetTaskArtifactRequest) (*TaskArtifact, error)
 	PlanWorkspace(*PlanWorkspaceRequest, grpc.ServerStreamingServer[PlanEventMsg]) error
 	ApprovePlan(context.Context, *ApprovePlanRequest) (*ApprovePlanResponse, error)
+	ListPlanDrafts(context.Context, *Empty) (*ListPlanDraftsResponse, error)
+	GetPlanDraft(context.Context, *GetPlanDraftRequest) (*PlanDraftInfo, error)
+	DeletePlanDraft(context.Context, *DeletePlanDraftRequest) (*Empty, error)
+	AddPlanTask(context.Context, *AddPlanTaskRequest) (*PlanDraftInfo, error)
+	RemovePlanTask(context.Context, *RemovePlanTaskRequest) (*PlanDraftInfo, error)
+	MovePlanTask(context.Context, *MovePlanTaskRequest) (*PlanDraftInfo, error)
+	UpdatePlanTask(context.Context, *UpdatePlanTaskRequest) (*PlanDraftInfo, error)
+	RevisePlan(*RevisePlanRequest, grpc.ServerStreamingServer[PlanEventMsg]) error
 	GetBoardOverview(context.Context, *Empty) (*BoardOverviewMsg, error)
 	WatchBoard(*WatchBoardRequest, grpc.ServerStreamingServer[BoardEventMsg]) error
 	GetTaskLog(context.Context, *GetTaskLogRequest) (*TaskLogResponse, error)
+	// SearchTasks finds tasks whose logs, result or error contain a text.
+	SearchTasks(context.Context, *SearchTasksRequest) (*SearchTasksResponse, error)
 	mustEmbedUnimplementedKeleServiceServer()
 }
 
@@ -460,7 +939,43 @@ func (UnimplementedKeleServiceServer) GetStatus(context.Context, *Empty) (*Statu
 func (UnimplementedKeleServiceServer) GetHeartbeatStatus(context.Context, *Empty) (*HeartbeatStatusResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetHeartbeatStatus not implemented")
 }
-func (UnimplementedKeleServiceServer) CreateWorkspace(context.Context, *CreateWorkspaceRequest) (*WorkspaceInfo, error) {
+func (UnimplementedKeleServiceServer) GetHeartbeatHistory(context.Context, *GetHeartbeatHistoryRequest) (*GetHeartbeatHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHeartbeatHistory not implemented")
+}
+func (UnimplementedKeleServiceServer) ReindexMemory(context.Context, *ReindexMemoryRequest) (*ReindexMemoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReindexMemory not implemented")
+}
+func (UnimplementedKeleServiceServer) SearchHistory(context.Context, *SearchHistoryRequest) (*SearchHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchHistory not implemented")
+}
+func (UnimplementedKeleServiceServer) ListCronJobs(context.Context, *Empty) (*ListCronJobsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCronJobs not implemented")
+}
+func (UnimplementedKeleServiceServer) GetCronJob(context.Context, *CronJobRequest) (*CronJobInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCronJob not implemented")
+}
+func (UnimplementedKeleServiceServer) CreateCronJob(context.Context, *CreateCronJobRequest) (*CronJobInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateCronJob not implemented")
+}
+func (UnimplementedKeleServiceServer) UpdateCronJob(context.Context, *UpdateCronJobRequest) (*CronJobInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateCronJob not implemented")
+}
+func (UnimplementedKeleServiceServer) DeleteCronJob(context.Context, *CronJobRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteCronJob not implemented")
+}
+func (UnimplementedKeleServiceServer) SetCronJobEnabled(context.Context, *SetCronJobEnabledRequest) (*CronJobInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetCronJobEnabled not implemented")
+}
+func (UnimplementedKeleServiceServer) RunCronJob(context.Context, *CronJobRequest) (*CronLogEntry, error) {
+	return nil, status.Error(codes.Unimplemented, "method RunCronJob not implemented")
+}
+func (UnimplementedKeleServiceServer) GetCronLogs(context.Context, *GetCronLogsRequest) (*GetCronLogsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCronLogs not implemented")
+}
+func (UnimplementedKeleServiceServer) PreviewCronSchedule(context.Context, *PreviewCronScheduleRequest) (*PreviewCronScheduleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PreviewCronSchedule not implemented")
+}
+func (UnimplementedKeleServiceServer) CreateWorkspace(context.Context, *CreateWorkspaceRequest) (*WorkspaceInfo, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateWorkspace not implemented")
 }
 func (UnimplementedKeleServiceServer) GetWorkspace(context.Context, *GetWorkspaceRequest) (*WorkspaceInfo, error) {
@@ -475,6 +990,30 @@ func (UnimplementedKeleServiceServer) DeleteWorkspace(context.Context, *DeleteWo
 func (UnimplementedKeleServiceServer) ListWorkspaces(context.Context, *Empty) (*ListWorkspacesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListWorkspaces not implemented")
 }
+func (UnimplementedKeleServiceServer) MakeWorkspaceTemplate(context.Context, *GetWorkspaceRequest) (*WorkspaceInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method MakeWorkspaceTemplate not implemented")
+}
+func (UnimplementedKeleServiceServer) CreateWorkspaceSchedule(context.Context, *CreateWorkspaceScheduleRequest) (*WorkspaceScheduleInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateWorkspaceSchedule not implemented")
+}
+func (UnimplementedKeleServiceServer) ListWorkspaceSchedules(context.Context, *Empty) (*ListWorkspaceSchedulesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWorkspaceSchedules not implemented")
+}
+func (UnimplementedKeleServiceServer) DeleteWorkspaceSchedule(context.Context, *WorkspaceScheduleRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteWorkspaceSchedule not implemented")
+}
+func (UnimplementedKeleServiceServer) SetWorkspaceScheduleEnabled(context.Context, *SetWorkspaceScheduleEnabledRequest) (*WorkspaceScheduleInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetWorkspaceScheduleEnabled not implemented")
+}
+func (UnimplementedKeleServiceServer) RunWorkspaceSchedule(context.Context, *WorkspaceScheduleRequest) (*WorkspaceInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method RunWorkspaceSchedule not implemented")
+}
+func (UnimplementedKeleServiceServer) ExportWorkspace(context.Context, *ExportWorkspaceRequest) (*ExportWorkspaceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportWorkspace not implemented")
+}
+func (UnimplementedKeleServiceServer) ImportWorkspace(context.Context, *ImportWorkspaceRequest) (*WorkspaceInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportWorkspace not implemented")
+}
 func (UnimplementedKeleServiceServer) CreateTask(context.Context, *CreateTaskRequest) (*TaskInfo, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateTask not implemented")
 }
@@ -499,12 +1038,51 @@ func (UnimplementedKeleServiceServer) CancelTask(context.Context, *CancelTaskReq
 func (UnimplementedKeleServiceServer) RetryTask(context.Context, *RetryTaskRequest) (*TaskInfo, error) {
 	return nil, status.Error(codes.Unimplemented, "method RetryTask not implemented")
 }
+func (UnimplementedKeleServiceServer) MergeTask(context.Context, *MergeTaskRequest) (*TaskInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method MergeTask not implemented")
+}
+func (UnimplementedKeleServiceServer) ApproveTask(context.Context, *ReviewTaskRequest) (*TaskInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApproveTask not implemented")
+}
+func (UnimplementedKeleServiceServer) RejectTask(context.Context, *ReviewTaskRequest) (*TaskInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method RejectTask not implemented")
+}
+func (UnimplementedKeleServiceServer) ListTaskArtifacts(context.Context, *GetTaskRequest) (*ListTaskArtifactsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTaskArtifacts not implemented")
+}
+func (UnimplementedKeleServiceServer) GetTaskArtifact(context.Context, *GetTaskArtifactRequest) (*TaskArtifact, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTaskArtifact not implemented")
+}
 func (UnimplementedKeleServiceServer) PlanWorkspace(*PlanWorkspaceRequest, grpc.ServerStreamingServer[PlanEventMsg]) error {
 	return status.Error(codes.Unimplemented, "method PlanWorkspace not implemented")
 }
 func (UnimplementedKeleServiceServer) ApprovePlan(context.Context, *ApprovePlanRequest) (*ApprovePlanResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ApprovePlan not implemented")
 }
+func (UnimplementedKeleServiceServer) ListPlanDrafts(context.Context, *Empty) (*ListPlanDraftsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPlanDrafts not implemented")
+}
+func (UnimplementedKeleServiceServer) GetPlanDraft(context.Context, *GetPlanDraftRequest) (*PlanDraftInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPlanDraft not implemented")
+}
+func (UnimplementedKeleServiceServer) DeletePlanDraft(context.Context, *DeletePlanDraftRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeletePlanDraft not implemented")
+}
+func (UnimplementedKeleServiceServer) AddPlanTask(context.Context, *AddPlanTaskRequest) (*PlanDraftInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddPlanTask not implemented")
+}
+func (UnimplementedKeleServiceServer) RemovePlanTask(context.Context, *RemovePlanTaskRequest) (*PlanDraftInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemovePlanTask not implemented")
+}
+func (UnimplementedKeleServiceServer) MovePlanTask(context.Context, *MovePlanTaskRequest) (*PlanDraftInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method MovePlanTask not implemented")
+}
+func (UnimplementedKeleServiceServer) UpdatePlanTask(context.Context, *UpdatePlanTaskRequest) (*PlanDraftInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdatePlanTask not implemented")
+}
+func (UnimplementedKeleServiceServer) RevisePlan(*RevisePlanRequest, grpc.ServerStreamingServer[PlanEventMsg]) error {
+	return status.Error(codes.Unimplemented, "method RevisePlan not implemented")
+}
 func (UnimplementedKeleServiceServer) GetBoardOverview(context.Context, *Empty) (*BoardOverviewMsg, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetBoardOverview not implemented")
 }
@@ -514,6 +1092,9 @@ func (UnimplementedKeleServiceServer) WatchBoard(*WatchBoardRequest, grpc.Server
 func (UnimplementedKeleServiceServer) GetTaskLog(context.Context, *GetTaskLogRequest) (*TaskLogResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetTaskLog not implemented")
 }
+func (UnimplementedKeleServiceServer) SearchTasks(context.Context, *SearchTasksRequest) (*SearchTasksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchTasks not implemented")
+}
 func (UnimplementedKeleServiceServer) mustEmbedUnimplementedKeleServiceServer() {}
 func (UnimplementedKeleServiceServer) testEmbeddedByValue()                     {}
 
@@ -552,388 +1133,975 @@ func _KeleService_Complete_Handler(srv interface{}, ctx context.Context, dec fun
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).Complete(ctx, in)
+		return srv.(KeleServiceServer).Complete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_Complete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).Complete(ctx, req.(*CompleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_RunCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).RunCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_RunCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).RunCommand(ctx, req.(*RunCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_CreateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).CreateSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_CreateSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).CreateSession(ctx, req.(*CreateSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_DeleteSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).DeleteSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_DeleteSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).DeleteSession(ctx, req.(*DeleteSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).ListSessions(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).GetStatus(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_GetHeartbeatStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).GetHeartbeatStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_GetHeartbeatStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).GetHeartbeatStatus(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_GetHeartbeatHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHeartbeatHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).GetHeartbeatHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_GetHeartbeatHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).GetHeartbeatHistory(ctx, req.(*GetHeartbeatHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_ReindexMemory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReindexMemoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).ReindexMemory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_ReindexMemory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).ReindexMemory(ctx, req.(*ReindexMemoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_SearchHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).SearchHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_SearchHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).SearchHistory(ctx, req.(*SearchHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_ListCronJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).ListCronJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_ListCronJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).ListCronJobs(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_GetCronJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CronJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).GetCronJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_GetCronJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).GetCronJob(ctx, req.(*CronJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_CreateCronJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCronJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).CreateCronJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_CreateCronJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).CreateCronJob(ctx, req.(*CreateCronJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_UpdateCronJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCronJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).UpdateCronJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_UpdateCronJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).UpdateCronJob(ctx, req.(*UpdateCronJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_DeleteCronJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CronJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).DeleteCronJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_DeleteCronJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).DeleteCronJob(ctx, req.(*CronJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_SetCronJobEnabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCronJobEnabledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).SetCronJobEnabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_SetCronJobEnabled_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).SetCronJobEnabled(ctx, req.(*SetCronJobEnabledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_RunCronJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CronJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).RunCronJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_RunCronJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).RunCronJob(ctx, req.(*CronJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_GetCronLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCronLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).GetCronLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_GetCronLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).GetCronLogs(ctx, req.(*GetCronLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_PreviewCronSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewCronScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).PreviewCronSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_PreviewCronSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).PreviewCronSchedule(ctx, req.(*PreviewCronScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_CreateWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).CreateWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_CreateWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).CreateWorkspace(ctx, req.(*CreateWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_GetWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).GetWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_GetWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).GetWorkspace(ctx, req.(*GetWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_UpdateWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).UpdateWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_UpdateWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).UpdateWorkspace(ctx, req.(*UpdateWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_DeleteWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).DeleteWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_DeleteWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).DeleteWorkspace(ctx, req.(*DeleteWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_ListWorkspaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).ListWorkspaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_ListWorkspaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).ListWorkspaces(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_MakeWorkspaceTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).MakeWorkspaceTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_MakeWorkspaceTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).MakeWorkspaceTemplate(ctx, req.(*GetWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_CreateWorkspaceSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWorkspaceScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).CreateWorkspaceSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_CreateWorkspaceSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).CreateWorkspaceSchedule(ctx, req.(*CreateWorkspaceScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_ListWorkspaceSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).ListWorkspaceSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_ListWorkspaceSchedules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).ListWorkspaceSchedules(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_DeleteWorkspaceSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkspaceScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).DeleteWorkspaceSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_DeleteWorkspaceSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).DeleteWorkspaceSchedule(ctx, req.(*WorkspaceScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_SetWorkspaceScheduleEnabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWorkspaceScheduleEnabledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).SetWorkspaceScheduleEnabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_SetWorkspaceScheduleEnabled_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).SetWorkspaceScheduleEnabled(ctx, req.(*SetWorkspaceScheduleEnabledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_RunWorkspaceSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkspaceScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).RunWorkspaceSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_RunWorkspaceSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).RunWorkspaceSchedule(ctx, req.(*WorkspaceScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_ExportWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).ExportWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_ExportWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).ExportWorkspace(ctx, req.(*ExportWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_ImportWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).ImportWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_ImportWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).ImportWorkspace(ctx, req.(*ImportWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_CreateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).CreateTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_CreateTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).CreateTask(ctx, req.(*CreateTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeleService_GetTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).GetTask(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_Complete_FullMethodName,
+		FullMethod: KeleService_GetTask_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).Complete(ctx, req.(*CompleteRequest))
+		return srv.(KeleServiceServer).GetTask(ctx, req.(*GetTaskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_RunCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RunCommandRequest)
+func _KeleService_UpdateTaskRPC_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTaskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).RunCommand(ctx, in)
+		return srv.(KeleServiceServer).UpdateTaskRPC(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_RunCommand_FullMethodName,
+		FullMethod: KeleService_UpdateTaskRPC_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).RunCommand(ctx, req.(*RunCommandRequest))
+		return srv.(KeleServiceServer).UpdateTaskRPC(ctx, req.(*UpdateTaskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_CreateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateSessionRequest)
+func _KeleService_DeleteTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTaskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).CreateSession(ctx, in)
+		return srv.(KeleServiceServer).DeleteTask(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_CreateSession_FullMethodName,
+		FullMethod: KeleService_DeleteTask_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).CreateSession(ctx, req.(*CreateSessionRequest))
+		return srv.(KeleServiceServer).DeleteTask(ctx, req.(*DeleteTaskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_DeleteSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteSessionRequest)
+func _KeleService_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTasksRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).DeleteSession(ctx, in)
+		return srv.(KeleServiceServer).ListTasks(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_DeleteSession_FullMethodName,
+		FullMethod: KeleService_ListTasks_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).DeleteSession(ctx, req.(*DeleteSessionRequest))
+		return srv.(KeleServiceServer).ListTasks(ctx, req.(*ListTasksRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _KeleService_StartTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartTaskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).ListSessions(ctx, in)
+		return srv.(KeleServiceServer).StartTask(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_ListSessions_FullMethodName,
+		FullMethod: KeleService_StartTask_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).ListSessions(ctx, req.(*Empty))
+		return srv.(KeleServiceServer).StartTask(ctx, req.(*StartTaskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _KeleService_CancelTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTaskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).GetStatus(ctx, in)
+		return srv.(KeleServiceServer).CancelTask(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_GetStatus_FullMethodName,
+		FullMethod: KeleService_CancelTask_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).GetStatus(ctx, req.(*Empty))
+		return srv.(KeleServiceServer).CancelTask(ctx, req.(*CancelTaskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_GetHeartbeatStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _KeleService_RetryTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryTaskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).GetHeartbeatStatus(ctx, in)
+		return srv.(KeleServiceServer).RetryTask(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_GetHeartbeatStatus_FullMethodName,
+		FullMethod: KeleService_RetryTask_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).GetHeartbeatStatus(ctx, req.(*Empty))
+		return srv.(KeleServiceServer).RetryTask(ctx, req.(*RetryTaskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_CreateWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateWorkspaceRequest)
+func _KeleService_MergeTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTaskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).CreateWorkspace(ctx, in)
+		return srv.(KeleServiceServer).MergeTask(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_CreateWorkspace_FullMethodName,
+		FullMethod: KeleService_MergeTask_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).CreateWorkspace(ctx, req.(*CreateWorkspaceRequest))
+		return srv.(KeleServiceServer).MergeTask(ctx, req.(*MergeTaskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_GetWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetWorkspaceRequest)
+func _KeleService_ApproveTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewTaskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).GetWorkspace(ctx, in)
+		return srv.(KeleServiceServer).ApproveTask(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_GetWorkspace_FullMethodName,
+		FullMethod: KeleService_ApproveTask_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).GetWorkspace(ctx, req.(*GetWorkspaceRequest))
+		return srv.(KeleServiceServer).ApproveTask(ctx, req.(*ReviewTaskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_UpdateWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateWorkspaceRequest)
+func _KeleService_RejectTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewTaskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).UpdateWorkspace(ctx, in)
+		return srv.(KeleServiceServer).RejectTask(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_UpdateWorkspace_FullMethodName,
+		FullMethod: KeleService_RejectTask_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).UpdateWorkspace(ctx, req.(*UpdateWorkspaceRequest))
+		return srv.(KeleServiceServer).RejectTask(ctx, req.(*ReviewTaskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_DeleteWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteWorkspaceRequest)
+func _KeleService_ListTaskArtifacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).DeleteWorkspace(ctx, in)
+		return srv.(KeleServiceServer).ListTaskArtifacts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_DeleteWorkspace_FullMethodName,
+		FullMethod: KeleService_ListTaskArtifacts_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).DeleteWorkspace(ctx, req.(*DeleteWorkspaceRequest))
+		return srv.(KeleServiceServer).ListTaskArtifacts(ctx, req.(*GetTaskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_ListWorkspaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _KeleService_GetTaskArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskArtifactRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).ListWorkspaces(ctx, in)
+		return srv.(KeleServiceServer).GetTaskArtifact(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_ListWorkspaces_FullMethodName,
+		FullMethod: KeleService_GetTaskArtifact_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).ListWorkspaces(ctx, req.(*Empty))
+		return srv.(KeleServiceServer).GetTaskArtifact(ctx, req.(*GetTaskArtifactRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_CreateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateTaskRequest)
+func _KeleService_PlanWorkspace_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PlanWorkspaceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KeleServiceServer).PlanWorkspace(m, &grpc.GenericServerStream[PlanWorkspaceRequest, PlanEventMsg]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KeleService_PlanWorkspaceServer = grpc.ServerStreamingServer[PlanEventMsg]
+
+func _KeleService_ApprovePlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApprovePlanRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).CreateTask(ctx, in)
+		return srv.(KeleServiceServer).ApprovePlan(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_CreateTask_FullMethodName,
+		FullMethod: KeleService_ApprovePlan_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).CreateTask(ctx, req.(*CreateTaskRequest))
+		return srv.(KeleServiceServer).ApprovePlan(ctx, req.(*ApprovePlanRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_GetTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTaskRequest)
+func _KeleService_ListPlanDrafts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).GetTask(ctx, in)
+		return srv.(KeleServiceServer).ListPlanDrafts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_GetTask_FullMethodName,
+		FullMethod: KeleService_ListPlanDrafts_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).GetTask(ctx, req.(*GetTaskRequest))
+		return srv.(KeleServiceServer).ListPlanDrafts(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_UpdateTaskRPC_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateTaskRequest)
+func _KeleService_GetPlanDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlanDraftRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).UpdateTaskRPC(ctx, in)
+		return srv.(KeleServiceServer).GetPlanDraft(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_UpdateTaskRPC_FullMethodName,
+		FullMethod: KeleService_GetPlanDraft_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).UpdateTaskRPC(ctx, req.(*UpdateTaskRequest))
+		return srv.(KeleServiceServer).GetPlanDraft(ctx, req.(*GetPlanDraftRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_DeleteTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteTaskRequest)
+func _KeleService_DeletePlanDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePlanDraftRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).DeleteTask(ctx, in)
+		return srv.(KeleServiceServer).DeletePlanDraft(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_DeleteTask_FullMethodName,
+		FullMethod: KeleService_DeletePlanDraft_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).DeleteTask(ctx, req.(*DeleteTaskRequest))
+		return srv.(KeleServiceServer).DeletePlanDraft(ctx, req.(*DeletePlanDraftRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListTasksRequest)
+func _KeleService_AddPlanTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPlanTaskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).ListTasks(ctx, in)
+		return srv.(KeleServiceServer).AddPlanTask(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_ListTasks_FullMethodName,
+		FullMethod: KeleService_AddPlanTask_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).ListTasks(ctx, req.(*ListTasksRequest))
+		return srv.(KeleServiceServer).AddPlanTask(ctx, req.(*AddPlanTaskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_StartTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StartTaskRequest)
+func _KeleService_RemovePlanTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePlanTaskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).StartTask(ctx, in)
+		return srv.(KeleServiceServer).RemovePlanTask(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_StartTask_FullMethodName,
+		FullMethod: KeleService_RemovePlanTask_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).StartTask(ctx, req.(*StartTaskRequest))
+		return srv.(KeleServiceServer).RemovePlanTask(ctx, req.(*RemovePlanTaskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_CancelTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CancelTaskRequest)
+func _KeleService_MovePlanTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MovePlanTaskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).CancelTask(ctx, in)
+		return srv.(KeleServiceServer).MovePlanTask(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_CancelTask_FullMethodName,
+		FullMethod: KeleService_MovePlanTask_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).CancelTask(ctx, req.(*CancelTaskRequest))
+		return srv.(KeleServiceServer).MovePlanTask(ctx, req.(*MovePlanTaskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_RetryTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RetryTaskRequest)
+func _KeleService_UpdatePlanTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePlanTaskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(KeleServiceServer).RetryTask(ctx, in)
+		return srv.(KeleServiceServer).UpdatePlanTask(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: KeleService_RetryTask_FullMethodName,
+		FullMethod: KeleService_UpdatePlanTask_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).RetryTask(ctx, req.(*RetryTaskRequest))
+		return srv.(KeleServiceServer).UpdatePlanTask(ctx, req.(*UpdatePlanTaskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _KeleService_PlanWorkspace_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(PlanWorkspaceRequest)
+func _KeleService_RevisePlan_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RevisePlanRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(KeleServiceServer).PlanWorkspace(m, &grpc.GenericServerStream[PlanWorkspaceRequest, PlanEventMsg]{ServerStream: stream})
+	return srv.(KeleServiceServer).RevisePlan(m, &grpc.GenericServerStream[RevisePlanRequest, PlanEventMsg]{ServerStream: stream})
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type KeleService_PlanWorkspaceServer = grpc.ServerStreamingServer[PlanEventMsg]
-
-func _KeleService_ApprovePlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ApprovePlanRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(KeleServiceServer).ApprovePlan(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: KeleService_ApprovePlan_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(KeleServiceServer).ApprovePlan(ctx, req.(*ApprovePlanRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
+type KeleService_RevisePlanServer = grpc.ServerStreamingServer[PlanEventMsg]
 
 func _KeleService_GetBoardOverview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
@@ -982,6 +2150,24 @@ func _KeleService_GetTaskLog_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _KeleService_SearchTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeleServiceServer).SearchTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeleService_SearchTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeleServiceServer).SearchTasks(ctx, req.(*SearchTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // KeleService_ServiceDesc is the grpc.ServiceDesc for KeleService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1017,6 +2203,54 @@ var KeleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetHeartbeatStatus",
 			Handler:    _KeleService_GetHeartbeatStatus_Handler,
 		},
+		{
+			MethodName: "GetHeartbeatHistory",
+			Handler:    _KeleService_GetHeartbeatHistory_Handler,
+		},
+		{
+			MethodName: "ReindexMemory",
+			Handler:    _KeleService_ReindexMemory_Handler,
+		},
+		{
+			MethodName: "SearchHistory",
+			Handler:    _KeleService_SearchHistory_Handler,
+		},
+		{
+			MethodName: "ListCronJobs",
+			Handler:    _KeleService_ListCronJobs_Handler,
+		},
+		{
+			MethodName: "GetCronJob",
+			Handler:    _KeleService_GetCronJob_Handler,
+		},
+		{
+			MethodName: "CreateCronJob",
+			Handler:    _KeleService_CreateCronJob_Handler,
+		},
+		{
+			MethodName: "UpdateCronJob",
+			Handler:    _KeleService_UpdateCronJob_Handler,
+		},
+		{
+			MethodName: "DeleteCronJob",
+			Handler:    _KeleService_DeleteCronJob_Handler,
+		},
+		{
+			MethodName: "SetCronJobEnabled",
+			Handler:    _KeleService_SetCronJobEnabled_Handler,
+		},
+		{
+			MethodName: "RunCronJob",
+			Handler:    _KeleService_RunCronJob_Handler,
+		},
+		{
+			MethodName: "GetCronLogs",
+			Handler:    _KeleService_GetCronLogs_Handler,
+		},
+		{
+			MethodName: "PreviewCronSchedule",
+			Handler:    _KeleService_PreviewCronSchedule_Handler,
+		},
 		{
 			MethodName: "CreateWorkspace",
 			Handler:    _KeleService_CreateWorkspace_Handler,
@@ -1037,6 +2271,38 @@ var KeleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListWorkspaces",
 			Handler:    _KeleService_ListWorkspaces_Handler,
 		},
+		{
+			MethodName: "MakeWorkspaceTemplate",
+			Handler:    _KeleService_MakeWorkspaceTemplate_Handler,
+		},
+		{
+			MethodName: "CreateWorkspaceSchedule",
+			Handler:    _KeleService_CreateWorkspaceSchedule_Handler,
+		},
+		{
+			MethodName: "ListWorkspaceSchedules",
+			Handler:    _KeleService_ListWorkspaceSchedules_Handler,
+		},
+		{
+			MethodName: "DeleteWorkspaceSchedule",
+			Handler:    _KeleService_DeleteWorkspaceSchedule_Handler,
+		},
+		{
+			MethodName: "SetWorkspaceScheduleEnabled",
+			Handler:    _KeleService_SetWorkspaceScheduleEnabled_Handler,
+		},
+		{
+			MethodName: "RunWorkspaceSchedule",
+			Handler:    _KeleService_RunWorkspaceSchedule_Handler,
+		},
+		{
+			MethodName: "ExportWorkspace",
+			Handler:    _KeleService_ExportWorkspace_Handler,
+		},
+		{
+			MethodName: "ImportWorkspace",
+			Handler:    _KeleService_ImportWorkspace_Handler,
+		},
 		{
 			MethodName: "CreateTask",
 			Handler:    _KeleService_CreateTask_Handler,
@@ -1069,10 +2335,58 @@ var KeleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RetryTask",
 			Handler:    _KeleService_RetryTask_Handler,
 		},
+		{
+			MethodName: "MergeTask",
+			Handler:    _KeleService_MergeTask_Handler,
+		},
+		{
+			MethodName: "ApproveTask",
+			Handler:    _KeleService_ApproveTask_Handler,
+		},
+		{
+			MethodName: "RejectTask",
+			Handler:    _KeleService_RejectTask_Handler,
+		},
+		{
+			MethodName: "ListTaskArtifacts",
+			Handler:    _KeleService_ListTaskArtifacts_Handler,
+		},
+		{
+			MethodName: "GetTaskArtifact",
+			Handler:    _KeleService_GetTaskArtifact_Handler,
+		},
 		{
 			MethodName: "ApprovePlan",
 			Handler:    _KeleService_ApprovePlan_Handler,
 		},
+		{
+			MethodName: "ListPlanDrafts",
+			Handler:    _KeleService_ListPlanDrafts_Handler,
+		},
+		{
+			MethodName: "GetPlanDraft",
+			Handler:    _KeleService_GetPlanDraft_Handler,
+		},
+		{
+			MethodName: "DeletePlanDraft",
+			Handler:    _KeleService_DeletePlanDraft_Handler,
+		},
+		{
+			MethodName: "AddPlanTask",
+			Handler:    _KeleService_AddPlanTask_Handler,
+		},
+		{
+			MethodName: "RemovePlanTask",
+			Handler:    _KeleService_RemovePlanTask_Handler,
+		},
+		{
+			MethodName: "MovePlanTask",
+			Handler:    _KeleService_MovePlanTask_Handler,
+		},
+		{
+			MethodName: "UpdatePlanTask",
+			Handler:    _KeleService_UpdatePlanTask_Handler,
+		},
 		{
 			MethodName: "GetBoardOverview",
 			Handler:    _KeleService_GetBoardOverview_Handler,
@@ -1081,6 +2395,10 @@ var KeleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTaskLog",
 			Handler:    _KeleService_GetTaskLog_Handler,
 		},
+		{
+			MethodName: "SearchTasks",
+			Handler:    _KeleService_SearchTasks_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1093,6 +2411,11 @@ var KeleService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _KeleService_PlanWorkspace_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "RevisePlan",
+			Handler:       _KeleService_RevisePlan_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "WatchBoard",
 			Handler:       _KeleService_WatchBoard_Handler,
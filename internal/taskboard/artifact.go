@@ -0,0 +1,114 @@
+package taskboard
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ArtifactKind tells how an artifact was produced.
+type ArtifactKind string
+
+const (
+	ArtifactFile   ArtifactKind = "file"   // a file declared in Task.Artifacts
+	ArtifactDiff   ArtifactKind = "diff"   // the full diff of the task's branch
+	ArtifactResult ArtifactKind = "result" // the full result text, when too long to inline
+)
+
+// Artifact is an output of a task run, stored under the task's ID.
+type Artifact struct {
+	TaskID    string
+	Name      string // path relative to the work dir, or diffArtifactName / resultArtifactName
+	Kind      ArtifactKind
+	Size      int64
+	Path      string // location of the stored copy
+	CreatedAt time.Time
+}
+
+const (
+	diffArtifactName   = "changes.diff"
+	resultArtifactName = "result.md"
+
+	// maxArtifactSize caps a single collected file.
+	maxArtifactSize = 10 << 20
+	// maxInlineResult is how much of a dependency's result is inlined into a
+	// prompt; longer results are passed as a result artifact.
+	maxInlineResult = 2000
+)
+
+// collectArtifacts stores the files declared in task.Artifacts. Each entry
+// is a glob relative to workDir; an entry that matches no file fails the run,
+// since dependents rely on it.
+func (s *Scheduler) collectArtifacts(task *Task, workDir string) error {
+	for _, pattern := range task.Artifacts {
+		matches, err := filepath.Glob(filepath.Join(workDir, pattern))
+		if err != nil {
+			return fmt.Errorf("artifact %q: %w", pattern, err)
+		}
+		collected := 0
+		for _, path := range matches {
+			info, err := os.Stat(path)
+			if err != nil || info.IsDir() {
+				continue
+			}
+			name, err := filepath.Rel(workDir, path)
+			if err != nil || workDir == "" {
+				name = filepath.Base(path)
+			}
+			if info.Size() > maxArtifactSize {
+				return fmt.Errorf("artifact %s is larger than %d bytes", name, maxArtifactSize)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("read artifact %s: %w", name, err)
+			}
+			if err := s.saveArtifact(task, filepath.ToSlash(name), ArtifactFile, data); err != nil {
+				return err
+			}
+			collected++
+		}
+		if collected == 0 {
+			return fmt.Errorf("declared artifact %q was not produced", pattern)
+		}
+	}
+	return nil
+}
+
+// saveRunArtifacts keeps the full diff and, when it is too long to inline
+// into dependent prompts, the full result of a successful run.
+func (s *Scheduler) saveRunArtifacts(task *Task, diff, result string) error {
+	if diff != "" {
+		if err := s.saveArtifact(task, diffArtifactName, ArtifactDiff, []byte(diff)); err != nil {
+			return err
+		}
+	}
+	if len(result) > maxInlineResult {
+		if err := s.saveArtifact(task, resultArtifactName, ArtifactResult, []byte(result)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) saveArtifact(task *Task, name string, kind ArtifactKind, data []byte) error {
+	a, err := s.board.Store().SaveArtifact(task.ID, name, kind, data)
+	if err != nil {
+		return fmt.Errorf("save artifact %s: %w", name, err)
+	}
+	s.board.Store().AppendTaskLog(task.ID, "artifact", fmt.Sprintf("%s (%s, %d bytes)", a.Name, a.Kind, a.Size), "")
+	return nil
+}
+
+// writeArtifactRefs lists a dependency's artifacts by path so the task can
+// read them with its tools instead of getting their content inlined.
+func writeArtifactRefs(b *strings.Builder, artifacts []*Artifact) {
+	if len(artifacts) == 0 {
+		return
+	}
+	b.WriteString("\n\n产出物（按路径用 read 工具读取）:\n")
+	for _, a := range artifacts {
+		b.WriteString(fmt.Sprintf("- %s（%s，%d 字节）: %s\n", a.Name, a.Kind, a.Size, a.Path))
+	}
+}
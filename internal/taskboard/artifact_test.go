@@ -0,0 +1,131 @@
+package taskboard
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestArtifactStore(t *testing.T) {
+	store, cleanup := tempDB(t)
+	defer cleanup()
+
+	ws := &Workspace{ID: "ws-art", Name: "art"}
+	if err := store.CreateWorkspace(ws); err != nil {
+		t.Fatal(err)
+	}
+	task := &Task{ID: "t-art", WorkspaceID: ws.ID, Title: "report", Status: StatusBacklog}
+	if err := store.CreateTask(task); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.SaveArtifact(task.ID, "docs/report.md", ArtifactFile, []byte("v1")); err != nil {
+		t.Fatal(err)
+	}
+	// A later attempt replaces the artifact of the same name
+	if _, err := store.SaveArtifact(task.ID, "docs/report.md", ArtifactFile, []byte("v2!")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.SaveArtifact(task.ID, "../escape", ArtifactFile, nil); err == nil {
+		t.Error("expected a name outside the artifact dir to be rejected")
+	}
+
+	list, err := store.ListArtifacts(task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Size != 3 || list[0].Kind != ArtifactFile {
+		t.Fatalf("unexpected artifacts %+v", list)
+	}
+	a, data, err := store.ReadArtifact(task.ID, "docs/report.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "v2!" || !strings.HasSuffix(filepath.ToSlash(a.Path), "t-art/docs/report.md") {
+		t.Errorf("unexpected artifact %s: %q", a.Path, data)
+	}
+	if _, _, err := store.ReadArtifact(task.ID, "missing"); err == nil {
+		t.Error("expected reading a missing artifact to fail")
+	}
+
+	if err := store.DeleteTask(task.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(a.Path); !os.IsNotExist(err) {
+		t.Errorf("expected artifact file removed with its task, got %v", err)
+	}
+}
+
+func TestArtifactsPassedToDependents(t *testing.T) {
+	store, cleanup := tempDB(t)
+	t.Cleanup(cleanup)
+	board := NewBoard(store)
+	s := NewScheduler(board, &scriptedSessions{reply: strings.Repeat("long analysis ", 200)})
+	board.SetScheduler(s)
+
+	workDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(workDir, "report.md"), []byte("# findings"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ws := &Workspace{Name: "artifacts", WorkDir: workDir}
+	if err := board.CreateWorkspace(ws); err != nil {
+		t.Fatal(err)
+	}
+	producer := &Task{WorkspaceID: ws.ID, Title: "analyze", Prompt: "analyze", MaxRetries: 1, Artifacts: []string{"*.md"}}
+	if err := board.CreateTask(producer); err != nil {
+		t.Fatal(err)
+	}
+	consumer := &Task{WorkspaceID: ws.ID, Title: "fix", Prompt: "fix the findings", DependsOn: []string{producer.ID}}
+	if err := board.CreateTask(consumer); err != nil {
+		t.Fatal(err)
+	}
+
+	s.executeTask(ws, producer)
+	waitFor(t, "the producer to finish", func() bool {
+		got, _ := board.GetTask(producer.ID)
+		return got != nil && got.Status == StatusDone
+	})
+
+	list, _ := store.ListArtifacts(producer.ID)
+	if len(list) != 2 || list[0].Name != "report.md" || list[1].Name != resultArtifactName {
+		t.Fatalf("expected the declared file and the long result, got %+v", list)
+	}
+
+	got, _ := board.GetTask(consumer.ID)
+	prompt := s.buildTaskPrompt(got)
+	for _, a := range list {
+		if !strings.Contains(prompt, a.Path) {
+			t.Errorf("expected a reference to %s in the dependent prompt:\n%s", a.Path, prompt)
+		}
+	}
+	if strings.Contains(prompt, "# findings") {
+		t.Error("expected the artifact to be passed by reference, not inlined")
+	}
+}
+
+func TestMissingDeclaredArtifactFailsRun(t *testing.T) {
+	store, cleanup := tempDB(t)
+	t.Cleanup(cleanup)
+	board := NewBoard(store)
+	s := NewScheduler(board, &scriptedSessions{reply: "done"})
+	board.SetScheduler(s)
+
+	ws := &Workspace{Name: "missing", WorkDir: t.TempDir()}
+	if err := board.CreateWorkspace(ws); err != nil {
+		t.Fatal(err)
+	}
+	task := &Task{WorkspaceID: ws.ID, Title: "report", Prompt: "write report.md", MaxRetries: 1, Artifacts: []string{"report.md"}}
+	if err := board.CreateTask(task); err != nil {
+		t.Fatal(err)
+	}
+	s.executeTask(ws, task)
+	waitFor(t, "the run to fail", func() bool {
+		got, _ := board.GetTask(task.ID)
+		return got != nil && got.Status == StatusFailed
+	})
+	got, _ := board.GetTask(task.ID)
+	if !strings.Contains(got.Error, `declared artifact "report.md" was not produced`) {
+		t.Errorf("unexpected error %q", got.Error)
+	}
+}
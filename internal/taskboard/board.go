@@ -12,6 +12,7 @@ import (
 type Board struct {
 	store     *TaskStore
 	scheduler *Scheduler
+	worktrees *WorktreeManager
 	eventBus  *eventBus
 
 	mu sync.RWMutex
@@ -31,6 +32,12 @@ func (b *Board) SetScheduler(s *Scheduler) {
 	b.scheduler = s
 }
 
+// SetWorktreeManager enables per-task git worktrees for git-backed workspaces.
+// Without it, tasks run directly in the workspace's WorkDir.
+func (b *Board) SetWorktreeManager(wm *WorktreeManager) {
+	b.worktrees = wm
+}
+
 // Store returns the underlying TaskStore.
 func (b *Board) Store() *TaskStore {
 	return b.store
@@ -48,6 +55,9 @@ func (b *Board) CreateWorkspace(ws *Workspace) error {
 	if ws.MaxConcurrent <= 0 {
 		ws.MaxConcurrent = 3
 	}
+	if ws.MergePolicy == "" {
+		ws.MergePolicy = MergeManual
+	}
 	now := time.Now()
 	ws.CreatedAt = now
 	ws.UpdatedAt = now
@@ -76,6 +86,9 @@ func (b *Board) PauseWorkspace(id string) error {
 	if err != nil {
 		return err
 	}
+	if ws.Status == WorkspaceTemplate {
+		return fmt.Errorf("workspace %s is a template, it runs through its schedules", id)
+	}
 	ws.Status = WorkspacePaused
 	if err := b.store.UpdateWorkspace(ws); err != nil {
 		return err
@@ -94,6 +107,9 @@ func (b *Board) ResumeWorkspace(id string) error {
 	if err != nil {
 		return err
 	}
+	if ws.Status == WorkspaceTemplate {
+		return fmt.Errorf("workspace %s is a template, it runs through its schedules", id)
+	}
 	ws.Status = WorkspaceActive
 	if err := b.store.UpdateWorkspace(ws); err != nil {
 		return err
@@ -136,6 +152,13 @@ func (b *Board) CreateTask(t *Task) error {
 	if t.DependsOn == nil {
 		t.DependsOn = []string{}
 	}
+	if err := b.checkDependencies(t); err != nil {
+		return err
+	}
+	if reason := b.dependencyBlock(t); reason != "" {
+		t.Status = StatusBlocked
+		t.BlockedReason = reason
+	}
 	t.CreatedAt = time.Now()
 	if err := b.store.CreateTask(t); err != nil {
 		return err
@@ -158,12 +181,35 @@ func (b *Board) GetTask(id string) (*Task, error) {
 	return b.store.GetTask(id)
 }
 
+// UpdateTask saves user edits to a task, rejecting dependency changes that
+// would introduce a cycle.
 func (b *Board) UpdateTask(t *Task) error {
-	return b.store.UpdateTask(t)
+	if err := b.checkDependencies(t); err != nil {
+		return err
+	}
+	if err := b.store.UpdateTask(t); err != nil {
+		return err
+	}
+	if b.refreshBlocked(t) {
+		b.refreshDependents(t)
+	}
+	return nil
 }
 
 func (b *Board) DeleteTask(id string) error {
-	return b.store.DeleteTask(id)
+	dependents, err := b.store.GetDependents(id)
+	if err != nil {
+		return err
+	}
+	if err := b.store.DeleteTask(id); err != nil {
+		return err
+	}
+	for _, dep := range dependents {
+		if b.refreshBlocked(dep) {
+			b.refreshDependents(dep)
+		}
+	}
+	return nil
 }
 
 func (b *Board) ListTasks(workspaceID, statusFilter string) ([]*Task, error) {
@@ -180,6 +226,7 @@ func (b *Board) StartTask(id string) (*Task, error) {
 		return nil, fmt.Errorf("task %s is in %s state, cannot start", id, t.Status)
 	}
 	t.Status = StatusReady
+	t.NextAttemptAt = time.Time{}
 	if err := b.store.UpdateTask(t); err != nil {
 		return nil, err
 	}
@@ -189,19 +236,35 @@ func (b *Board) StartTask(id string) (*Task, error) {
 	return t, nil
 }
 
-// CancelTask cancels a task.
+// CancelTask cancels a task. A running task's session is stopped: its LLM
+// stream and tool processes are aborted and the run's outcome is discarded.
 func (b *Board) CancelTask(id string) (*Task, error) {
-	t, err := b.store.GetTask(id)
-	if err != nil {
-		return nil, err
-	}
-	if t.Status.IsTerminal() {
-		return nil, fmt.Errorf("task %s is already in terminal state %s", id, t.Status)
-	}
-	t.Status = StatusCancelled
-	t.CompletedAt = time.Now()
-	if err := b.store.UpdateTask(t); err != nil {
-		return nil, err
+	var t *Task
+	for {
+		var err error
+		t, err = b.store.GetTask(id)
+		if err != nil {
+			return nil, err
+		}
+		if t.Status.IsTerminal() {
+			return nil, fmt.Errorf("task %s is already in terminal state %s", id, t.Status)
+		}
+		if t.Status == StatusRunning && b.scheduler != nil {
+			// A run that already released its handle can't be stopped; the
+			// conditional writes below and in executeTask decide which outcome stands
+			b.scheduler.stopTask(t.ID)
+		}
+		from := t.Status
+		t.Status = StatusCancelled
+		t.CompletedAt = time.Now()
+		ok, err := b.store.UpdateTaskFrom(t, from)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			break
+		}
+		// The task moved on meanwhile (e.g. its run finished); look again
 	}
 	b.broadcast(BoardEvent{
 		Type:        EventTaskCancelled,
@@ -210,6 +273,7 @@ func (b *Board) CancelTask(id string) (*Task, error) {
 		Detail:      t.Title,
 		Timestamp:   t.CompletedAt,
 	})
+	b.refreshDependents(t)
 	return t, nil
 }
 
@@ -224,18 +288,143 @@ func (b *Board) RetryTask(id string) (*Task, error) {
 	}
 	t.Status = StatusReady
 	t.Error = ""
+	t.ErrorClass = ""
 	t.AssignedSession = ""
 	t.StartedAt = time.Time{}
 	t.CompletedAt = time.Time{}
+	// A manual retry runs at once
+	t.NextAttemptAt = time.Time{}
+	t.TransientRetries = 0
 	if err := b.store.UpdateTask(t); err != nil {
 		return nil, err
 	}
+	// Tasks blocked on this failure can wait for it again
+	b.refreshDependents(t)
 	if b.scheduler != nil {
 		b.scheduler.Trigger()
 	}
 	return t, nil
 }
 
+// MergeTask fast-forwards the workspace repository to a done task's branch.
+func (b *Board) MergeTask(id string) (*Task, error) {
+	t, err := b.store.GetTask(id)
+	if err != nil {
+		return nil, err
+	}
+	if t.Status != StatusDone {
+		return nil, fmt.Errorf("task %s is in %s state, can only merge done tasks", id, t.Status)
+	}
+	if t.Branch == "" || t.MergeStatus == MergeNone {
+		return nil, fmt.Errorf("task %s has no branch to merge", id)
+	}
+	if t.MergeStatus == MergeMerged {
+		return nil, fmt.Errorf("task %s is already merged", id)
+	}
+	ws, err := b.store.GetWorkspace(t.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.mergeTask(ws, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// mergeTask merges the task branch and records the outcome on the task.
+func (b *Board) mergeTask(ws *Workspace, t *Task) error {
+	if b.worktrees == nil {
+		return fmt.Errorf("worktrees not enabled")
+	}
+	mergeErr := b.worktrees.Merge(ws.WorkDir, t.Branch)
+	if mergeErr != nil {
+		t.MergeStatus = MergeFailed
+		b.store.AppendTaskLog(t.ID, "error", fmt.Sprintf("merge %s: %v", t.Branch, mergeErr), "")
+	} else {
+		t.MergeStatus = MergeMerged
+		b.store.AppendTaskLog(t.ID, "merge", fmt.Sprintf("merged %s", t.Branch), "")
+	}
+	if err := b.store.UpdateTask(t); err != nil {
+		return err
+	}
+	if mergeErr != nil {
+		return mergeErr
+	}
+	b.broadcast(BoardEvent{
+		Type:        EventTaskMerged,
+		WorkspaceID: ws.ID,
+		TaskID:      t.ID,
+		Detail:      t.Branch,
+		Timestamp:   time.Now(),
+	})
+	return nil
+}
+
+// deleteTaskBranch drops a task branch that produced no changes.
+func (b *Board) deleteTaskBranch(ws *Workspace, t *Task) {
+	if b.worktrees != nil {
+		b.worktrees.DeleteBranch(ws.WorkDir, t.Branch)
+	}
+	t.Branch = ""
+}
+
+// --- Plan drafts ---
+
+// CreatePlanDraft stores a validated plan for editing before approval.
+func (b *Board) CreatePlanDraft(goal, workDir string, plan *PlanResult) (*PlanDraft, error) {
+	if err := plan.Validate(); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	d := &PlanDraft{
+		ID:        fmt.Sprintf("draft-%d", now.UnixNano()),
+		Goal:      goal,
+		WorkDir:   workDir,
+		Plan:      plan,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := b.store.CreatePlanDraft(d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (b *Board) GetPlanDraft(id string) (*PlanDraft, error) {
+	return b.store.GetPlanDraft(id)
+}
+
+func (b *Board) ListPlanDrafts() ([]*PlanDraft, error) {
+	return b.store.ListPlanDrafts()
+}
+
+func (b *Board) DeletePlanDraft(id string) error {
+	return b.store.DeletePlanDraft(id)
+}
+
+// EditPlanDraft applies edit to a draft's plan and saves it. The edit is
+// rejected, leaving the draft unchanged, if the resulting plan fails
+// validation (e.g. it introduces a dependency cycle).
+func (b *Board) EditPlanDraft(id string, edit func(*PlanResult) error) (*PlanDraft, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	d, err := b.store.GetPlanDraft(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := edit(d.Plan); err != nil {
+		return nil, err
+	}
+	if err := d.Plan.Validate(); err != nil {
+		return nil, err
+	}
+	if err := b.store.UpdatePlanDraft(d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
 // --- Board overview ---
 
 // GetOverview returns an aggregated view of all workspaces.
@@ -259,7 +448,11 @@ func (b *Board) GetOverview() (*BoardOverview, error) {
 			Running:       counts.Running,
 			Done:          counts.Done,
 			Failed:        counts.Failed,
+			Blocked:       counts.Blocked,
+			Review:        counts.Review,
 			MaxConcurrent: ws.MaxConcurrent,
+			Budget:        ws.Budget,
+			Usage:         ws.Usage,
 		}
 		overview.Workspaces = append(overview.Workspaces, wo)
 		overview.TotalTasks += counts.Total()
@@ -289,16 +482,23 @@ type WorkspaceOverview struct {
 	Running       int
 	Done          int
 	Failed        int
+	Blocked       int
+	Review        int
 	MaxConcurrent int
+	Budget        Budget
+	Usage         BudgetUsage
 }
 
 // --- Dependency resolution + completion detection ---
 
 // OnTaskFinished is called when a task reaches a terminal state.
-// It promotes dependent tasks and checks if the workspace is complete.
+// It promotes (or blocks) dependent tasks and checks if the workspace is complete.
 func (b *Board) OnTaskFinished(ws *Workspace, task *Task) {
-	if task.Status == StatusDone {
+	switch task.Status {
+	case StatusDone:
 		b.resolveDependencies(task)
+	case StatusFailed:
+		b.refreshDependents(task)
 	}
 	// Check if workspace is fully complete
 	counts, err := b.store.CountByStatus(ws.ID)
@@ -329,20 +529,7 @@ func (b *Board) resolveDependencies(completedTask *Task) {
 			continue
 		}
 		// Check if ALL dependencies of this task are now done
-		allDone := true
-		if len(dep.DependsOn) > 0 {
-			depTasks, err := b.store.GetTasksByIDs(dep.DependsOn)
-			if err != nil {
-				continue
-			}
-			for _, dt := range depTasks {
-				if dt.Status != StatusDone {
-					allDone = false
-					break
-				}
-			}
-		}
-		if allDone {
+		if b.dependenciesDone(dep) {
 			dep.Status = StatusReady
 			if err := b.store.UpdateTask(dep); err != nil {
 				log.Printf("board: promote task %s to ready error: %v", dep.ID, err)
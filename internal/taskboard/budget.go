@@ -0,0 +1,83 @@
+package taskboard
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Budget caps what a workspace may spend. Zero fields are unlimited.
+type Budget struct {
+	MaxTokens    int64         // LLM tokens across all task runs
+	MaxCost      float64       // estimated cost in USD, priced with TokenPrice
+	TokenPrice   float64       // USD per million tokens, used to estimate cost
+	MaxDuration  time.Duration // wall-clock time since the first task started
+	MaxToolCalls int           // tool calls per task run
+}
+
+// IsZero reports whether no limit is set.
+func (b Budget) IsZero() bool {
+	return b.MaxTokens == 0 && b.MaxCost == 0 && b.MaxDuration == 0 && b.MaxToolCalls == 0
+}
+
+// BudgetUsage is what a workspace has spent so far.
+type BudgetUsage struct {
+	Tokens    int64     // estimated LLM tokens
+	ToolCalls int64     // tool calls across all task runs
+	StartedAt time.Time // when the first task started; zero until then
+}
+
+// Cost estimates the spend of the used tokens at the budget's token price.
+func (u BudgetUsage) Cost(b Budget) float64 {
+	return float64(u.Tokens) / 1e6 * b.TokenPrice
+}
+
+// Elapsed returns the wall-clock time since the first task started.
+func (u BudgetUsage) Elapsed(now time.Time) time.Duration {
+	if u.StartedAt.IsZero() {
+		return 0
+	}
+	return now.Sub(u.StartedAt)
+}
+
+// Exceeded describes the first workspace-wide limit that usage has reached,
+// or returns "" while within budget. MaxToolCalls is enforced per task run.
+func (b Budget) Exceeded(u BudgetUsage, now time.Time) string {
+	switch {
+	case b.MaxTokens > 0 && u.Tokens >= b.MaxTokens:
+		return fmt.Sprintf("token budget exhausted (%d/%d)", u.Tokens, b.MaxTokens)
+	case b.MaxCost > 0 && u.Cost(b) >= b.MaxCost:
+		return fmt.Sprintf("cost budget exhausted ($%.2f/$%.2f)", u.Cost(b), b.MaxCost)
+	case b.MaxDuration > 0 && u.Elapsed(now) >= b.MaxDuration:
+		return fmt.Sprintf("time budget exhausted (%s/%s)", u.Elapsed(now).Truncate(time.Second), b.MaxDuration)
+	}
+	return ""
+}
+
+// errBudgetExceeded aborts a task run when its workspace runs out of budget.
+var errBudgetExceeded = errors.New("workspace budget exceeded")
+
+// enforceBudget pauses the workspace when one of its budgets is exhausted and
+// returns the exhausted limit, or "" while the workspace is within budget.
+func (b *Board) enforceBudget(wsID string) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ws, err := b.store.GetWorkspace(wsID)
+	if err != nil {
+		return ""
+	}
+	reason := ws.Budget.Exceeded(ws.Usage, time.Now())
+	if reason == "" || ws.Status != WorkspaceActive {
+		return reason
+	}
+	if err := b.PauseWorkspace(ws.ID); err != nil {
+		return reason
+	}
+	b.broadcast(BoardEvent{
+		Type:        EventWorkspaceBudgetExceeded,
+		WorkspaceID: ws.ID,
+		Detail:      reason,
+		Timestamp:   time.Now(),
+	})
+	return reason
+}
@@ -0,0 +1,133 @@
+package taskboard
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// usageSession replays a fixed event sequence, like an agent loop that
+// reports token usage and calls tools.
+type usageSession struct {
+	id     string
+	events []SessionEvent
+}
+
+func (s *usageSession) GetID() string        { return s.id }
+func (s *usageSession) InjectContext(string) {}
+func (s *usageSession) ChatStream(ctx context.Context, _ string) (<-chan SessionEvent, error) {
+	ch := make(chan SessionEvent)
+	go func() {
+		defer close(ch)
+		for _, ev := range s.events {
+			select {
+			case ch <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+type usageSessions struct{ events []SessionEvent }
+
+func (m *usageSessions) CreateTaskSession(name string, _ TaskSessionOptions) TaskSession {
+	return &usageSession{id: name, events: m.events}
+}
+
+func (m *usageSessions) DeleteTaskSession(string) {}
+
+func runWithBudget(t *testing.T, budget Budget, events []SessionEvent) (*Scheduler, *Workspace, *Task) {
+	t.Helper()
+	store, cleanup := tempDB(t)
+	t.Cleanup(cleanup)
+	board := NewBoard(store)
+	s := NewScheduler(board, &usageSessions{events: events})
+	board.SetScheduler(s)
+
+	ws := &Workspace{Name: "budget", Budget: budget}
+	if err := board.CreateWorkspace(ws); err != nil {
+		t.Fatal(err)
+	}
+	task := &Task{WorkspaceID: ws.ID, Title: "loop", Prompt: "loop", MaxRetries: 3}
+	if err := board.CreateTask(task); err != nil {
+		t.Fatal(err)
+	}
+	s.executeTask(ws, task)
+	waitFor(t, "the run to finish", func() bool {
+		got, _ := board.GetTask(task.ID)
+		return got != nil && got.Status != StatusRunning
+	})
+	return s, ws, task
+}
+
+func TestBudgetExceeded(t *testing.T) {
+	now := time.Now()
+	b := Budget{MaxTokens: 1000, MaxCost: 1, TokenPrice: 2, MaxDuration: time.Hour}
+	cases := []struct {
+		usage BudgetUsage
+		want  string
+	}{
+		{BudgetUsage{Tokens: 100, StartedAt: now.Add(-time.Minute)}, ""},
+		{BudgetUsage{Tokens: 1000}, "token budget"},
+		{BudgetUsage{StartedAt: now.Add(-2 * time.Hour)}, "time budget"},
+		{BudgetUsage{}, ""},
+	}
+	for i, c := range cases {
+		got := b.Exceeded(c.usage, now)
+		if (c.want == "") != (got == "") || !strings.Contains(got, c.want) {
+			t.Errorf("case %d: expected %q, got %q", i, c.want, got)
+		}
+	}
+
+	// $2 per million tokens: 600k tokens cost $1.20
+	cost := Budget{MaxCost: 1, TokenPrice: 2}
+	if got := cost.Exceeded(BudgetUsage{Tokens: 600000}, now); !strings.Contains(got, "cost budget") {
+		t.Errorf("expected cost budget to be exhausted, got %q", got)
+	}
+}
+
+func TestTokenBudgetPausesWorkspace(t *testing.T) {
+	events := []SessionEvent{
+		{Type: "usage", Tokens: 400},
+		{Type: "content", Content: "working"},
+		{Type: "usage", Tokens: 700},
+		{Type: "content", Content: "never reached"},
+	}
+	s, ws, task := runWithBudget(t, Budget{MaxTokens: 1000}, events)
+
+	got, err := s.board.GetWorkspace(ws.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != WorkspacePaused {
+		t.Errorf("expected workspace to be paused, got %s", got.Status)
+	}
+	if got.Usage.Tokens != 1100 || got.Usage.StartedAt.IsZero() {
+		t.Errorf("unexpected usage %+v", got.Usage)
+	}
+
+	gotTask, _ := s.board.GetTask(task.ID)
+	if gotTask.Status != StatusReady || gotTask.RetryCount != 0 {
+		t.Errorf("expected task requeued without using a retry, got %s (retries %d)", gotTask.Status, gotTask.RetryCount)
+	}
+	if !strings.Contains(gotTask.Error, "token budget exhausted") {
+		t.Errorf("unexpected error %q", gotTask.Error)
+	}
+}
+
+func TestToolCallLimitFailsTask(t *testing.T) {
+	call := SessionEvent{Type: "tool_call", ToolName: "bash"}
+	s, ws, task := runWithBudget(t, Budget{MaxToolCalls: 2}, []SessionEvent{call, call, call, call})
+
+	got, _ := s.board.GetTask(task.ID)
+	if got.RetryCount != 1 || !strings.Contains(got.Error, "tool call limit reached") {
+		t.Errorf("expected tool call limit failure, got %s (%s)", got.Status, got.Error)
+	}
+	gotWs, _ := s.board.GetWorkspace(ws.ID)
+	if gotWs.Status != WorkspaceActive || gotWs.Usage.ToolCalls != 3 {
+		t.Errorf("expected active workspace with 3 tool calls, got %s %d", gotWs.Status, gotWs.Usage.ToolCalls)
+	}
+}
@@ -0,0 +1,279 @@
+package taskboard
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// dagLevels layers the nodes 0..n-1 of a dependency graph: level 0 has no
+// dependencies, level k depends only on earlier levels. Nodes within a level
+// are sorted by priority, then index. Out-of-range dependencies are ignored.
+// A cycle is reported with the node names along it.
+func dagLevels(n int, deps func(int) []int, name func(int) string, priority func(int) int) ([][]int, error) {
+	level := make([]int, n)
+	state := make([]int, n) // 0=unvisited, 1=visiting, 2=done
+	var stack []int
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case 1:
+			// Report the cycle from the first occurrence of i on the stack
+			var cycle []string
+			for k := len(stack) - 1; k >= 0; k-- {
+				cycle = append([]string{name(stack[k])}, cycle...)
+				if stack[k] == i {
+					break
+				}
+			}
+			return fmt.Errorf("dependency cycle: %s -> %s", strings.Join(cycle, " -> "), name(i))
+		case 2:
+			return nil
+		}
+		state[i] = 1
+		stack = append(stack, i)
+		for _, d := range deps(i) {
+			if d < 0 || d >= n {
+				continue
+			}
+			if err := visit(d); err != nil {
+				return err
+			}
+			if level[d]+1 > level[i] {
+				level[i] = level[d] + 1
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[i] = 2
+		return nil
+	}
+
+	maxLevel := -1
+	for i := 0; i < n; i++ {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+		if level[i] > maxLevel {
+			maxLevel = level[i]
+		}
+	}
+
+	levels := make([][]int, maxLevel+1)
+	for i, l := range level {
+		levels[l] = append(levels[l], i)
+	}
+	for _, l := range levels {
+		sort.SliceStable(l, func(a, b int) bool {
+			return priority(l[a]) < priority(l[b])
+		})
+	}
+	return levels, nil
+}
+
+// TaskLevels groups a workspace's tasks into dependency layers (see
+// PlanResult.Levels). Dependencies on tasks outside the slice are ignored.
+func TaskLevels(tasks []*Task) ([][]*Task, error) {
+	index := make(map[string]int, len(tasks))
+	for i, t := range tasks {
+		index[t.ID] = i
+	}
+	levels, err := dagLevels(len(tasks),
+		func(i int) []int {
+			var deps []int
+			for _, id := range tasks[i].DependsOn {
+				if d, ok := index[id]; ok {
+					deps = append(deps, d)
+				}
+			}
+			return deps
+		},
+		func(i int) string { return tasks[i].ID },
+		func(i int) int { return tasks[i].Priority })
+	if err != nil {
+		return nil, err
+	}
+	result := make([][]*Task, len(levels))
+	for l, level := range levels {
+		for _, i := range level {
+			result[l] = append(result[l], tasks[i])
+		}
+	}
+	return result, nil
+}
+
+// checkDependencies verifies that t's dependencies exist in the same
+// workspace and that adding t (or its new dependency list) keeps the
+// workspace graph acyclic.
+func (b *Board) checkDependencies(t *Task) error {
+	if len(t.DependsOn) == 0 {
+		return nil
+	}
+	tasks, err := b.store.ListTasks(t.WorkspaceID, "")
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]bool, len(tasks))
+	for _, other := range tasks {
+		byID[other.ID] = true
+	}
+	for _, dep := range t.DependsOn {
+		if dep == t.ID {
+			return fmt.Errorf("task %s cannot depend on itself", t.ID)
+		}
+		if !byID[dep] {
+			return fmt.Errorf("dependency %s not found in workspace %s", dep, t.WorkspaceID)
+		}
+	}
+
+	// Substitute the candidate for its stored version (if any) and check for cycles
+	graph := make([]*Task, 0, len(tasks)+1)
+	for _, other := range tasks {
+		if other.ID != t.ID {
+			graph = append(graph, other)
+		}
+	}
+	graph = append(graph, t)
+	_, err = TaskLevels(graph)
+	return err
+}
+
+// dependencyBlock returns why t cannot run because of its dependencies, or "".
+func (b *Board) dependencyBlock(t *Task) string {
+	if len(t.DependsOn) == 0 {
+		return ""
+	}
+	deps, err := b.store.GetTasksByIDs(t.DependsOn)
+	if err != nil {
+		return ""
+	}
+	found := make(map[string]bool, len(deps))
+	for _, d := range deps {
+		found[d.ID] = true
+		switch d.Status {
+		case StatusFailed:
+			return fmt.Sprintf("dependency %s (%s) failed", d.ID, d.Title)
+		case StatusCancelled:
+			return fmt.Sprintf("dependency %s (%s) was cancelled", d.ID, d.Title)
+		case StatusBlocked:
+			return fmt.Sprintf("dependency %s (%s) is blocked", d.ID, d.Title)
+		}
+	}
+	for _, id := range t.DependsOn {
+		if !found[id] {
+			return fmt.Sprintf("dependency %s no longer exists", id)
+		}
+	}
+	return ""
+}
+
+// refreshDependents re-evaluates the tasks waiting on task: they become
+// blocked if one of their dependencies failed, was cancelled or is itself
+// blocked, and return to backlog (or ready) once that is no longer true.
+// Changes propagate transitively down the graph.
+func (b *Board) refreshDependents(task *Task) {
+	dependents, err := b.store.GetDependents(task.ID)
+	if err != nil {
+		log.Printf("board: get dependents error: %v", err)
+		return
+	}
+	for _, dep := range dependents {
+		if b.refreshBlocked(dep) {
+			b.refreshDependents(dep)
+		}
+	}
+}
+
+// refreshBlocked updates the blocked state of a waiting task and reports
+// whether it changed.
+func (b *Board) refreshBlocked(t *Task) bool {
+	if t.Status != StatusBacklog && t.Status != StatusBlocked {
+		return false
+	}
+	reason := b.dependencyBlock(t)
+	evType := ""
+	switch {
+	case reason != "" && (t.Status != StatusBlocked || t.BlockedReason != reason):
+		t.Status = StatusBlocked
+		t.BlockedReason = reason
+		evType = EventTaskBlocked
+	case reason == "" && t.Status == StatusBlocked:
+		t.Status = StatusBacklog
+		t.BlockedReason = ""
+		if b.dependenciesDone(t) {
+			t.Status = StatusReady
+		}
+		evType = EventTaskUnblocked
+	default:
+		return false
+	}
+	if err := b.store.UpdateTask(t); err != nil {
+		log.Printf("board: update blocked state of task %s error: %v", t.ID, err)
+		return false
+	}
+	detail := t.Title
+	if reason != "" {
+		detail = reason
+	}
+	b.broadcast(BoardEvent{
+		Type:        evType,
+		WorkspaceID: t.WorkspaceID,
+		TaskID:      t.ID,
+		Detail:      detail,
+		Timestamp:   time.Now(),
+	})
+	if t.Status == StatusReady && b.scheduler != nil {
+		b.scheduler.Trigger()
+	}
+	return true
+}
+
+// dependenciesDone reports whether every dependency of t exists and is done.
+func (b *Board) dependenciesDone(t *Task) bool {
+	if len(t.DependsOn) == 0 {
+		return true
+	}
+	deps, err := b.store.GetTasksByIDs(t.DependsOn)
+	if err != nil || len(deps) < len(t.DependsOn) {
+		return false
+	}
+	for _, d := range deps {
+		if d.Status != StatusDone {
+			return false
+		}
+	}
+	return true
+}
+
+// ReconcileBlocked scans every waiting task and fixes its blocked state,
+// e.g. for databases written before blocked tasks were tracked.
+// It returns the number of tasks whose state changed.
+func (b *Board) ReconcileBlocked() (int, error) {
+	workspaces, err := b.store.ListWorkspaces()
+	if err != nil {
+		return 0, err
+	}
+	changed := 0
+	for _, ws := range workspaces {
+		tasks, err := b.store.ListTasks(ws.ID, string(StatusBacklog)+","+string(StatusBlocked))
+		if err != nil {
+			return changed, err
+		}
+		levels, err := TaskLevels(tasks)
+		if err != nil {
+			log.Printf("board: workspace %s: %v", ws.ID, err)
+			continue
+		}
+		// Upstream first so blocks propagate in a single pass
+		for _, level := range levels {
+			for _, t := range level {
+				if b.refreshBlocked(t) {
+					changed++
+				}
+			}
+		}
+	}
+	return changed, nil
+}
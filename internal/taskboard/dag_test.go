@@ -0,0 +1,144 @@
+package taskboard
+
+import (
+	"strings"
+	"testing"
+)
+
+// chainBoard creates a workspace with t1 ← t2 ← t3 (t3 depends on t2, t2 on t1).
+func chainBoard(t *testing.T) (*Board, *Workspace) {
+	t.Helper()
+	store, cleanup := tempDB(t)
+	t.Cleanup(cleanup)
+	board := NewBoard(store)
+	ws := &Workspace{ID: "ws-dag", Name: "dag"}
+	if err := board.CreateWorkspace(ws); err != nil {
+		t.Fatal(err)
+	}
+	for i, deps := range [][]string{nil, {"t1"}, {"t2"}} {
+		task := &Task{ID: []string{"t1", "t2", "t3"}[i], WorkspaceID: ws.ID, Title: "task", Prompt: "p", DependsOn: deps}
+		if err := board.CreateTask(task); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return board, ws
+}
+
+func taskStatus(t *testing.T, b *Board, id string) TaskStatus {
+	t.Helper()
+	task, err := b.GetTask(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return task.Status
+}
+
+func TestCreateTaskRejectsBadDependencies(t *testing.T) {
+	board, ws := chainBoard(t)
+
+	err := board.CreateTask(&Task{WorkspaceID: ws.ID, Title: "x", Prompt: "p", DependsOn: []string{"missing"}})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected unknown dependency error, got %v", err)
+	}
+
+	t1, _ := board.GetTask("t1")
+	t1.DependsOn = []string{"t3"}
+	err = board.UpdateTask(t1)
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+	if stored, _ := board.GetTask("t1"); len(stored.DependsOn) != 0 {
+		t.Errorf("rejected update was saved: %v", stored.DependsOn)
+	}
+}
+
+func TestCancelBlocksDependentsTransitively(t *testing.T) {
+	board, _ := chainBoard(t)
+
+	if _, err := board.CancelTask("t1"); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"t2", "t3"} {
+		if s := taskStatus(t, board, id); s != StatusBlocked {
+			t.Errorf("%s: expected blocked, got %s", id, s)
+		}
+	}
+	t2, _ := board.GetTask("t2")
+	if !strings.Contains(t2.BlockedReason, "cancelled") {
+		t.Errorf("unexpected reason %q", t2.BlockedReason)
+	}
+
+	// New tasks depending on a blocked task start out blocked
+	t4 := &Task{WorkspaceID: "ws-dag", Title: "t4", Prompt: "p", DependsOn: []string{"t3"}}
+	if err := board.CreateTask(t4); err != nil {
+		t.Fatal(err)
+	}
+	if t4.Status != StatusBlocked {
+		t.Errorf("expected new dependent to be blocked, got %s", t4.Status)
+	}
+}
+
+func TestRetryUnblocksDependents(t *testing.T) {
+	board, ws := chainBoard(t)
+
+	t1, _ := board.GetTask("t1")
+	t1.Status = StatusFailed
+	board.Store().UpdateTask(t1)
+	board.OnTaskFinished(ws, t1)
+	if s := taskStatus(t, board, "t3"); s != StatusBlocked {
+		t.Fatalf("expected t3 blocked after t1 failed, got %s", s)
+	}
+
+	if _, err := board.RetryTask("t1"); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"t2", "t3"} {
+		if s := taskStatus(t, board, id); s != StatusBacklog {
+			t.Errorf("%s: expected backlog after retry, got %s", id, s)
+		}
+	}
+}
+
+func TestReconcileBlocked(t *testing.T) {
+	board, _ := chainBoard(t)
+
+	// Simulate a database from before blocked tracking: t1 cancelled, dependents left in backlog
+	t1, _ := board.GetTask("t1")
+	t1.Status = StatusCancelled
+	board.Store().UpdateTask(t1)
+
+	n, err := board.ReconcileBlocked()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("expected 2 tasks changed, got %d", n)
+	}
+	if s := taskStatus(t, board, "t3"); s != StatusBlocked {
+		t.Errorf("expected t3 blocked, got %s", s)
+	}
+}
+
+func TestTaskLevels(t *testing.T) {
+	tasks := []*Task{
+		{ID: "c", DependsOn: []string{"b"}},
+		{ID: "a"},
+		{ID: "b", DependsOn: []string{"a", "outside"}},
+	}
+	levels, err := TaskLevels(tasks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, l := range levels {
+		got = append(got, l[0].ID)
+	}
+	if strings.Join(got, ",") != "a,b,c" {
+		t.Errorf("unexpected levels %v", got)
+	}
+
+	tasks[1].DependsOn = []string{"c"}
+	if _, err := TaskLevels(tasks); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("expected cycle error, got %v", err)
+	}
+}
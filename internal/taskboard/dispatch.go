@@ -0,0 +1,163 @@
+package taskboard
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"time"
+)
+
+// ThrottleFunc reports until when the provider serving model is rate
+// limited. The zero time means the provider is available.
+type ThrottleFunc func(model string) time.Time
+
+// SchedulerOptions controls how the scheduler shares capacity between
+// workspaces.
+type SchedulerOptions struct {
+	MaxConcurrent int           // task runs across all workspaces; 0 = only per-workspace limits
+	AgingInterval time.Duration // a ready task gains one priority level per interval waited; 0 disables aging
+	Throttle      ThrottleFunc  // nil disables provider throttling
+
+	RetryBackoff        time.Duration // delay before the first retry, doubled for each further one; 0 retries at once
+	MaxRetryBackoff     time.Duration // cap on the retry delay; 0 = no cap
+	MaxTransientRetries int           // retries after transient errors that do not use up MaxRetries
+}
+
+// DefaultAgingInterval lets a low priority (3) task catch up with critical
+// tasks after half an hour in the ready queue.
+const DefaultAgingInterval = 10 * time.Minute
+
+// errLLMFailed marks a run aborted by an error from the LLM provider.
+var errLLMFailed = errors.New("llm error")
+
+// SetOptions replaces the scheduler's options.
+func (s *Scheduler) SetOptions(opts SchedulerOptions) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.opts = opts
+}
+
+// Options returns the scheduler's current options.
+func (s *Scheduler) Options() SchedulerOptions {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.opts
+}
+
+// workspaceQueue is a workspace's dispatchable tasks in one scheduling cycle.
+type workspaceQueue struct {
+	ws      *Workspace
+	running int // tasks running now, plus those picked this cycle
+	slots   int // room left under the workspace's MaxConcurrent
+	tasks   []*Task
+}
+
+type dispatch struct {
+	ws   *Workspace
+	task *Task
+}
+
+// pickFair hands out free slots one at a time, each to the workspace with
+// the fewest running tasks, so a busy workspace cannot starve the others.
+// Ties go to the workspace whose next task ranks highest. free < 0 means
+// there is no global limit.
+func pickFair(queues []*workspaceQueue, free int, aging time.Duration, now time.Time) []dispatch {
+	var picks []dispatch
+	for free != 0 {
+		var best *workspaceQueue
+		for _, q := range queues {
+			if q.slots <= 0 || len(q.tasks) == 0 {
+				continue
+			}
+			if best == nil || q.running < best.running ||
+				q.running == best.running && runsBefore(q.tasks[0], best.tasks[0], aging, now) {
+				best = q
+			}
+		}
+		if best == nil {
+			break
+		}
+		picks = append(picks, dispatch{ws: best.ws, task: best.tasks[0]})
+		best.tasks = best.tasks[1:]
+		best.running++
+		best.slots--
+		if free > 0 {
+			free--
+		}
+	}
+	return picks
+}
+
+// dispatchable drops the tasks that are backing off after a failure or whose
+// provider is rate limited, and orders the rest by aged priority.
+func (s *Scheduler) dispatchable(tasks []*Task, opts SchedulerOptions, now time.Time) []*Task {
+	out := tasks[:0]
+	for _, t := range tasks {
+		if t.NextAttemptAt.After(now) {
+			continue
+		}
+		if !s.throttled(t, opts, now) {
+			out = append(out, t)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return runsBefore(out[i], out[j], opts.AgingInterval, now)
+	})
+	return out
+}
+
+// throttled reports whether the provider of the task's model is cooling
+// down from a rate limit. Each cooldown is logged once.
+func (s *Scheduler) throttled(t *Task, opts SchedulerOptions, now time.Time) bool {
+	if opts.Throttle == nil {
+		return false
+	}
+	until := opts.Throttle(t.Model)
+	if !until.After(now) {
+		return false
+	}
+	s.mu.Lock()
+	if !s.throttleLog[t.Model].Equal(until) {
+		s.throttleLog[t.Model] = until
+		log.Printf("scheduler: provider for model %q rate limited until %s, holding tasks", t.Model, until.Format(time.TimeOnly))
+	}
+	s.mu.Unlock()
+	return true
+}
+
+// providerThrottled reports whether the provider of the task's model is
+// rate limited right now.
+func (s *Scheduler) providerThrottled(t *Task) bool {
+	throttle := s.Options().Throttle
+	return throttle != nil && throttle(t.Model).After(time.Now())
+}
+
+// runsBefore orders ready tasks by aged priority, then by how long they
+// have been waiting.
+func runsBefore(a, b *Task, aging time.Duration, now time.Time) bool {
+	pa, pb := agedPriority(a, aging, now), agedPriority(b, aging, now)
+	if pa != pb {
+		return pa < pb
+	}
+	return readySince(a).Before(readySince(b))
+}
+
+// agedPriority is the task's priority raised by one level per aging
+// interval spent in the ready queue, up to critical (0).
+func agedPriority(t *Task, aging time.Duration, now time.Time) int {
+	p := t.Priority
+	if aging > 0 {
+		p -= int(now.Sub(readySince(t)) / aging)
+	}
+	if p < 0 {
+		p = 0
+	}
+	return p
+}
+
+func readySince(t *Task) time.Time {
+	if !t.ReadyAt.IsZero() {
+		return t.ReadyAt
+	}
+	return t.CreatedAt
+}
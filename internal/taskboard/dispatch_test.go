@@ -0,0 +1,182 @@
+package taskboard
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// parkedSessions hand out sessions that run until their context is cancelled.
+type parkedSessions struct{}
+
+type parkedSession struct{ id string }
+
+func (s *parkedSession) GetID() string        { return s.id }
+func (s *parkedSession) InjectContext(string) {}
+func (s *parkedSession) ChatStream(ctx context.Context, _ string) (<-chan SessionEvent, error) {
+	ch := make(chan SessionEvent)
+	go func() {
+		defer close(ch)
+		<-ctx.Done()
+	}()
+	return ch, nil
+}
+
+func (parkedSessions) CreateTaskSession(name string, _ TaskSessionOptions) TaskSession {
+	return &parkedSession{id: name}
+}
+
+func (parkedSessions) DeleteTaskSession(string) {}
+
+func TestPickFair(t *testing.T) {
+	now := time.Now()
+	tasks := func(ws string, n, priority int) []*Task {
+		var list []*Task
+		for i := 0; i < n; i++ {
+			list = append(list, &Task{ID: fmt.Sprintf("%s-%d", ws, i), WorkspaceID: ws, Priority: priority, ReadyAt: now})
+		}
+		return list
+	}
+	queues := []*workspaceQueue{
+		{ws: &Workspace{ID: "a"}, running: 2, slots: 3, tasks: tasks("a", 5, 2)},
+		{ws: &Workspace{ID: "b"}, running: 0, slots: 3, tasks: tasks("b", 3, 1)},
+		{ws: &Workspace{ID: "c"}, running: 0, slots: 3, tasks: tasks("c", 1, 2)},
+	}
+
+	picked := map[string]int{}
+	for _, d := range pickFair(queues, 4, 0, now) {
+		picked[d.ws.ID]++
+	}
+	// b and c start one each, then b and a compete at equal load and b's
+	// higher priority wins
+	if picked["a"] != 0 || picked["b"] != 3 || picked["c"] != 1 {
+		t.Errorf("unexpected picks %v", picked)
+	}
+
+	// Without a global limit only the workspace limits apply
+	queues[0].slots = 1
+	picks := pickFair(queues, -1, 0, now)
+	if len(picks) != 1 || picks[0].ws.ID != "a" {
+		t.Errorf("expected the last workspace slot used, got %d picks", len(picks))
+	}
+}
+
+func TestPriorityAging(t *testing.T) {
+	now := time.Now()
+	low := &Task{Priority: 3, ReadyAt: now.Add(-25 * time.Minute)}
+	normal := &Task{Priority: 2, ReadyAt: now}
+
+	if got := agedPriority(low, 10*time.Minute, now); got != 1 {
+		t.Errorf("expected low priority task aged to 1, got %d", got)
+	}
+	if !runsBefore(low, normal, 10*time.Minute, now) {
+		t.Error("expected the aged task to run before a fresh normal one")
+	}
+	if runsBefore(low, normal, 0, now) {
+		t.Error("expected plain priority order without aging")
+	}
+	if got := agedPriority(&Task{Priority: 1, ReadyAt: now.Add(-time.Hour)}, time.Minute, now); got != 0 {
+		t.Errorf("expected aging to stop at critical, got %d", got)
+	}
+}
+
+func TestGlobalConcurrencyCap(t *testing.T) {
+	store, cleanup := tempDB(t)
+	t.Cleanup(cleanup)
+	board := NewBoard(store)
+	s := NewScheduler(board, parkedSessions{})
+	board.SetScheduler(s)
+	s.SetOptions(SchedulerOptions{MaxConcurrent: 4})
+
+	var workspaces []*Workspace
+	for i := 0; i < 3; i++ {
+		ws := &Workspace{Name: fmt.Sprintf("ws%d", i), MaxConcurrent: 3}
+		if err := board.CreateWorkspace(ws); err != nil {
+			t.Fatal(err)
+		}
+		for j := 0; j < 3; j++ {
+			if err := board.CreateTask(&Task{WorkspaceID: ws.ID, Title: "work", Prompt: "work", Status: StatusReady}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		workspaces = append(workspaces, ws)
+	}
+	t.Cleanup(func() {
+		tasks, _ := store.ListTasks("", "")
+		for _, task := range tasks {
+			s.stopTask(task.ID)
+		}
+		waitFor(t, "the runs to stop", func() bool {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			return len(s.running) == 0
+		})
+	})
+
+	s.runScheduleCycle()
+	if n, _ := store.CountRunning(); n != 4 {
+		t.Fatalf("expected the global limit of 4 running tasks, got %d", n)
+	}
+	for _, ws := range workspaces {
+		counts, _ := store.CountByStatus(ws.ID)
+		if counts.Running == 0 {
+			t.Errorf("workspace %s got no slot", ws.Name)
+		}
+	}
+
+	// At the limit another cycle starts nothing
+	s.runScheduleCycle()
+	if n, _ := store.CountRunning(); n != 4 {
+		t.Errorf("expected no new runs at the limit, got %d running", n)
+	}
+}
+
+func TestThrottledProviderHoldsTasks(t *testing.T) {
+	store, cleanup := tempDB(t)
+	t.Cleanup(cleanup)
+	board := NewBoard(store)
+	s := NewScheduler(board, &usageSessions{events: []SessionEvent{{Type: "error", Error: "请求频率超限: 请稍后重试"}}})
+	board.SetScheduler(s)
+	until := time.Now().Add(time.Minute)
+	s.SetOptions(SchedulerOptions{Throttle: func(model string) time.Time {
+		if model == "busy" {
+			return until
+		}
+		return time.Time{}
+	}})
+
+	ws := &Workspace{Name: "throttle"}
+	if err := board.CreateWorkspace(ws); err != nil {
+		t.Fatal(err)
+	}
+	held := &Task{WorkspaceID: ws.ID, Title: "held", Prompt: "p", Model: "busy", MaxRetries: 1, Status: StatusReady}
+	if err := board.CreateTask(held); err != nil {
+		t.Fatal(err)
+	}
+	other := &Task{WorkspaceID: ws.ID, Title: "other", Prompt: "p", Model: "free", MaxRetries: 1, Status: StatusReady}
+	if err := board.CreateTask(other); err != nil {
+		t.Fatal(err)
+	}
+
+	s.runScheduleCycle()
+	waitFor(t, "the other task to finish", func() bool {
+		got, _ := board.GetTask(other.ID)
+		return got != nil && got.Status != StatusReady && got.Status != StatusRunning
+	})
+	if got, _ := board.GetTask(held.ID); got.Status != StatusReady || !got.StartedAt.IsZero() {
+		t.Errorf("expected the throttled task to stay queued, got %s", got.Status)
+	}
+
+	// A run that hits the rate limit is requeued without using a retry
+	s.executeTask(ws, held)
+	waitFor(t, "the run to be requeued", func() bool {
+		got, _ := board.GetTask(held.ID)
+		return got != nil && got.Status == StatusReady && got.Error != ""
+	})
+	got, _ := board.GetTask(held.ID)
+	if got.RetryCount != 0 || !strings.Contains(got.Error, "rate limited") || got.ReadyAt.IsZero() {
+		t.Errorf("unexpected requeue: retries %d, error %q, ready at %v", got.RetryCount, got.Error, got.ReadyAt)
+	}
+}
@@ -0,0 +1,133 @@
+package taskboard
+
+import "fmt"
+
+// Plan editing operations. Tasks reference each other by index, so every
+// structural edit remaps DependsOn to keep the references pointing at the
+// same tasks.
+
+// AddTask appends a task to the plan and returns its index.
+func (p *PlanResult) AddTask(t PlannedTask) int {
+	p.Tasks = append(p.Tasks, t)
+	return len(p.Tasks) - 1
+}
+
+// TaskAt returns a pointer to task i for in-place field edits.
+func (p *PlanResult) TaskAt(i int) (*PlannedTask, error) {
+	if err := p.checkIndex(i); err != nil {
+		return nil, err
+	}
+	return &p.Tasks[i], nil
+}
+
+// RemoveTask deletes the task at index i; dependencies on it are dropped.
+func (p *PlanResult) RemoveTask(i int) error {
+	if err := p.checkIndex(i); err != nil {
+		return err
+	}
+	p.Tasks = append(p.Tasks[:i], p.Tasks[i+1:]...)
+	for k := range p.Tasks {
+		deps := p.Tasks[k].DependsOn[:0]
+		for _, d := range p.Tasks[k].DependsOn {
+			switch {
+			case d == i:
+				continue
+			case d > i:
+				d--
+			}
+			deps = append(deps, d)
+		}
+		p.Tasks[k].DependsOn = deps
+	}
+	return nil
+}
+
+// MoveTask moves the task at index from to index to, shifting the tasks in between.
+func (p *PlanResult) MoveTask(from, to int) error {
+	if err := p.checkIndex(from); err != nil {
+		return err
+	}
+	if err := p.checkIndex(to); err != nil {
+		return err
+	}
+	if from == to {
+		return nil
+	}
+
+	order := make([]int, 0, len(p.Tasks)) // order[newIndex] = oldIndex
+	for i := range p.Tasks {
+		if i != from {
+			order = append(order, i)
+		}
+	}
+	order = append(order[:to], append([]int{from}, order[to:]...)...)
+
+	newIndex := make([]int, len(p.Tasks))
+	tasks := make([]PlannedTask, len(p.Tasks))
+	for n, old := range order {
+		newIndex[old] = n
+		tasks[n] = p.Tasks[old]
+	}
+	for k := range tasks {
+		deps := make([]int, len(tasks[k].DependsOn))
+		for j, d := range tasks[k].DependsOn {
+			deps[j] = newIndex[d]
+		}
+		tasks[k].DependsOn = deps
+	}
+	p.Tasks = tasks
+	return nil
+}
+
+// SetDependencies replaces the dependencies of task i.
+func (p *PlanResult) SetDependencies(i int, deps []int) error {
+	if err := p.checkIndex(i); err != nil {
+		return err
+	}
+	seen := make(map[int]bool, len(deps))
+	clean := []int{}
+	for _, d := range deps {
+		if err := p.checkIndex(d); err != nil {
+			return err
+		}
+		if d == i {
+			return fmt.Errorf("task %d: cannot depend on itself", i)
+		}
+		if !seen[d] {
+			seen[d] = true
+			clean = append(clean, d)
+		}
+	}
+	p.Tasks[i].DependsOn = clean
+	return nil
+}
+
+// SetPriority changes the priority of task i.
+func (p *PlanResult) SetPriority(i, priority int) error {
+	if err := p.checkIndex(i); err != nil {
+		return err
+	}
+	if priority < 0 || priority > 3 {
+		return fmt.Errorf("invalid priority %d (0-3)", priority)
+	}
+	p.Tasks[i].Priority = priority
+	return nil
+}
+
+// Levels groups task indices into dependency layers: level 0 has no
+// dependencies, level n depends only on tasks in earlier levels. Tasks
+// within a level are ordered by priority, then index. It returns an error
+// naming the tasks involved if the dependencies contain a cycle.
+func (p *PlanResult) Levels() ([][]int, error) {
+	return dagLevels(len(p.Tasks),
+		func(i int) []int { return p.Tasks[i].DependsOn },
+		func(i int) string { return fmt.Sprintf("%d", i) },
+		func(i int) int { return p.Tasks[i].Priority })
+}
+
+func (p *PlanResult) checkIndex(i int) error {
+	if i < 0 || i >= len(p.Tasks) {
+		return fmt.Errorf("task index %d out of range (0-%d)", i, len(p.Tasks)-1)
+	}
+	return nil
+}
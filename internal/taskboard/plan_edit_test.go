@@ -0,0 +1,137 @@
+package taskboard
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// chainPlan returns a → b → c (c depends on b, b depends on a) plus independent d.
+func chainPlan() *PlanResult {
+	return &PlanResult{
+		WorkspaceName: "edit",
+		Tasks: []PlannedTask{
+			{Title: "a", Prompt: "a", Priority: 2},
+			{Title: "b", Prompt: "b", DependsOn: []int{0}},
+			{Title: "c", Prompt: "c", DependsOn: []int{1}},
+			{Title: "d", Prompt: "d", Priority: 1},
+		},
+	}
+}
+
+func titles(p *PlanResult) string {
+	var s []string
+	for _, t := range p.Tasks {
+		s = append(s, t.Title)
+	}
+	return strings.Join(s, ",")
+}
+
+func TestPlanRemoveTask(t *testing.T) {
+	p := chainPlan()
+	if err := p.RemoveTask(1); err != nil {
+		t.Fatal(err)
+	}
+	if got := titles(p); got != "a,c,d" {
+		t.Fatalf("unexpected tasks %s", got)
+	}
+	if len(p.Tasks[1].DependsOn) != 0 {
+		t.Errorf("expected dependency on removed task to be dropped, got %v", p.Tasks[1].DependsOn)
+	}
+	if err := p.RemoveTask(5); err == nil {
+		t.Error("expected out of range error")
+	}
+}
+
+func TestPlanMoveTask(t *testing.T) {
+	p := chainPlan()
+	if err := p.MoveTask(3, 0); err != nil {
+		t.Fatal(err)
+	}
+	if got := titles(p); got != "d,a,b,c" {
+		t.Fatalf("unexpected order %s", got)
+	}
+	// b still depends on a, c still depends on b
+	if !reflect.DeepEqual(p.Tasks[2].DependsOn, []int{1}) || !reflect.DeepEqual(p.Tasks[3].DependsOn, []int{2}) {
+		t.Errorf("dependencies not remapped: %v %v", p.Tasks[2].DependsOn, p.Tasks[3].DependsOn)
+	}
+
+	if err := p.MoveTask(1, 3); err != nil {
+		t.Fatal(err)
+	}
+	if got := titles(p); got != "d,b,c,a" {
+		t.Fatalf("unexpected order %s", got)
+	}
+	if !reflect.DeepEqual(p.Tasks[1].DependsOn, []int{3}) {
+		t.Errorf("expected b to depend on a at index 3, got %v", p.Tasks[1].DependsOn)
+	}
+}
+
+func TestPlanLevelsAndCycles(t *testing.T) {
+	p := chainPlan()
+	levels, err := p.Levels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]int{{3, 0}, {1}, {2}} // d (high) sorts before a (normal)
+	if !reflect.DeepEqual(levels, want) {
+		t.Errorf("expected levels %v, got %v", want, levels)
+	}
+
+	if err := p.SetDependencies(0, []int{2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("expected cycle error, got %v", err)
+	}
+	if err := p.SetDependencies(0, []int{0}); err == nil {
+		t.Error("expected self-dependency error")
+	}
+}
+
+func TestEditPlanDraft(t *testing.T) {
+	store, cleanup := tempDB(t)
+	defer cleanup()
+	board := NewBoard(store)
+
+	d, err := board.CreatePlanDraft("goal", "/tmp", chainPlan())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := board.EditPlanDraft(d.ID, func(p *PlanResult) error {
+		return p.SetPriority(2, 0)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// An edit that introduces a cycle is rejected and not saved
+	if _, err := board.EditPlanDraft(d.ID, func(p *PlanResult) error {
+		return p.SetDependencies(0, []int{2})
+	}); err == nil {
+		t.Fatal("expected cycle to be rejected")
+	}
+
+	got, err := board.GetPlanDraft(d.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Plan.Tasks[2].Priority != 0 {
+		t.Errorf("expected priority edit to be saved, got %d", got.Plan.Tasks[2].Priority)
+	}
+	if len(got.Plan.Tasks[0].DependsOn) != 0 {
+		t.Errorf("expected rejected edit to be discarded, got %v", got.Plan.Tasks[0].DependsOn)
+	}
+	if got.Goal != "goal" || got.WorkDir != "/tmp" {
+		t.Errorf("unexpected draft metadata: %+v", got)
+	}
+
+	drafts, _ := board.ListPlanDrafts()
+	if len(drafts) != 1 {
+		t.Errorf("expected 1 draft, got %d", len(drafts))
+	}
+	board.DeletePlanDraft(d.ID)
+	if _, err := board.GetPlanDraft(d.ID); err == nil {
+		t.Error("expected draft to be deleted")
+	}
+}
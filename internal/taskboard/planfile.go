@@ -0,0 +1,405 @@
+package taskboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PlanFileVersion is the format version written by ExportWorkspace.
+const PlanFileVersion = 1
+
+// PlanFile is the portable form of a workspace, written as YAML or JSON so a
+// plan can be shared or checked into a repository. Text fields may contain
+// {{name}} placeholders that are filled from Variables at import time.
+type PlanFile struct {
+	Version       int               `json:"version" yaml:"version"`
+	Name          string            `json:"name" yaml:"name"`
+	Description   string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Goal          string            `json:"goal,omitempty" yaml:"goal,omitempty"`
+	Context       string            `json:"context,omitempty" yaml:"context,omitempty"`
+	WorkDir       string            `json:"work_dir,omitempty" yaml:"work_dir,omitempty"`
+	MaxConcurrent int               `json:"max_concurrent,omitempty" yaml:"max_concurrent,omitempty"`
+	MergePolicy   string            `json:"merge_policy,omitempty" yaml:"merge_policy,omitempty"`
+	TaskTimeout   string            `json:"task_timeout,omitempty" yaml:"task_timeout,omitempty"` // e.g. "30m"
+	ApprovalTags  []string          `json:"approval_tags,omitempty" yaml:"approval_tags,omitempty"`
+	Variables     map[string]string `json:"variables,omitempty" yaml:"variables,omitempty"` // name → default; "" = required
+	Tasks         []PlanFileTask    `json:"tasks" yaml:"tasks"`
+	Summary       string            `json:"summary,omitempty" yaml:"summary,omitempty"` // exported with results only
+}
+
+// PlanFileTask is a task in a PlanFile. Dependencies refer to other tasks by
+// Key, which stays stable when tasks are reordered or edited.
+type PlanFileTask struct {
+	Key              string   `json:"key" yaml:"key"`
+	Title            string   `json:"title" yaml:"title"`
+	Description      string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Prompt           string   `json:"prompt" yaml:"prompt"`
+	Priority         int      `json:"priority" yaml:"priority"`
+	DependsOn        []string `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+	Tags             []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	MaxRetries       int      `json:"max_retries,omitempty" yaml:"max_retries,omitempty"`
+	Timeout          string   `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	VerifyCommand    string   `json:"verify_command,omitempty" yaml:"verify_command,omitempty"`
+	VerifyPrompt     string   `json:"verify_prompt,omitempty" yaml:"verify_prompt,omitempty"`
+	RequiresApproval bool     `json:"requires_approval,omitempty" yaml:"requires_approval,omitempty"`
+	Model            string   `json:"model,omitempty" yaml:"model,omitempty"`
+	Temperature      *float64 `json:"temperature,omitempty" yaml:"temperature,omitempty"`
+	Tools            []string `json:"tools,omitempty" yaml:"tools,omitempty"`
+	MaxToolRounds    int      `json:"max_tool_rounds,omitempty" yaml:"max_tool_rounds,omitempty"`
+	Artifacts        []string `json:"artifacts,omitempty" yaml:"artifacts,omitempty"`
+
+	// Run output, exported with results only and ignored on import
+	Status string        `json:"status,omitempty" yaml:"status,omitempty"`
+	Result string        `json:"result,omitempty" yaml:"result,omitempty"`
+	Error  string        `json:"error,omitempty" yaml:"error,omitempty"`
+	Logs   []PlanFileLog `json:"logs,omitempty" yaml:"logs,omitempty"`
+}
+
+// PlanFileLog is an exported task log entry.
+type PlanFileLog struct {
+	Time    string `json:"time" yaml:"time"`
+	Type    string `json:"type" yaml:"type"`
+	Tool    string `json:"tool,omitempty" yaml:"tool,omitempty"`
+	Content string `json:"content" yaml:"content"`
+}
+
+// ExportOptions controls what ExportWorkspace includes besides the plan.
+type ExportOptions struct {
+	Results bool // task status, result, error and the workspace summary
+	Logs    int  // task log entries per task; 0 = none
+}
+
+// ExportWorkspace converts a workspace and its tasks into a PlanFile.
+func (b *Board) ExportWorkspace(id string, opts ExportOptions) (*PlanFile, error) {
+	ws, err := b.store.GetWorkspace(id)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := b.store.ListTasks(id, "")
+	if err != nil {
+		return nil, err
+	}
+
+	f := &PlanFile{
+		Version:       PlanFileVersion,
+		Name:          ws.Name,
+		Description:   ws.Description,
+		Goal:          ws.Goal,
+		Context:       ws.Context,
+		WorkDir:       ws.WorkDir,
+		MaxConcurrent: ws.MaxConcurrent,
+		MergePolicy:   string(ws.MergePolicy),
+		ApprovalTags:  ws.ApprovalTags,
+	}
+	if ws.TaskTimeout > 0 {
+		f.TaskTimeout = ws.TaskTimeout.String()
+	}
+	if opts.Results {
+		f.Summary = ws.Summary
+	}
+
+	keys := taskKeys(tasks)
+	for _, t := range tasks {
+		ft := PlanFileTask{
+			Key:              keys[t.ID],
+			Title:            t.Title,
+			Description:      t.Description,
+			Prompt:           t.Prompt,
+			Priority:         t.Priority,
+			Tags:             t.Tags,
+			MaxRetries:       t.MaxRetries,
+			VerifyCommand:    t.VerifyCommand,
+			VerifyPrompt:     t.VerifyPrompt,
+			RequiresApproval: t.RequiresApproval,
+			Model:            t.Model,
+			Temperature:      t.Temperature,
+			Tools:            t.AllowedTools,
+			MaxToolRounds:    t.MaxToolRounds,
+			Artifacts:        t.Artifacts,
+		}
+		for _, dep := range t.DependsOn {
+			if key, ok := keys[dep]; ok {
+				ft.DependsOn = append(ft.DependsOn, key)
+			}
+		}
+		if t.Timeout > 0 {
+			ft.Timeout = t.Timeout.String()
+		}
+		if opts.Results {
+			ft.Status = string(t.Status)
+			ft.Result = t.Result
+			ft.Error = t.Error
+		}
+		if opts.Logs > 0 {
+			logs, _ := b.store.GetTaskLog(t.ID, opts.Logs)
+			for _, l := range logs {
+				ft.Logs = append(ft.Logs, PlanFileLog{
+					Time:    l.Timestamp.Format(time.RFC3339),
+					Type:    l.EventType,
+					Tool:    l.ToolName,
+					Content: l.Content,
+				})
+			}
+		}
+		f.Tasks = append(f.Tasks, ft)
+	}
+	return f, nil
+}
+
+// taskKeys derives a stable, unique key for each task from its title,
+// falling back to task-<n> for titles without ASCII letters or digits.
+func taskKeys(tasks []*Task) map[string]string {
+	keys := make(map[string]string, len(tasks))
+	used := make(map[string]bool, len(tasks))
+	for i, t := range tasks {
+		base := slugify(t.Title)
+		if base == "" {
+			base = fmt.Sprintf("task-%d", i+1)
+		}
+		key := base
+		for n := 2; used[key]; n++ {
+			key = fmt.Sprintf("%s-%d", base, n)
+		}
+		used[key] = true
+		keys[t.ID] = key
+	}
+	return keys
+}
+
+func slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if b.Len() > 0 && !dash {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// MarshalPlanFile encodes a PlanFile as "yaml" (default) or "json".
+func MarshalPlanFile(f *PlanFile, format string) ([]byte, error) {
+	switch format {
+	case "", "yaml", "yml":
+		return yaml.Marshal(f)
+	case "json":
+		return json.MarshalIndent(f, "", "  ")
+	}
+	return nil, fmt.Errorf("unknown plan file format %q (yaml, json)", format)
+}
+
+// ParsePlanFile decodes a YAML or JSON plan file.
+func ParsePlanFile(data []byte) (*PlanFile, error) {
+	var f PlanFile
+	// JSON is valid YAML, so one decoder handles both formats
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse plan file: %w", err)
+	}
+	if f.Version > PlanFileVersion {
+		return nil, fmt.Errorf("plan file version %d is newer than supported version %d", f.Version, PlanFileVersion)
+	}
+	return &f, nil
+}
+
+var placeholderRe = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// Expand fills the {{name}} placeholders of the plan's text fields from vars,
+// falling back to the defaults in f.Variables. It fails listing every
+// placeholder that has no value.
+func (f *PlanFile) Expand(vars map[string]string) (*PlanFile, error) {
+	values := make(map[string]string, len(f.Variables)+len(vars))
+	for k, v := range f.Variables {
+		values[k] = v
+	}
+	for k, v := range vars {
+		values[k] = v
+	}
+
+	missing := map[string]bool{}
+	expand := func(s string) string {
+		return placeholderRe.ReplaceAllStringFunc(s, func(m string) string {
+			name := placeholderRe.FindStringSubmatch(m)[1]
+			v, ok := values[name]
+			if !ok || v == "" {
+				missing[name] = true
+				return m
+			}
+			return v
+		})
+	}
+
+	out := *f
+	out.Name = expand(f.Name)
+	out.Description = expand(f.Description)
+	out.Goal = expand(f.Goal)
+	out.Context = expand(f.Context)
+	out.WorkDir = expand(f.WorkDir)
+	out.Tasks = make([]PlanFileTask, len(f.Tasks))
+	for i, t := range f.Tasks {
+		t.Title = expand(t.Title)
+		t.Description = expand(t.Description)
+		t.Prompt = expand(t.Prompt)
+		t.VerifyCommand = expand(t.VerifyCommand)
+		t.VerifyPrompt = expand(t.VerifyPrompt)
+		out.Tasks[i] = t
+	}
+
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("missing values for variables: %s", strings.Join(names, ", "))
+	}
+	return &out, nil
+}
+
+// ToPlan converts the file to a PlanResult, resolving dependency keys to
+// task indices.
+func (f *PlanFile) ToPlan() (*PlanResult, error) {
+	index := make(map[string]int, len(f.Tasks))
+	for i, t := range f.Tasks {
+		if t.Key == "" {
+			return nil, fmt.Errorf("task %d: key is required", i)
+		}
+		if _, dup := index[t.Key]; dup {
+			return nil, fmt.Errorf("duplicate task key %q", t.Key)
+		}
+		index[t.Key] = i
+	}
+
+	plan := &PlanResult{
+		WorkspaceName:    f.Name,
+		WorkspaceContext: f.Context,
+		MaxConcurrent:    f.MaxConcurrent,
+	}
+	for _, t := range f.Tasks {
+		deps := []int{}
+		for _, key := range t.DependsOn {
+			i, ok := index[key]
+			if !ok {
+				return nil, fmt.Errorf("task %q depends on unknown key %q", t.Key, key)
+			}
+			deps = append(deps, i)
+		}
+		plan.Tasks = append(plan.Tasks, PlannedTask{
+			Title:            t.Title,
+			Description:      t.Description,
+			Prompt:           t.Prompt,
+			Priority:         t.Priority,
+			DependsOn:        deps,
+			Tags:             t.Tags,
+			VerifyCommand:    t.VerifyCommand,
+			VerifyPrompt:     t.VerifyPrompt,
+			RequiresApproval: t.RequiresApproval,
+			Model:            t.Model,
+			Temperature:      t.Temperature,
+			Tools:            t.Tools,
+			MaxToolRounds:    t.MaxToolRounds,
+			Artifacts:        t.Artifacts,
+		})
+	}
+	if err := plan.Validate(); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// ImportPlanFile recreates a workspace from a plan file. Placeholders are
+// filled from vars; workDir, when set, overrides the file's work_dir. A paused
+// workspace is created without starting any task.
+func (b *Board) ImportPlanFile(f *PlanFile, vars map[string]string, workDir string, paused bool) (*Workspace, []*Task, error) {
+	f, err := f.Expand(vars)
+	if err != nil {
+		return nil, nil, err
+	}
+	plan, err := f.ToPlan()
+	if err != nil {
+		return nil, nil, err
+	}
+	policy := MergeManual
+	if f.MergePolicy != "" {
+		policy = MergePolicy(f.MergePolicy)
+		if !policy.Valid() {
+			return nil, nil, fmt.Errorf("invalid merge policy: %s", f.MergePolicy)
+		}
+	}
+	var taskTimeout time.Duration
+	if f.TaskTimeout != "" {
+		if taskTimeout, err = time.ParseDuration(f.TaskTimeout); err != nil {
+			return nil, nil, fmt.Errorf("task_timeout: %w", err)
+		}
+	}
+	timeouts := make([]time.Duration, len(f.Tasks))
+	for i, t := range f.Tasks {
+		if t.Timeout == "" {
+			continue
+		}
+		if timeouts[i], err = time.ParseDuration(t.Timeout); err != nil {
+			return nil, nil, fmt.Errorf("task %q timeout: %w", t.Key, err)
+		}
+	}
+	if workDir == "" {
+		workDir = f.WorkDir
+	}
+
+	// Settings a PlanResult does not carry are stored with the workspace and
+	// its tasks, so the scheduler never runs a task without them
+	ws := &Workspace{
+		ID:           fmt.Sprintf("ws-%d", time.Now().UnixNano()),
+		Description:  f.Description,
+		Goal:         f.Goal,
+		Status:       WorkspaceActive,
+		WorkDir:      workDir,
+		MergePolicy:  policy,
+		TaskTimeout:  taskTimeout,
+		ApprovalTags: f.ApprovalTags,
+	}
+	if paused {
+		ws.Status = WorkspacePaused
+	}
+	ws, tasks, err := b.store.createFromPlan(plan, ws, func(i int, t *Task) {
+		if f.Tasks[i].MaxRetries > 0 {
+			t.MaxRetries = f.Tasks[i].MaxRetries
+		}
+		t.Timeout = timeouts[i]
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b.broadcast(BoardEvent{
+		Type:        EventWorkspaceCreated,
+		WorkspaceID: ws.ID,
+		Detail:      fmt.Sprintf("%s (%d tasks, imported)", ws.Name, len(tasks)),
+		Timestamp:   time.Now(),
+	})
+	for _, t := range tasks {
+		evType := EventTaskCreated
+		if t.Status == StatusReady {
+			evType = EventTaskReady
+		}
+		b.broadcast(BoardEvent{
+			Type:        evType,
+			WorkspaceID: ws.ID,
+			TaskID:      t.ID,
+			Detail:      t.Title,
+			Timestamp:   time.Now(),
+		})
+	}
+	if !paused && b.scheduler != nil {
+		b.scheduler.Trigger()
+	}
+	return ws, tasks, nil
+}
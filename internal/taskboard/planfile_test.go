@@ -0,0 +1,135 @@
+package taskboard
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlanFileRoundTrip(t *testing.T) {
+	store, cleanup := tempDB(t)
+	t.Cleanup(cleanup)
+	board := NewBoard(store)
+
+	ws := &Workspace{Name: "release", Goal: "ship {{version}}", MergePolicy: MergeAuto, TaskTimeout: 30 * time.Minute}
+	if err := board.CreateWorkspace(ws); err != nil {
+		t.Fatal(err)
+	}
+	build := &Task{WorkspaceID: ws.ID, Title: "Build", Prompt: "build {{version}}", Tags: []string{"ci"}, MaxRetries: 3, VerifyCommand: "make test"}
+	if err := board.CreateTask(build); err != nil {
+		t.Fatal(err)
+	}
+	notes := &Task{WorkspaceID: ws.ID, Title: "Build", Prompt: "write notes for {{version}}", DependsOn: []string{build.ID}, Artifacts: []string{"NOTES.md"}}
+	if err := board.CreateTask(notes); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := board.ExportWorkspace(ws.ID, ExportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Tasks[0].Key != "build" || f.Tasks[1].Key != "build-2" {
+		t.Fatalf("expected unique keys, got %q and %q", f.Tasks[0].Key, f.Tasks[1].Key)
+	}
+	if len(f.Tasks[1].DependsOn) != 1 || f.Tasks[1].DependsOn[0] != "build" {
+		t.Fatalf("expected dependency by key, got %v", f.Tasks[1].DependsOn)
+	}
+
+	for _, format := range []string{"yaml", "json"} {
+		data, err := MarshalPlanFile(f, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := ParsePlanFile(data)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		imported, tasks, err := board.ImportPlanFile(parsed, map[string]string{"version": "v2.0"}, "", false)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+
+		if imported.Goal != "ship v2.0" || imported.MergePolicy != MergeAuto || imported.TaskTimeout != 30*time.Minute {
+			t.Errorf("%s: unexpected workspace %+v", format, imported)
+		}
+		if len(tasks) != 2 || tasks[0].Prompt != "build v2.0" {
+			t.Fatalf("%s: unexpected tasks %+v", format, tasks)
+		}
+		got, _ := board.GetTask(tasks[0].ID)
+		if got.MaxRetries != 3 || got.VerifyCommand != "make test" || got.Status != StatusReady {
+			t.Errorf("%s: unexpected task %+v", format, got)
+		}
+		got, _ = board.GetTask(tasks[1].ID)
+		if len(got.DependsOn) != 1 || got.DependsOn[0] != tasks[0].ID || got.Status != StatusBacklog {
+			t.Errorf("%s: expected the dependency remapped, got %v (%s)", format, got.DependsOn, got.Status)
+		}
+		if len(got.Artifacts) != 1 || got.Artifacts[0] != "NOTES.md" {
+			t.Errorf("%s: unexpected artifacts %v", format, got.Artifacts)
+		}
+	}
+}
+
+func TestPlanFileVariables(t *testing.T) {
+	f := &PlanFile{
+		Name:      "deploy {{ env }}",
+		Variables: map[string]string{"env": "staging", "host": ""},
+		Tasks: []PlanFileTask{
+			{Key: "deploy", Title: "deploy", Prompt: "deploy to {{host}} as {{user}}"},
+		},
+	}
+	if _, err := f.Expand(nil); err == nil || !strings.Contains(err.Error(), "host, user") {
+		t.Errorf("expected missing host and user, got %v", err)
+	}
+
+	out, err := f.Expand(map[string]string{"host": "db1", "user": "ops"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "deploy staging" || out.Tasks[0].Prompt != "deploy to db1 as ops" {
+		t.Errorf("unexpected expansion %q / %q", out.Name, out.Tasks[0].Prompt)
+	}
+	if f.Tasks[0].Prompt != "deploy to {{host}} as {{user}}" {
+		t.Error("expected Expand to leave the original untouched")
+	}
+
+	f.Tasks = append(f.Tasks, PlanFileTask{Key: "check", Title: "check", Prompt: "check", DependsOn: []string{"missing"}})
+	if _, err := f.ToPlan(); err == nil || !strings.Contains(err.Error(), `unknown key "missing"`) {
+		t.Errorf("expected an unknown dependency key error, got %v", err)
+	}
+}
+
+func TestImportPlanFileStoresSettingsUpFront(t *testing.T) {
+	store, cleanup := tempDB(t)
+	t.Cleanup(cleanup)
+	board := NewBoard(store)
+
+	f := &PlanFile{
+		Name:         "hotfix",
+		MergePolicy:  string(MergeAuto),
+		TaskTimeout:  "20m",
+		ApprovalTags: []string{"prod"},
+		Tasks: []PlanFileTask{
+			{Key: "patch", Title: "patch", Prompt: "fix it", Tags: []string{"prod"}, MaxRetries: 2, Timeout: "5m"},
+		},
+	}
+	ws, tasks, err := board.ImportPlanFile(f, nil, t.TempDir(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := store.GetWorkspace(ws.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != WorkspacePaused || stored.MergePolicy != MergeAuto || stored.TaskTimeout != 20*time.Minute ||
+		len(stored.ApprovalTags) != 1 {
+		t.Errorf("workspace settings not stored: %+v", stored)
+	}
+	task, err := store.GetTask(tasks[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.MaxRetries != 2 || task.Timeout != 5*time.Minute || !task.NeedsApproval(stored) {
+		t.Errorf("task settings not stored: %+v", task)
+	}
+}
@@ -1,17 +1,21 @@
 package taskboard
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 )
 
 // PlanEvent is a streaming event emitted by the Planner during goal decomposition.
 type PlanEvent struct {
-	Type     string // thinking, reading, plan_ready, error
-	Content  string // progress info for thinking/reading/error
+	Type     string // thinking, reading, brief, plan_ready, error
+	Content  string // progress info for thinking/reading/error; the project brief for brief
 	PlanJSON string // populated when type=plan_ready
 }
 
@@ -33,6 +37,10 @@ const plannerPrompt = `你是一个任务规划专家。用户给出了一个目
 4. 明确任务间的依赖关系（哪些必须先完成才能开始后续任务）
 5. 按优先级排序（0=critical, 1=high, 2=normal, 3=low）
 6. 每个任务的 prompt 要足够具体和详细，包含文件路径、实现要求、设计约束等
+7. 如果任务结果可以自动验证，给出验收命令 verify_command（如 "go test ./pkg/..."，退出码 0 表示通过）和/或验收标准 verify_prompt（交给审查 agent 判断）；无法验证时留空
+8. 涉及数据库迁移、删除数据、对外发布等高风险改动的任务，设置 requires_approval 为 true，执行完成后由人工审批
+9. 按任务难度选择模型 model：文档、格式调整等简单任务用 "small"，复杂编码用 "large"（默认）；只需阅读分析的任务（如代码审查）将 tools 限定为只读工具 ["read", "search", "list", "git"]
+10. 任务生成的报告、数据等文件如需交给后续任务使用，在 artifacts 中列出其相对路径（支持通配符），执行完成后会被收集并以路径引用的方式传给依赖它的任务
 
 用户目标: %s
 
@@ -48,74 +56,313 @@ const plannerPrompt = `你是一个任务规划专家。用户给出了一个目
       "prompt": "给执行 agent 的完整、详细的 prompt",
       "priority": 2,
       "depends_on": [],
-      "tags": ["backend"]
+      "tags": ["backend"],
+      "verify_command": "",
+      "verify_prompt": "",
+      "requires_approval": false,
+      "model": "large",
+      "tools": [],
+      "artifacts": []
     }
   ]
 }`
 
+const revisePrompt = `你之前为以下目标生成了一份任务计划，用户审阅后给出了修改意见。
+
+用户目标: %s
+
+当前计划:
+%s
+
+用户反馈: %s
+
+请根据反馈修改计划。任务的 depends_on 是 tasks 数组中的下标（从 0 开始），调整任务顺序时要同步更新。
+请直接输出修改后的完整 JSON 计划（不要用 markdown 代码块包裹），格式与当前计划相同。`
+
+const explorePrompt = `你是一个代码库调研员。用户准备在下面的仓库中完成一个目标，规划任务之前需要先弄清楚项目的真实情况。
+
+用户目标: %s
+仓库目录: %s
+
+请使用 list、search、read、git（只读）工具探索仓库：
+1. 了解项目结构、语言与技术栈、构建和测试方式
+2. 找到与目标相关的模块、文件、函数和已有实现
+3. 留意代码风格、命名、错误处理和测试的组织方式
+
+探索完成后输出一份项目概要（Markdown），包括：
+- 项目概况：用途、语言、技术栈、构建/测试命令
+- 目录结构：主要目录及其职责
+- 相关文件：逐条列出与目标相关、真实存在的文件路径及其作用
+- 约定：实现时需要遵守的代码风格和测试布局
+
+只输出概要本身，不要规划任务。`
+
+const briefSection = `以下是对仓库的调研概要（仓库目录: %s）:
+
+%s
+
+规划要求：任务 prompt 中引用的文件路径必须是仓库中真实存在的路径（相对仓库根目录）；需要新建的文件请在同一行写明"新建"。拿不准时可以用只读工具核实。
+
+`
+
+const fixPathsPrompt = `计划中引用的以下路径在仓库中不存在:
+%s
+
+请用只读工具核实后改成真实路径；如果确实是要新建的文件，请在引用它的那一行写明"新建"。
+请直接输出修改后的完整 JSON 计划（不要用 markdown 代码块包裹）。`
+
 // Plan runs the AI planner to decompose a goal into tasks.
 // Returns a channel of PlanEvents for streaming progress.
-func (p *Planner) Plan(goal string) (<-chan PlanEvent, error) {
+//
+// With a workDir the planner works in two phases: a read-only session first
+// explores the repository and writes a project brief (each tool call is
+// streamed as a reading event, the brief as a brief event), then the goal is
+// decomposed against that brief. Task prompts that mention paths missing from
+// the repository are sent back for one round of correction, and the brief
+// becomes the plan's workspace context.
+func (p *Planner) Plan(goal, workDir string) (<-chan PlanEvent, error) {
 	if goal == "" {
 		return nil, fmt.Errorf("goal is required")
 	}
+	if workDir == "" {
+		return p.run("正在分析目标并生成任务计划...", fmt.Sprintf(plannerPrompt, goal), TaskSessionOptions{}), nil
+	}
 
 	eventCh := make(chan PlanEvent, 32)
-
 	go func() {
 		defer close(eventCh)
+		opts := TaskSessionOptions{WorkDir: workDir, ReadOnly: true}
 
-		eventCh <- PlanEvent{Type: "thinking", Content: "正在分析目标并生成任务计划..."}
+		eventCh <- PlanEvent{Type: "thinking", Content: fmt.Sprintf("正在探索仓库 %s ...", workDir)}
+		explorer := p.sessions.CreateTaskSession("planner:explore", opts)
+		brief, err := p.chat(explorer, fmt.Sprintf(explorePrompt, goal, workDir), eventCh)
+		p.sessions.DeleteTaskSession(explorer.GetID())
+		if err != nil {
+			eventCh <- PlanEvent{Type: "error", Content: err.Error()}
+			return
+		}
+		brief = strings.TrimSpace(brief)
+		if brief == "" {
+			eventCh <- PlanEvent{Type: "error", Content: "仓库探索未产生项目概要"}
+			return
+		}
+		eventCh <- PlanEvent{Type: "brief", Content: brief}
 
-		// Create a temporary session for planning
-		sess := p.sessions.CreateTaskSession("planner")
+		eventCh <- PlanEvent{Type: "thinking", Content: "正在根据项目概要生成任务计划..."}
+		sess := p.sessions.CreateTaskSession("planner", opts)
 		defer p.sessions.DeleteTaskSession(sess.GetID())
 
-		prompt := fmt.Sprintf(plannerPrompt, goal)
-		chatEvents, err := sess.ChatStream(prompt)
+		prompt := fmt.Sprintf(briefSection, workDir, brief) + fmt.Sprintf(plannerPrompt, goal)
+		plan, err := p.requestPlan(sess, prompt, eventCh)
 		if err != nil {
-			eventCh <- PlanEvent{Type: "error", Content: fmt.Sprintf("chat error: %v", err)}
+			eventCh <- PlanEvent{Type: "error", Content: err.Error()}
 			return
 		}
 
-		var fullContent strings.Builder
-		for ev := range chatEvents {
-			switch ev.Type {
-			case "content":
-				fullContent.WriteString(ev.Content)
-			case "thinking":
-				eventCh <- PlanEvent{Type: "thinking", Content: ev.Content}
-			case "tool_call":
-				eventCh <- PlanEvent{Type: "reading", Content: fmt.Sprintf("调用工具: %s", ev.ToolName)}
-			case "error":
-				eventCh <- PlanEvent{Type: "error", Content: ev.Error}
+		if missing := missingPaths(plan, workDir); len(missing) > 0 {
+			eventCh <- PlanEvent{Type: "thinking", Content: fmt.Sprintf("计划引用了 %d 个不存在的路径，正在修正...", len(missing))}
+			fixed, err := p.requestPlan(sess, fmt.Sprintf(fixPathsPrompt, "- "+strings.Join(missing, "\n- ")), eventCh)
+			if err != nil {
+				eventCh <- PlanEvent{Type: "error", Content: err.Error()}
 				return
 			}
+			plan = fixed
+			if missing := missingPaths(plan, workDir); len(missing) > 0 {
+				eventCh <- PlanEvent{Type: "thinking", Content: "以下路径仍不存在，请审阅计划时确认: " + strings.Join(missing, ", ")}
+			}
 		}
 
-		content := fullContent.String()
-		// Try to extract JSON from the content
-		planJSON := extractJSON(content)
-		if planJSON == "" {
-			eventCh <- PlanEvent{Type: "error", Content: "AI 未返回有效的 JSON 计划"}
+		plan.WorkspaceContext = mergeContext(brief, plan.WorkspaceContext)
+		planJSON, err := json.Marshal(plan)
+		if err != nil {
+			eventCh <- PlanEvent{Type: "error", Content: err.Error()}
 			return
 		}
+		eventCh <- PlanEvent{Type: "plan_ready", PlanJSON: string(planJSON)}
+	}()
+	return eventCh, nil
+}
 
-		// Validate
-		var plan PlanResult
-		if err := json.Unmarshal([]byte(planJSON), &plan); err != nil {
-			eventCh <- PlanEvent{Type: "error", Content: fmt.Sprintf("JSON 解析失败: %v", err)}
+// Revise asks the planner to rework an existing plan according to user feedback.
+// The revised plan is delivered as a plan_ready event, like Plan.
+func (p *Planner) Revise(goal string, plan *PlanResult, feedback string) (<-chan PlanEvent, error) {
+	if feedback == "" {
+		return nil, fmt.Errorf("feedback is required")
+	}
+	current, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return p.run("正在根据反馈修改计划...", fmt.Sprintf(revisePrompt, goal, current, feedback), TaskSessionOptions{}), nil
+}
+
+// run streams a single planner session for prompt and emits plan_ready with the validated plan JSON.
+func (p *Planner) run(intro, prompt string, opts TaskSessionOptions) <-chan PlanEvent {
+	eventCh := make(chan PlanEvent, 32)
+
+	go func() {
+		defer close(eventCh)
+
+		eventCh <- PlanEvent{Type: "thinking", Content: intro}
+
+		// Create a temporary session for planning
+		sess := p.sessions.CreateTaskSession("planner", opts)
+		defer p.sessions.DeleteTaskSession(sess.GetID())
+
+		plan, err := p.requestPlan(sess, prompt, eventCh)
+		if err != nil {
+			eventCh <- PlanEvent{Type: "error", Content: err.Error()}
 			return
 		}
-		if err := plan.Validate(); err != nil {
-			eventCh <- PlanEvent{Type: "error", Content: fmt.Sprintf("计划验证失败: %v", err)}
+		planJSON, err := json.Marshal(plan)
+		if err != nil {
+			eventCh <- PlanEvent{Type: "error", Content: err.Error()}
 			return
 		}
-
-		eventCh <- PlanEvent{Type: "plan_ready", PlanJSON: planJSON}
+		eventCh <- PlanEvent{Type: "plan_ready", PlanJSON: string(planJSON)}
 	}()
 
-	return eventCh, nil
+	return eventCh
+}
+
+// requestPlan sends prompt to sess and parses the reply as a validated plan.
+func (p *Planner) requestPlan(sess TaskSession, prompt string, eventCh chan<- PlanEvent) (*PlanResult, error) {
+	content, err := p.chat(sess, prompt, eventCh)
+	if err != nil {
+		return nil, err
+	}
+	// Try to extract JSON from the content
+	planJSON := extractJSON(content)
+	if planJSON == "" {
+		return nil, fmt.Errorf("AI 未返回有效的 JSON 计划")
+	}
+	var plan PlanResult
+	if err := json.Unmarshal([]byte(planJSON), &plan); err != nil {
+		return nil, fmt.Errorf("JSON 解析失败: %v", err)
+	}
+	if err := plan.Validate(); err != nil {
+		return nil, fmt.Errorf("计划验证失败: %v", err)
+	}
+	return &plan, nil
+}
+
+// chat sends prompt to sess, forwards thinking and tool calls as progress
+// events and returns the reply text.
+func (p *Planner) chat(sess TaskSession, prompt string, eventCh chan<- PlanEvent) (string, error) {
+	chatEvents, err := sess.ChatStream(context.Background(), prompt)
+	if err != nil {
+		return "", fmt.Errorf("chat error: %v", err)
+	}
+
+	var fullContent strings.Builder
+	var chatErr string
+	for ev := range chatEvents {
+		switch ev.Type {
+		case "content":
+			fullContent.WriteString(ev.Content)
+		case "thinking":
+			eventCh <- PlanEvent{Type: "thinking", Content: ev.Content}
+		case "tool_call":
+			eventCh <- PlanEvent{Type: "reading", Content: describeToolCall(ev.ToolName, ev.Content)}
+		case "error":
+			if chatErr == "" {
+				chatErr = ev.Error
+			}
+		}
+	}
+	if chatErr != "" {
+		return "", fmt.Errorf("%s", chatErr)
+	}
+	return fullContent.String(), nil
+}
+
+// describeToolCall renders a tool call as a short progress line, e.g. "read main.go".
+func describeToolCall(name, argsJSON string) string {
+	var args map[string]interface{}
+	json.Unmarshal([]byte(argsJSON), &args)
+	arg := func(key string) string {
+		v, _ := args[key].(string)
+		return v
+	}
+
+	var detail string
+	switch name {
+	case "read":
+		detail = arg("path")
+	case "list":
+		detail = arg("path")
+		if detail == "" {
+			detail = "."
+		}
+		if g := arg("glob"); g != "" {
+			detail += " " + g
+		}
+	case "search":
+		detail = fmt.Sprintf("%q", arg("pattern"))
+		if path := arg("path"); path != "" {
+			detail += " in " + path
+		}
+		if g := arg("glob"); g != "" {
+			detail += " (" + g + ")"
+		}
+	case "git":
+		detail = strings.TrimSpace(arg("subcommand") + " " + arg("args"))
+	}
+	if detail == "" {
+		return "调用工具: " + name
+	}
+	return name + " " + detail
+}
+
+// pathToken matches relative file paths such as internal/foo/bar.go or ./cmd/main.go.
+var pathToken = regexp.MustCompile(`(?:\./)?[A-Za-z0-9_.\-]+(?:/[A-Za-z0-9_.\-]+)+\.[A-Za-z0-9]{1,8}\b`)
+
+// missingPaths lists the file paths referenced in task prompts that do not
+// exist under workDir. Paths on a line that announces a new file are skipped.
+func missingPaths(plan *PlanResult, workDir string) []string {
+	seen := make(map[string]bool)
+	var missing []string
+	for _, t := range plan.Tasks {
+		for _, line := range strings.Split(t.Prompt, "\n") {
+			if isNewFileLine(line) {
+				continue
+			}
+			for _, m := range pathToken.FindAllStringIndex(line, -1) {
+				// Skip URLs and parts of longer tokens such as host/path
+				if m[0] > 0 && strings.ContainsRune(":/@", rune(line[m[0]-1])) {
+					continue
+				}
+				path := strings.TrimPrefix(line[m[0]:m[1]], "./")
+				if seen[path] {
+					continue
+				}
+				seen[path] = true
+				if _, err := os.Stat(filepath.Join(workDir, path)); err != nil {
+					missing = append(missing, path)
+				}
+			}
+		}
+	}
+	return missing
+}
+
+func isNewFileLine(line string) bool {
+	lower := strings.ToLower(line)
+	for _, kw := range []string{"新建", "新增", "创建", "create", "new file", "add a new"} {
+		if strings.Contains(lower, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeContext puts the exploration brief ahead of the planner's own shared context.
+func mergeContext(brief, context string) string {
+	context = strings.TrimSpace(context)
+	if context == "" || strings.Contains(brief, context) {
+		return brief
+	}
+	return brief + "\n\n## 补充说明\n\n" + context
 }
 
 // ParsePlan parses a JSON string into a PlanResult.
@@ -195,10 +442,10 @@ func (p *Planner) Synthesize(board *Board, ws *Workspace) (string, error) {
 	}
 
 	// Create temporary session for synthesis
-	sess := p.sessions.CreateTaskSession("synthesizer")
+	sess := p.sessions.CreateTaskSession("synthesizer", TaskSessionOptions{})
 	defer p.sessions.DeleteTaskSession(sess.GetID())
 
-	eventChan, err := sess.ChatStream(prompt.String())
+	eventChan, err := sess.ChatStream(context.Background(), prompt.String())
 	if err != nil {
 		return "", fmt.Errorf("synthesis chat: %w", err)
 	}
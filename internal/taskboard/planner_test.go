@@ -1,6 +1,10 @@
 package taskboard
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -106,3 +110,131 @@ func TestParsePlan(t *testing.T) {
 		t.Error("empty workspace name should fail")
 	}
 }
+
+// plannerSession replays one scripted turn per ChatStream call.
+type plannerSession struct {
+	id     string
+	turns  [][]SessionEvent
+	inputs []string
+}
+
+func (s *plannerSession) GetID() string        { return s.id }
+func (s *plannerSession) InjectContext(string) {}
+func (s *plannerSession) ChatStream(_ context.Context, input string) (<-chan SessionEvent, error) {
+	s.inputs = append(s.inputs, input)
+	ch := make(chan SessionEvent, 16)
+	if len(s.turns) > 0 {
+		for _, ev := range s.turns[0] {
+			ch <- ev
+		}
+		s.turns = s.turns[1:]
+	}
+	close(ch)
+	return ch, nil
+}
+
+type plannerSessions struct {
+	scripts  map[string][][]SessionEvent
+	sessions map[string]*plannerSession
+	opts     map[string]TaskSessionOptions
+}
+
+func (m *plannerSessions) CreateTaskSession(name string, opts TaskSessionOptions) TaskSession {
+	s := &plannerSession{id: name, turns: m.scripts[name]}
+	m.sessions[name] = s
+	m.opts[name] = opts
+	return s
+}
+
+func (m *plannerSessions) DeleteTaskSession(string) {}
+
+func reply(text string) []SessionEvent {
+	return []SessionEvent{{Type: "content", Content: text}}
+}
+
+func TestPlanExploresRepository(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "pkg"), 0755)
+	os.WriteFile(filepath.Join(dir, "pkg", "run.go"), []byte("package pkg\n"), 0644)
+
+	planWith := func(path string) string {
+		return `{"workspace_name":"ws","workspace_context":"Go project","tasks":[` +
+			`{"title":"edit","prompt":"Update ` + path + `"},` +
+			`{"title":"add","prompt":"新建 pkg/extra.go"}]}`
+	}
+	sessions := &plannerSessions{
+		scripts: map[string][][]SessionEvent{
+			"planner:explore": {{
+				{Type: "tool_call", ToolName: "list", Content: `{"path":"pkg"}`},
+				{Type: "tool_call", ToolName: "read", Content: `{"path":"pkg/run.go"}`},
+				{Type: "content", Content: "## 项目概况\npkg/run.go holds the runner"},
+			}},
+			"planner": {reply(planWith("pkg/runner.go")), reply(planWith("pkg/run.go"))},
+		},
+		sessions: map[string]*plannerSession{},
+		opts:     map[string]TaskSessionOptions{},
+	}
+
+	events, err := NewPlanner(sessions).Plan("improve the runner", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reading []string
+	var brief, planJSON string
+	for ev := range events {
+		switch ev.Type {
+		case "reading":
+			reading = append(reading, ev.Content)
+		case "brief":
+			brief = ev.Content
+		case "plan_ready":
+			planJSON = ev.PlanJSON
+		case "error":
+			t.Fatalf("unexpected error: %s", ev.Content)
+		}
+	}
+
+	if strings.Join(reading, "; ") != "list pkg; read pkg/run.go" {
+		t.Errorf("unexpected reading events %q", reading)
+	}
+	if !strings.Contains(brief, "pkg/run.go holds the runner") {
+		t.Errorf("unexpected brief %q", brief)
+	}
+	for _, name := range []string{"planner:explore", "planner"} {
+		if opts := sessions.opts[name]; !opts.ReadOnly || opts.WorkDir != dir {
+			t.Errorf("%s: expected read-only session in %s, got %+v", name, dir, opts)
+		}
+	}
+
+	planner := sessions.sessions["planner"]
+	if !strings.Contains(planner.inputs[0], brief) {
+		t.Error("decomposition prompt should include the brief")
+	}
+	if len(planner.inputs) != 2 || !strings.Contains(planner.inputs[1], "pkg/runner.go") {
+		t.Fatalf("expected a correction round naming the missing path, got %q", planner.inputs)
+	}
+
+	plan, err := ParsePlan(planJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Tasks[0].Prompt != "Update pkg/run.go" {
+		t.Errorf("expected corrected plan, got %q", plan.Tasks[0].Prompt)
+	}
+	if !strings.HasPrefix(plan.WorkspaceContext, brief) || !strings.Contains(plan.WorkspaceContext, "Go project") {
+		t.Errorf("expected brief as workspace context, got %q", plan.WorkspaceContext)
+	}
+}
+
+func TestMissingPaths(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "internal", "db"), 0755)
+	os.WriteFile(filepath.Join(dir, "internal", "db", "store.go"), nil, 0644)
+
+	plan := &PlanResult{Tasks: []PlannedTask{{Prompt: "Edit ./internal/db/store.go and internal/db/schema.sql.\n" +
+		"新建 internal/db/migrate.go\nSee https://example.com/docs/index.html"}}}
+	got := missingPaths(plan, dir)
+	if strings.Join(got, ",") != "internal/db/schema.sql" {
+		t.Errorf("missingPaths = %v", got)
+	}
+}
@@ -0,0 +1,372 @@
+package taskboard
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/BlakeLiAFK/kele/internal/cron"
+)
+
+// WorkspaceSchedule instantiates a template workspace on a cron schedule.
+// Each run clones the template's tasks into a fresh workspace; once that
+// workspace settles, its synthesized summary is reported to Channel.
+type WorkspaceSchedule struct {
+	ID              string
+	TemplateID      string // workspace in WorkspaceTemplate state whose tasks are cloned
+	Cron            string // 5-field cron expression, see cron.Parse
+	Channel         string // channel the run summary is sent to, e.g. "telegram"; empty = none
+	Target          string // recipient on Channel; empty uses the channel's default
+	Enabled         bool
+	LastRun         time.Time
+	NextRun         time.Time
+	LastWorkspaceID string // workspace created by the last run
+	LastError       string
+	LastSummary     string // summary of the last run, set once it has been reported
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// MakeTemplate turns a workspace into a template: its tasks are no longer
+// scheduled and serve as the blueprint for WorkspaceSchedule runs.
+func (b *Board) MakeTemplate(id string) (*Workspace, error) {
+	ws, err := b.store.GetWorkspace(id)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := b.store.CountByStatus(id)
+	if err != nil {
+		return nil, err
+	}
+	if counts.Running > 0 {
+		return nil, fmt.Errorf("workspace %s has %d running tasks", id, counts.Running)
+	}
+	if counts.Total() == 0 {
+		return nil, fmt.Errorf("workspace %s has no tasks to use as a template", id)
+	}
+	ws.Status = WorkspaceTemplate
+	if err := b.store.UpdateWorkspace(ws); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+// InstantiateTemplate clones a template workspace and its tasks into a new
+// active workspace. Run state (results, retries, branches) is not copied.
+func (b *Board) InstantiateTemplate(templateID, scheduleID string) (*Workspace, []*Task, error) {
+	tmpl, err := b.store.GetWorkspace(templateID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("template %s: %w", templateID, err)
+	}
+	if tmpl.Status != WorkspaceTemplate {
+		return nil, nil, fmt.Errorf("workspace %s is not a template", templateID)
+	}
+	tmplTasks, err := b.store.ListTasks(templateID, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	ws := &Workspace{
+		Name:          fmt.Sprintf("%s %s", tmpl.Name, now.Format("2006-01-02 15:04")),
+		Description:   tmpl.Description,
+		Goal:          tmpl.Goal,
+		MaxConcurrent: tmpl.MaxConcurrent,
+		Context:       tmpl.Context,
+		WorkDir:       tmpl.WorkDir,
+		MergePolicy:   tmpl.MergePolicy,
+		TaskTimeout:   tmpl.TaskTimeout,
+		ApprovalTags:  tmpl.ApprovalTags,
+		Budget:        tmpl.Budget,
+		ScheduleID:    scheduleID,
+	}
+	ws.ID = fmt.Sprintf("ws-%d", now.UnixNano())
+	if err := b.CreateWorkspace(ws); err != nil {
+		return nil, nil, err
+	}
+
+	ids := make(map[string]string, len(tmplTasks))
+	for i, t := range tmplTasks {
+		ids[t.ID] = fmt.Sprintf("%s-t%d", ws.ID, i+1)
+	}
+	tasks := make([]*Task, 0, len(tmplTasks))
+	for _, t := range tmplTasks {
+		deps := make([]string, 0, len(t.DependsOn))
+		for _, dep := range t.DependsOn {
+			if id, ok := ids[dep]; ok {
+				deps = append(deps, id)
+			}
+		}
+		status := StatusReady
+		if len(deps) > 0 {
+			status = StatusBacklog
+		}
+		clone := &Task{
+			ID:          ids[t.ID],
+			WorkspaceID: ws.ID,
+			Title:       t.Title,
+			Description: t.Description,
+			Prompt:      t.Prompt,
+			Status:      status,
+			Priority:    t.Priority,
+			MaxRetries:  t.MaxRetries,
+			Tags:        t.Tags,
+			DependsOn:   deps,
+			CreatedAt:   now,
+
+			VerifyCommand:    t.VerifyCommand,
+			VerifyPrompt:     t.VerifyPrompt,
+			Timeout:          t.Timeout,
+			RequiresApproval: t.RequiresApproval,
+			Model:            t.Model,
+			Temperature:      t.Temperature,
+			AllowedTools:     t.AllowedTools,
+			MaxToolRounds:    t.MaxToolRounds,
+			Artifacts:        t.Artifacts,
+		}
+		if err := b.store.CreateTask(clone); err != nil {
+			return nil, nil, fmt.Errorf("clone task %s: %w", t.ID, err)
+		}
+		tasks = append(tasks, clone)
+		b.broadcast(BoardEvent{
+			Type:        EventTaskCreated,
+			WorkspaceID: ws.ID,
+			TaskID:      clone.ID,
+			Detail:      clone.Title,
+			Timestamp:   now,
+		})
+	}
+
+	if b.scheduler != nil {
+		b.scheduler.Trigger()
+	}
+	return ws, tasks, nil
+}
+
+// CreateSchedule validates and stores a schedule for a template workspace.
+func (b *Board) CreateSchedule(sc *WorkspaceSchedule) error {
+	expr, err := cron.Parse(sc.Cron)
+	if err != nil {
+		return err
+	}
+	tmpl, err := b.store.GetWorkspace(sc.TemplateID)
+	if err != nil {
+		return fmt.Errorf("template %s: %w", sc.TemplateID, err)
+	}
+	if tmpl.Status != WorkspaceTemplate {
+		return fmt.Errorf("workspace %s is not a template, convert it first", sc.TemplateID)
+	}
+	now := time.Now()
+	if sc.ID == "" {
+		sc.ID = fmt.Sprintf("sch-%d", now.UnixNano())
+	}
+	sc.NextRun = expr.NextAfter(now)
+	sc.CreatedAt = now
+	sc.UpdatedAt = now
+	return b.store.CreateSchedule(sc)
+}
+
+// SetScheduleEnabled enables or disables a schedule. Re-enabling it skips
+// the runs that were missed while it was disabled.
+func (b *Board) SetScheduleEnabled(id string, enabled bool) (*WorkspaceSchedule, error) {
+	sc, err := b.store.GetSchedule(id)
+	if err != nil {
+		return nil, err
+	}
+	if enabled && !sc.Enabled {
+		if expr, err := cron.Parse(sc.Cron); err == nil {
+			sc.NextRun = expr.NextAfter(time.Now())
+		}
+	}
+	sc.Enabled = enabled
+	if err := b.store.UpdateSchedule(sc); err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+// RunSchedule instantiates the schedule's template now. A run is skipped
+// while the workspace of the previous run still has work in progress.
+func (b *Board) RunSchedule(id string) (*Workspace, error) {
+	sc, err := b.store.GetSchedule(id)
+	if err != nil {
+		return nil, err
+	}
+	return b.runSchedule(sc, time.Now())
+}
+
+func (b *Board) runSchedule(sc *WorkspaceSchedule, now time.Time) (*Workspace, error) {
+	sc.LastRun = now
+	if expr, err := cron.Parse(sc.Cron); err == nil {
+		sc.NextRun = expr.NextAfter(now)
+	}
+
+	var ws *Workspace
+	err := b.previousRunBusy(sc)
+	if err == nil {
+		ws, _, err = b.InstantiateTemplate(sc.TemplateID, sc.ID)
+	}
+	if err != nil {
+		sc.LastError = err.Error()
+	} else {
+		sc.LastError = ""
+		sc.LastWorkspaceID = ws.ID
+		sc.LastSummary = ""
+	}
+	if uerr := b.store.UpdateSchedule(sc); uerr != nil {
+		log.Printf("board: update schedule %s: %v", sc.ID, uerr)
+	}
+	return ws, err
+}
+
+func (b *Board) previousRunBusy(sc *WorkspaceSchedule) error {
+	if sc.LastWorkspaceID == "" {
+		return nil
+	}
+	counts, err := b.store.CountByStatus(sc.LastWorkspaceID)
+	if err != nil {
+		return nil
+	}
+	if !runSettled(counts) {
+		return fmt.Errorf("previous run %s is still in progress", sc.LastWorkspaceID)
+	}
+	return nil
+}
+
+// runSettled reports whether a workspace can make no further progress on its
+// own: nothing is queued, running or waiting for review. Blocked and failed
+// tasks need a human and do not keep a run open.
+func runSettled(c *StatusCounts) bool {
+	return c.Backlog == 0 && c.Ready == 0 && c.Running == 0 && c.Review == 0
+}
+
+// ReportFunc delivers the summary of a finished scheduled run to a channel.
+type ReportFunc func(channel, target, text string) error
+
+// Recurrer runs due workspace schedules and reports their results.
+type Recurrer struct {
+	board   *Board
+	planner *Planner   // synthesizes run summaries; nil reports task counts only
+	report  ReportFunc // nil disables reporting
+
+	mu        sync.Mutex
+	reporting map[string]bool // schedule IDs whose report is being prepared
+	reports   sync.WaitGroup  // reports in flight
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// NewRecurrer creates a recurrer for the board's schedules.
+func NewRecurrer(board *Board, planner *Planner, report ReportFunc) *Recurrer {
+	return &Recurrer{
+		board:     board,
+		planner:   planner,
+		report:    report,
+		reporting: make(map[string]bool),
+		stopCh:    make(chan struct{}),
+		doneCh:    make(chan struct{}),
+	}
+}
+
+// Start checks schedules once a minute until Stop is called.
+func (r *Recurrer) Start() {
+	go func() {
+		defer close(r.doneCh)
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.stopCh:
+				return
+			case now := <-ticker.C:
+				r.tick(now)
+			}
+		}
+	}()
+}
+
+// Stop ends the schedule loop and waits for reports in flight.
+func (r *Recurrer) Stop() {
+	close(r.stopCh)
+	<-r.doneCh
+	r.reports.Wait()
+}
+
+// tick starts the runs that are due and reports runs that have settled.
+// Reports are prepared in the background so a slow summary never holds up
+// a schedule that is due.
+func (r *Recurrer) tick(now time.Time) {
+	schedules, err := r.board.store.ListSchedules()
+	if err != nil {
+		log.Printf("recurrer: list schedules: %v", err)
+		return
+	}
+	for _, sc := range schedules {
+		if sc.LastWorkspaceID != "" && sc.LastSummary == "" {
+			r.reportIfSettled(*sc)
+		}
+		if !sc.Enabled || sc.NextRun.IsZero() || sc.NextRun.After(now) {
+			continue
+		}
+		if ws, err := r.board.runSchedule(sc, now); err != nil {
+			log.Printf("recurrer: schedule %s skipped: %v", sc.ID, err)
+		} else {
+			log.Printf("recurrer: schedule %s started workspace %s", sc.ID, ws.ID)
+		}
+	}
+}
+
+// reportIfSettled starts reporting the schedule's last run once it has
+// settled, unless a report for the schedule is already being prepared.
+func (r *Recurrer) reportIfSettled(sc WorkspaceSchedule) {
+	counts, err := r.board.store.CountByStatus(sc.LastWorkspaceID)
+	if err != nil || !runSettled(counts) {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.reporting[sc.ID] {
+		return
+	}
+	r.reporting[sc.ID] = true
+	r.reports.Add(1)
+	go func() {
+		defer r.reports.Done()
+		r.reportRun(&sc, counts)
+		r.mu.Lock()
+		delete(r.reporting, sc.ID)
+		r.mu.Unlock()
+	}()
+}
+
+// reportRun synthesizes the summary of the schedule's last run and sends it
+// to the schedule's channel. The summary is kept on the schedule so each run
+// is reported once, even if sending fails.
+func (r *Recurrer) reportRun(sc *WorkspaceSchedule, counts *StatusCounts) {
+	ws, err := r.board.store.GetWorkspace(sc.LastWorkspaceID)
+	if err != nil {
+		return
+	}
+	text := fmt.Sprintf("定时工作区 %s 运行结束：完成 %d，失败 %d，阻塞 %d", ws.Name, counts.Done, counts.Failed, counts.Blocked)
+	if r.planner != nil && counts.Done > 0 {
+		summary, err := r.planner.Synthesize(r.board, ws)
+		if err != nil {
+			log.Printf("recurrer: synthesize %s: %v", ws.ID, err)
+		} else if summary != "" {
+			text += "\n\n" + summary
+		}
+	}
+
+	var reportErr string
+	if r.report != nil && sc.Channel != "" {
+		if err := r.report(sc.Channel, sc.Target, text); err != nil {
+			reportErr = fmt.Sprintf("report to %s: %v", sc.Channel, err)
+		}
+	}
+	// Only the report fields: the schedule may have been edited or run again meanwhile
+	if err := r.board.store.SetScheduleReport(sc.ID, sc.LastWorkspaceID, text, reportErr); err != nil {
+		log.Printf("recurrer: update schedule %s: %v", sc.ID, err)
+	}
+}
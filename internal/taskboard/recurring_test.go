@@ -0,0 +1,179 @@
+package taskboard
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTemplate creates a template workspace with a two-task chain.
+func newTemplate(t *testing.T, board *Board) (*Workspace, *Task, *Task) {
+	t.Helper()
+	tmpl := &Workspace{Name: "dependency-audit", Context: "audit go.mod", MaxConcurrent: 1}
+	if err := board.CreateWorkspace(tmpl); err != nil {
+		t.Fatal(err)
+	}
+	scan := &Task{WorkspaceID: tmpl.ID, Title: "scan", Prompt: "list outdated modules", Model: "small"}
+	if err := board.CreateTask(scan); err != nil {
+		t.Fatal(err)
+	}
+	report := &Task{WorkspaceID: tmpl.ID, Title: "report", Prompt: "write the report", DependsOn: []string{scan.ID}}
+	if err := board.CreateTask(report); err != nil {
+		t.Fatal(err)
+	}
+	scan.Status = StatusDone
+	scan.Result = "stale result"
+	if err := board.Store().UpdateTask(scan); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := board.MakeTemplate(tmpl.ID); err != nil {
+		t.Fatal(err)
+	}
+	return tmpl, scan, report
+}
+
+func TestInstantiateTemplate(t *testing.T) {
+	store, cleanup := tempDB(t)
+	t.Cleanup(cleanup)
+	board := NewBoard(store)
+	tmpl, _, _ := newTemplate(t, board)
+
+	if err := board.ResumeWorkspace(tmpl.ID); err == nil {
+		t.Error("expected resuming a template to fail")
+	}
+
+	ws, tasks, err := board.InstantiateTemplate(tmpl.ID, "sch-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ws.Status != WorkspaceActive || ws.Context != "audit go.mod" || ws.ScheduleID != "sch-1" {
+		t.Errorf("unexpected workspace %+v", ws)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("expected 2 cloned tasks, got %d", len(tasks))
+	}
+	scan, report := tasks[0], tasks[1]
+	if scan.Status != StatusReady || scan.Result != "" || scan.Model != "small" {
+		t.Errorf("expected a fresh ready scan task, got %s %q %q", scan.Status, scan.Result, scan.Model)
+	}
+	if report.Status != StatusBacklog || len(report.DependsOn) != 1 || report.DependsOn[0] != scan.ID {
+		t.Errorf("expected report to depend on the cloned scan, got %s %v", report.Status, report.DependsOn)
+	}
+}
+
+func TestRecurrerRunsAndReports(t *testing.T) {
+	store, cleanup := tempDB(t)
+	t.Cleanup(cleanup)
+	board := NewBoard(store)
+	tmpl, _, _ := newTemplate(t, board)
+
+	sc := &WorkspaceSchedule{TemplateID: tmpl.ID, Cron: "0 9 * * 1", Channel: "telegram", Target: "42", Enabled: true}
+	if err := board.CreateSchedule(sc); err != nil {
+		t.Fatal(err)
+	}
+	if sc.NextRun.Weekday() != time.Monday || sc.NextRun.Hour() != 9 {
+		t.Errorf("unexpected next run %s", sc.NextRun)
+	}
+	if err := board.CreateSchedule(&WorkspaceSchedule{TemplateID: tmpl.ID, Cron: "bad"}); err == nil {
+		t.Error("expected an invalid cron expression to be rejected")
+	}
+
+	var reports []string
+	report := func(channel, target, text string) error {
+		reports = append(reports, channel+"/"+target+": "+text)
+		return nil
+	}
+	r := NewRecurrer(board, NewPlanner(&scriptedSessions{reply: "all modules current"}), report)
+
+	due := sc.NextRun
+	r.tick(due.Add(-time.Minute))
+	if got, _ := store.GetSchedule(sc.ID); got.LastWorkspaceID != "" {
+		t.Fatal("expected no run before the schedule is due")
+	}
+
+	r.tick(due)
+	got, _ := store.GetSchedule(sc.ID)
+	if got.LastWorkspaceID == "" || !got.NextRun.After(due) {
+		t.Fatalf("expected a run and the next one scheduled, got %+v", got)
+	}
+	runID := got.LastWorkspaceID
+
+	// Overlapping runs are skipped while the previous one has work left
+	got.NextRun = due
+	store.UpdateSchedule(got)
+	r.tick(due.Add(time.Minute))
+	got, _ = store.GetSchedule(sc.ID)
+	if got.LastWorkspaceID != runID || !strings.Contains(got.LastError, "still in progress") {
+		t.Errorf("expected the overlapping run to be skipped, got %q %q", got.LastWorkspaceID, got.LastError)
+	}
+	if len(reports) != 0 {
+		t.Fatalf("expected no report before the run settles, got %v", reports)
+	}
+
+	tasks, _ := store.ListTasks(runID, "")
+	for _, task := range tasks {
+		task.Status = StatusDone
+		task.Result = "ok"
+		store.UpdateTask(task)
+	}
+	got.NextRun = due.Add(7 * 24 * time.Hour)
+	store.UpdateSchedule(got)
+	r.tick(due.Add(2 * time.Minute))
+	r.reports.Wait()
+	r.tick(due.Add(3 * time.Minute))
+	r.reports.Wait()
+
+	if len(reports) != 1 || !strings.HasPrefix(reports[0], "telegram/42: ") || !strings.Contains(reports[0], "all modules current") {
+		t.Fatalf("expected one report with the summary, got %v", reports)
+	}
+	ws, _ := store.GetWorkspace(runID)
+	if ws.Summary != "all modules current" {
+		t.Errorf("expected the synthesized summary on the workspace, got %q", ws.Summary)
+	}
+}
+
+func TestSlowReportDoesNotDelaySchedules(t *testing.T) {
+	store, cleanup := tempDB(t)
+	t.Cleanup(cleanup)
+	board := NewBoard(store)
+	tmpl, _, _ := newTemplate(t, board)
+
+	// A settled run waiting for its report, and a schedule that is due
+	settled := &WorkspaceSchedule{TemplateID: tmpl.ID, Cron: "0 9 * * 1", Enabled: true}
+	due := &WorkspaceSchedule{TemplateID: tmpl.ID, Cron: "0 9 * * 2", Enabled: true}
+	for _, sc := range []*WorkspaceSchedule{settled, due} {
+		if err := board.CreateSchedule(sc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Now()
+	run, err := board.runSchedule(settled, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tasks, _ := store.ListTasks(run.ID, "")
+	for _, task := range tasks {
+		task.Status = StatusDone
+		store.UpdateTask(task)
+	}
+	due.NextRun = now
+	store.UpdateSchedule(due)
+
+	sessions := &blockingSessions{started: make(chan struct{}), finish: make(chan struct{})}
+	r := NewRecurrer(board, NewPlanner(sessions), nil)
+	r.tick(now)
+	<-sessions.started
+
+	if got, _ := store.GetSchedule(due.ID); got.LastWorkspaceID == "" {
+		t.Error("the due schedule must start while the other run's summary is being written")
+	}
+	// A second tick does not start another report for the same run
+	r.tick(now.Add(time.Minute))
+
+	close(sessions.finish)
+	r.reports.Wait()
+	got, _ := store.GetSchedule(settled.ID)
+	if !strings.Contains(got.LastSummary, "finished") || got.NextRun.IsZero() {
+		t.Errorf("expected the summary stored without touching the schedule, got %+v", got)
+	}
+}
@@ -0,0 +1,182 @@
+package taskboard
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrorClass tells whether a failed run is worth repeating as it was.
+type ErrorClass string
+
+const (
+	ErrorTransient ErrorClass = "transient" // provider or network trouble; the same attempt may succeed later
+	ErrorTask      ErrorClass = "task"      // the run itself went wrong: failed checks, limits, timeouts
+)
+
+// Retry defaults: the first retry waits 30s, each further one twice as long.
+const (
+	DefaultRetryBackoff        = 30 * time.Second
+	DefaultMaxRetryBackoff     = 30 * time.Minute
+	DefaultMaxTransientRetries = 5
+)
+
+// transientMarkers are lower-case substrings of provider errors that say
+// nothing about the task: rate limits, server errors and network failures.
+var transientMarkers = []string{
+	"频率超限", "服务暂时不可用", "overloaded",
+	"网络错误", "connection", "timeout", "deadline exceeded", "eof", "重试 3 次后仍失败",
+}
+
+// transientStatus matches a 429 or 5xx HTTP status in a provider error, e.g.
+// "(HTTP 504)" or "status 503"; bare numbers such as token counts don't count.
+var transientStatus = regexp.MustCompile(`(?i)\b(?:http|status(?: code)?)[\s:=]*(?:429|5\d\d)\b`)
+
+// classifyError sorts a run error into transient provider trouble and
+// errors caused by the task itself.
+func classifyError(err error) ErrorClass {
+	if !errors.Is(err, errLLMFailed) {
+		return ErrorTask
+	}
+	msg := strings.ToLower(err.Error())
+	for _, m := range transientMarkers {
+		if strings.Contains(msg, m) {
+			return ErrorTransient
+		}
+	}
+	if transientStatus.MatchString(msg) {
+		return ErrorTransient
+	}
+	return ErrorTask
+}
+
+// retryDelay is the backoff before the given retry (1-based).
+func (o SchedulerOptions) retryDelay(attempt int) time.Duration {
+	if o.RetryBackoff <= 0 || attempt <= 0 {
+		return 0
+	}
+	d := o.RetryBackoff
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if o.MaxRetryBackoff > 0 && d >= o.MaxRetryBackoff {
+			return o.MaxRetryBackoff
+		}
+	}
+	return d
+}
+
+// recordFailure decides what happens to a task after a failed run.
+// Transient errors are retried with backoff without using up MaxRetries, up
+// to MaxTransientRetries times; other errors use a retry and back off too.
+func (s *Scheduler) recordFailure(task *Task, err error, now time.Time) {
+	opts := s.Options()
+	task.ErrorClass = classifyError(err)
+	s.board.Store().AppendTaskLog(task.ID, "error", fmt.Sprintf("%s error: %s", task.ErrorClass, err.Error()), "")
+
+	if task.ErrorClass == ErrorTransient && task.TransientRetries < opts.MaxTransientRetries {
+		task.TransientRetries++
+		task.Status = StatusReady
+		task.Error = fmt.Sprintf("transient error %d: %s", task.TransientRetries, err.Error())
+		task.StartedAt = time.Time{}
+		task.NextAttemptAt = now.Add(opts.retryDelay(task.TransientRetries))
+		return
+	}
+
+	task.Status = StatusFailed
+	task.Error = err.Error()
+	task.CompletedAt = now
+	task.RetryCount++
+	if task.RetryCount < task.MaxRetries {
+		task.Status = StatusReady
+		task.Error = fmt.Sprintf("retry %d: %s", task.RetryCount, err.Error())
+		task.CompletedAt = time.Time{}
+		task.NextAttemptAt = now.Add(opts.retryDelay(task.RetryCount))
+	}
+}
+
+// maxAttemptSummary caps the summary of a previous attempt in a retry prompt.
+const maxAttemptSummary = 3000
+
+// writeRetryNotes tells a retried task why the previous attempt failed and
+// what it did, so the agent does not repeat the same mistake.
+func (s *Scheduler) writeRetryNotes(b *strings.Builder, task *Task) {
+	if task.Error == "" || task.RetryCount+task.TransientRetries == 0 {
+		return
+	}
+	b.WriteString("\n\n---\n\n## 上次执行失败\n\n")
+	b.WriteString(truncateResult(task.Error, 500))
+	if summary := s.attemptSummary(task.ID); summary != "" {
+		b.WriteString("\n\n上次执行过程:\n")
+		b.WriteString(summary)
+	}
+	if task.ErrorClass == ErrorTransient {
+		b.WriteString("\n\n上次执行因临时故障中断，可在已完成步骤的基础上继续。")
+	} else {
+		b.WriteString("\n\n请先分析失败原因，换一种做法，不要重复同样的错误。")
+	}
+}
+
+// attemptSummary condenses the log of the task's last attempt: the tools it
+// called, errors and checks, and the tail of its final answer.
+func (s *Scheduler) attemptSummary(taskID string) string {
+	logs, err := s.board.Store().GetTaskLogTail(taskID, 300)
+	if err != nil {
+		return ""
+	}
+	start := 0
+	for i := len(logs) - 1; i >= 0; i-- {
+		if logs[i].EventType == "attempt" {
+			start = i + 1
+			break
+		}
+	}
+
+	var lines []string
+	var content strings.Builder
+	for _, l := range logs[start:] {
+		switch l.EventType {
+		case "content":
+			content.WriteString(l.Content)
+		case "tool_call":
+			lines = append(lines, fmt.Sprintf("- 调用 %s", l.ToolName))
+		case "tool_result":
+			if n := len(lines); n > 0 && strings.HasPrefix(lines[n-1], "- 调用 "+l.ToolName) {
+				lines[n-1] += " → " + oneLine(l.Content, 160)
+			}
+		case "error", "verify", "verdict":
+			lines = append(lines, fmt.Sprintf("- [%s] %s", l.EventType, oneLine(l.Content, 300)))
+		}
+	}
+	if text := strings.TrimSpace(content.String()); text != "" {
+		if tail := lastRunes(text, 500); tail != text {
+			text = "..." + tail
+		}
+		lines = append(lines, "- 最终输出: "+oneLine(text, 600))
+	}
+
+	summary := strings.Join(lines, "\n")
+	if tail := lastRunes(summary, maxAttemptSummary); tail != summary {
+		summary = "...\n" + tail
+	}
+	return summary
+}
+
+// oneLine flattens s to a single line of at most max characters.
+func oneLine(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if runes := []rune(s); len(runes) > max {
+		return string(runes[:max]) + "..."
+	}
+	return s
+}
+
+// lastRunes returns the last n characters of s.
+func lastRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[len(runes)-n:])
+}
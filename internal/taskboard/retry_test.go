@@ -0,0 +1,151 @@
+package taskboard
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestClassifyError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want ErrorClass
+	}{
+		{fmt.Errorf("%w: 请求频率超限: 请稍后重试", errLLMFailed), ErrorTransient},
+		{fmt.Errorf("%w: 服务暂时不可用 (HTTP 503): 请稍后重试", errLLMFailed), ErrorTransient},
+		{fmt.Errorf("%w: API 错误 (HTTP 504): gateway timed out", errLLMFailed), ErrorTransient},
+		{fmt.Errorf("%w: upstream returned status 502 Bad Gateway", errLLMFailed), ErrorTransient},
+		{fmt.Errorf("%w: status code: 429", errLLMFailed), ErrorTransient},
+		{fmt.Errorf("%w: read tcp 10.0.0.2:443: Connection reset by peer", errLLMFailed), ErrorTransient},
+		{fmt.Errorf("%w: context deadline exceeded (Client.Timeout exceeded while awaiting headers)", errLLMFailed), ErrorTransient},
+		{fmt.Errorf("%w: unexpected EOF", errLLMFailed), ErrorTransient},
+		{fmt.Errorf("%w: 认证失败: API Key 无效或已过期", errLLMFailed), ErrorTask},
+		{fmt.Errorf("%w: API 错误 (HTTP 400): prompt is 503 tokens over the limit", errLLMFailed), ErrorTask},
+		{fmt.Errorf("%w: 模型不存在: 请检查模型名称是否正确。model gpt-500-turbo", errLLMFailed), ErrorTask},
+		{fmt.Errorf("%w: API 错误 (HTTP 422): max_tokens must be <= 4290, got 5000", errLLMFailed), ErrorTask},
+		{errors.New("verification failed: exit status 1"), ErrorTask},
+		{errors.New("timed out after 1m0s"), ErrorTask},
+	}
+	for _, c := range cases {
+		if got := classifyError(c.err); got != c.want {
+			t.Errorf("%v: expected %s, got %s", c.err, c.want, got)
+		}
+	}
+}
+
+func TestOneLineKeepsUTF8(t *testing.T) {
+	s := strings.Repeat("写入文件失败，", 100)
+	for _, out := range []string{oneLine(s, 161), lastRunes(s, 499)} {
+		if !utf8.ValidString(out) {
+			t.Errorf("truncation split a character: %q", out)
+		}
+	}
+	if got := oneLine("数据库\n 迁移", 4); got != "数据库 ..." {
+		t.Errorf("oneLine should count characters, got %q", got)
+	}
+	if got := lastRunes("数据库迁移", 2); got != "迁移" {
+		t.Errorf("lastRunes should count characters, got %q", got)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	o := SchedulerOptions{RetryBackoff: 30 * time.Second, MaxRetryBackoff: 5 * time.Minute}
+	want := map[int]time.Duration{1: 30 * time.Second, 2: time.Minute, 4: 4 * time.Minute, 5: 5 * time.Minute, 40: 5 * time.Minute}
+	for attempt, d := range want {
+		if got := o.retryDelay(attempt); got != d {
+			t.Errorf("attempt %d: expected %s, got %s", attempt, d, got)
+		}
+	}
+	if got := (SchedulerOptions{}).retryDelay(3); got != 0 {
+		t.Errorf("expected immediate retry without backoff, got %s", got)
+	}
+}
+
+func runFailing(t *testing.T, events []SessionEvent, maxRetries int) (*Scheduler, *Workspace, *Task) {
+	t.Helper()
+	store, cleanup := tempDB(t)
+	t.Cleanup(cleanup)
+	board := NewBoard(store)
+	s := NewScheduler(board, &usageSessions{events: events})
+	board.SetScheduler(s)
+
+	ws := &Workspace{Name: "retry"}
+	if err := board.CreateWorkspace(ws); err != nil {
+		t.Fatal(err)
+	}
+	task := &Task{WorkspaceID: ws.ID, Title: "flaky", Prompt: "fix the build", MaxRetries: maxRetries, Status: StatusReady}
+	if err := board.CreateTask(task); err != nil {
+		t.Fatal(err)
+	}
+	return s, ws, task
+}
+
+func waitSettled(t *testing.T, s *Scheduler, id string) *Task {
+	t.Helper()
+	waitFor(t, "the run to finish", func() bool {
+		got, _ := s.board.GetTask(id)
+		return got != nil && got.Status != StatusRunning
+	})
+	got, _ := s.board.GetTask(id)
+	return got
+}
+
+func TestTaskErrorBacksOffWithSummary(t *testing.T) {
+	events := []SessionEvent{
+		{Type: "tool_call", ToolName: "bash"},
+		{Type: "tool_result", ToolName: "bash", ToolResult: "go: permission denied"},
+		{Type: "content", Content: "I could not write the file"},
+		{Type: "error", Error: "model refused the request"},
+	}
+	s, ws, task := runFailing(t, events, 2)
+
+	before := time.Now()
+	s.executeTask(ws, task)
+	got := waitSettled(t, s, task.ID)
+	if got.Status != StatusReady || got.RetryCount != 1 || got.ErrorClass != ErrorTask {
+		t.Fatalf("expected a counted retry, got %s (retries %d, class %s)", got.Status, got.RetryCount, got.ErrorClass)
+	}
+	if wait := got.NextAttemptAt.Sub(before); wait < DefaultRetryBackoff || wait > DefaultRetryBackoff+5*time.Second {
+		t.Errorf("expected the retry %s out, got %s", DefaultRetryBackoff, wait)
+	}
+
+	// The scheduler leaves the task alone until its backoff has passed
+	s.runScheduleCycle()
+	if again, _ := s.board.GetTask(task.ID); again.Status != StatusReady {
+		t.Errorf("expected the task to wait out its backoff, got %s", again.Status)
+	}
+
+	prompt := s.buildTaskPrompt(got)
+	for _, want := range []string{"上次执行失败", "model refused the request", "调用 bash → go: permission denied", "I could not write the file"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("expected %q in the retry prompt:\n%s", want, prompt)
+		}
+	}
+}
+
+func TestTransientErrorKeepsRetries(t *testing.T) {
+	s, ws, task := runFailing(t, []SessionEvent{{Type: "error", Error: "请求频率超限: 请稍后重试"}}, 1)
+	opts := s.Options()
+	opts.MaxTransientRetries = 1
+	s.SetOptions(opts)
+
+	s.executeTask(ws, task)
+	got := waitSettled(t, s, task.ID)
+	if got.Status != StatusReady || got.RetryCount != 0 || got.TransientRetries != 1 || got.ErrorClass != ErrorTransient {
+		t.Fatalf("expected a free transient retry, got %s (retries %d, transient %d, class %s)",
+			got.Status, got.RetryCount, got.TransientRetries, got.ErrorClass)
+	}
+	if !strings.Contains(s.buildTaskPrompt(got), "临时故障") {
+		t.Error("expected the retry prompt to mention the transient failure")
+	}
+
+	// Once the transient retries are used up the error counts
+	s.executeTask(ws, got)
+	got = waitSettled(t, s, task.ID)
+	if got.Status != StatusFailed || got.RetryCount != 1 {
+		t.Errorf("expected the task to fail, got %s (retries %d)", got.Status, got.RetryCount)
+	}
+}
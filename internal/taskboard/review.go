@@ -0,0 +1,122 @@
+package taskboard
+
+import (
+	"fmt"
+	"time"
+)
+
+// NeedsApproval reports whether a finished run of t must wait in review:
+// either the task asks for it or it carries one of the workspace's approval tags.
+func (t *Task) NeedsApproval(ws *Workspace) bool {
+	if t.RequiresApproval {
+		return true
+	}
+	for _, tag := range t.Tags {
+		for _, at := range ws.ApprovalTags {
+			if tag == at {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ApproveTask accepts a task waiting in review. The task becomes done, its
+// branch is merged under the auto merge policy and its dependents are released.
+func (b *Board) ApproveTask(id, feedback string) (*Task, error) {
+	t, ws, err := b.reviewedTask(id)
+	if err != nil {
+		return nil, err
+	}
+	t.Status = StatusDone
+	t.ReviewFeedback = ""
+	if err := b.leaveReview(t); err != nil {
+		return nil, err
+	}
+	b.store.AppendTaskLog(t.ID, "review", reviewNote("approved", feedback), "")
+	b.broadcast(BoardEvent{
+		Type:        EventTaskApproved,
+		WorkspaceID: ws.ID,
+		TaskID:      t.ID,
+		Detail:      t.Title,
+		Timestamp:   time.Now(),
+	})
+
+	if t.MergeStatus == MergePending && ws.MergePolicy == MergeAuto {
+		if err := b.mergeTask(ws, t); err != nil {
+			b.store.AppendTaskLog(t.ID, "error", fmt.Sprintf("auto merge after approval: %v", err), "")
+		}
+	}
+	b.OnTaskFinished(ws, t)
+	if b.scheduler != nil {
+		b.scheduler.Trigger()
+	}
+	return t, nil
+}
+
+// RejectTask sends a task waiting in review back to ready. The feedback is
+// kept on the task and injected into the prompt of its next attempt, which
+// continues on the same branch.
+func (b *Board) RejectTask(id, feedback string) (*Task, error) {
+	t, ws, err := b.reviewedTask(id)
+	if err != nil {
+		return nil, err
+	}
+	t.Status = StatusReady
+	t.ReviewFeedback = feedback
+	t.MergeStatus = MergeNone
+	t.AssignedSession = ""
+	t.StartedAt = time.Time{}
+	t.CompletedAt = time.Time{}
+	if err := b.leaveReview(t); err != nil {
+		return nil, err
+	}
+	b.store.AppendTaskLog(t.ID, "review", reviewNote("rejected", feedback), "")
+	b.broadcast(BoardEvent{
+		Type:        EventTaskRejected,
+		WorkspaceID: ws.ID,
+		TaskID:      t.ID,
+		Detail:      feedback,
+		Timestamp:   time.Now(),
+	})
+	if b.scheduler != nil {
+		b.scheduler.Trigger()
+	}
+	return t, nil
+}
+
+// reviewedTask loads a task that is waiting in review, with its workspace.
+func (b *Board) reviewedTask(id string) (*Task, *Workspace, error) {
+	t, err := b.store.GetTask(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if t.Status != StatusReview {
+		return nil, nil, fmt.Errorf("task %s is in %s state, can only review tasks in review", id, t.Status)
+	}
+	ws, err := b.store.GetWorkspace(t.WorkspaceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, ws, nil
+}
+
+// leaveReview persists the decided task unless it left review meanwhile,
+// e.g. through CancelTask or a concurrent approve/reject.
+func (b *Board) leaveReview(t *Task) error {
+	ok, err := b.store.UpdateTaskFrom(t, StatusReview)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("task %s is no longer in review", t.ID)
+	}
+	return nil
+}
+
+func reviewNote(decision, feedback string) string {
+	if feedback == "" {
+		return decision
+	}
+	return decision + ": " + feedback
+}
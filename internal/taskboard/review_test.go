@@ -0,0 +1,149 @@
+package taskboard
+
+import (
+	"strings"
+	"testing"
+)
+
+// newReviewBoard returns a scheduler over a workspace that gates "risky"
+// tasks, with a risky task and a task depending on it.
+func newReviewBoard(t *testing.T) (*Scheduler, *Workspace, *Task, *Task) {
+	t.Helper()
+	store, cleanup := tempDB(t)
+	t.Cleanup(cleanup)
+	board := NewBoard(store)
+	s := NewScheduler(board, &scriptedSessions{reply: "migration written"})
+	board.SetScheduler(s)
+
+	ws := &Workspace{Name: "review", ApprovalTags: []string{"risky"}}
+	if err := board.CreateWorkspace(ws); err != nil {
+		t.Fatal(err)
+	}
+	gated := &Task{WorkspaceID: ws.ID, Title: "migrate", Prompt: "write the migration", MaxRetries: 1, Tags: []string{"db", "risky"}}
+	if err := board.CreateTask(gated); err != nil {
+		t.Fatal(err)
+	}
+	next := &Task{WorkspaceID: ws.ID, Title: "deploy", Prompt: "deploy", DependsOn: []string{gated.ID}}
+	if err := board.CreateTask(next); err != nil {
+		t.Fatal(err)
+	}
+	return s, ws, gated, next
+}
+
+// runToReview executes the task and waits until it is held in review.
+func runToReview(t *testing.T, s *Scheduler, ws *Workspace, task *Task) {
+	t.Helper()
+	s.executeTask(ws, task)
+	waitFor(t, "the task to reach review", func() bool {
+		got, _ := s.board.GetTask(task.ID)
+		return got != nil && got.Status == StatusReview
+	})
+}
+
+func TestNeedsApproval(t *testing.T) {
+	ws := &Workspace{ApprovalTags: []string{"prod"}}
+	cases := []struct {
+		task *Task
+		want bool
+	}{
+		{&Task{}, false},
+		{&Task{RequiresApproval: true}, true},
+		{&Task{Tags: []string{"docs", "prod"}}, true},
+		{&Task{Tags: []string{"docs"}}, false},
+	}
+	for i, c := range cases {
+		if got := c.task.NeedsApproval(ws); got != c.want {
+			t.Errorf("case %d: expected %v, got %v", i, c.want, got)
+		}
+	}
+}
+
+func TestApproveTaskReleasesDependents(t *testing.T) {
+	s, ws, gated, next := newReviewBoard(t)
+	runToReview(t, s, ws, gated)
+
+	if got, _ := s.board.GetTask(next.ID); got.Status != StatusBacklog {
+		t.Fatalf("expected dependent to wait while in review, got %s", got.Status)
+	}
+	if _, err := s.board.ApproveTask(next.ID, ""); err == nil {
+		t.Error("expected approving a task not in review to fail")
+	}
+
+	if _, err := s.board.ApproveTask(gated.ID, "looks good"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.board.GetTask(gated.ID); got.Status != StatusDone || got.Result != "migration written" {
+		t.Errorf("expected approved task done with its result, got %s %q", got.Status, got.Result)
+	}
+	if got, _ := s.board.GetTask(next.ID); got.Status != StatusReady {
+		t.Errorf("expected dependent to be released, got %s", got.Status)
+	}
+}
+
+func TestRejectTaskFeedsNextAttempt(t *testing.T) {
+	s, ws, gated, _ := newReviewBoard(t)
+	runToReview(t, s, ws, gated)
+
+	if _, err := s.board.RejectTask(gated.ID, "keep the old column"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.board.GetTask(gated.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != StatusReady || got.ReviewFeedback != "keep the old column" {
+		t.Fatalf("expected rejected task ready with feedback, got %s %q", got.Status, got.ReviewFeedback)
+	}
+	if prompt := s.buildTaskPrompt(got); !strings.Contains(prompt, "keep the old column") {
+		t.Errorf("expected feedback in the next prompt, got:\n%s", prompt)
+	}
+
+	logs, _ := s.board.Store().GetTaskLog(gated.ID, 0)
+	last := logs[len(logs)-1]
+	if last.EventType != "review" || last.Content != "rejected: keep the old column" {
+		t.Errorf("unexpected review log %s %q", last.EventType, last.Content)
+	}
+}
+
+func TestReviewDecisionsRace(t *testing.T) {
+	decide := map[string]func(b *Board, id string) error{
+		"approve": func(b *Board, id string) error { _, err := b.ApproveTask(id, ""); return err },
+		"reject":  func(b *Board, id string) error { _, err := b.RejectTask(id, "redo"); return err },
+		"cancel":  func(b *Board, id string) error { _, err := b.CancelTask(id); return err },
+	}
+	final := map[string]TaskStatus{"approve": StatusDone, "reject": StatusReady, "cancel": StatusCancelled}
+
+	// Each pair excludes the other: whichever lands second must fail
+	// instead of overwriting the first
+	for _, pair := range [][2]string{{"approve", "cancel"}, {"approve", "reject"}, {"approve", "approve"}, {"reject", "reject"}} {
+		for i := 0; i < 10; i++ {
+			s, ws, gated, _ := newReviewBoard(t)
+			runToReview(t, s, ws, gated)
+
+			type outcome struct {
+				name string
+				err  error
+			}
+			results := make(chan outcome, 2)
+			for _, name := range pair {
+				go func(name string) { results <- outcome{name, decide[name](s.board, gated.ID)} }(name)
+			}
+			a, b := <-results, <-results
+			if (a.err == nil) == (b.err == nil) {
+				t.Fatalf("%s vs %s: exactly one decision must win, got %v and %v", a.name, b.name, a.err, b.err)
+			}
+			winner := a.name
+			if a.err != nil {
+				winner = b.name
+			}
+
+			got, err := s.board.GetTask(gated.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Status != final[winner] {
+				t.Fatalf("%s vs %s: %s won but the task is %s", a.name, b.name, winner, got.Status)
+			}
+		}
+	}
+}
@@ -1,31 +1,48 @@
 package taskboard
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 	"time"
 )
 
 // SessionEvent mirrors daemon.ChatEvent for cross-package usage.
 type SessionEvent struct {
-	Type       string // content, thinking, tool_call, tool_result, error, done
-	Content    string
+	Type       string // content, thinking, tool_call, tool_result, usage, error, done
+	Content    string // text for content/thinking; JSON arguments for tool_call
 	ToolName   string
 	ToolResult string
 	Error      string
+	Tokens     int // usage: estimated tokens consumed by one LLM round
 }
 
 // TaskSession is the interface a session must satisfy for task execution.
 type TaskSession interface {
 	GetID() string
 	InjectContext(ctx string)
-	ChatStream(input string) (<-chan SessionEvent, error)
+	// ChatStream runs input through the agent loop. Cancelling ctx must abort
+	// the LLM request and any tool processes and close the event channel.
+	ChatStream(ctx context.Context, input string) (<-chan SessionEvent, error)
+}
+
+// TaskSessionOptions customizes a task session.
+type TaskSessionOptions struct {
+	WorkDir       string   // tool working directory; empty uses the daemon's default
+	ReadOnly      bool     // only expose tools that cannot modify the working directory
+	Model         string   // model name or tier ("small"/"large"); empty uses the daemon's model
+	Temperature   *float64 // sampling temperature; nil uses the configured one
+	AllowedTools  []string // tool whitelist; empty exposes every tool
+	MaxToolRounds int      // tool call rounds per chat turn; 0 uses the configured limit
+	WorkspaceID   string   // workspace the session works for; scopes its memories
 }
 
 // TaskSessionManager creates and destroys sessions for task execution.
 type TaskSessionManager interface {
-	CreateTaskSession(name string) TaskSession
+	CreateTaskSession(name string, opts TaskSessionOptions) TaskSession
 	DeleteTaskSession(id string)
 }
 
@@ -37,6 +54,17 @@ type Scheduler struct {
 	triggerCh chan struct{}
 	stopCh    chan struct{}
 	doneCh    chan struct{}
+
+	mu          sync.Mutex
+	running     map[string]*runHandle // task ID → handle of its in-flight run
+	opts        SchedulerOptions
+	throttleLog map[string]time.Time // model → logged end of its provider's rate limit
+}
+
+// runHandle lets CancelTask and timeouts stop a task's in-flight run.
+type runHandle struct {
+	cancel    context.CancelFunc
+	cancelled bool // set by stopTask; the run's outcome is then discarded
 }
 
 // NewScheduler creates a scheduler linked to the given board.
@@ -47,6 +75,15 @@ func NewScheduler(board *Board, sessions TaskSessionManager) *Scheduler {
 		triggerCh: make(chan struct{}, 1),
 		stopCh:    make(chan struct{}),
 		doneCh:    make(chan struct{}),
+		running:   make(map[string]*runHandle),
+		opts: SchedulerOptions{
+			AgingInterval:       DefaultAgingInterval,
+			RetryBackoff:        DefaultRetryBackoff,
+			MaxRetryBackoff:     DefaultMaxRetryBackoff,
+			MaxTransientRetries: DefaultMaxTransientRetries,
+		},
+
+		throttleLog: make(map[string]time.Time),
 	}
 }
 
@@ -61,6 +98,33 @@ func (s *Scheduler) Stop() {
 	<-s.doneCh
 }
 
+// stopTask aborts the in-flight run of a task, if any. The run's session
+// stream and tool processes are cancelled and its result is not recorded.
+func (s *Scheduler) stopTask(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h, ok := s.running[id]
+	if !ok {
+		return false
+	}
+	h.cancelled = true
+	h.cancel()
+	return true
+}
+
+// release drops the handle of a finished run and reports whether it was stopped.
+func (s *Scheduler) release(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h, ok := s.running[id]
+	if !ok {
+		return false
+	}
+	delete(s.running, id)
+	h.cancel()
+	return h.cancelled
+}
+
 // Trigger wakes up the scheduler to check for ready tasks.
 func (s *Scheduler) Trigger() {
 	select {
@@ -85,17 +149,40 @@ func (s *Scheduler) scheduleLoop() {
 	}
 }
 
+// runScheduleCycle starts ready tasks while there is capacity. Slots under
+// the global limit are shared fairly between workspaces, and within a
+// workspace tasks run by aged priority.
 func (s *Scheduler) runScheduleCycle() {
 	workspaces, err := s.board.Store().ListWorkspaces()
 	if err != nil {
 		log.Printf("scheduler: list workspaces error: %v", err)
 		return
 	}
+	opts := s.Options()
+	now := time.Now()
 
+	free := -1
+	if opts.MaxConcurrent > 0 {
+		running, err := s.board.Store().CountRunning()
+		if err != nil {
+			log.Printf("scheduler: count running tasks error: %v", err)
+			return
+		}
+		free = max(opts.MaxConcurrent-running, 0)
+	}
+
+	var queues []*workspaceQueue
 	for _, ws := range workspaces {
 		if ws.Status != WorkspaceActive {
 			continue
 		}
+		if ws.Budget.Exceeded(ws.Usage, now) != "" {
+			s.board.enforceBudget(ws.ID)
+			continue
+		}
+		if free == 0 {
+			continue
+		}
 
 		counts, err := s.board.Store().CountByStatus(ws.ID)
 		if err != nil {
@@ -106,15 +193,21 @@ func (s *Scheduler) runScheduleCycle() {
 			continue
 		}
 
-		slots := ws.MaxConcurrent - counts.Running
-		readyTasks, err := s.board.Store().GetReadyTasks(ws.ID, slots)
+		// All ready tasks are loaded so aging can reorder them
+		readyTasks, err := s.board.Store().GetReadyTasks(ws.ID, -1)
 		if err != nil {
 			continue
 		}
+		queues = append(queues, &workspaceQueue{
+			ws:      ws,
+			running: counts.Running,
+			slots:   ws.MaxConcurrent - counts.Running,
+			tasks:   s.dispatchable(readyTasks, opts, now),
+		})
+	}
 
-		for _, task := range readyTasks {
-			s.executeTask(ws, task)
-		}
+	for _, d := range pickFair(queues, free, opts.AgingInterval, now) {
+		s.executeTask(d.ws, d.task)
 	}
 }
 
@@ -129,6 +222,9 @@ func (s *Scheduler) executeTask(ws *Workspace, task *Task) {
 		log.Printf("scheduler: update task %s error: %v", task.ID, err)
 		return
 	}
+	s.board.Store().MarkWorkspaceStarted(ws.ID, task.StartedAt)
+	// Marks where this attempt's log starts, for the summary in a retry
+	s.board.Store().AppendTaskLog(task.ID, "attempt", fmt.Sprintf("attempt %d", task.RetryCount+task.TransientRetries+1), "")
 	s.board.broadcast(BoardEvent{
 		Type:        EventTaskStarted,
 		WorkspaceID: ws.ID,
@@ -137,43 +233,96 @@ func (s *Scheduler) executeTask(ws *Workspace, task *Task) {
 		Timestamp:   task.StartedAt,
 	})
 
+	// The run is bound to a context so CancelTask and the timeout can stop it
+	timeout := task.Timeout
+	if timeout <= 0 {
+		timeout = ws.TaskTimeout
+	}
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+	s.mu.Lock()
+	s.running[task.ID] = &runHandle{cancel: cancel}
+	s.mu.Unlock()
+
 	// Execute asynchronously
 	go func() {
-		result, err := s.runTaskSession(ws, task, prompt)
+		result, err := s.runTaskSession(ctx, ws, task, prompt)
 		now := time.Now()
 
-		if err != nil {
-			task.Status = StatusFailed
+		if s.release(task.ID) {
+			// Cancelled: CancelTask already recorded the final state
+			s.board.Store().AppendTaskLog(task.ID, "cancelled", "run aborted by cancel", "")
+			s.Trigger()
+			return
+		}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %s", timeout)
+		}
+
+		// Announced only once the outcome is persisted, so listeners that
+		// re-read the task see its final status and result
+		var event *BoardEvent
+		if errors.Is(err, errBudgetExceeded) {
+			// Not the task's fault: requeue it without using up a retry,
+			// it runs again once the workspace is resumed
+			task.Status = StatusReady
 			task.Error = err.Error()
-			task.CompletedAt = now
-			task.RetryCount++
-			if task.RetryCount < task.MaxRetries {
-				task.Status = StatusReady
-				task.Error = fmt.Sprintf("retry %d: %s", task.RetryCount, err.Error())
-				task.CompletedAt = time.Time{}
-			}
-			s.board.broadcast(BoardEvent{
-				Type:        EventTaskFailed,
-				WorkspaceID: ws.ID,
-				TaskID:      task.ID,
-				Detail:      task.Error,
-				Timestamp:   now,
-			})
+			task.StartedAt = time.Time{}
+			s.board.Store().AppendTaskLog(task.ID, "budget", err.Error(), "")
+		} else if errors.Is(err, errLLMFailed) && s.providerThrottled(task) {
+			// The provider is cooling down from a rate limit: requeue without
+			// using up a retry, the scheduler holds it until the cooldown ends
+			task.Status = StatusReady
+			task.Error = fmt.Sprintf("rate limited: %s", err.Error())
+			task.StartedAt = time.Time{}
+			s.board.Store().AppendTaskLog(task.ID, "throttle", err.Error(), "")
+		} else if err != nil {
+			s.recordFailure(task, err, now)
+			event = &BoardEvent{Type: EventTaskFailed, Detail: task.Error}
 		} else {
 			task.Status = StatusDone
 			task.Result = result
 			task.CompletedAt = now
-			s.board.broadcast(BoardEvent{
-				Type:        EventTaskCompleted,
-				WorkspaceID: ws.ID,
-				TaskID:      task.ID,
-				Detail:      task.Title,
-				Timestamp:   now,
-			})
+			if task.Branch != "" {
+				if task.Diff == "" {
+					s.board.deleteTaskBranch(ws, task)
+				} else {
+					task.MergeStatus = MergePending
+				}
+			}
+			event = &BoardEvent{Type: EventTaskCompleted, Detail: task.Title}
+			if task.NeedsApproval(ws) {
+				// Held for a human decision; dependents wait until ApproveTask
+				task.Status = StatusReview
+				event.Type = EventTaskReview
+			}
 		}
 
-		if err := s.board.Store().UpdateTask(task); err != nil {
+		// CancelTask may have won the race after release; its state stands
+		written, err := s.board.Store().UpdateTaskFrom(task, StatusRunning)
+		if err != nil {
 			log.Printf("scheduler: update task %s after execution error: %v", task.ID, err)
+		} else if !written {
+			s.board.Store().AppendTaskLog(task.ID, "cancelled", "run finished after cancel, outcome discarded", "")
+			s.Trigger()
+			return
+		}
+		if event != nil && written {
+			event.WorkspaceID = ws.ID
+			event.TaskID = task.ID
+			event.Timestamp = now
+			s.board.broadcast(*event)
+		}
+
+		if task.Status == StatusDone && task.MergeStatus == MergePending && ws.MergePolicy == MergeAuto {
+			if err := s.board.mergeTask(ws, task); err != nil {
+				log.Printf("scheduler: auto merge task %s: %v", task.ID, err)
+			}
 		}
 
 		// Resolve dependencies and check workspace completion
@@ -191,15 +340,22 @@ func (s *Scheduler) buildTaskPrompt(task *Task) string {
 		if err == nil {
 			hasDeps := false
 			for _, dep := range deps {
-				if dep.Status == StatusDone && dep.Result != "" {
-					if !hasDeps {
-						b.WriteString("## 前置任务结果\n\n")
-						hasDeps = true
-					}
-					b.WriteString(fmt.Sprintf("### [%s] %s\n", dep.ID, dep.Title))
-					b.WriteString(truncateResult(dep.Result, 2000))
-					b.WriteString("\n\n")
+				if dep.Status != StatusDone {
+					continue
+				}
+				// Files, the full diff and long results are passed by reference
+				artifacts, _ := s.board.Store().ListArtifacts(dep.ID)
+				if dep.Result == "" && len(artifacts) == 0 {
+					continue
+				}
+				if !hasDeps {
+					b.WriteString("## 前置任务结果\n\n")
+					hasDeps = true
 				}
+				b.WriteString(fmt.Sprintf("### [%s] %s\n", dep.ID, dep.Title))
+				b.WriteString(truncateResult(dep.Result, maxInlineResult))
+				writeArtifactRefs(&b, artifacts)
+				b.WriteString("\n\n")
 			}
 			if hasDeps {
 				b.WriteString("---\n\n")
@@ -208,23 +364,128 @@ func (s *Scheduler) buildTaskPrompt(task *Task) string {
 	}
 
 	b.WriteString(task.Prompt)
+
+	// Feed the previous attempt's failed verification back into the retry
+	if task.Verdict == VerdictFailed && task.VerifyNotes != "" {
+		b.WriteString("\n\n---\n\n## 上次执行未通过验收\n\n")
+		b.WriteString(truncateResult(task.VerifyNotes, maxVerifyOutput))
+		b.WriteString("\n\n请针对以上问题进行修正，确保满足验收标准。")
+	}
+
+	// Why the previous attempt failed and what it did
+	s.writeRetryNotes(&b, task)
+
+	// A reviewer sent the previous result back
+	if task.ReviewFeedback != "" {
+		b.WriteString("\n\n---\n\n## 审阅意见\n\n")
+		b.WriteString(task.ReviewFeedback)
+		b.WriteString("\n\n上次的结果已被审阅者退回，请根据以上意见修改。")
+	}
 	return b.String()
 }
 
 // runTaskSession creates a temporary session, runs the task prompt through ChatStream,
-// collects the output, and returns the result.
-func (s *Scheduler) runTaskSession(ws *Workspace, task *Task, prompt string) (string, error) {
+// collects the output, runs the task's acceptance checks and returns the result.
+// In git-backed workspaces the session runs in a dedicated worktree on branch
+// kele/<task-id>; task.Branch and task.Diff are filled in once the run finishes.
+func (s *Scheduler) runTaskSession(ctx context.Context, ws *Workspace, task *Task, prompt string) (string, error) {
+	workDir := ws.WorkDir
+	var wt *Worktree
+	if wm := s.board.worktrees; wm != nil {
+		var err error
+		wt, err = wm.Prepare(ws.WorkDir, task.ID, s.dependencyBranches(task)...)
+		if err != nil {
+			return "", fmt.Errorf("prepare worktree: %w", err)
+		}
+		if wt != nil {
+			workDir = wt.Path
+			task.Branch = wt.Branch
+			s.board.Store().AppendTaskLog(task.ID, "worktree", wt.Path, "")
+		}
+	}
+
 	// Create temporary session
-	sess := s.sessions.CreateTaskSession(fmt.Sprintf("task:%s", task.ID))
+	sess := s.sessions.CreateTaskSession(fmt.Sprintf("task:%s", task.ID), TaskSessionOptions{
+		WorkDir:       workDir,
+		Model:         task.Model,
+		Temperature:   task.Temperature,
+		AllowedTools:  task.AllowedTools,
+		MaxToolRounds: task.MaxToolRounds,
+		WorkspaceID:   ws.ID,
+	})
 	defer s.sessions.DeleteTaskSession(sess.GetID())
 
+	result, err := s.streamTaskSession(ctx, sess, ws, task, prompt)
+	if err == nil {
+		// Acceptance checks run before Finalize so they see the worktree
+		err = s.verifyTask(ctx, ws, task, workDir, result)
+	}
+	if err == nil {
+		err = s.collectArtifacts(task, workDir)
+	}
+
+	var diff string
+	if wt != nil {
+		var ferr error
+		diff, ferr = s.board.worktrees.Finalize(wt, fmt.Sprintf("kele: %s\n\nTask: %s", task.Title, task.ID))
+		if ferr != nil {
+			log.Printf("scheduler: finalize worktree for task %s: %v", task.ID, ferr)
+			if err == nil {
+				err = fmt.Errorf("finalize worktree: %w", ferr)
+			}
+		}
+		task.Diff = truncateResult(diff, maxDiffSize)
+	}
+	if err == nil {
+		err = s.saveRunArtifacts(task, diff, result)
+	}
+	return result, err
+}
+
+// dependencyBranches returns the task branches of task's dependencies that
+// have not been merged yet; the task's worktree starts from them so it sees
+// its predecessors' changes under the manual merge policy too.
+func (s *Scheduler) dependencyBranches(task *Task) []string {
+	if len(task.DependsOn) == 0 {
+		return nil
+	}
+	deps, err := s.board.Store().GetTasksByIDs(task.DependsOn)
+	if err != nil {
+		log.Printf("scheduler: load dependencies of task %s: %v", task.ID, err)
+		return nil
+	}
+	byID := make(map[string]*Task, len(deps))
+	for _, dep := range deps {
+		byID[dep.ID] = dep
+	}
+	var branches []string
+	for _, id := range task.DependsOn {
+		if dep := byID[id]; dep != nil && dep.Branch != "" && dep.MergeStatus != MergeMerged {
+			branches = append(branches, dep.Branch)
+		}
+	}
+	return branches
+}
+
+// maxDiffSize caps the diff stored on a task.
+const maxDiffSize = 200 * 1024
+
+// streamTaskSession runs the prompt and records events in the task log.
+// Token and tool call usage is charged to the workspace as it streams; the
+// run is stopped when it exceeds the workspace budget.
+func (s *Scheduler) streamTaskSession(ctx context.Context, sess TaskSession, ws *Workspace, task *Task, prompt string) (string, error) {
 	// Inject workspace context into the session's system prompt
 	if ws.Context != "" {
 		sess.InjectContext(ws.Context)
 	}
 
+	// Returning early must stop the session as well
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	toolCalls := 0
+
 	// Run ChatStream
-	eventChan, err := sess.ChatStream(prompt)
+	eventChan, err := sess.ChatStream(ctx, prompt)
 	if err != nil {
 		return "", fmt.Errorf("chat stream: %w", err)
 	}
@@ -239,12 +500,22 @@ func (s *Scheduler) runTaskSession(ws *Workspace, task *Task, prompt string) (st
 		case "thinking":
 			s.board.Store().AppendTaskLog(task.ID, "thinking", ev.Content, "")
 		case "tool_call":
-			s.board.Store().AppendTaskLog(task.ID, "tool_call", "", ev.ToolName)
+			s.board.Store().AppendTaskLog(task.ID, "tool_call", ev.Content, ev.ToolName)
+			toolCalls++
+			s.board.Store().AddWorkspaceUsage(ws.ID, 0, 1)
+			if limit := ws.Budget.MaxToolCalls; limit > 0 && toolCalls > limit {
+				return "", fmt.Errorf("tool call limit reached (%d per task)", limit)
+			}
+		case "usage":
+			s.board.Store().AddWorkspaceUsage(ws.ID, int64(ev.Tokens), 0)
+			if reason := s.board.enforceBudget(ws.ID); reason != "" {
+				return "", fmt.Errorf("%w: %s", errBudgetExceeded, reason)
+			}
 		case "tool_result":
 			s.board.Store().AppendTaskLog(task.ID, "tool_result", ev.ToolResult, ev.ToolName)
 		case "error":
 			if ev.Error != "" {
-				return "", fmt.Errorf("llm error: %s", ev.Error)
+				return "", fmt.Errorf("%w: %s", errLLMFailed, ev.Error)
 			}
 		}
 	}
@@ -0,0 +1,320 @@
+package taskboard
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// blockingSession runs until its context is cancelled, like a long agent loop,
+// or until finish is closed, when it replies and ends normally.
+type blockingSession struct {
+	id      string
+	started chan struct{}
+	finish  chan struct{}
+}
+
+func (s *blockingSession) GetID() string        { return s.id }
+func (s *blockingSession) InjectContext(string) {}
+func (s *blockingSession) ChatStream(ctx context.Context, _ string) (<-chan SessionEvent, error) {
+	ch := make(chan SessionEvent)
+	go func() {
+		defer close(ch)
+		close(s.started)
+		select {
+		case <-ctx.Done():
+			ch <- SessionEvent{Type: "error", Error: ctx.Err().Error()}
+		case <-s.finish:
+			ch <- SessionEvent{Type: "content", Content: "finished"}
+		}
+	}()
+	return ch, nil
+}
+
+type blockingSessions struct {
+	started chan struct{}
+	finish  chan struct{} // nil runs until cancelled
+}
+
+func (m *blockingSessions) CreateTaskSession(name string, _ TaskSessionOptions) TaskSession {
+	return &blockingSession{id: name, started: m.started, finish: m.finish}
+}
+
+func (m *blockingSessions) DeleteTaskSession(string) {}
+
+func newRunningTask(t *testing.T, ws *Workspace) (*Scheduler, *Task, chan struct{}) {
+	t.Helper()
+	return newFinishingTask(t, ws, &Task{Title: "long", Prompt: "run forever", MaxRetries: 1}, nil)
+}
+
+// newFinishingTask starts task in ws; its run completes successfully once finish is closed.
+func newFinishingTask(t *testing.T, ws *Workspace, task *Task, finish chan struct{}) (*Scheduler, *Task, chan struct{}) {
+	t.Helper()
+	store, cleanup := tempDB(t)
+	t.Cleanup(cleanup)
+	board := NewBoard(store)
+	sessions := &blockingSessions{started: make(chan struct{}), finish: finish}
+	s := NewScheduler(board, sessions)
+	board.SetScheduler(s)
+
+	if err := board.CreateWorkspace(ws); err != nil {
+		t.Fatal(err)
+	}
+	task.WorkspaceID = ws.ID
+	if err := board.CreateTask(task); err != nil {
+		t.Fatal(err)
+	}
+	s.executeTask(ws, task)
+	return s, task, sessions.started
+}
+
+// waitFor polls cond until it holds or the test times out.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}
+
+func TestCancelRunningTask(t *testing.T) {
+	s, task, started := newRunningTask(t, &Workspace{Name: "cancel"})
+	<-started
+
+	if _, err := s.board.CancelTask(task.ID); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the run to stop", func() bool {
+		logs, _ := s.board.Store().GetTaskLog(task.ID, 0)
+		return len(logs) > 0 && logs[len(logs)-1].EventType == "cancelled"
+	})
+
+	got, err := s.board.GetTask(task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != StatusCancelled {
+		t.Errorf("expected the run to leave the task cancelled, got %s (%s)", got.Status, got.Error)
+	}
+}
+
+func TestCancelAfterRunReleased(t *testing.T) {
+	finish := make(chan struct{})
+	s, task, started := newFinishingTask(t, &Workspace{Name: "late-cancel"}, &Task{Title: "late", Prompt: "p"}, finish)
+	<-started
+
+	// The run has finished and released its handle but not yet written its
+	// outcome: stopTask finds nothing to stop
+	s.mu.Lock()
+	delete(s.running, task.ID)
+	s.mu.Unlock()
+	if _, err := s.board.CancelTask(task.ID); err != nil {
+		t.Fatal(err)
+	}
+	close(finish)
+
+	waitFor(t, "the run to give up its outcome", func() bool {
+		logs, _ := s.board.Store().GetTaskLog(task.ID, 0)
+		return len(logs) > 0 && logs[len(logs)-1].EventType == "cancelled"
+	})
+	got, err := s.board.GetTask(task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != StatusCancelled || got.Result != "" {
+		t.Errorf("a late run must not overwrite the cancel, got %s (%q)", got.Status, got.Result)
+	}
+}
+
+func TestCancelRacesRunCompletion(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		finish := make(chan struct{})
+		s, task, started := newFinishingTask(t, &Workspace{Name: "race"}, &Task{Title: "race", Prompt: "p"}, finish)
+		<-started
+
+		go close(finish)
+		_, cancelErr := s.board.CancelTask(task.ID)
+
+		var got *Task
+		waitFor(t, "the task to settle", func() bool {
+			s.mu.Lock()
+			_, running := s.running[task.ID]
+			s.mu.Unlock()
+			got, _ = s.board.GetTask(task.ID)
+			return !running && got != nil && got.Status.IsTerminal()
+		})
+		// Give a run that lost the race time to (not) write its outcome
+		time.Sleep(20 * time.Millisecond)
+		got, _ = s.board.GetTask(task.ID)
+		if cancelErr == nil && got.Status != StatusCancelled {
+			t.Fatalf("cancel succeeded but the task ended %s", got.Status)
+		}
+		if cancelErr != nil && got.Status != StatusDone {
+			t.Fatalf("cancel failed (%v) but the task ended %s", cancelErr, got.Status)
+		}
+	}
+}
+
+func TestOutcomePersistedBeforeEvent(t *testing.T) {
+	for _, approval := range []bool{false, true} {
+		finish := make(chan struct{})
+		s, task, started := newFinishingTask(t, &Workspace{Name: "events"},
+			&Task{Title: "events", Prompt: "p", RequiresApproval: approval}, finish)
+		id, events := s.board.Subscribe()
+		<-started
+		close(finish)
+
+		want, status := EventTaskCompleted, StatusDone
+		if approval {
+			want, status = EventTaskReview, StatusReview
+		}
+		timeout := time.After(5 * time.Second)
+		for done := false; !done; {
+			select {
+			case ev := <-events:
+				if ev.Type != want {
+					continue
+				}
+				got, err := s.board.GetTask(task.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got.Status != status || got.Result != "finished" {
+					t.Errorf("%s broadcast before the outcome was stored: %s (%q)", want, got.Status, got.Result)
+				}
+				done = true
+			case <-timeout:
+				t.Fatalf("no %s event", want)
+			}
+		}
+		s.board.Unsubscribe(id)
+	}
+}
+
+func TestTaskTimeout(t *testing.T) {
+	s, task, started := newRunningTask(t, &Workspace{Name: "timeout", TaskTimeout: 50 * time.Millisecond})
+	<-started
+	var got *Task
+	waitFor(t, "the run to time out", func() bool {
+		got, _ = s.board.GetTask(task.ID)
+		return got != nil && got.Status != StatusRunning
+	})
+	if got.Status != StatusFailed || !strings.Contains(got.Error, "timed out after 50ms") {
+		t.Errorf("expected timeout failure, got %s (%s)", got.Status, got.Error)
+	}
+}
+
+func TestPlannedTaskSessionOptions(t *testing.T) {
+	store, cleanup := tempDB(t)
+	t.Cleanup(cleanup)
+	board := NewBoard(store)
+	sessions := &scriptedSessions{reply: "reviewed"}
+	s := NewScheduler(board, sessions)
+	board.SetScheduler(s)
+
+	temp := 0.2
+	plan := &PlanResult{
+		WorkspaceName: "configured",
+		Tasks: []PlannedTask{{
+			Title: "review", Prompt: "review the change",
+			Model: "small", Temperature: &temp, Tools: []string{"read", "search"}, MaxToolRounds: 5,
+		}},
+	}
+	ws, tasks, err := store.CreateFromPlan(plan, "ws-configured", "review", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := board.GetTask(tasks[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Model != "small" || task.Temperature == nil || *task.Temperature != 0.2 ||
+		len(task.AllowedTools) != 2 || task.MaxToolRounds != 5 {
+		t.Fatalf("session settings not stored: %+v", task)
+	}
+
+	s.executeTask(ws, task)
+	waitFor(t, "the run to finish", func() bool {
+		got, _ := board.GetTask(task.ID)
+		return got != nil && got.Status == StatusDone
+	})
+	opts := sessions.opts
+	if opts.Model != "small" || opts.Temperature == nil || *opts.Temperature != 0.2 ||
+		strings.Join(opts.AllowedTools, ",") != "read,search" || opts.MaxToolRounds != 5 {
+		t.Errorf("unexpected session options %+v", opts)
+	}
+
+	// Unset settings fall back to the daemon defaults
+	plain := &Task{WorkspaceID: ws.ID, Title: "plain", Prompt: "plain"}
+	if err := board.CreateTask(plain); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := board.GetTask(plain.ID)
+	if got.Model != "" || got.Temperature != nil || len(got.AllowedTools) != 0 || got.MaxToolRounds != 0 {
+		t.Errorf("expected default session settings, got %+v", got)
+	}
+}
+
+// loopingSession calls a tool every round, like an agent that never
+// finishes, and gives up at its round cap the way daemon task sessions do.
+type loopingSession struct {
+	id     string
+	rounds int
+}
+
+func (s *loopingSession) GetID() string        { return s.id }
+func (s *loopingSession) InjectContext(string) {}
+func (s *loopingSession) ChatStream(_ context.Context, _ string) (<-chan SessionEvent, error) {
+	ch := make(chan SessionEvent, 2*s.rounds+1)
+	for i := 0; i < s.rounds; i++ {
+		ch <- SessionEvent{Type: "tool_call", ToolName: "bash"}
+		ch <- SessionEvent{Type: "tool_result", ToolName: "bash", ToolResult: "still failing"}
+	}
+	ch <- SessionEvent{Type: "error", Error: fmt.Sprintf("已达到最大工具轮数 (%d)，任务未完成", s.rounds)}
+	close(ch)
+	return ch, nil
+}
+
+type loopingSessions struct{}
+
+func (loopingSessions) CreateTaskSession(name string, opts TaskSessionOptions) TaskSession {
+	return &loopingSession{id: name, rounds: opts.MaxToolRounds}
+}
+
+func (loopingSessions) DeleteTaskSession(string) {}
+
+func TestTaskFailsAtToolRoundCap(t *testing.T) {
+	store, cleanup := tempDB(t)
+	t.Cleanup(cleanup)
+	board := NewBoard(store)
+	s := NewScheduler(board, loopingSessions{})
+	board.SetScheduler(s)
+
+	ws := &Workspace{Name: "rounds"}
+	if err := board.CreateWorkspace(ws); err != nil {
+		t.Fatal(err)
+	}
+	task := &Task{WorkspaceID: ws.ID, Title: "endless", Prompt: "fix it", MaxToolRounds: 3}
+	if err := board.CreateTask(task); err != nil {
+		t.Fatal(err)
+	}
+	s.executeTask(ws, task)
+
+	var got *Task
+	waitFor(t, "the run to finish", func() bool {
+		got, _ = board.GetTask(task.ID)
+		return got != nil && got.Status != StatusRunning
+	})
+	if got.Status != StatusFailed || got.ErrorClass != ErrorTask || !strings.Contains(got.Error, "最大工具轮数 (3)") {
+		t.Errorf("hitting the round cap must fail the task, got %s %s %q", got.Status, got.ErrorClass, got.Error)
+	}
+	if got.Result != "" {
+		t.Errorf("a capped run has no result, got %q", got.Result)
+	}
+}
@@ -0,0 +1,329 @@
+package taskboard
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Snippet markers around the matched text in search results.
+const (
+	SnippetOpen  = "«"
+	SnippetClose = "»"
+)
+
+const (
+	defaultSearchLimit = 20  // tasks per search
+	maxSearchMatches   = 5   // matches kept per task
+	searchRowLimit     = 500 // rows scanned per source
+	snippetRadius      = 60  // characters of context around a LIKE match
+)
+
+// SearchQuery selects task log entries and task results/errors containing
+// Text (case-insensitive substring).
+type SearchQuery struct {
+	Text        string
+	WorkspaceID string
+	Tool        string    // only log entries of this tool; skips results and errors
+	Since       time.Time // only activity at or after this time
+	Limit       int       // max tasks, default 20
+}
+
+// SearchHit is one task matching a search.
+type SearchHit struct {
+	Task    *Task
+	Matches []*SearchMatch // best matches first, at most 5
+	Total   int            // all matches found, may exceed len(Matches)
+}
+
+// SearchMatch is a matching log entry, result or error of a task.
+type SearchMatch struct {
+	Field     string // log, result or error
+	EventType string // log entries only
+	ToolName  string
+	Snippet   string // matched text wrapped in SnippetOpen/SnippetClose
+	Timestamp time.Time
+}
+
+// initSearchIndex sets up the FTS5 indexes over task logs and task
+// results/errors, kept in sync by triggers. Without FTS5 support in the
+// SQLite build, search falls back to LIKE.
+func (s *TaskStore) initSearchIndex() {
+	var existing int
+	s.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name IN ('task_logs_fts', 'task_text_fts')`).Scan(&existing)
+
+	_, err := s.db.Exec(`
+		CREATE VIRTUAL TABLE IF NOT EXISTS task_logs_fts USING fts5(
+			content, content='task_logs', content_rowid='id', tokenize='trigram'
+		);
+		CREATE VIRTUAL TABLE IF NOT EXISTS task_text_fts USING fts5(
+			result, error, content='tasks', content_rowid='rowid', tokenize='trigram'
+		);
+
+		CREATE TRIGGER IF NOT EXISTS task_logs_fts_ai AFTER INSERT ON task_logs BEGIN
+			INSERT INTO task_logs_fts(rowid, content) VALUES (new.id, new.content);
+		END;
+		CREATE TRIGGER IF NOT EXISTS task_logs_fts_ad AFTER DELETE ON task_logs BEGIN
+			INSERT INTO task_logs_fts(task_logs_fts, rowid, content) VALUES ('delete', old.id, old.content);
+		END;
+
+		CREATE TRIGGER IF NOT EXISTS task_text_fts_ai AFTER INSERT ON tasks BEGIN
+			INSERT INTO task_text_fts(rowid, result, error) VALUES (new.rowid, new.result, new.error);
+		END;
+		CREATE TRIGGER IF NOT EXISTS task_text_fts_ad AFTER DELETE ON tasks BEGIN
+			INSERT INTO task_text_fts(task_text_fts, rowid, result, error) VALUES ('delete', old.rowid, old.result, old.error);
+		END;
+		CREATE TRIGGER IF NOT EXISTS task_text_fts_au AFTER UPDATE OF result, error ON tasks
+		WHEN old.result IS NOT new.result OR old.error IS NOT new.error BEGIN
+			INSERT INTO task_text_fts(task_text_fts, rowid, result, error) VALUES ('delete', old.rowid, old.result, old.error);
+			INSERT INTO task_text_fts(rowid, result, error) VALUES (new.rowid, new.result, new.error);
+		END;
+	`)
+	if err != nil {
+		return
+	}
+	s.hasFTS = true
+
+	// Index rows written before the index existed
+	if existing < 2 {
+		s.db.Exec(`INSERT INTO task_logs_fts(task_logs_fts) VALUES ('rebuild')`)
+		s.db.Exec(`INSERT INTO task_text_fts(task_text_fts) VALUES ('rebuild')`)
+	}
+}
+
+// SearchTasks finds tasks whose logs, result or error contain q.Text.
+// Hits are ordered by their best match: results and errors first, then log
+// entries by relevance (FTS5) or recency (LIKE fallback).
+func (s *TaskStore) SearchTasks(q SearchQuery) ([]*SearchHit, error) {
+	q.Text = strings.TrimSpace(q.Text)
+	if q.Text == "" {
+		return nil, fmt.Errorf("empty search query")
+	}
+	if q.Limit <= 0 {
+		q.Limit = defaultSearchLimit
+	}
+	// The trigram index cannot match fewer than 3 characters
+	useFTS := s.hasFTS && utf8.RuneCountInString(q.Text) >= 3
+
+	var matches []taskMatch
+	if q.Tool == "" {
+		found, err := s.searchTaskText(q, useFTS)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, found...)
+	}
+	found, err := s.searchLogs(q, useFTS)
+	if err != nil {
+		return nil, err
+	}
+	matches = append(matches, found...)
+
+	var order []string
+	byTask := make(map[string]*SearchHit)
+	for _, m := range matches {
+		hit, ok := byTask[m.taskID]
+		if !ok {
+			if len(order) == q.Limit {
+				continue
+			}
+			hit = &SearchHit{}
+			byTask[m.taskID] = hit
+			order = append(order, m.taskID)
+		}
+		hit.Total++
+		if len(hit.Matches) < maxSearchMatches {
+			hit.Matches = append(hit.Matches, m.match)
+		}
+	}
+
+	tasks, err := s.GetTasksByIDs(order)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tasks {
+		byTask[t.ID].Task = t
+	}
+	hits := make([]*SearchHit, 0, len(order))
+	for _, id := range order {
+		if hit := byTask[id]; hit.Task != nil {
+			hits = append(hits, hit)
+		}
+	}
+	return hits, nil
+}
+
+// taskMatch is a search match before grouping by task.
+type taskMatch struct {
+	taskID string
+	match  *SearchMatch
+}
+
+// searchLogs finds matching task log entries.
+func (s *TaskStore) searchLogs(q SearchQuery, useFTS bool) ([]taskMatch, error) {
+	var where []string
+	var args []interface{}
+	query := `SELECT l.task_id, l.event_type, l.tool_name, l.timestamp, l.content FROM task_logs l`
+	if useFTS {
+		query = `SELECT l.task_id, l.event_type, l.tool_name, l.timestamp,
+			snippet(task_logs_fts, 0, ?, ?, '…', 24)
+			FROM task_logs_fts JOIN task_logs l ON l.id = task_logs_fts.rowid`
+		args = append(args, SnippetOpen, SnippetClose)
+		where = append(where, "task_logs_fts MATCH ?")
+		args = append(args, ftsPhrase(q.Text))
+	} else {
+		where = append(where, `l.content LIKE ? ESCAPE '\'`)
+		args = append(args, likePattern(q.Text))
+	}
+	if q.WorkspaceID != "" {
+		where = append(where, "l.task_id IN (SELECT id FROM tasks WHERE workspace_id = ?)")
+		args = append(args, q.WorkspaceID)
+	}
+	if q.Tool != "" {
+		where = append(where, "l.tool_name = ?")
+		args = append(args, q.Tool)
+	}
+	if !q.Since.IsZero() {
+		// timestamp defaults to CURRENT_TIMESTAMP, which is UTC
+		where = append(where, "l.timestamp >= ?")
+		args = append(args, q.Since.UTC().Format("2006-01-02 15:04:05"))
+	}
+	query += " WHERE " + strings.Join(where, " AND ")
+	if useFTS {
+		query += " ORDER BY rank"
+	} else {
+		query += " ORDER BY l.id DESC"
+	}
+	query += fmt.Sprintf(" LIMIT %d", searchRowLimit)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("search logs: %w", err)
+	}
+	defer rows.Close()
+	var result []taskMatch
+	for rows.Next() {
+		var taskID, text string
+		m := &SearchMatch{Field: "log"}
+		if err := rows.Scan(&taskID, &m.EventType, &m.ToolName, &m.Timestamp, &text); err != nil {
+			return nil, err
+		}
+		m.Snippet = text
+		if !useFTS {
+			m.Snippet = likeSnippet(text, q.Text)
+		}
+		result = append(result, taskMatch{taskID, m})
+	}
+	return result, rows.Err()
+}
+
+// searchTaskText finds tasks whose result or error matches.
+func (s *TaskStore) searchTaskText(q SearchQuery, useFTS bool) ([]taskMatch, error) {
+	var where []string
+	var args []interface{}
+	query := `SELECT t.id, t.result, t.error, t.started_at, t.completed_at FROM tasks t`
+	if useFTS {
+		query = `SELECT t.id,
+			snippet(task_text_fts, 0, ?, ?, '…', 24), snippet(task_text_fts, 1, ?, ?, '…', 24),
+			t.started_at, t.completed_at
+			FROM task_text_fts JOIN tasks t ON t.rowid = task_text_fts.rowid`
+		args = append(args, SnippetOpen, SnippetClose, SnippetOpen, SnippetClose)
+		where = append(where, "task_text_fts MATCH ?")
+		args = append(args, ftsPhrase(q.Text))
+	} else {
+		where = append(where, `(t.result LIKE ? ESCAPE '\' OR t.error LIKE ? ESCAPE '\')`)
+		args = append(args, likePattern(q.Text), likePattern(q.Text))
+	}
+	if q.WorkspaceID != "" {
+		where = append(where, "t.workspace_id = ?")
+		args = append(args, q.WorkspaceID)
+	}
+	query += " WHERE " + strings.Join(where, " AND ")
+	if useFTS {
+		query += " ORDER BY rank"
+	}
+	query += fmt.Sprintf(" LIMIT %d", searchRowLimit)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("search tasks: %w", err)
+	}
+	defer rows.Close()
+	var result []taskMatch
+	for rows.Next() {
+		var taskID, res, errText string
+		var started, completed *time.Time
+		if err := rows.Scan(&taskID, &res, &errText, &started, &completed); err != nil {
+			return nil, err
+		}
+		at := time.Time{}
+		if completed != nil {
+			at = *completed
+		} else if started != nil {
+			at = *started
+		}
+		if !q.Since.IsZero() && at.Before(q.Since) {
+			continue
+		}
+		for _, f := range []struct{ field, text string }{{"result", res}, {"error", errText}} {
+			snippet := f.text
+			if useFTS {
+				if !strings.Contains(snippet, SnippetOpen) {
+					continue
+				}
+			} else {
+				if snippet = likeSnippet(f.text, q.Text); snippet == "" {
+					continue
+				}
+			}
+			result = append(result, taskMatch{taskID, &SearchMatch{Field: f.field, Snippet: snippet, Timestamp: at}})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if !useFTS {
+		sort.SliceStable(result, func(i, j int) bool { return result[i].match.Timestamp.After(result[j].match.Timestamp) })
+	}
+	return result, nil
+}
+
+// ftsPhrase quotes text as a single FTS5 phrase, so it matches as a literal
+// substring under the trigram tokenizer.
+func ftsPhrase(text string) string {
+	return `"` + strings.ReplaceAll(text, `"`, `""`) + `"`
+}
+
+// likePattern matches text anywhere, escaping LIKE wildcards.
+func likePattern(text string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + r.Replace(text) + "%"
+}
+
+// likeSnippet cuts the context around the first case-insensitive occurrence
+// of text in s and marks the match. It returns "" when s does not contain text.
+func likeSnippet(s, text string) string {
+	idx := strings.Index(strings.ToLower(s), strings.ToLower(text))
+	if idx < 0 || len(strings.ToLower(s)) != len(s) {
+		// ToLower changed byte offsets; fall back to an exact match
+		idx = strings.Index(s, text)
+	}
+	if idx < 0 {
+		return ""
+	}
+	end := idx + len(text)
+	before := []rune(s[:idx])
+	after := []rune(s[end:])
+	prefix, suffix := "", ""
+	if len(before) > snippetRadius {
+		before = before[len(before)-snippetRadius:]
+		prefix = "…"
+	}
+	if len(after) > snippetRadius {
+		after = after[:snippetRadius]
+		suffix = "…"
+	}
+	return prefix + string(before) + SnippetOpen + s[idx:end] + SnippetClose + string(after) + suffix
+}
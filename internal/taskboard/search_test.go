@@ -0,0 +1,75 @@
+package taskboard
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSearchTasks(t *testing.T) {
+	store, cleanup := tempDB(t)
+	t.Cleanup(cleanup)
+
+	ws := &Workspace{ID: "ws-1", Name: "search", CreatedAt: time.Now()}
+	if err := store.CreateWorkspace(ws); err != nil {
+		t.Fatal(err)
+	}
+	deps := &Task{ID: "t-1", WorkspaceID: ws.ID, Title: "deps", Prompt: "p", Status: StatusDone, Result: "dependencies cleaned up"}
+	conf := &Task{ID: "t-2", WorkspaceID: ws.ID, Title: "config", Prompt: "p", Status: StatusReady}
+	for _, task := range []*Task{deps, conf} {
+		if err := store.CreateTask(task); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store.AppendTaskLog(deps.ID, "tool_call", `{"command":"go mod tidy"}`, "bash")
+	store.AppendTaskLog(deps.ID, "tool_result", "go: downloading golang.org/x/text", "bash")
+	store.AppendTaskLog(conf.ID, "content", "I will go mod tidy later", "")
+	conf.Status = StatusFailed
+	conf.Error = "open Config.yaml: permission denied"
+	if err := store.UpdateTask(conf); err != nil {
+		t.Fatal(err)
+	}
+
+	hits, err := store.SearchTasks(SearchQuery{Text: "go mod tidy"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(hits))
+	}
+
+	hits, _ = store.SearchTasks(SearchQuery{Text: "go mod tidy", Tool: "bash"})
+	if len(hits) != 1 || hits[0].Task.ID != deps.ID {
+		t.Fatalf("expected only the task that ran the command, got %d hits", len(hits))
+	}
+	m := hits[0].Matches[0]
+	if m.EventType != "tool_call" || !strings.Contains(m.Snippet, SnippetOpen+"go mod tidy"+SnippetClose) {
+		t.Errorf("unexpected match %+v", m)
+	}
+
+	hits, _ = store.SearchTasks(SearchQuery{Text: "config.yaml"})
+	if len(hits) != 1 || hits[0].Task.ID != conf.ID || hits[0].Matches[0].Field != "error" {
+		t.Fatalf("expected the error of the config task, got %d hits", len(hits))
+	}
+	if s := hits[0].Matches[0].Snippet; !strings.Contains(s, SnippetOpen+"Config.yaml"+SnippetClose) {
+		t.Errorf("expected the match highlighted in its original case, got %q", s)
+	}
+
+	if hits, _ := store.SearchTasks(SearchQuery{Text: "go mod tidy", WorkspaceID: "other"}); len(hits) != 0 {
+		t.Errorf("expected no hits outside the workspace, got %d", len(hits))
+	}
+	if hits, _ := store.SearchTasks(SearchQuery{Text: "go mod tidy", Since: time.Now().Add(time.Hour)}); len(hits) != 0 {
+		t.Errorf("expected no hits after the logs were written, got %d", len(hits))
+	}
+	if hits, _ := store.SearchTasks(SearchQuery{Text: "100%"}); len(hits) != 0 {
+		t.Errorf("expected LIKE wildcards to be literal, got %d hits", len(hits))
+	}
+
+	// Deleted tasks leave the index
+	if err := store.DeleteTask(deps.ID); err != nil {
+		t.Fatal(err)
+	}
+	if hits, _ := store.SearchTasks(SearchQuery{Text: "golang.org"}); len(hits) != 0 {
+		t.Errorf("expected no hits for a deleted task, got %d", len(hits))
+	}
+}
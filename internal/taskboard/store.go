@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -12,7 +14,9 @@ import (
 
 // TaskStore provides SQLite-backed persistence for workspaces, tasks, and logs.
 type TaskStore struct {
-	db *sql.DB
+	db          *sql.DB
+	artifactDir string // artifact files live in <artifactDir>/<task-id>/<name>
+	hasFTS      bool   // FTS5 search index available (see initSearchIndex)
 }
 
 // NewTaskStore opens (or creates) the taskboard database and ensures tables exist.
@@ -21,7 +25,7 @@ func NewTaskStore(dbPath string) (*TaskStore, error) {
 	if err != nil {
 		return nil, fmt.Errorf("open db: %w", err)
 	}
-	s := &TaskStore{db: db}
+	s := &TaskStore{db: db, artifactDir: filepath.Join(filepath.Dir(dbPath), "artifacts")}
 	if err := s.migrate(); err != nil {
 		db.Close()
 		return nil, fmt.Errorf("migrate: %w", err)
@@ -46,6 +50,18 @@ func (s *TaskStore) migrate() error {
 			context        TEXT DEFAULT '',
 			work_dir       TEXT DEFAULT '',
 			summary        TEXT DEFAULT '',
+			merge_policy   TEXT DEFAULT 'manual',
+			task_timeout   INTEGER DEFAULT 0,
+			approval_tags  TEXT DEFAULT '[]',
+			max_tokens     INTEGER DEFAULT 0,
+			max_cost       REAL DEFAULT 0,
+			token_price    REAL DEFAULT 0,
+			max_duration   INTEGER DEFAULT 0,
+			max_tool_calls INTEGER DEFAULT 0,
+			used_tokens    INTEGER DEFAULT 0,
+			used_tool_calls INTEGER DEFAULT 0,
+			started_at     DATETIME,
+			schedule_id    TEXT DEFAULT '',
 			created_at     DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at     DATETIME DEFAULT CURRENT_TIMESTAMP
 		);
@@ -65,6 +81,26 @@ func (s *TaskStore) migrate() error {
 			retry_count      INTEGER DEFAULT 0,
 			tags             TEXT DEFAULT '[]',
 			depends_on       TEXT DEFAULT '[]',
+			branch           TEXT DEFAULT '',
+			diff             TEXT DEFAULT '',
+			merge_status     TEXT DEFAULT '',
+			verify_command   TEXT DEFAULT '',
+			verify_prompt    TEXT DEFAULT '',
+			verdict          TEXT DEFAULT '',
+			verify_notes     TEXT DEFAULT '',
+			blocked_reason   TEXT DEFAULT '',
+			timeout          INTEGER DEFAULT 0,
+			requires_approval INTEGER DEFAULT 0,
+			review_feedback  TEXT DEFAULT '',
+			model            TEXT DEFAULT '',
+			temperature      REAL,
+			tools            TEXT DEFAULT '[]',
+			max_tool_rounds  INTEGER DEFAULT 0,
+			artifacts        TEXT DEFAULT '[]',
+			ready_at         DATETIME,
+			next_attempt_at  DATETIME,
+			error_class      TEXT DEFAULT '',
+			transient_retries INTEGER DEFAULT 0,
 			created_at       DATETIME DEFAULT CURRENT_TIMESTAMP,
 			started_at       DATETIME,
 			completed_at     DATETIME
@@ -84,75 +120,247 @@ func (s *TaskStore) migrate() error {
 		);
 
 		CREATE INDEX IF NOT EXISTS idx_task_logs_task ON task_logs(task_id);
+
+		CREATE TABLE IF NOT EXISTS task_artifacts (
+			id         INTEGER PRIMARY KEY AUTOINCREMENT,
+			task_id    TEXT NOT NULL REFERENCES tasks(id),
+			name       TEXT NOT NULL,
+			kind       TEXT NOT NULL,
+			size       INTEGER DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE(task_id, name)
+		);
+
+		CREATE TABLE IF NOT EXISTS workspace_schedules (
+			id                TEXT PRIMARY KEY,
+			template_id       TEXT NOT NULL REFERENCES workspaces(id),
+			cron              TEXT NOT NULL,
+			channel           TEXT DEFAULT '',
+			target            TEXT DEFAULT '',
+			enabled           INTEGER DEFAULT 1,
+			last_run          DATETIME,
+			next_run          DATETIME,
+			last_workspace_id TEXT DEFAULT '',
+			last_error        TEXT DEFAULT '',
+			last_summary      TEXT DEFAULT '',
+			created_at        DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at        DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS plan_drafts (
+			id         TEXT PRIMARY KEY,
+			goal       TEXT DEFAULT '',
+			work_dir   TEXT DEFAULT '',
+			plan_json  TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
 	`)
-	return err
+	if err != nil {
+		return err
+	}
+	if err := s.addMissingColumns(); err != nil {
+		return err
+	}
+	s.initSearchIndex()
+	return nil
+}
+
+// columnMigrations lists columns added after the initial schema.
+// Databases created by older versions get them via ALTER TABLE.
+var columnMigrations = []struct {
+	table, column, def string
+}{
+	{"workspaces", "merge_policy", "TEXT DEFAULT 'manual'"},
+	{"tasks", "branch", "TEXT DEFAULT ''"},
+	{"tasks", "diff", "TEXT DEFAULT ''"},
+	{"tasks", "merge_status", "TEXT DEFAULT ''"},
+	{"tasks", "verify_command", "TEXT DEFAULT ''"},
+	{"tasks", "verify_prompt", "TEXT DEFAULT ''"},
+	{"tasks", "verdict", "TEXT DEFAULT ''"},
+	{"tasks", "verify_notes", "TEXT DEFAULT ''"},
+	{"tasks", "blocked_reason", "TEXT DEFAULT ''"},
+	{"workspaces", "task_timeout", "INTEGER DEFAULT 0"},
+	{"tasks", "timeout", "INTEGER DEFAULT 0"},
+	{"tasks", "requires_approval", "INTEGER DEFAULT 0"},
+	{"tasks", "review_feedback", "TEXT DEFAULT ''"},
+	{"tasks", "model", "TEXT DEFAULT ''"},
+	{"tasks", "temperature", "REAL"},
+	{"tasks", "tools", "TEXT DEFAULT '[]'"},
+	{"tasks", "max_tool_rounds", "INTEGER DEFAULT 0"},
+	{"tasks", "artifacts", "TEXT DEFAULT '[]'"},
+	{"workspaces", "schedule_id", "TEXT DEFAULT ''"},
+	{"workspaces", "approval_tags", "TEXT DEFAULT '[]'"},
+	{"workspaces", "max_tokens", "INTEGER DEFAULT 0"},
+	{"workspaces", "max_cost", "REAL DEFAULT 0"},
+	{"workspaces", "token_price", "REAL DEFAULT 0"},
+	{"workspaces", "max_duration", "INTEGER DEFAULT 0"},
+	{"workspaces", "max_tool_calls", "INTEGER DEFAULT 0"},
+	{"workspaces", "used_tokens", "INTEGER DEFAULT 0"},
+	{"workspaces", "used_tool_calls", "INTEGER DEFAULT 0"},
+	{"workspaces", "started_at", "DATETIME"},
+	{"tasks", "ready_at", "DATETIME"},
+	{"tasks", "next_attempt_at", "DATETIME"},
+	{"tasks", "error_class", "TEXT DEFAULT ''"},
+	{"tasks", "transient_retries", "INTEGER DEFAULT 0"},
+}
+
+func (s *TaskStore) addMissingColumns() error {
+	existing := make(map[string]map[string]bool)
+	for _, m := range columnMigrations {
+		cols, ok := existing[m.table]
+		if !ok {
+			var err error
+			cols, err = s.tableColumns(m.table)
+			if err != nil {
+				return err
+			}
+			existing[m.table] = cols
+		}
+		if cols[m.column] {
+			continue
+		}
+		if _, err := s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", m.table, m.column, m.def)); err != nil {
+			return fmt.Errorf("add column %s.%s: %w", m.table, m.column, err)
+		}
+		cols[m.column] = true
+	}
+	return nil
+}
+
+func (s *TaskStore) tableColumns(table string) (map[string]bool, error) {
+	rows, err := s.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	cols := make(map[string]bool)
+	for rows.Next() {
+		var (
+			cid, notNull, pk int
+			name, typ        string
+			dflt             sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &dflt, &pk); err != nil {
+			return nil, err
+		}
+		cols[name] = true
+	}
+	return cols, rows.Err()
+}
+
+// workspaceColumns is the column list shared by all workspace SELECTs (see scanWorkspace).
+const workspaceColumns = `id, name, description, goal, status, max_concurrent, context, work_dir, summary,
+	merge_policy, task_timeout, approval_tags,
+	max_tokens, max_cost, token_price, max_duration, max_tool_calls,
+	used_tokens, used_tool_calls, started_at, schedule_id,
+	created_at, updated_at`
+
+// taskColumns is the column list shared by all task SELECTs (see scanTask).
+const taskColumns = `id, workspace_id, title, description, prompt, status, priority,
+	assigned_session, result, error, max_retries, retry_count,
+	tags, depends_on, branch, diff, merge_status,
+	verify_command, verify_prompt, verdict, verify_notes, blocked_reason, timeout,
+	requires_approval, review_feedback,
+	model, temperature, tools, max_tool_rounds, artifacts,
+	next_attempt_at, error_class, transient_retries,
+	ready_at, created_at, started_at, completed_at`
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
 }
 
 // --- Workspace CRUD ---
 
 func (s *TaskStore) CreateWorkspace(ws *Workspace) error {
 	_, err := s.db.Exec(`
-		INSERT INTO workspaces (id, name, description, goal, status, max_concurrent, context, work_dir, summary, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		INSERT INTO workspaces (id, name, description, goal, status, max_concurrent, context, work_dir, summary, merge_policy, task_timeout, approval_tags,
+			max_tokens, max_cost, token_price, max_duration, max_tool_calls, schedule_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		ws.ID, ws.Name, ws.Description, ws.Goal, string(ws.Status),
 		ws.MaxConcurrent, ws.Context, ws.WorkDir, ws.Summary,
-		ws.CreatedAt, ws.UpdatedAt)
+		string(ws.MergePolicy), seconds(ws.TaskTimeout), jsonList(ws.ApprovalTags),
+		ws.Budget.MaxTokens, ws.Budget.MaxCost, ws.Budget.TokenPrice, seconds(ws.Budget.MaxDuration), ws.Budget.MaxToolCalls,
+		ws.ScheduleID, ws.CreatedAt, ws.UpdatedAt)
 	return err
 }
 
 func (s *TaskStore) GetWorkspace(id string) (*Workspace, error) {
-	ws := &Workspace{}
-	var status string
-	err := s.db.QueryRow(`SELECT id, name, description, goal, status, max_concurrent, context, work_dir, summary, created_at, updated_at FROM workspaces WHERE id = ?`, id).
-		Scan(&ws.ID, &ws.Name, &ws.Description, &ws.Goal, &status,
-			&ws.MaxConcurrent, &ws.Context, &ws.WorkDir, &ws.Summary,
-			&ws.CreatedAt, &ws.UpdatedAt)
-	if err != nil {
-		return nil, err
-	}
-	ws.Status = WorkspaceStatus(status)
-	return ws, nil
+	return scanWorkspace(s.db.QueryRow(`SELECT `+workspaceColumns+` FROM workspaces WHERE id = ?`, id))
 }
 
 func (s *TaskStore) UpdateWorkspace(ws *Workspace) error {
 	ws.UpdatedAt = time.Now()
 	_, err := s.db.Exec(`
-		UPDATE workspaces SET name=?, description=?, goal=?, status=?, max_concurrent=?, context=?, work_dir=?, summary=?, updated_at=?
+		UPDATE workspaces SET name=?, description=?, goal=?, status=?, max_concurrent=?, context=?, work_dir=?, summary=?, merge_policy=?, task_timeout=?, approval_tags=?,
+		       max_tokens=?, max_cost=?, token_price=?, max_duration=?, max_tool_calls=?, updated_at=?
 		WHERE id=?`,
 		ws.Name, ws.Description, ws.Goal, string(ws.Status),
 		ws.MaxConcurrent, ws.Context, ws.WorkDir, ws.Summary,
+		string(ws.MergePolicy), seconds(ws.TaskTimeout), jsonList(ws.ApprovalTags),
+		ws.Budget.MaxTokens, ws.Budget.MaxCost, ws.Budget.TokenPrice, seconds(ws.Budget.MaxDuration), ws.Budget.MaxToolCalls,
 		ws.UpdatedAt, ws.ID)
 	return err
 }
 
+// AddWorkspaceUsage adds to the tokens and tool calls a workspace has used.
+// Usage is only changed through this increment, so concurrent task runs and
+// UpdateWorkspace calls cannot lose each other's counts.
+func (s *TaskStore) AddWorkspaceUsage(id string, tokens, toolCalls int64) error {
+	_, err := s.db.Exec(`UPDATE workspaces SET used_tokens = used_tokens + ?, used_tool_calls = used_tool_calls + ? WHERE id = ?`,
+		tokens, toolCalls, id)
+	return err
+}
+
+// MarkWorkspaceStarted records when the first task of a workspace started.
+func (s *TaskStore) MarkWorkspaceStarted(id string, at time.Time) error {
+	_, err := s.db.Exec(`UPDATE workspaces SET started_at = ? WHERE id = ? AND started_at IS NULL`, at, id)
+	return err
+}
+
 func (s *TaskStore) DeleteWorkspace(id string) error {
 	tx, err := s.db.Begin()
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
+	var taskIDs []string
+	if rows, err := tx.Query(`SELECT id FROM tasks WHERE workspace_id = ?`, id); err == nil {
+		for rows.Next() {
+			var taskID string
+			if rows.Scan(&taskID) == nil {
+				taskIDs = append(taskIDs, taskID)
+			}
+		}
+		rows.Close()
+	}
 	tx.Exec(`DELETE FROM task_logs WHERE task_id IN (SELECT id FROM tasks WHERE workspace_id = ?)`, id)
+	tx.Exec(`DELETE FROM task_artifacts WHERE task_id IN (SELECT id FROM tasks WHERE workspace_id = ?)`, id)
 	tx.Exec(`DELETE FROM tasks WHERE workspace_id = ?`, id)
+	tx.Exec(`DELETE FROM workspace_schedules WHERE template_id = ?`, id)
 	tx.Exec(`DELETE FROM workspaces WHERE id = ?`, id)
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	for _, taskID := range taskIDs {
+		os.RemoveAll(s.taskArtifactDir(taskID))
+	}
+	return nil
 }
 
 func (s *TaskStore) ListWorkspaces() ([]*Workspace, error) {
-	rows, err := s.db.Query(`SELECT id, name, description, goal, status, max_concurrent, context, work_dir, summary, created_at, updated_at FROM workspaces ORDER BY created_at DESC`)
+	rows, err := s.db.Query(`SELECT ` + workspaceColumns + ` FROM workspaces ORDER BY created_at DESC`)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 	var result []*Workspace
 	for rows.Next() {
-		ws := &Workspace{}
-		var status string
-		if err := rows.Scan(&ws.ID, &ws.Name, &ws.Description, &ws.Goal, &status,
-			&ws.MaxConcurrent, &ws.Context, &ws.WorkDir, &ws.Summary,
-			&ws.CreatedAt, &ws.UpdatedAt); err != nil {
+		ws, err := scanWorkspace(rows)
+		if err != nil {
 			return nil, err
 		}
-		ws.Status = WorkspaceStatus(status)
 		result = append(result, ws)
 	}
 	return result, nil
@@ -163,51 +371,41 @@ func (s *TaskStore) ListWorkspaces() ([]*Workspace, error) {
 func (s *TaskStore) CreateTask(t *Task) error {
 	tags, _ := json.Marshal(t.Tags)
 	deps, _ := json.Marshal(t.DependsOn)
+	if t.Status == StatusReady && t.ReadyAt.IsZero() {
+		t.ReadyAt = time.Now()
+	}
 	_, err := s.db.Exec(`
-		INSERT INTO tasks (id, workspace_id, title, description, prompt, status, priority, assigned_session, result, error, max_retries, retry_count, tags, depends_on, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		INSERT INTO tasks (id, workspace_id, title, description, prompt, status, priority, assigned_session, result, error, max_retries, retry_count, tags, depends_on, branch, diff, merge_status, verify_command, verify_prompt, verdict, verify_notes, blocked_reason, timeout, requires_approval, review_feedback, model, temperature, tools, max_tool_rounds, artifacts, next_attempt_at, error_class, transient_retries, ready_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		t.ID, t.WorkspaceID, t.Title, t.Description, t.Prompt,
 		string(t.Status), t.Priority, t.AssignedSession,
 		t.Result, t.Error, t.MaxRetries, t.RetryCount,
-		string(tags), string(deps), t.CreatedAt)
+		string(tags), string(deps), t.Branch, t.Diff, string(t.MergeStatus),
+		t.VerifyCommand, t.VerifyPrompt, string(t.Verdict), t.VerifyNotes, t.BlockedReason, seconds(t.Timeout),
+		t.RequiresApproval, t.ReviewFeedback,
+		t.Model, t.Temperature, jsonList(t.AllowedTools), t.MaxToolRounds, jsonList(t.Artifacts),
+		nullTime(t.NextAttemptAt), string(t.ErrorClass), t.TransientRetries, nullTime(t.ReadyAt), t.CreatedAt)
 	return err
 }
 
 func (s *TaskStore) GetTask(id string) (*Task, error) {
-	t := &Task{}
-	var status, tags, deps string
-	var startedAt, completedAt sql.NullTime
-	err := s.db.QueryRow(`
-		SELECT id, workspace_id, title, description, prompt, status, priority,
-		       assigned_session, result, error, max_retries, retry_count,
-		       tags, depends_on, created_at, started_at, completed_at
-		FROM tasks WHERE id = ?`, id).
-		Scan(&t.ID, &t.WorkspaceID, &t.Title, &t.Description, &t.Prompt,
-			&status, &t.Priority, &t.AssignedSession,
-			&t.Result, &t.Error, &t.MaxRetries, &t.RetryCount,
-			&tags, &deps, &t.CreatedAt, &startedAt, &completedAt)
-	if err != nil {
-		return nil, err
-	}
-	t.Status = TaskStatus(status)
-	json.Unmarshal([]byte(tags), &t.Tags)
-	json.Unmarshal([]byte(deps), &t.DependsOn)
-	if startedAt.Valid {
-		t.StartedAt = startedAt.Time
-	}
-	if completedAt.Valid {
-		t.CompletedAt = completedAt.Time
-	}
-	if t.Tags == nil {
-		t.Tags = []string{}
-	}
-	if t.DependsOn == nil {
-		t.DependsOn = []string{}
-	}
-	return t, nil
+	return scanTask(s.db.QueryRow(`SELECT `+taskColumns+` FROM tasks WHERE id = ?`, id))
 }
 
 func (s *TaskStore) UpdateTask(t *Task) error {
+	_, err := s.updateTask(t, "")
+	return err
+}
+
+// UpdateTaskFrom persists t only if the stored task is still in status from,
+// and reports whether it did. A run finishing and a concurrent CancelTask use
+// it so neither overwrites the other's outcome.
+func (s *TaskStore) UpdateTaskFrom(t *Task, from TaskStatus) (bool, error) {
+	return s.updateTask(t, from)
+}
+
+// updateTask writes t; a non-empty from makes the write conditional on the stored status.
+func (s *TaskStore) updateTask(t *Task, from TaskStatus) (bool, error) {
 	tags, _ := json.Marshal(t.Tags)
 	deps, _ := json.Marshal(t.DependsOn)
 	var startedAt, completedAt *time.Time
@@ -217,15 +415,48 @@ func (s *TaskStore) UpdateTask(t *Task) error {
 	if !t.CompletedAt.IsZero() {
 		completedAt = &t.CompletedAt
 	}
-	_, err := s.db.Exec(`
-		UPDATE tasks SET title=?, description=?, prompt=?, status=?, priority=?,
-		       assigned_session=?, result=?, error=?, max_retries=?, retry_count=?,
-		       tags=?, depends_on=?, started_at=?, completed_at=?
-		WHERE id=?`,
+	// ready_at keeps the time the task entered ready, whichever code path
+	// made it ready; it is cleared once the task leaves the ready state
+	now := time.Now()
+	args := []any{
+		string(t.Status), now,
 		t.Title, t.Description, t.Prompt, string(t.Status), t.Priority,
 		t.AssignedSession, t.Result, t.Error, t.MaxRetries, t.RetryCount,
-		string(tags), string(deps), startedAt, completedAt, t.ID)
-	return err
+		string(tags), string(deps), t.Branch, t.Diff, string(t.MergeStatus),
+		t.VerifyCommand, t.VerifyPrompt, string(t.Verdict), t.VerifyNotes, t.BlockedReason, seconds(t.Timeout),
+		t.RequiresApproval, t.ReviewFeedback,
+		t.Model, t.Temperature, jsonList(t.AllowedTools), t.MaxToolRounds, jsonList(t.Artifacts),
+		nullTime(t.NextAttemptAt), string(t.ErrorClass), t.TransientRetries,
+		startedAt, completedAt, t.ID,
+	}
+	where := "WHERE id=?"
+	if from != "" {
+		where += " AND status=?"
+		args = append(args, string(from))
+	}
+	res, err := s.db.Exec(`
+		UPDATE tasks SET ready_at = CASE WHEN ? = 'ready' THEN COALESCE(CASE WHEN status = 'ready' THEN ready_at END, ?) END,
+		       title=?, description=?, prompt=?, status=?, priority=?,
+		       assigned_session=?, result=?, error=?, max_retries=?, retry_count=?,
+		       tags=?, depends_on=?, branch=?, diff=?, merge_status=?,
+		       verify_command=?, verify_prompt=?, verdict=?, verify_notes=?, blocked_reason=?, timeout=?,
+		       requires_approval=?, review_feedback=?,
+		       model=?, temperature=?, tools=?, max_tool_rounds=?, artifacts=?,
+		       next_attempt_at=?, error_class=?, transient_retries=?,
+		       started_at=?, completed_at=?
+		`+where, args...)
+	if err != nil {
+		return false, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+	if t.Status != StatusReady {
+		t.ReadyAt = time.Time{}
+	} else if t.ReadyAt.IsZero() {
+		t.ReadyAt = now
+	}
+	return true, nil
 }
 
 func (s *TaskStore) DeleteTask(id string) error {
@@ -235,15 +466,17 @@ func (s *TaskStore) DeleteTask(id string) error {
 	}
 	defer tx.Rollback()
 	tx.Exec(`DELETE FROM task_logs WHERE task_id = ?`, id)
+	tx.Exec(`DELETE FROM task_artifacts WHERE task_id = ?`, id)
 	tx.Exec(`DELETE FROM tasks WHERE id = ?`, id)
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	os.RemoveAll(s.taskArtifactDir(id))
+	return nil
 }
 
 func (s *TaskStore) ListTasks(workspaceID string, statusFilter string) ([]*Task, error) {
-	query := `SELECT id, workspace_id, title, description, prompt, status, priority,
-	                 assigned_session, result, error, max_retries, retry_count,
-	                 tags, depends_on, created_at, started_at, completed_at
-	          FROM tasks`
+	query := `SELECT ` + taskColumns + ` FROM tasks`
 	var args []interface{}
 	var conditions []string
 
@@ -270,36 +503,7 @@ func (s *TaskStore) ListTasks(workspaceID string, statusFilter string) ([]*Task,
 		return nil, err
 	}
 	defer rows.Close()
-
-	var result []*Task
-	for rows.Next() {
-		t := &Task{}
-		var status, tags, deps string
-		var startedAt, completedAt sql.NullTime
-		if err := rows.Scan(&t.ID, &t.WorkspaceID, &t.Title, &t.Description, &t.Prompt,
-			&status, &t.Priority, &t.AssignedSession,
-			&t.Result, &t.Error, &t.MaxRetries, &t.RetryCount,
-			&tags, &deps, &t.CreatedAt, &startedAt, &completedAt); err != nil {
-			return nil, err
-		}
-		t.Status = TaskStatus(status)
-		json.Unmarshal([]byte(tags), &t.Tags)
-		json.Unmarshal([]byte(deps), &t.DependsOn)
-		if startedAt.Valid {
-			t.StartedAt = startedAt.Time
-		}
-		if completedAt.Valid {
-			t.CompletedAt = completedAt.Time
-		}
-		if t.Tags == nil {
-			t.Tags = []string{}
-		}
-		if t.DependsOn == nil {
-			t.DependsOn = []string{}
-		}
-		result = append(result, t)
-	}
-	return result, nil
+	return s.scanTasks(rows)
 }
 
 // --- Queries ---
@@ -311,9 +515,7 @@ func (s *TaskStore) GetReadyTasks(workspaceID string, limit int) ([]*Task, error
 
 func (s *TaskStore) listByStatus(workspaceID string, status TaskStatus, limit int) ([]*Task, error) {
 	rows, err := s.db.Query(`
-		SELECT id, workspace_id, title, description, prompt, status, priority,
-		       assigned_session, result, error, max_retries, retry_count,
-		       tags, depends_on, created_at, started_at, completed_at
+		SELECT `+taskColumns+`
 		FROM tasks WHERE workspace_id = ? AND status = ?
 		ORDER BY priority ASC, created_at ASC
 		LIMIT ?`, workspaceID, string(status), limit)
@@ -324,6 +526,13 @@ func (s *TaskStore) listByStatus(workspaceID string, status TaskStatus, limit in
 	return s.scanTasks(rows)
 }
 
+// CountRunning returns the number of running tasks across all workspaces.
+func (s *TaskStore) CountRunning() (int, error) {
+	var n int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM tasks WHERE status = ?`, string(StatusRunning)).Scan(&n)
+	return n, err
+}
+
 // CountByStatus returns task counts grouped by status for a workspace.
 func (s *TaskStore) CountByStatus(workspaceID string) (*StatusCounts, error) {
 	rows, err := s.db.Query(`SELECT status, COUNT(*) FROM tasks WHERE workspace_id = ? GROUP BY status`, workspaceID)
@@ -351,6 +560,10 @@ func (s *TaskStore) CountByStatus(workspaceID string) (*StatusCounts, error) {
 			counts.Failed = count
 		case StatusCancelled:
 			counts.Cancelled = count
+		case StatusBlocked:
+			counts.Blocked = count
+		case StatusReview:
+			counts.Review = count
 		}
 	}
 	return counts, nil
@@ -368,9 +581,7 @@ func (s *TaskStore) GetTasksByIDs(ids []string) ([]*Task, error) {
 		args[i] = id
 	}
 	query := fmt.Sprintf(`
-		SELECT id, workspace_id, title, description, prompt, status, priority,
-		       assigned_session, result, error, max_retries, retry_count,
-		       tags, depends_on, created_at, started_at, completed_at
+		SELECT `+taskColumns+`
 		FROM tasks WHERE id IN (%s)`, strings.Join(placeholders, ","))
 	rows, err := s.db.Query(query, args...)
 	if err != nil {
@@ -384,9 +595,7 @@ func (s *TaskStore) GetTasksByIDs(ids []string) ([]*Task, error) {
 func (s *TaskStore) GetDependents(taskID string) ([]*Task, error) {
 	// SQLite JSON: depends_on is stored as JSON array, search with LIKE
 	rows, err := s.db.Query(`
-		SELECT id, workspace_id, title, description, prompt, status, priority,
-		       assigned_session, result, error, max_retries, retry_count,
-		       tags, depends_on, created_at, started_at, completed_at
+		SELECT `+taskColumns+`
 		FROM tasks WHERE depends_on LIKE ?`, fmt.Sprintf("%%%s%%", taskID))
 	if err != nil {
 		return nil, err
@@ -412,23 +621,32 @@ func (s *TaskStore) GetDependents(taskID string) ([]*Task, error) {
 // CreateFromPlan creates a workspace and all tasks from a PlanResult.
 // Returns the workspace and tasks with their assigned IDs.
 func (s *TaskStore) CreateFromPlan(plan *PlanResult, workspaceID string, goal string, workDir string) (*Workspace, []*Task, error) {
+	ws := &Workspace{
+		ID:          workspaceID,
+		Goal:        goal,
+		Status:      WorkspaceActive,
+		WorkDir:     workDir,
+		MergePolicy: MergeManual,
+	}
+	return s.createFromPlan(plan, ws, nil)
+}
+
+// createFromPlan inserts ws, with the plan's name, concurrency and context,
+// and the plan's tasks in one transaction, so the scheduler never sees the
+// workspace before all its settings are in place. adjust, if set, can change
+// task i before it is stored.
+func (s *TaskStore) createFromPlan(plan *PlanResult, ws *Workspace, adjust func(i int, t *Task)) (*Workspace, []*Task, error) {
 	now := time.Now()
 	maxConcurrent := plan.MaxConcurrent
 	if maxConcurrent <= 0 {
 		maxConcurrent = 3
 	}
-
-	ws := &Workspace{
-		ID:            workspaceID,
-		Name:          plan.WorkspaceName,
-		Goal:          goal,
-		Status:        WorkspaceActive,
-		MaxConcurrent: maxConcurrent,
-		Context:       plan.WorkspaceContext,
-		WorkDir:       workDir,
-		CreatedAt:     now,
-		UpdatedAt:     now,
-	}
+	workspaceID := ws.ID
+	ws.Name = plan.WorkspaceName
+	ws.MaxConcurrent = maxConcurrent
+	ws.Context = plan.WorkspaceContext
+	ws.CreatedAt = now
+	ws.UpdatedAt = now
 
 	tx, err := s.db.Begin()
 	if err != nil {
@@ -438,11 +656,14 @@ func (s *TaskStore) CreateFromPlan(plan *PlanResult, workspaceID string, goal st
 
 	// Insert workspace
 	_, err = tx.Exec(`
-		INSERT INTO workspaces (id, name, description, goal, status, max_concurrent, context, work_dir, summary, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		INSERT INTO workspaces (id, name, description, goal, status, max_concurrent, context, work_dir, summary, merge_policy, task_timeout, approval_tags,
+			max_tokens, max_cost, token_price, max_duration, max_tool_calls, schedule_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		ws.ID, ws.Name, ws.Description, ws.Goal, string(ws.Status),
 		ws.MaxConcurrent, ws.Context, ws.WorkDir, ws.Summary,
-		ws.CreatedAt, ws.UpdatedAt)
+		string(ws.MergePolicy), seconds(ws.TaskTimeout), jsonList(ws.ApprovalTags),
+		ws.Budget.MaxTokens, ws.Budget.MaxCost, ws.Budget.TokenPrice, seconds(ws.Budget.MaxDuration), ws.Budget.MaxToolCalls,
+		ws.ScheduleID, ws.CreatedAt, ws.UpdatedAt)
 	if err != nil {
 		return nil, nil, fmt.Errorf("create workspace: %w", err)
 	}
@@ -481,6 +702,19 @@ func (s *TaskStore) CreateFromPlan(plan *PlanResult, workspaceID string, goal st
 			Tags:        pt.Tags,
 			DependsOn:   deps,
 			CreatedAt:   now,
+
+			VerifyCommand:    pt.VerifyCommand,
+			VerifyPrompt:     pt.VerifyPrompt,
+			RequiresApproval: pt.RequiresApproval,
+
+			Model:         pt.Model,
+			Temperature:   pt.Temperature,
+			AllowedTools:  pt.Tools,
+			MaxToolRounds: pt.MaxToolRounds,
+			Artifacts:     pt.Artifacts,
+		}
+		if status == StatusReady {
+			t.ReadyAt = now
 		}
 		if t.Tags == nil {
 			t.Tags = []string{}
@@ -488,16 +722,21 @@ func (s *TaskStore) CreateFromPlan(plan *PlanResult, workspaceID string, goal st
 		if t.DependsOn == nil {
 			t.DependsOn = []string{}
 		}
+		if adjust != nil {
+			adjust(i, t)
+		}
 
 		tagsJSON, _ := json.Marshal(t.Tags)
 		depsJSON, _ := json.Marshal(t.DependsOn)
 
 		_, err = tx.Exec(`
-			INSERT INTO tasks (id, workspace_id, title, description, prompt, status, priority, max_retries, tags, depends_on, created_at)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			INSERT INTO tasks (id, workspace_id, title, description, prompt, status, priority, max_retries, tags, depends_on, verify_command, verify_prompt, requires_approval, timeout, model, temperature, tools, max_tool_rounds, artifacts, ready_at, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 			t.ID, t.WorkspaceID, t.Title, t.Description, t.Prompt,
 			string(t.Status), t.Priority, t.MaxRetries,
-			string(tagsJSON), string(depsJSON), t.CreatedAt)
+			string(tagsJSON), string(depsJSON),
+			t.VerifyCommand, t.VerifyPrompt, t.RequiresApproval, seconds(t.Timeout),
+			t.Model, t.Temperature, jsonList(t.AllowedTools), t.MaxToolRounds, jsonList(t.Artifacts), nullTime(t.ReadyAt), t.CreatedAt)
 		if err != nil {
 			return nil, nil, fmt.Errorf("create task %d: %w", i, err)
 		}
@@ -541,11 +780,236 @@ func (s *TaskStore) GetTaskLog(taskID string, limit int) ([]*TaskLog, error) {
 	return result, nil
 }
 
+// GetTaskLogTail returns the last limit log entries of a task, oldest first.
+func (s *TaskStore) GetTaskLogTail(taskID string, limit int) ([]*TaskLog, error) {
+	rows, err := s.db.Query(`
+		SELECT id, task_id, event_type, content, tool_name, timestamp FROM (
+			SELECT id, task_id, event_type, content, tool_name, timestamp
+			FROM task_logs WHERE task_id = ?
+			ORDER BY id DESC LIMIT ?
+		) ORDER BY id ASC`, taskID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []*TaskLog
+	for rows.Next() {
+		l := &TaskLog{}
+		if err := rows.Scan(&l.ID, &l.TaskID, &l.EventType, &l.Content, &l.ToolName, &l.Timestamp); err != nil {
+			return nil, err
+		}
+		result = append(result, l)
+	}
+	return result, nil
+}
+
+// --- Task Artifacts ---
+
+// SaveArtifact stores data as the task's artifact name, replacing an earlier
+// artifact of the same name (e.g. from a previous attempt).
+func (s *TaskStore) SaveArtifact(taskID, name string, kind ArtifactKind, data []byte) (*Artifact, error) {
+	path, err := s.artifactPath(taskID, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create artifact dir: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return nil, fmt.Errorf("write artifact: %w", err)
+	}
+	now := time.Now()
+	_, err = s.db.Exec(`
+		INSERT INTO task_artifacts (task_id, name, kind, size, created_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(task_id, name) DO UPDATE SET kind=excluded.kind, size=excluded.size, created_at=excluded.created_at`,
+		taskID, name, string(kind), len(data), now)
+	if err != nil {
+		return nil, err
+	}
+	return &Artifact{TaskID: taskID, Name: name, Kind: kind, Size: int64(len(data)), Path: path, CreatedAt: now}, nil
+}
+
+// ListArtifacts returns the artifacts of a task in the order they were stored.
+func (s *TaskStore) ListArtifacts(taskID string) ([]*Artifact, error) {
+	rows, err := s.db.Query(`
+		SELECT task_id, name, kind, size, created_at
+		FROM task_artifacts WHERE task_id = ?
+		ORDER BY id ASC`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []*Artifact
+	for rows.Next() {
+		a := &Artifact{}
+		var kind string
+		if err := rows.Scan(&a.TaskID, &a.Name, &kind, &a.Size, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		a.Kind = ArtifactKind(kind)
+		a.Path, _ = s.artifactPath(a.TaskID, a.Name)
+		result = append(result, a)
+	}
+	return result, nil
+}
+
+// ReadArtifact returns an artifact's metadata and content.
+func (s *TaskStore) ReadArtifact(taskID, name string) (*Artifact, []byte, error) {
+	artifacts, err := s.ListArtifacts(taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, a := range artifacts {
+		if a.Name == name {
+			data, err := os.ReadFile(a.Path)
+			if err != nil {
+				return nil, nil, fmt.Errorf("read artifact: %w", err)
+			}
+			return a, data, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("task %s has no artifact %q", taskID, name)
+}
+
+func (s *TaskStore) taskArtifactDir(taskID string) string {
+	return filepath.Join(s.artifactDir, taskID)
+}
+
+// artifactPath maps an artifact name to its file, rejecting names that
+// would escape the task's artifact directory.
+func (s *TaskStore) artifactPath(taskID, name string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(name))
+	if name == "" || filepath.IsAbs(clean) || clean == "." || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid artifact name %q", name)
+	}
+	return filepath.Join(s.taskArtifactDir(taskID), clean), nil
+}
+
+// --- Plan Drafts ---
+
+func (s *TaskStore) CreatePlanDraft(d *PlanDraft) error {
+	planJSON, err := json.Marshal(d.Plan)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		INSERT INTO plan_drafts (id, goal, work_dir, plan_json, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		d.ID, d.Goal, d.WorkDir, string(planJSON), d.CreatedAt, d.UpdatedAt)
+	return err
+}
+
+func (s *TaskStore) GetPlanDraft(id string) (*PlanDraft, error) {
+	return scanPlanDraft(s.db.QueryRow(`
+		SELECT id, goal, work_dir, plan_json, created_at, updated_at
+		FROM plan_drafts WHERE id = ?`, id))
+}
+
+func (s *TaskStore) UpdatePlanDraft(d *PlanDraft) error {
+	planJSON, err := json.Marshal(d.Plan)
+	if err != nil {
+		return err
+	}
+	d.UpdatedAt = time.Now()
+	_, err = s.db.Exec(`UPDATE plan_drafts SET goal=?, work_dir=?, plan_json=?, updated_at=? WHERE id=?`,
+		d.Goal, d.WorkDir, string(planJSON), d.UpdatedAt, d.ID)
+	return err
+}
+
+func (s *TaskStore) DeletePlanDraft(id string) error {
+	_, err := s.db.Exec(`DELETE FROM plan_drafts WHERE id = ?`, id)
+	return err
+}
+
+func (s *TaskStore) ListPlanDrafts() ([]*PlanDraft, error) {
+	rows, err := s.db.Query(`
+		SELECT id, goal, work_dir, plan_json, created_at, updated_at
+		FROM plan_drafts ORDER BY updated_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []*PlanDraft
+	for rows.Next() {
+		d, err := scanPlanDraft(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, d)
+	}
+	return result, nil
+}
+
+// --- Workspace Schedules ---
+
+const scheduleColumns = `id, template_id, cron, channel, target, enabled,
+	last_run, next_run, last_workspace_id, last_error, last_summary, created_at, updated_at`
+
+func (s *TaskStore) CreateSchedule(sc *WorkspaceSchedule) error {
+	_, err := s.db.Exec(`
+		INSERT INTO workspace_schedules (`+scheduleColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		sc.ID, sc.TemplateID, sc.Cron, sc.Channel, sc.Target, sc.Enabled,
+		nullTime(sc.LastRun), nullTime(sc.NextRun), sc.LastWorkspaceID, sc.LastError, sc.LastSummary,
+		sc.CreatedAt, sc.UpdatedAt)
+	return err
+}
+
+func (s *TaskStore) GetSchedule(id string) (*WorkspaceSchedule, error) {
+	return scanSchedule(s.db.QueryRow(`SELECT `+scheduleColumns+` FROM workspace_schedules WHERE id = ?`, id))
+}
+
+func (s *TaskStore) UpdateSchedule(sc *WorkspaceSchedule) error {
+	sc.UpdatedAt = time.Now()
+	_, err := s.db.Exec(`
+		UPDATE workspace_schedules SET cron=?, channel=?, target=?, enabled=?,
+		       last_run=?, next_run=?, last_workspace_id=?, last_error=?, last_summary=?, updated_at=?
+		WHERE id=?`,
+		sc.Cron, sc.Channel, sc.Target, sc.Enabled,
+		nullTime(sc.LastRun), nullTime(sc.NextRun), sc.LastWorkspaceID, sc.LastError, sc.LastSummary, sc.UpdatedAt,
+		sc.ID)
+	return err
+}
+
+// SetScheduleReport stores the report of the schedule's run in workspaceID
+// without touching the rest of the schedule; it does nothing once a newer run
+// has replaced that one. An empty lastError keeps the current one.
+func (s *TaskStore) SetScheduleReport(id, workspaceID, summary, lastError string) error {
+	_, err := s.db.Exec(`
+		UPDATE workspace_schedules SET last_summary=?,
+		       last_error=CASE WHEN ? = '' THEN last_error ELSE ? END, updated_at=?
+		WHERE id=? AND last_workspace_id=?`,
+		summary, lastError, lastError, time.Now(), id, workspaceID)
+	return err
+}
+
+func (s *TaskStore) DeleteSchedule(id string) error {
+	_, err := s.db.Exec(`DELETE FROM workspace_schedules WHERE id = ?`, id)
+	return err
+}
+
+func (s *TaskStore) ListSchedules() ([]*WorkspaceSchedule, error) {
+	rows, err := s.db.Query(`SELECT ` + scheduleColumns + ` FROM workspace_schedules ORDER BY created_at ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []*WorkspaceSchedule
+	for rows.Next() {
+		sc, err := scanSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, sc)
+	}
+	return result, nil
+}
+
 // --- Recovery ---
 
 // RecoverRunningTasks resets any tasks left in running state (from a daemon crash) back to ready.
 func (s *TaskStore) RecoverRunningTasks() (int64, error) {
-	res, err := s.db.Exec(`UPDATE tasks SET status = 'ready', assigned_session = '' WHERE status = 'running'`)
+	res, err := s.db.Exec(`UPDATE tasks SET status = 'ready', assigned_session = '', ready_at = ? WHERE status = 'running'`, time.Now())
 	if err != nil {
 		return 0, err
 	}
@@ -557,31 +1021,142 @@ func (s *TaskStore) RecoverRunningTasks() (int64, error) {
 func (s *TaskStore) scanTasks(rows *sql.Rows) ([]*Task, error) {
 	var result []*Task
 	for rows.Next() {
-		t := &Task{}
-		var status, tags, deps string
-		var startedAt, completedAt sql.NullTime
-		if err := rows.Scan(&t.ID, &t.WorkspaceID, &t.Title, &t.Description, &t.Prompt,
-			&status, &t.Priority, &t.AssignedSession,
-			&t.Result, &t.Error, &t.MaxRetries, &t.RetryCount,
-			&tags, &deps, &t.CreatedAt, &startedAt, &completedAt); err != nil {
+		t, err := scanTask(rows)
+		if err != nil {
 			return nil, err
 		}
-		t.Status = TaskStatus(status)
-		json.Unmarshal([]byte(tags), &t.Tags)
-		json.Unmarshal([]byte(deps), &t.DependsOn)
-		if startedAt.Valid {
-			t.StartedAt = startedAt.Time
-		}
-		if completedAt.Valid {
-			t.CompletedAt = completedAt.Time
-		}
-		if t.Tags == nil {
-			t.Tags = []string{}
-		}
-		if t.DependsOn == nil {
-			t.DependsOn = []string{}
-		}
 		result = append(result, t)
 	}
 	return result, nil
 }
+
+func scanTask(row rowScanner) (*Task, error) {
+	t := &Task{}
+	var status, tags, deps, mergeStatus, verdict, allowedTools, artifacts, errorClass string
+	var timeout int64
+	var requiresApproval bool
+	var temperature sql.NullFloat64
+	var nextAttemptAt, readyAt, startedAt, completedAt sql.NullTime
+	if err := row.Scan(&t.ID, &t.WorkspaceID, &t.Title, &t.Description, &t.Prompt,
+		&status, &t.Priority, &t.AssignedSession,
+		&t.Result, &t.Error, &t.MaxRetries, &t.RetryCount,
+		&tags, &deps, &t.Branch, &t.Diff, &mergeStatus,
+		&t.VerifyCommand, &t.VerifyPrompt, &verdict, &t.VerifyNotes, &t.BlockedReason, &timeout,
+		&requiresApproval, &t.ReviewFeedback,
+		&t.Model, &temperature, &allowedTools, &t.MaxToolRounds, &artifacts,
+		&nextAttemptAt, &errorClass, &t.TransientRetries,
+		&readyAt, &t.CreatedAt, &startedAt, &completedAt); err != nil {
+		return nil, err
+	}
+	t.Status = TaskStatus(status)
+	t.MergeStatus = MergeStatus(mergeStatus)
+	t.Verdict = Verdict(verdict)
+	t.Timeout = time.Duration(timeout) * time.Second
+	t.RequiresApproval = requiresApproval
+	if temperature.Valid {
+		t.Temperature = &temperature.Float64
+	}
+	json.Unmarshal([]byte(tags), &t.Tags)
+	json.Unmarshal([]byte(allowedTools), &t.AllowedTools)
+	json.Unmarshal([]byte(artifacts), &t.Artifacts)
+	json.Unmarshal([]byte(deps), &t.DependsOn)
+	t.ErrorClass = ErrorClass(errorClass)
+	if nextAttemptAt.Valid {
+		t.NextAttemptAt = nextAttemptAt.Time
+	}
+	if readyAt.Valid {
+		t.ReadyAt = readyAt.Time
+	}
+	if startedAt.Valid {
+		t.StartedAt = startedAt.Time
+	}
+	if completedAt.Valid {
+		t.CompletedAt = completedAt.Time
+	}
+	if t.Tags == nil {
+		t.Tags = []string{}
+	}
+	if t.DependsOn == nil {
+		t.DependsOn = []string{}
+	}
+	return t, nil
+}
+
+func scanWorkspace(row rowScanner) (*Workspace, error) {
+	ws := &Workspace{}
+	var status, mergePolicy, approvalTags string
+	var taskTimeout, maxDuration int64
+	var startedAt sql.NullTime
+	if err := row.Scan(&ws.ID, &ws.Name, &ws.Description, &ws.Goal, &status,
+		&ws.MaxConcurrent, &ws.Context, &ws.WorkDir, &ws.Summary,
+		&mergePolicy, &taskTimeout, &approvalTags,
+		&ws.Budget.MaxTokens, &ws.Budget.MaxCost, &ws.Budget.TokenPrice, &maxDuration, &ws.Budget.MaxToolCalls,
+		&ws.Usage.Tokens, &ws.Usage.ToolCalls, &startedAt, &ws.ScheduleID,
+		&ws.CreatedAt, &ws.UpdatedAt); err != nil {
+		return nil, err
+	}
+	ws.Budget.MaxDuration = time.Duration(maxDuration) * time.Second
+	if startedAt.Valid {
+		ws.Usage.StartedAt = startedAt.Time
+	}
+	json.Unmarshal([]byte(approvalTags), &ws.ApprovalTags)
+	ws.TaskTimeout = time.Duration(taskTimeout) * time.Second
+	ws.Status = WorkspaceStatus(status)
+	ws.MergePolicy = MergePolicy(mergePolicy)
+	if ws.MergePolicy == "" {
+		ws.MergePolicy = MergeManual
+	}
+	return ws, nil
+}
+
+func scanSchedule(row rowScanner) (*WorkspaceSchedule, error) {
+	sc := &WorkspaceSchedule{}
+	var lastRun, nextRun sql.NullTime
+	if err := row.Scan(&sc.ID, &sc.TemplateID, &sc.Cron, &sc.Channel, &sc.Target, &sc.Enabled,
+		&lastRun, &nextRun, &sc.LastWorkspaceID, &sc.LastError, &sc.LastSummary,
+		&sc.CreatedAt, &sc.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if lastRun.Valid {
+		sc.LastRun = lastRun.Time
+	}
+	if nextRun.Valid {
+		sc.NextRun = nextRun.Time
+	}
+	return sc, nil
+}
+
+// nullTime stores a zero time as NULL.
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// jsonList encodes a string list for a JSON column, storing nil as [].
+func jsonList(list []string) string {
+	if list == nil {
+		return "[]"
+	}
+	data, _ := json.Marshal(list)
+	return string(data)
+}
+
+// seconds converts a timeout to the whole seconds stored in the database.
+func seconds(d time.Duration) int64 {
+	return int64(d / time.Second)
+}
+
+func scanPlanDraft(row rowScanner) (*PlanDraft, error) {
+	d := &PlanDraft{}
+	var planJSON string
+	if err := row.Scan(&d.ID, &d.Goal, &d.WorkDir, &planJSON, &d.CreatedAt, &d.UpdatedAt); err != nil {
+		return nil, err
+	}
+	d.Plan = &PlanResult{}
+	if err := json.Unmarshal([]byte(planJSON), d.Plan); err != nil {
+		return nil, fmt.Errorf("parse draft %s: %w", d.ID, err)
+	}
+	return d, nil
+}
@@ -15,17 +15,23 @@ const (
 	StatusDone      TaskStatus = "done"
 	StatusFailed    TaskStatus = "failed"
 	StatusCancelled TaskStatus = "cancelled"
+	StatusBlocked   TaskStatus = "blocked" // a dependency failed or was cancelled
+	StatusReview    TaskStatus = "review"  // finished, waiting for a person to approve or reject
 )
 
 // ValidTransition checks if a task status transition is allowed.
 func (s TaskStatus) ValidTransition(to TaskStatus) bool {
 	switch s {
 	case StatusBacklog:
-		return to == StatusReady || to == StatusCancelled
+		return to == StatusReady || to == StatusCancelled || to == StatusBlocked
+	case StatusBlocked:
+		return to == StatusBacklog || to == StatusReady || to == StatusCancelled // unblocked by a retry
 	case StatusReady:
 		return to == StatusRunning || to == StatusBacklog || to == StatusCancelled
 	case StatusRunning:
-		return to == StatusDone || to == StatusFailed || to == StatusCancelled
+		return to == StatusDone || to == StatusFailed || to == StatusCancelled || to == StatusReview
+	case StatusReview:
+		return to == StatusDone || to == StatusReady || to == StatusCancelled // approve, reject or cancel
 	case StatusFailed:
 		return to == StatusReady || to == StatusCancelled // retry or cancel
 	case StatusDone, StatusCancelled:
@@ -46,42 +52,101 @@ const (
 	WorkspaceActive   WorkspaceStatus = "active"
 	WorkspacePaused   WorkspaceStatus = "paused"
 	WorkspaceArchived WorkspaceStatus = "archived"
+	WorkspaceTemplate WorkspaceStatus = "template" // never scheduled; cloned by WorkspaceSchedule runs
+)
+
+// MergePolicy controls how a finished task's worktree branch is merged back.
+type MergePolicy string
+
+const (
+	MergeManual MergePolicy = "manual" // wait for an explicit MergeTask call
+	MergeAuto   MergePolicy = "auto"   // fast-forward automatically when the task is done
+)
+
+// Valid reports whether p is a known merge policy.
+func (p MergePolicy) Valid() bool {
+	return p == MergeManual || p == MergeAuto
+}
+
+// MergeStatus tracks the merge state of a task branch.
+type MergeStatus string
+
+const (
+	MergeNone    MergeStatus = ""        // no branch or nothing to merge
+	MergePending MergeStatus = "pending" // branch has changes awaiting merge
+	MergeMerged  MergeStatus = "merged"
+	MergeFailed  MergeStatus = "failed" // fast-forward not possible
+)
+
+// Verdict is the outcome of a task's acceptance checks.
+type Verdict string
+
+const (
+	VerdictNone   Verdict = "" // no acceptance criteria, or not yet verified
+	VerdictPassed Verdict = "passed"
+	VerdictFailed Verdict = "failed"
 )
 
 // Workspace groups related tasks with shared context and concurrency control.
 type Workspace struct {
-	ID             string
-	Name           string
-	Description    string
-	Goal           string // user's original goal (vague statement)
-	Status         WorkspaceStatus
-	MaxConcurrent  int
-	Context        string // system prompt injected into all task sessions (Planner-generated)
-	WorkDir        string
-	Summary        string // Synthesizer-generated completion report
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	ID            string
+	Name          string
+	Description   string
+	Goal          string // user's original goal (vague statement)
+	Status        WorkspaceStatus
+	MaxConcurrent int
+	Context       string // system prompt injected into all task sessions (Planner-generated)
+	WorkDir       string
+	Summary       string // Synthesizer-generated completion report
+	MergePolicy   MergePolicy
+	TaskTimeout   time.Duration // default limit for each task run; 0 = no limit
+	ApprovalTags  []string      // tasks carrying any of these tags require approval
+	Budget        Budget
+	Usage         BudgetUsage // maintained by the scheduler; not written by UpdateWorkspace
+	ScheduleID    string      // schedule that instantiated this workspace, empty if created directly
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 }
 
 // Task is a single unit of work within a workspace.
 type Task struct {
-	ID              string
-	WorkspaceID     string
-	Title           string
-	Description     string
-	Prompt          string
-	Status          TaskStatus
-	Priority        int // 0=critical, 1=high, 2=normal, 3=low
-	AssignedSession string
-	Result          string
-	Error           string
-	MaxRetries      int
-	RetryCount      int
-	Tags            []string
-	DependsOn       []string // task IDs
-	CreatedAt       time.Time
-	StartedAt       time.Time
-	CompletedAt     time.Time
+	ID               string
+	WorkspaceID      string
+	Title            string
+	Description      string
+	Prompt           string
+	Status           TaskStatus
+	Priority         int // 0=critical, 1=high, 2=normal, 3=low
+	AssignedSession  string
+	Result           string
+	Error            string
+	MaxRetries       int
+	RetryCount       int
+	Tags             []string
+	DependsOn        []string // task IDs
+	Branch           string   // git branch the task ran on (kele/<task-id>), empty if not a git workspace
+	Diff             string   // diff of Branch against its merge base
+	MergeStatus      MergeStatus
+	VerifyCommand    string        // shell command run in the task's work dir after execution; exit 0 = pass
+	VerifyPrompt     string        // acceptance criteria for an LLM reviewer
+	Verdict          Verdict       // outcome of the last verification
+	VerifyNotes      string        // check output / reviewer notes behind Verdict, fed into retries
+	BlockedReason    string        // why the task is blocked (set with StatusBlocked)
+	Timeout          time.Duration // limit for each run; 0 falls back to the workspace TaskTimeout
+	RequiresApproval bool          // a finished run waits in review for ApproveTask/RejectTask
+	ReviewFeedback   string        // feedback from the last rejection, fed into the next attempt
+	Model            string        // model name or tier ("small"/"large"); empty uses the daemon's model
+	Temperature      *float64      // sampling temperature; nil uses the configured one
+	AllowedTools     []string      // tool whitelist; empty exposes every tool
+	MaxToolRounds    int           // tool call rounds per run; 0 uses the configured limit
+	Artifacts        []string      // files (globs relative to the work dir) collected after a successful run
+	NextAttemptAt    time.Time     // a retried task is not started before this time
+	ErrorClass       ErrorClass    // class of the last run error
+	TransientRetries int           // retries after transient errors; these do not count against MaxRetries
+	ReadyAt          time.Time     // when the task last became ready; drives priority aging
+	CreatedAt        time.Time
+	StartedAt        time.Time
+	CompletedAt      time.Time
 }
 
 // TaskLog records a single event during task execution.
@@ -102,10 +167,18 @@ const (
 	EventTaskCompleted      = "task_completed"
 	EventTaskFailed         = "task_failed"
 	EventTaskCancelled      = "task_cancelled"
+	EventTaskMerged         = "task_merged"
+	EventTaskBlocked        = "task_blocked"
+	EventTaskUnblocked      = "task_unblocked"
+	EventTaskReview         = "task_review"
+	EventTaskApproved       = "task_approved"
+	EventTaskRejected       = "task_rejected"
 	EventWorkspaceCreated   = "workspace_created"
 	EventWorkspacePaused    = "workspace_paused"
 	EventWorkspaceResumed   = "workspace_resumed"
 	EventWorkspaceCompleted = "workspace_completed"
+
+	EventWorkspaceBudgetExceeded = "workspace_budget_exceeded"
 )
 
 // BoardEvent is a state change notification broadcast to subscribers.
@@ -125,16 +198,18 @@ type StatusCounts struct {
 	Done      int
 	Failed    int
 	Cancelled int
+	Blocked   int
+	Review    int
 }
 
 // Total returns the total number of tasks.
 func (c StatusCounts) Total() int {
-	return c.Backlog + c.Ready + c.Running + c.Done + c.Failed + c.Cancelled
+	return c.Backlog + c.Ready + c.Running + c.Done + c.Failed + c.Cancelled + c.Blocked + c.Review
 }
 
 // AllDone returns true if all non-cancelled tasks are done.
 func (c StatusCounts) AllDone() bool {
-	return c.Backlog == 0 && c.Ready == 0 && c.Running == 0 && c.Failed == 0 && c.Done > 0
+	return c.Backlog == 0 && c.Ready == 0 && c.Running == 0 && c.Failed == 0 && c.Blocked == 0 && c.Review == 0 && c.Done > 0
 }
 
 // PlanResult is the structured output from the Planner AI agent.
@@ -153,6 +228,18 @@ type PlannedTask struct {
 	Priority    int      `json:"priority"`
 	DependsOn   []int    `json:"depends_on"` // indices into Tasks slice
 	Tags        []string `json:"tags,omitempty"`
+
+	VerifyCommand string `json:"verify_command,omitempty"` // acceptance check, e.g. "go test ./..."
+	VerifyPrompt  string `json:"verify_prompt,omitempty"`  // acceptance criteria for the LLM reviewer
+
+	RequiresApproval bool `json:"requires_approval,omitempty"` // hold the result for human review
+
+	Model         string   `json:"model,omitempty"`           // "small" for cheap tasks, "large" or a model name
+	Temperature   *float64 `json:"temperature,omitempty"`     // sampling temperature
+	Tools         []string `json:"tools,omitempty"`           // tool whitelist, e.g. read-only tools for reviews
+	MaxToolRounds int      `json:"max_tool_rounds,omitempty"` // tool call rounds per run
+
+	Artifacts []string `json:"artifacts,omitempty"` // files the task produces for its dependents
 }
 
 // Validate checks the PlanResult for basic correctness.
@@ -179,5 +266,19 @@ func (p *PlanResult) Validate() error {
 			}
 		}
 	}
+	if _, err := p.Levels(); err != nil {
+		return err
+	}
 	return nil
 }
+
+// PlanDraft is a planner result kept server-side so it can be edited
+// before being approved into a workspace.
+type PlanDraft struct {
+	ID        string
+	Goal      string
+	WorkDir   string
+	Plan      *PlanResult
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
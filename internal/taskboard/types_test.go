@@ -13,6 +13,10 @@ func TestTaskStatusTransitions(t *testing.T) {
 		{StatusBacklog, StatusReady, true},
 		{StatusBacklog, StatusCancelled, true},
 		{StatusBacklog, StatusRunning, false},
+		{StatusBacklog, StatusBlocked, true},
+		{StatusBlocked, StatusBacklog, true},
+		{StatusBlocked, StatusCancelled, true},
+		{StatusBlocked, StatusRunning, false},
 		{StatusReady, StatusRunning, true},
 		{StatusReady, StatusBacklog, true},
 		{StatusReady, StatusCancelled, true},
@@ -20,6 +24,10 @@ func TestTaskStatusTransitions(t *testing.T) {
 		{StatusRunning, StatusFailed, true},
 		{StatusRunning, StatusCancelled, true},
 		{StatusRunning, StatusReady, false},
+		{StatusRunning, StatusReview, true},
+		{StatusReview, StatusDone, true},
+		{StatusReview, StatusReady, true},
+		{StatusReview, StatusFailed, false},
 		{StatusFailed, StatusReady, true},
 		{StatusFailed, StatusCancelled, true},
 		{StatusDone, StatusReady, false},
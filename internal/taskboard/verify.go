@@ -0,0 +1,192 @@
+package taskboard
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// verifyTimeout bounds a task's acceptance check command.
+const verifyTimeout = 10 * time.Minute
+
+// maxVerifyOutput caps check output kept in notes and fed to the reviewer.
+const maxVerifyOutput = 4000
+
+const reviewerPrompt = `你是一个严格的代码审查员，负责验收另一个 AI agent 完成的任务。
+你可以使用只读工具查看工作目录中的文件和 git 记录来核实结果，无法修改任何文件。
+
+## 任务
+%s
+
+## 验收标准
+%s
+
+## 执行 agent 的输出
+%s
+%s
+请根据验收标准判断任务是否真正完成。
+回复的第一行必须是 "VERDICT: PASS" 或 "VERDICT: FAIL"，之后写出具体的审查意见；
+如果不通过，请列出需要修正的问题，这些意见会交给执行 agent 重试。`
+
+// NeedsVerification reports whether the task has acceptance criteria.
+func (t *Task) NeedsVerification() bool {
+	return t.VerifyCommand != "" || t.VerifyPrompt != ""
+}
+
+// verifyTask runs the task's acceptance checks in workDir after a successful
+// execution. The check command runs first; the LLM reviewer only runs if it
+// passes. The verdict is stored on the task and recorded in the task log;
+// a failed verification is returned as an error so the normal retry path
+// re-runs the task with the notes in its prompt.
+func (s *Scheduler) verifyTask(ctx context.Context, ws *Workspace, task *Task, workDir, result string) error {
+	if !task.NeedsVerification() {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var checkOutput string
+	if task.VerifyCommand != "" {
+		out, err := runCheckCommand(ctx, workDir, task.VerifyCommand)
+		checkOutput = truncateResult(out, maxVerifyOutput)
+		if ctx.Err() != nil {
+			// Stopped from outside; that says nothing about the task's work
+			return ctx.Err()
+		}
+		if err != nil {
+			notes := fmt.Sprintf("验收命令 `%s` 失败: %v\n%s", task.VerifyCommand, err, checkOutput)
+			s.board.Store().AppendTaskLog(task.ID, "verify", notes, "check")
+			return s.recordVerdict(task, VerdictFailed, notes)
+		}
+		s.board.Store().AppendTaskLog(task.ID, "verify",
+			fmt.Sprintf("验收命令 `%s` 通过\n%s", task.VerifyCommand, checkOutput), "check")
+	}
+
+	if task.VerifyPrompt != "" {
+		passed, review, err := s.reviewTask(ctx, ws, task, workDir, result, checkOutput)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			notes := fmt.Sprintf("审查失败: %v", err)
+			s.board.Store().AppendTaskLog(task.ID, "verify", notes, "review")
+			return s.recordVerdict(task, VerdictFailed, notes)
+		}
+		s.board.Store().AppendTaskLog(task.ID, "verify", review, "review")
+		if !passed {
+			return s.recordVerdict(task, VerdictFailed, review)
+		}
+		return s.recordVerdict(task, VerdictPassed, review)
+	}
+
+	return s.recordVerdict(task, VerdictPassed, checkOutput)
+}
+
+// recordVerdict stores the verdict on the task and logs it; it returns an
+// error for a failed verdict.
+func (s *Scheduler) recordVerdict(task *Task, verdict Verdict, notes string) error {
+	task.Verdict = verdict
+	task.VerifyNotes = strings.TrimSpace(notes)
+	s.board.Store().AppendTaskLog(task.ID, "verdict", string(verdict), "")
+	if verdict == VerdictFailed {
+		return fmt.Errorf("verification failed: %s", firstLine(task.VerifyNotes))
+	}
+	return nil
+}
+
+// reviewTask asks a read-only LLM reviewer session, running in the task's
+// work dir, whether the task meets its acceptance criteria.
+func (s *Scheduler) reviewTask(ctx context.Context, ws *Workspace, task *Task, workDir, result, checkOutput string) (bool, string, error) {
+	sess := s.sessions.CreateTaskSession(fmt.Sprintf("reviewer:%s", task.ID), TaskSessionOptions{
+		WorkDir:     workDir,
+		ReadOnly:    true,
+		WorkspaceID: ws.ID,
+	})
+	defer s.sessions.DeleteTaskSession(sess.GetID())
+
+	if ws.Context != "" {
+		sess.InjectContext(ws.Context)
+	}
+
+	var check string
+	if task.VerifyCommand != "" {
+		check = fmt.Sprintf("\n## 验收命令 `%s` 的输出（已通过）\n%s\n", task.VerifyCommand, checkOutput)
+	}
+	prompt := fmt.Sprintf(reviewerPrompt,
+		task.Prompt, task.VerifyPrompt, truncateResult(result, maxVerifyOutput), check)
+
+	events, err := sess.ChatStream(ctx, prompt)
+	if err != nil {
+		return false, "", fmt.Errorf("chat stream: %w", err)
+	}
+	var review strings.Builder
+	for ev := range events {
+		switch ev.Type {
+		case "content":
+			review.WriteString(ev.Content)
+		case "usage":
+			s.board.Store().AddWorkspaceUsage(ws.ID, int64(ev.Tokens), 0)
+		case "error":
+			if ev.Error != "" {
+				return false, "", fmt.Errorf("llm error: %s", ev.Error)
+			}
+		}
+	}
+
+	text := strings.TrimSpace(review.String())
+	passed, ok := parseVerdict(text)
+	if !ok {
+		return false, "", fmt.Errorf("reviewer gave no verdict: %s", firstLine(text))
+	}
+	return passed, text, nil
+}
+
+// parseVerdict looks for "VERDICT: PASS|FAIL" in the reviewer's reply,
+// preferring the first line but tolerating leading preamble. Only the first
+// word after "VERDICT:" counts, so "VERDICT: FAIL (would PASS after fix)"
+// is a failure.
+func parseVerdict(text string) (passed bool, ok bool) {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.ToUpper(strings.Trim(strings.TrimSpace(line), "*#` "))
+		rest, found := strings.CutPrefix(line, "VERDICT")
+		if !found {
+			continue
+		}
+		words := strings.Fields(strings.TrimLeft(rest, ":：*` "))
+		if len(words) == 0 {
+			continue
+		}
+		word := strings.TrimFunc(words[0], func(r rune) bool { return r < 'A' || r > 'Z' })
+		switch word {
+		case "PASS", "PASSED":
+			return true, true
+		case "FAIL", "FAILED":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// runCheckCommand runs a shell command in dir and returns its combined output.
+func runCheckCommand(parent context.Context, dir, command string) (string, error) {
+	ctx, cancel := context.WithTimeout(parent, verifyTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return string(out), fmt.Errorf("timed out after %s", verifyTimeout)
+	}
+	return string(out), err
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,158 @@
+package taskboard
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// scriptedSession replies to every ChatStream call with a fixed content event.
+type scriptedSession struct {
+	id    string
+	reply string
+	input string
+}
+
+func (s *scriptedSession) GetID() string        { return s.id }
+func (s *scriptedSession) InjectContext(string) {}
+func (s *scriptedSession) ChatStream(_ context.Context, input string) (<-chan SessionEvent, error) {
+	s.input = input
+	ch := make(chan SessionEvent, 1)
+	ch <- SessionEvent{Type: "content", Content: s.reply}
+	close(ch)
+	return ch, nil
+}
+
+type scriptedSessions struct {
+	reply string
+	last  *scriptedSession
+	opts  TaskSessionOptions // options of the last created session
+}
+
+func (m *scriptedSessions) CreateTaskSession(name string, opts TaskSessionOptions) TaskSession {
+	m.opts = opts
+	m.last = &scriptedSession{id: name, reply: m.reply}
+	return m.last
+}
+
+func (m *scriptedSessions) DeleteTaskSession(string) {}
+
+func newVerifyScheduler(t *testing.T, reply string) (*Scheduler, *scriptedSessions, *Task) {
+	t.Helper()
+	store, cleanup := tempDB(t)
+	t.Cleanup(cleanup)
+	board := NewBoard(store)
+	ws := &Workspace{Name: "verify"}
+	if err := board.CreateWorkspace(ws); err != nil {
+		t.Fatal(err)
+	}
+	task := &Task{WorkspaceID: ws.ID, Title: "t", Prompt: "do it"}
+	if err := board.CreateTask(task); err != nil {
+		t.Fatal(err)
+	}
+	sessions := &scriptedSessions{reply: reply}
+	return NewScheduler(board, sessions), sessions, task
+}
+
+func TestParseVerdict(t *testing.T) {
+	tests := []struct {
+		text   string
+		passed bool
+		ok     bool
+	}{
+		{"VERDICT: PASS\nlooks good", true, true},
+		{"VERDICT: FAIL\nmissing tests", false, true},
+		{"**Verdict: pass**", true, true},
+		{"Let me check.\nVERDICT: FAIL", false, true},
+		{"looks fine to me", false, false},
+		{"VERDICT: FAIL — 3/5 tests PASS", false, true},
+		{"VERDICT: FAIL (would PASS after fix)", false, true},
+		{"VERDICT: PASS, though FAIL-safe handling could improve", true, true},
+		{"Verdict：FAILED. PASS rate too low", false, true},
+		{"Verdict follows below\nVERDICT: PASS", true, true},
+		{"VERDICT: PASSABLE", false, false},
+	}
+	for _, tt := range tests {
+		passed, ok := parseVerdict(tt.text)
+		if passed != tt.passed || ok != tt.ok {
+			t.Errorf("parseVerdict(%q) = %v, %v; want %v, %v", tt.text, passed, ok, tt.passed, tt.ok)
+		}
+	}
+}
+
+func TestVerifyTaskCheckCommand(t *testing.T) {
+	s, _, task := newVerifyScheduler(t, "")
+	ws := &Workspace{}
+	dir := t.TempDir()
+
+	task.VerifyCommand = "echo ok"
+	if err := s.verifyTask(context.Background(), ws, task, dir, "result"); err != nil {
+		t.Fatalf("expected pass, got %v", err)
+	}
+	if task.Verdict != VerdictPassed {
+		t.Errorf("expected passed verdict, got %q", task.Verdict)
+	}
+
+	task.VerifyCommand = "echo broken >&2; exit 3"
+	err := s.verifyTask(context.Background(), ws, task, dir, "result")
+	if err == nil || !strings.Contains(err.Error(), "verification failed") {
+		t.Fatalf("expected verification error, got %v", err)
+	}
+	if task.Verdict != VerdictFailed || !strings.Contains(task.VerifyNotes, "broken") {
+		t.Errorf("expected failed verdict with output, got %q / %q", task.Verdict, task.VerifyNotes)
+	}
+
+	logs, _ := s.board.Store().GetTaskLog(task.ID, 0)
+	var verdicts int
+	for _, l := range logs {
+		if l.EventType == "verdict" {
+			verdicts++
+		}
+	}
+	if verdicts != 2 {
+		t.Errorf("expected 2 verdict log entries, got %d", verdicts)
+	}
+}
+
+func TestVerifyTaskReviewer(t *testing.T) {
+	s, sessions, task := newVerifyScheduler(t, "VERDICT: FAIL\nno tests were added")
+	task.VerifyPrompt = "must add tests"
+
+	err := s.verifyTask(context.Background(), &Workspace{}, task, t.TempDir(), "done")
+	if err == nil {
+		t.Fatal("expected reviewer failure")
+	}
+	if !strings.Contains(sessions.last.input, "must add tests") {
+		t.Error("expected acceptance criteria in reviewer prompt")
+	}
+	if !sessions.opts.ReadOnly {
+		t.Error("reviewer session must be read-only")
+	}
+	if !strings.Contains(task.VerifyNotes, "no tests were added") {
+		t.Errorf("expected reviewer notes, got %q", task.VerifyNotes)
+	}
+
+	// The retry prompt carries the reviewer's notes
+	prompt := s.buildTaskPrompt(task)
+	if !strings.Contains(prompt, "no tests were added") {
+		t.Errorf("expected retry prompt to include notes, got:\n%s", prompt)
+	}
+
+	sessions.reply = "VERDICT: PASS"
+	if err := s.verifyTask(context.Background(), &Workspace{}, task, t.TempDir(), "done"); err != nil {
+		t.Fatalf("expected pass, got %v", err)
+	}
+	if strings.Contains(s.buildTaskPrompt(task), "未通过验收") {
+		t.Error("passed verdict should not add retry feedback")
+	}
+}
+
+func TestVerifyTaskWithoutCriteria(t *testing.T) {
+	s, _, task := newVerifyScheduler(t, "")
+	if err := s.verifyTask(context.Background(), &Workspace{}, task, "", "done"); err != nil {
+		t.Fatal(err)
+	}
+	if task.Verdict != VerdictNone {
+		t.Errorf("expected no verdict, got %q", task.Verdict)
+	}
+}
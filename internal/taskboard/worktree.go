@@ -0,0 +1,232 @@
+package taskboard
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// BranchPrefix is the prefix of git branches created for task worktrees.
+const BranchPrefix = "kele/"
+
+// Worktree is an isolated git checkout used by a single task.
+type Worktree struct {
+	RepoRoot string // top-level directory of the workspace's repository
+	Path     string // worktree checkout directory
+	Branch   string // kele/<task-id>
+}
+
+// WorktreeManager creates, finalizes and merges per-task git worktrees.
+// Worktrees live under root/<task-id>, outside the repository, so they never
+// show up as untracked files in the user's checkout.
+type WorktreeManager struct {
+	root string
+
+	// git serializes worktree/branch mutations per repository (git takes
+	// repository-wide locks for these and fails on contention).
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// NewWorktreeManager creates a manager that places worktrees under root.
+func NewWorktreeManager(root string) *WorktreeManager {
+	return &WorktreeManager{
+		root:  root,
+		locks: make(map[string]*sync.Mutex),
+	}
+}
+
+// BranchName returns the branch used for a task.
+func BranchName(taskID string) string {
+	return BranchPrefix + taskID
+}
+
+// RepoRoot returns the top-level directory of the git repository containing dir,
+// or "" if dir is not inside a work tree.
+func RepoRoot(dir string) string {
+	if dir == "" {
+		return ""
+	}
+	out, err := runGit(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+// Prepare creates (or re-creates, on retry) the worktree for a task.
+// A new branch starts at HEAD with the given base branches (the task
+// branches of its dependencies that are not merged yet) merged in, so the
+// task builds on its predecessors' work; bases that no longer exist are
+// already part of HEAD and skipped.
+// It returns nil, nil when workDir is not inside a git repository or the
+// repository has no commits yet; the task then runs directly in workDir.
+func (m *WorktreeManager) Prepare(workDir, taskID string, bases ...string) (*Worktree, error) {
+	repo := RepoRoot(workDir)
+	if repo == "" {
+		return nil, nil
+	}
+	if _, err := runGit(repo, "rev-parse", "--verify", "HEAD"); err != nil {
+		return nil, nil
+	}
+
+	lock := m.repoLock(repo)
+	lock.Lock()
+	defer lock.Unlock()
+
+	wt := &Worktree{
+		RepoRoot: repo,
+		Path:     filepath.Join(m.root, taskID),
+		Branch:   BranchName(taskID),
+	}
+
+	// Leftovers from a crashed or cancelled run
+	if _, err := os.Stat(wt.Path); err == nil {
+		runGit(repo, "worktree", "remove", "--force", wt.Path)
+		os.RemoveAll(wt.Path)
+	}
+	runGit(repo, "worktree", "prune")
+
+	if err := os.MkdirAll(m.root, 0755); err != nil {
+		return nil, fmt.Errorf("create worktree root: %w", err)
+	}
+
+	if branchExists(repo, wt.Branch) {
+		// Retry: continue from the previous attempt's commits
+		if _, err := runGit(repo, "worktree", "add", wt.Path, wt.Branch); err != nil {
+			return nil, fmt.Errorf("git worktree add: %w", err)
+		}
+		return wt, nil
+	}
+
+	if _, err := runGit(repo, "worktree", "add", "-b", wt.Branch, wt.Path, "HEAD"); err != nil {
+		return nil, fmt.Errorf("git worktree add: %w", err)
+	}
+	for _, base := range bases {
+		if !branchExists(repo, base) {
+			continue
+		}
+		args := append(identityArgs(wt.Path), "merge", "--no-edit", "--no-verify", "-m", "kele: merge "+base, base)
+		if _, err := runGit(wt.Path, args...); err != nil {
+			// Start over from HEAD next time rather than from a half-merged branch
+			runGit(wt.Path, "merge", "--abort")
+			runGit(repo, "worktree", "remove", "--force", wt.Path)
+			runGit(repo, "branch", "-D", wt.Branch)
+			return nil, fmt.Errorf("merge dependency branch %s: %w", base, err)
+		}
+	}
+	return wt, nil
+}
+
+// Finalize commits any uncommitted changes in the worktree, removes the
+// checkout directory (the branch is kept) and returns the branch's diff
+// against its merge base with the repository's current HEAD.
+func (m *WorktreeManager) Finalize(wt *Worktree, message string) (string, error) {
+	lock := m.repoLock(wt.RepoRoot)
+	lock.Lock()
+	defer lock.Unlock()
+
+	defer func() {
+		runGit(wt.RepoRoot, "worktree", "remove", "--force", wt.Path)
+		runGit(wt.RepoRoot, "worktree", "prune")
+	}()
+
+	if _, err := runGit(wt.Path, "add", "-A"); err != nil {
+		return "", fmt.Errorf("git add: %w", err)
+	}
+	if _, err := runGit(wt.Path, "diff", "--cached", "--quiet"); err != nil {
+		// Non-zero exit means there are staged changes
+		args := append(identityArgs(wt.Path), "commit", "--no-verify", "-m", message)
+		if _, err := runGit(wt.Path, args...); err != nil {
+			return "", fmt.Errorf("git commit: %w", err)
+		}
+	}
+
+	return branchDiff(wt.RepoRoot, wt.Branch)
+}
+
+// Merge fast-forwards the branch currently checked out in the repository
+// containing workDir to the task branch, then deletes the task branch.
+func (m *WorktreeManager) Merge(workDir, branch string) error {
+	repo := RepoRoot(workDir)
+	if repo == "" {
+		return fmt.Errorf("%s is not a git repository", workDir)
+	}
+	lock := m.repoLock(repo)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if _, err := runGit(repo, "merge", "--ff-only", branch); err != nil {
+		return fmt.Errorf("fast-forward to %s: %w", branch, err)
+	}
+	runGit(repo, "branch", "-d", branch)
+	return nil
+}
+
+// DeleteBranch removes a task branch that has nothing left to merge.
+func (m *WorktreeManager) DeleteBranch(workDir, branch string) {
+	repo := RepoRoot(workDir)
+	if repo == "" {
+		return
+	}
+	lock := m.repoLock(repo)
+	lock.Lock()
+	defer lock.Unlock()
+	runGit(repo, "branch", "-D", branch)
+}
+
+func (m *WorktreeManager) repoLock(repo string) *sync.Mutex {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l, ok := m.locks[repo]
+	if !ok {
+		l = &sync.Mutex{}
+		m.locks[repo] = l
+	}
+	return l
+}
+
+// --- git helpers ---
+
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			return stdout.String(), err
+		}
+		return stdout.String(), fmt.Errorf("%w: %s", err, msg)
+	}
+	return stdout.String(), nil
+}
+
+func branchExists(repo, branch string) bool {
+	_, err := runGit(repo, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	return err == nil
+}
+
+// branchDiff returns the diff introduced by branch relative to the repository HEAD.
+func branchDiff(repo, branch string) (string, error) {
+	base, err := runGit(repo, "merge-base", "HEAD", branch)
+	if err != nil {
+		return "", fmt.Errorf("git merge-base: %w", err)
+	}
+	return runGit(repo, "diff", strings.TrimSpace(base), branch)
+}
+
+// identityArgs supplies a fallback committer identity when none is configured,
+// so task commits never fail on a fresh machine.
+func identityArgs(dir string) []string {
+	if out, err := runGit(dir, "config", "user.email"); err == nil && strings.TrimSpace(out) != "" {
+		return nil
+	}
+	return []string{"-c", "user.name=Kele", "-c", "user.email=kele@localhost"}
+}
@@ -0,0 +1,189 @@
+package taskboard
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func tempRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.name", "test"},
+		{"config", "user.email", "test@example.com"},
+	} {
+		if _, err := runGit(dir, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("hello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runGit(dir, "add", "-A"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runGit(dir, "commit", "-q", "-m", "init"); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestWorktreePrepareFinalizeMerge(t *testing.T) {
+	repo := tempRepo(t)
+	wm := NewWorktreeManager(t.TempDir())
+
+	wt, err := wm.Prepare(repo, "t-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wt == nil {
+		t.Fatal("expected worktree for git repo")
+	}
+	if wt.Branch != "kele/t-1" {
+		t.Errorf("expected branch kele/t-1, got %s", wt.Branch)
+	}
+
+	if err := os.WriteFile(filepath.Join(wt.Path, "new.txt"), []byte("task output\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// The main checkout must not see the task's changes yet
+	if _, err := os.Stat(filepath.Join(repo, "new.txt")); err == nil {
+		t.Fatal("change leaked into main checkout")
+	}
+
+	diff, err := wm.Finalize(wt, "kele: test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff, "+task output") {
+		t.Errorf("expected diff to contain new file, got:\n%s", diff)
+	}
+	if _, err := os.Stat(wt.Path); err == nil {
+		t.Error("expected worktree directory to be removed")
+	}
+
+	if err := wm.Merge(repo, wt.Branch); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(repo, "new.txt"))
+	if err != nil || string(data) != "task output\n" {
+		t.Errorf("expected merged file, got %q (%v)", data, err)
+	}
+	if branchExists(repo, wt.Branch) {
+		t.Error("expected task branch to be deleted after merge")
+	}
+}
+
+func TestWorktreeRetryReusesBranch(t *testing.T) {
+	repo := tempRepo(t)
+	wm := NewWorktreeManager(t.TempDir())
+
+	wt, err := wm.Prepare(repo, "t-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(wt.Path, "a.txt"), []byte("first\n"), 0644)
+	if _, err := wm.Finalize(wt, "attempt 1"); err != nil {
+		t.Fatal(err)
+	}
+
+	wt, err = wm.Prepare(repo, "t-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(wt.Path, "a.txt")); err != nil {
+		t.Error("expected retry to continue from previous attempt's commit")
+	}
+	diff, err := wm.Finalize(wt, "attempt 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff, "+first") {
+		t.Errorf("expected cumulative diff, got:\n%s", diff)
+	}
+}
+
+func TestWorktreeStartsFromDependencyBranches(t *testing.T) {
+	repo := tempRepo(t)
+	wm := NewWorktreeManager(t.TempDir())
+
+	// Two finished, unmerged predecessors
+	for _, id := range []string{"dep-a", "dep-b"} {
+		wt, err := wm.Prepare(repo, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		os.WriteFile(filepath.Join(wt.Path, id+".txt"), []byte(id+"\n"), 0644)
+		if _, err := wm.Finalize(wt, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wt, err := wm.Prepare(repo, "child", BranchName("dep-a"), BranchName("dep-b"), BranchName("merged-and-gone"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"dep-a", "dep-b"} {
+		if _, err := os.Stat(filepath.Join(wt.Path, id+".txt")); err != nil {
+			t.Errorf("expected the worktree to contain %s's change", id)
+		}
+	}
+	os.WriteFile(filepath.Join(wt.Path, "child.txt"), []byte("child\n"), 0644)
+	if _, err := wm.Finalize(wt, "child"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Merging the dependent brings its predecessors along
+	if err := wm.Merge(repo, wt.Branch); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"dep-a.txt", "dep-b.txt", "child.txt"} {
+		if _, err := os.Stat(filepath.Join(repo, name)); err != nil {
+			t.Errorf("expected %s after merging the dependent", name)
+		}
+	}
+}
+
+func TestWorktreeConflictingDependencies(t *testing.T) {
+	repo := tempRepo(t)
+	wm := NewWorktreeManager(t.TempDir())
+	for _, id := range []string{"left", "right"} {
+		wt, err := wm.Prepare(repo, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		os.WriteFile(filepath.Join(wt.Path, "README.md"), []byte(id+"\n"), 0644)
+		if _, err := wm.Finalize(wt, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := wm.Prepare(repo, "both", BranchName("left"), BranchName("right"))
+	if err == nil || !strings.Contains(err.Error(), "kele/right") {
+		t.Fatalf("expected a merge conflict on kele/right, got %v", err)
+	}
+	if branchExists(repo, BranchName("both")) {
+		t.Error("a failed merge must not leave the task branch behind")
+	}
+}
+
+func TestWorktreeNonGitDir(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	wm := NewWorktreeManager(t.TempDir())
+	wt, err := wm.Prepare(t.TempDir(), "t-3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wt != nil {
+		t.Error("expected nil worktree outside a git repository")
+	}
+}
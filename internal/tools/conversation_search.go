@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/BlakeLiAFK/kele/internal/memory"
+)
+
+// HistoryBackend 对话归档检索接口（由 memory.Store 实现）
+type HistoryBackend interface {
+	SearchHistory(q memory.HistoryQuery) ([]memory.HistoryHit, error)
+}
+
+// ConversationSearchTool 在所有会话的历史对话中检索
+type ConversationSearchTool struct {
+	store HistoryBackend
+}
+
+// NewConversationSearchTool 创建 conversation_search 工具
+func NewConversationSearchTool(store HistoryBackend) *ConversationSearchTool {
+	return &ConversationSearchTool{store: store}
+}
+
+func (t *ConversationSearchTool) Name() string { return "conversation_search" }
+func (t *ConversationSearchTool) Description() string {
+	return "在所有会话（命令行、Telegram、任务）的历史对话中按关键词检索，用于回忆之前讨论过的内容，如\"上周说的那个部署方案\"。返回命中的消息摘要、时间和所在会话。长期事实请用 recall 查询记忆。"
+}
+func (t *ConversationSearchTool) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"query": map[string]interface{}{
+				"type":        "string",
+				"description": "关键词，多个用空格分隔，全部出现才算命中；用具体的词（项目名、报错信息、文件名）效果最好",
+			},
+			"days": map[string]interface{}{
+				"type":        "integer",
+				"description": "只查最近几天的对话（可选，默认不限）",
+			},
+			"session": map[string]interface{}{
+				"type":        "string",
+				"description": "只查指定会话 ID 或名称（可选）",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": "最多返回条数（默认 10，最大 30）",
+			},
+		},
+		"required": []string{"query"},
+	}
+}
+
+func (t *ConversationSearchTool) Execute(args map[string]interface{}) (string, error) {
+	query, _ := args["query"].(string)
+	if strings.TrimSpace(query) == "" {
+		return "", fmt.Errorf("缺少 query 参数")
+	}
+	q := memory.HistoryQuery{Text: query, Limit: 10}
+	if v, ok := args["limit"].(float64); ok && v > 0 {
+		q.Limit = int(v)
+	}
+	if q.Limit > 30 {
+		q.Limit = 30
+	}
+	if v, ok := args["days"].(float64); ok && v > 0 {
+		q.Since = time.Now().AddDate(0, 0, -int(v))
+	}
+	q.Session, _ = args["session"].(string)
+
+	hits, err := t.store.SearchHistory(q)
+	if err != nil {
+		return "", err
+	}
+	if len(hits) == 0 {
+		return "历史对话中没有找到相关内容，可以换个关键词或放宽时间范围", nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "找到 %d 条历史消息（«» 标出命中处）:\n", len(hits))
+	for _, h := range hits {
+		session := h.SessionName
+		if session == "" {
+			session = h.SessionID
+		}
+		if session == "" {
+			session = "未知会话"
+		}
+		fmt.Fprintf(&b, "- %s [%s/%s] %s: %s\n", h.Timestamp.Local().Format("2006-01-02 15:04"), session, h.Channel, h.Role,
+			strings.Join(strings.Fields(h.Snippet), " "))
+	}
+	return b.String(), nil
+}
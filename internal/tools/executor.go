@@ -22,8 +22,12 @@ type Executor struct {
 	registry  *Registry
 	cfg       *config.Config
 	audit     *AuditLogger
+	allowed   map[string]bool // 非 nil 时只开放其中的工具
 }
 
+// ReadOnlyTools 只读执行器开放的工具，不会修改工作目录中的文件
+var ReadOnlyTools = []string{"read", "search", "list", "git"}
+
 // NewExecutor 创建执行器
 func NewExecutor(scheduler *cron.Scheduler, cfg *config.Config) *Executor {
 	wd, _ := os.Getwd()
@@ -36,7 +40,14 @@ func NewExecutor(scheduler *cron.Scheduler, cfg *config.Config) *Executor {
 		audit:     NewAuditLogger(cfg.Memory.AuditLog),
 	}
 
-	// 注册内置工具
+	e.registerBuiltins(wd)
+
+	return e
+}
+
+// registerBuiltins 注册内置工具
+func (e *Executor) registerBuiltins(wd string) {
+	cfg := e.cfg
 	e.registry.Register(&BashTool{
 		workDir:       wd,
 		cfg:           cfg,
@@ -44,13 +55,65 @@ func NewExecutor(scheduler *cron.Scheduler, cfg *config.Config) *Executor {
 		timeout:       time.Duration(cfg.Tools.BashTimeout) * time.Second,
 	})
 	e.registry.Register(&ReadTool{workDir: wd})
+	e.registry.Register(&SearchTool{workDir: wd})
+	e.registry.Register(&ListTool{workDir: wd})
 	e.registry.Register(&WriteTool{workDir: wd, maxWriteSize: cfg.Tools.MaxWriteSize})
 	e.registry.Register(NewHTTPTool(cfg.Tools.MaxOutputSize))
 	e.registry.Register(NewGitTool(wd, cfg.Tools.MaxOutputSize))
 	e.registry.Register(NewPythonTool(wd, cfg.Tools.MaxOutputSize))
 	e.registry.Register(NewWebFetchTool(cfg.Tools.MaxOutputSize))
+}
 
-	return e
+// Fork 创建一个拥有独立工作目录的执行器副本。
+// 内置工具按新目录重新创建，外部注册的工具（子 agent、消息推送等）与原执行器共享。
+// 用于 TaskBoard 任务会话，避免 SetWorkDir 影响其他会话。
+func (e *Executor) Fork(workDir string) *Executor {
+	f := &Executor{
+		workDir:   workDir,
+		scheduler: e.scheduler,
+		registry:  NewRegistry(),
+		cfg:       e.cfg,
+		audit:     e.audit,
+	}
+	f.registerBuiltins(workDir)
+	for _, name := range e.registry.List() {
+		if f.registry.Has(name) {
+			continue
+		}
+		if handler, ok := e.registry.GetHandler(name); ok {
+			f.registry.Register(handler)
+		}
+	}
+	return f
+}
+
+// ForkReadOnly 创建只能浏览 workDir 的执行器副本：仅开放 ReadOnlyTools，
+// 且 git 只允许查询类子命令。用于规划阶段探索仓库。
+func (e *Executor) ForkReadOnly(workDir string) *Executor {
+	f := e.Fork(workDir)
+	if handler, ok := f.registry.GetHandler("git"); ok {
+		if git, ok := handler.(*GitTool); ok {
+			git.readOnly = true
+		}
+	}
+	f.SetAllowedTools(ReadOnlyTools)
+	return f
+}
+
+// SetAllowedTools 限制执行器只开放 names 中的工具，传 nil 取消限制
+func (e *Executor) SetAllowedTools(names []string) {
+	if names == nil {
+		e.allowed = nil
+		return
+	}
+	e.allowed = make(map[string]bool, len(names))
+	for _, name := range names {
+		e.allowed[name] = true
+	}
+}
+
+func (e *Executor) isAllowed(name string) bool {
+	return e.allowed == nil || e.allowed[name]
 }
 
 // RegisterTool 注册外部工具（用于延迟注册）
@@ -88,24 +151,44 @@ func (e *Executor) GetTools() []llm.Tool {
 
 	tools = append(tools, askUserTool())
 
-	return tools
+	if e.allowed == nil {
+		return tools
+	}
+	filtered := tools[:0]
+	for _, t := range tools {
+		if e.isAllowed(t.Function.Name) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
 }
 
 // Execute 执行工具调用（带审计日志）
 func (e *Executor) Execute(toolCall llm.ToolCall) (string, error) {
+	return e.ExecuteContext(context.Background(), toolCall)
+}
+
+// ExecuteContext 执行工具调用，ctx 取消时终止 bash/python 等外部进程
+func (e *Executor) ExecuteContext(ctx context.Context, toolCall llm.ToolCall) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
 	var args map[string]interface{}
 	if err := json.Unmarshal([]byte(toolCall.Function.Arguments), &args); err != nil {
 		return "", fmt.Errorf("解析参数失败: %v", err)
 	}
 
 	name := toolCall.Function.Name
+	if !e.isAllowed(name) {
+		return "", fmt.Errorf("当前会话不允许使用工具: %s", name)
+	}
 	start := time.Now()
 
 	var result string
 	var execErr error
 
 	if e.registry.Has(name) {
-		result, execErr = e.registry.Execute(name, args)
+		result, execErr = e.registry.ExecuteContext(ctx, name, args)
 	} else {
 		switch name {
 		case "cron_create":
@@ -139,7 +222,16 @@ func (e *Executor) ListTools() []string {
 		tools = append(tools, "cron_create", "cron_list", "cron_get", "cron_update", "cron_delete")
 	}
 	tools = append(tools, "ask_user")
-	return tools
+	if e.allowed == nil {
+		return tools
+	}
+	filtered := tools[:0]
+	for _, name := range tools {
+		if e.isAllowed(name) {
+			filtered = append(filtered, name)
+		}
+	}
+	return filtered
 }
 
 // ListCronJobs 返回所有定时任务（供 /cron 命令使用）
@@ -179,6 +271,10 @@ func (t *BashTool) Parameters() map[string]interface{} {
 }
 
 func (t *BashTool) Execute(args map[string]interface{}) (string, error) {
+	return t.ExecuteContext(context.Background(), args)
+}
+
+func (t *BashTool) ExecuteContext(parent context.Context, args map[string]interface{}) (string, error) {
 	command, ok := args["command"].(string)
 	if !ok {
 		return "", fmt.Errorf("缺少 command 参数")
@@ -188,11 +284,12 @@ func (t *BashTool) Execute(args map[string]interface{}) (string, error) {
 		return "", fmt.Errorf("禁止执行危险命令: %s", command)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), t.timeout)
+	ctx, cancel := context.WithTimeout(parent, t.timeout)
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, "bash", "-c", command)
 	cmd.Dir = t.workDir
+	killProcessGroup(cmd)
 	output, err := cmd.CombinedOutput()
 	result := string(output)
 
@@ -200,6 +297,9 @@ func (t *BashTool) Execute(args map[string]interface{}) (string, error) {
 		result = result[:t.maxOutputSize] + fmt.Sprintf("\n\n... [输出被截断，超过 %d 字节]", t.maxOutputSize)
 	}
 
+	if parent.Err() != nil {
+		return result, fmt.Errorf("命令已中止: %v", parent.Err())
+	}
 	if ctx.Err() == context.DeadlineExceeded {
 		return result, fmt.Errorf("命令执行超时 (%v)", t.timeout)
 	}
@@ -315,33 +415,116 @@ func askUserTool() llm.Tool {
 
 func (e *Executor) cronTools() []llm.Tool {
 	return []llm.Tool{
-		{Type: "function", Function: llm.ToolFunction{Name: "cron_create", Description: "创建定时任务。使用标准 5 字段 cron 表达式。", Parameters: map[string]interface{}{"type": "object", "properties": map[string]interface{}{"name": map[string]interface{}{"type": "string", "description": "任务名称"}, "schedule": map[string]interface{}{"type": "string", "description": "cron 表达式"}, "command": map[string]interface{}{"type": "string", "description": "bash 命令"}}, "required": []string{"name", "schedule", "command"}}}},
+		{Type: "function", Function: llm.ToolFunction{Name: "cron_create", Description: "创建定时任务。使用标准 5 字段 cron 表达式。command 任务执行 bash 命令；agent 任务在新会话中让 AI 执行 prompt（如\"总结今天的 git log\"），可指定模型、工具和目录。设置 deliver_channel 后执行结果会发送到该渠道。", Parameters: map[string]interface{}{"type": "object", "properties": cronJobProperties(false), "required": []string{"name", "schedule"}}}},
 		{Type: "function", Function: llm.ToolFunction{Name: "cron_list", Description: "列出所有定时任务。", Parameters: map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}}},
 		{Type: "function", Function: llm.ToolFunction{Name: "cron_get", Description: "查看定时任务详情。", Parameters: map[string]interface{}{"type": "object", "properties": map[string]interface{}{"id": map[string]interface{}{"type": "string", "description": "任务 ID"}}, "required": []string{"id"}}}},
-		{Type: "function", Function: llm.ToolFunction{Name: "cron_update", Description: "更新定时任务。", Parameters: map[string]interface{}{"type": "object", "properties": map[string]interface{}{"id": map[string]interface{}{"type": "string", "description": "任务 ID"}, "name": map[string]interface{}{"type": "string"}, "schedule": map[string]interface{}{"type": "string"}, "command": map[string]interface{}{"type": "string"}, "enabled": map[string]interface{}{"type": "boolean"}}, "required": []string{"id"}}}},
+		{Type: "function", Function: llm.ToolFunction{Name: "cron_update", Description: "更新定时任务，只修改传入的字段。", Parameters: map[string]interface{}{"type": "object", "properties": cronJobProperties(true), "required": []string{"id"}}}},
 		{Type: "function", Function: llm.ToolFunction{Name: "cron_delete", Description: "删除定时任务。", Parameters: map[string]interface{}{"type": "object", "properties": map[string]interface{}{"id": map[string]interface{}{"type": "string", "description": "任务 ID"}}, "required": []string{"id"}}}},
 	}
 }
 
+// cronJobProperties cron_create / cron_update 的参数定义，update 时额外包含 id 和 enabled
+func cronJobProperties(update bool) map[string]interface{} {
+	props := map[string]interface{}{
+		"name":            map[string]interface{}{"type": "string", "description": "任务名称"},
+		"schedule":        map[string]interface{}{"type": "string", "description": "cron 表达式：5 字段（分 时 日 月 周）或带秒的 6 字段，也支持 @daily、@hourly 和 @every 90s 这样的固定间隔"},
+		"timezone":        map[string]interface{}{"type": "string", "description": "按哪个时区计算执行时间，如 Asia/Shanghai，默认本地时区"},
+		"overlap":         map[string]interface{}{"type": "string", "enum": []string{cron.OverlapSkip, cron.OverlapQueue, cron.OverlapAllow}, "description": "上一次执行还没结束时: skip 跳过本次，queue 排队等待，allow 同时执行；默认 skip"},
+		"catch_up":        map[string]interface{}{"type": "string", "enum": []string{cron.CatchUpNone, cron.CatchUpOnce, cron.CatchUpAll}, "description": "kele 未运行期间错过的执行: none 不补跑，once 补跑一次，all 每次都补跑；默认按全局配置"},
+		"type":            map[string]interface{}{"type": "string", "enum": []string{cron.JobCommand, cron.JobAgent}, "description": "任务类型，默认 command"},
+		"command":         map[string]interface{}{"type": "string", "description": "bash 命令（command 任务）"},
+		"prompt":          map[string]interface{}{"type": "string", "description": "交给 AI 执行的指令（agent 任务）"},
+		"model":           map[string]interface{}{"type": "string", "description": "agent 任务使用的模型或档位 small/large，默认当前模型"},
+		"tools":           map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "agent 任务可用的工具，默认全部"},
+		"work_dir":        map[string]interface{}{"type": "string", "description": "执行目录，默认 daemon 启动目录"},
+		"deliver_channel": map[string]interface{}{"type": "string", "description": "结果发送到的渠道，如 telegram；为空不发送"},
+		"deliver_target":  map[string]interface{}{"type": "string", "description": "渠道内的目标（如 chat ID），默认渠道的默认目标"},
+		"deliver_when":    map[string]interface{}{"type": "string", "enum": []string{cron.DeliverAlways, cron.DeliverFailure, cron.DeliverNonEmpty}, "description": "何时发送: always 每次，failure 仅失败时，non_empty 输出非空或失败时；默认 always"},
+	}
+	if update {
+		props["id"] = map[string]interface{}{"type": "string", "description": "任务 ID"}
+		props["enabled"] = map[string]interface{}{"type": "boolean"}
+	}
+	return props
+}
+
 func (e *Executor) executeCronCreate(args map[string]interface{}) (string, error) {
 	if e.scheduler == nil {
 		return "", fmt.Errorf("定时任务调度器未初始化")
 	}
-	name, _ := args["name"].(string)
-	schedule, _ := args["schedule"].(string)
-	command, _ := args["command"].(string)
-	if name == "" || schedule == "" || command == "" {
-		return "", fmt.Errorf("缺少必填参数: name, schedule, command")
+	job := cron.Job{}
+	job.Name, _ = args["name"].(string)
+	job.Schedule, _ = args["schedule"].(string)
+	job.Type, _ = args["type"].(string)
+	job.Command, _ = args["command"].(string)
+	job.Prompt, _ = args["prompt"].(string)
+	job.Model, _ = args["model"].(string)
+	job.WorkDir, _ = args["work_dir"].(string)
+	job.Deliver.Channel, _ = args["deliver_channel"].(string)
+	job.Deliver.Target, _ = args["deliver_target"].(string)
+	job.Deliver.When, _ = args["deliver_when"].(string)
+	job.Timezone, _ = args["timezone"].(string)
+	job.Overlap, _ = args["overlap"].(string)
+	job.CatchUp, _ = args["catch_up"].(string)
+	if list, ok := args["tools"].([]interface{}); ok {
+		for _, v := range list {
+			if name, ok := v.(string); ok {
+				job.Tools = append(job.Tools, name)
+			}
+		}
+	}
+	if job.Name == "" || job.Schedule == "" {
+		return "", fmt.Errorf("缺少必填参数: name, schedule")
 	}
-	job, err := e.scheduler.CreateJob(name, schedule, command)
+	created, err := e.scheduler.AddJob(job)
 	if err != nil {
 		return "", err
 	}
 	nextStr := "N/A"
-	if job.NextRun != nil {
-		nextStr = job.NextRun.Format("2006-01-02 15:04")
+	if created.NextRun != nil {
+		nextStr = created.NextRun.Format("2006-01-02 15:04")
+	}
+	return fmt.Sprintf("定时任务已创建\nID: %s\n名称: %s\n表达式: %s\n%s下次执行: %s", created.ID, created.Name, created.Schedule, formatCronJob(*created), nextStr), nil
+}
+
+// formatCronJob 描述任务的执行内容和投递设置，每项一行
+func formatCronJob(job cron.Job) string {
+	var sb strings.Builder
+	if job.Type == cron.JobAgent {
+		sb.WriteString(fmt.Sprintf("类型: agent\n指令: %s\n", job.Prompt))
+		if job.Model != "" {
+			sb.WriteString(fmt.Sprintf("模型: %s\n", job.Model))
+		}
+		if len(job.Tools) > 0 {
+			sb.WriteString(fmt.Sprintf("工具: %s\n", strings.Join(job.Tools, ", ")))
+		}
+	} else {
+		sb.WriteString(fmt.Sprintf("命令: %s\n", job.Command))
+	}
+	if job.WorkDir != "" {
+		sb.WriteString(fmt.Sprintf("目录: %s\n", job.WorkDir))
+	}
+	if job.Timezone != "" {
+		sb.WriteString(fmt.Sprintf("时区: %s\n", job.Timezone))
+	}
+	if job.Overlap != "" && job.Overlap != cron.OverlapSkip {
+		sb.WriteString(fmt.Sprintf("重叠策略: %s\n", job.Overlap))
+	}
+	if job.CatchUp != "" {
+		sb.WriteString(fmt.Sprintf("补跑策略: %s\n", job.CatchUp))
+	}
+	if job.Deliver.Channel != "" {
+		target := job.Deliver.Channel
+		if job.Deliver.Target != "" {
+			target += ":" + job.Deliver.Target
+		}
+		when := job.Deliver.When
+		if when == "" {
+			when = cron.DeliverAlways
+		}
+		sb.WriteString(fmt.Sprintf("投递: %s (%s)\n", target, when))
 	}
-	return fmt.Sprintf("定时任务已创建\nID: %s\n名称: %s\n表达式: %s\n命令: %s\n下次执行: %s", job.ID, job.Name, job.Schedule, job.Command, nextStr), nil
+	return sb.String()
 }
 
 func (e *Executor) executeCronList() (string, error) {
@@ -388,7 +571,7 @@ func (e *Executor) executeCronGet(args map[string]interface{}) (string, error) {
 		status = "暂停"
 	}
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("任务详情\n\nID: %s\n名称: %s\n表达式: %s\n命令: %s\n状态: %s\n", job.ID, job.Name, job.Schedule, job.Command, status))
+	sb.WriteString(fmt.Sprintf("任务详情\n\nID: %s\n名称: %s\n表达式: %s\n%s状态: %s\n", job.ID, job.Name, job.Schedule, formatCronJob(*job), status))
 	if job.LastRun != nil {
 		sb.WriteString(fmt.Sprintf("上次执行: %s\n", job.LastRun.Format("2006-01-02 15:04:05")))
 	}
@@ -421,7 +604,8 @@ func (e *Executor) executeCronUpdate(args map[string]interface{}) (string, error
 		return "", fmt.Errorf("缺少 id 参数")
 	}
 	updates := make(map[string]interface{})
-	for _, key := range []string{"name", "schedule", "command", "enabled"} {
+	for _, key := range []string{"name", "schedule", "type", "command", "prompt", "model", "tools", "work_dir",
+		"deliver_channel", "deliver_target", "deliver_when", "timezone", "overlap", "catch_up", "enabled"} {
 		if v, ok := args[key]; ok {
 			updates[key] = v
 		}
@@ -11,6 +11,7 @@ import (
 type GitTool struct {
 	workDir       string
 	maxOutputSize int
+	readOnly      bool // 只读模式：仅允许查询类子命令
 }
 
 // NewGitTool 创建 Git 工具
@@ -58,6 +59,15 @@ var allowedGitCommands = map[string]bool{
 	"remote":   true,
 }
 
+// 只读模式下允许的 Git 子命令
+var readOnlyGitCommands = map[string]bool{
+	"status": true,
+	"diff":   true,
+	"log":    true,
+	"show":   true,
+	"blame":  true,
+}
+
 // 危险的 Git 参数组合
 var dangerousGitPatterns = []string{
 	"push --force",
@@ -80,6 +90,9 @@ func (t *GitTool) Execute(args map[string]interface{}) (string, error) {
 	if !allowedGitCommands[subcommand] {
 		return "", fmt.Errorf("不支持的 Git 子命令: %s (支持: status/diff/log/add/commit/branch/checkout/show/stash)", subcommand)
 	}
+	if t.readOnly && !readOnlyGitCommands[subcommand] {
+		return "", fmt.Errorf("只读模式下不允许 git %s (支持: status/diff/log/show/blame)", subcommand)
+	}
 
 	// 构建完整命令
 	cmdArgs := []string{subcommand}
@@ -0,0 +1,284 @@
+package tools
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/BlakeLiAFK/kele/internal/memory"
+)
+
+// MemoryBackend 长期记忆存储接口（由 memory.Store 实现）
+type MemoryBackend interface {
+	UpdateMemoryIn(scope, key, value string) error
+	GetMemoryIn(scope, key string) (string, error)
+	DeleteMemoryIn(scope, key string) error
+	FindMemory(scopes []string, key string) (memory.Entry, error)
+	SearchEntriesIn(scopes []string, query string, limit int) ([]memory.Entry, error)
+}
+
+type memoryScopesKey struct{}
+
+// WithMemoryScopes 把会话可见的记忆作用域放入 ctx，记忆工具据此读写；
+// ctx 中没有作用域时只能访问全局记忆
+func WithMemoryScopes(ctx context.Context, scopes memory.Scopes) context.Context {
+	return context.WithValue(ctx, memoryScopesKey{}, scopes)
+}
+
+func memoryScopes(ctx context.Context) memory.Scopes {
+	scopes, _ := ctx.Value(memoryScopesKey{}).(memory.Scopes)
+	return scopes
+}
+
+// NewMemoryTools 创建 remember/recall/update_memory/forget 四个记忆工具
+func NewMemoryTools(store MemoryBackend) []ToolHandler {
+	return []ToolHandler{
+		&RememberTool{store: store},
+		&RecallTool{store: store},
+		&UpdateMemoryTool{store: store},
+		&ForgetTool{store: store},
+	}
+}
+
+// scopeLabel 在工具输出中标注非全局记忆的作用域
+func scopeLabel(scope string) string {
+	if scope == memory.ScopeGlobal || scope == "" {
+		return ""
+	}
+	return fmt.Sprintf("（%s）", memory.ScopeKind(scope))
+}
+
+// --- remember 工具 ---
+
+// RememberTool 保存一条新的长期记忆
+type RememberTool struct {
+	store MemoryBackend
+}
+
+func (t *RememberTool) Name() string { return "remember" }
+func (t *RememberTool) Description() string {
+	return "保存一条长期记忆，今后的对话都能用到。只保存持久的事实：用户偏好、项目约定、环境信息、长期决定；不要保存一次性任务细节。保存前可先用 recall 确认没有重复。"
+}
+func (t *RememberTool) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"content": map[string]interface{}{
+				"type":        "string",
+				"description": "要记住的事实，一句完整、独立可读的话",
+			},
+			"key": map[string]interface{}{
+				"type":        "string",
+				"description": "简短标识，如 preferred_language（可选，省略则自动生成）",
+			},
+			"scope": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"global", "project", "workspace", "session"},
+				"description": "适用范围：global 处处适用（用户偏好，默认），project 只在当前项目（仓库）中适用（项目约定），workspace 只在当前任务工作区，session 只在本次会话",
+			},
+		},
+		"required": []string{"content"},
+	}
+}
+
+func (t *RememberTool) Execute(args map[string]interface{}) (string, error) {
+	return t.ExecuteContext(context.Background(), args)
+}
+
+func (t *RememberTool) ExecuteContext(ctx context.Context, args map[string]interface{}) (string, error) {
+	content, _ := args["content"].(string)
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", fmt.Errorf("缺少 content 参数")
+	}
+	kind, _ := args["scope"].(string)
+	scope, err := memoryScopes(ctx).Resolve(kind)
+	if err != nil {
+		return "", err
+	}
+	key, _ := args["key"].(string)
+	key = strings.TrimSpace(key)
+	if key != "" {
+		if _, err := t.store.GetMemoryIn(scope, key); err == nil {
+			return "", fmt.Errorf("记忆 [%s] 已存在，修改请使用 update_memory", key)
+		}
+	} else {
+		key = t.newKey(scope)
+	}
+	if err := t.store.UpdateMemoryIn(scope, key, content); err != nil {
+		return "", fmt.Errorf("保存记忆失败: %w", err)
+	}
+	return fmt.Sprintf("已保存记忆 [%s]%s", key, scopeLabel(scope)), nil
+}
+
+// newKey 生成与 /remember 相同格式的 key，同一秒内重复时加序号
+func (t *RememberTool) newKey(scope string) string {
+	base := fmt.Sprintf("note_%d", time.Now().Unix())
+	key := base
+	for i := 2; ; i++ {
+		if _, err := t.store.GetMemoryIn(scope, key); err != nil {
+			return key
+		}
+		key = fmt.Sprintf("%s_%d", base, i)
+	}
+}
+
+// --- recall 工具 ---
+
+// RecallTool 搜索长期记忆
+type RecallTool struct {
+	store MemoryBackend
+}
+
+func (t *RecallTool) Name() string { return "recall" }
+func (t *RecallTool) Description() string {
+	return "按关键词搜索当前可见的长期记忆（全局、当前项目、工作区和会话），返回匹配的记忆及其 key。修改或删除记忆前先用它找到 key。"
+}
+func (t *RecallTool) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"query": map[string]interface{}{
+				"type":        "string",
+				"description": "搜索关键词，多个关键词用空格分隔",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": "最多返回条数（默认 5，最大 20）",
+			},
+		},
+		"required": []string{"query"},
+	}
+}
+
+func (t *RecallTool) Execute(args map[string]interface{}) (string, error) {
+	return t.ExecuteContext(context.Background(), args)
+}
+
+func (t *RecallTool) ExecuteContext(ctx context.Context, args map[string]interface{}) (string, error) {
+	query, _ := args["query"].(string)
+	if strings.TrimSpace(query) == "" {
+		return "", fmt.Errorf("缺少 query 参数")
+	}
+	limit := 5
+	if v, ok := args["limit"].(float64); ok && v > 0 {
+		limit = int(v)
+	}
+	if limit > 20 {
+		limit = 20
+	}
+	entries, err := t.store.SearchEntriesIn(memoryScopes(ctx).List(), query, limit)
+	if err != nil {
+		return "", fmt.Errorf("搜索记忆失败: %w", err)
+	}
+	if len(entries) == 0 {
+		return "未找到相关记忆", nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "找到 %d 条记忆:\n", len(entries))
+	for _, e := range entries {
+		fmt.Fprintf(&b, "- [%s]%s %s\n", e.Key, scopeLabel(e.Scope), e.Value)
+	}
+	return b.String(), nil
+}
+
+// --- update_memory 工具 ---
+
+// UpdateMemoryTool 修改已有的长期记忆
+type UpdateMemoryTool struct {
+	store MemoryBackend
+}
+
+func (t *UpdateMemoryTool) Name() string { return "update_memory" }
+func (t *UpdateMemoryTool) Description() string {
+	return "用新内容替换一条已有的长期记忆。记忆过时或不准确时使用，key 可通过 recall 查到。"
+}
+func (t *UpdateMemoryTool) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"key": map[string]interface{}{
+				"type":        "string",
+				"description": "要修改的记忆 key",
+			},
+			"content": map[string]interface{}{
+				"type":        "string",
+				"description": "新的记忆内容",
+			},
+		},
+		"required": []string{"key", "content"},
+	}
+}
+
+func (t *UpdateMemoryTool) Execute(args map[string]interface{}) (string, error) {
+	return t.ExecuteContext(context.Background(), args)
+}
+
+func (t *UpdateMemoryTool) ExecuteContext(ctx context.Context, args map[string]interface{}) (string, error) {
+	key, _ := args["key"].(string)
+	if key == "" {
+		return "", fmt.Errorf("缺少 key 参数")
+	}
+	content, _ := args["content"].(string)
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", fmt.Errorf("缺少 content 参数")
+	}
+	old, err := t.store.FindMemory(memoryScopes(ctx).List(), key)
+	if err != nil {
+		return "", fmt.Errorf("记忆 [%s] 不存在，可先用 recall 查找", key)
+	}
+	if err := t.store.UpdateMemoryIn(old.Scope, key, content); err != nil {
+		return "", fmt.Errorf("更新记忆失败: %w", err)
+	}
+	return fmt.Sprintf("已更新记忆 [%s]%s\n原内容: %s", key, scopeLabel(old.Scope), old.Value), nil
+}
+
+// --- forget 工具 ---
+
+// ForgetTool 删除一条长期记忆
+type ForgetTool struct {
+	store MemoryBackend
+}
+
+func (t *ForgetTool) Name() string { return "forget" }
+func (t *ForgetTool) Description() string {
+	return "删除一条长期记忆。用户要求忘记某事，或记忆已完全失效时使用，key 可通过 recall 查到。"
+}
+func (t *ForgetTool) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"key": map[string]interface{}{
+				"type":        "string",
+				"description": "要删除的记忆 key",
+			},
+		},
+		"required": []string{"key"},
+	}
+}
+
+func (t *ForgetTool) Execute(args map[string]interface{}) (string, error) {
+	return t.ExecuteContext(context.Background(), args)
+}
+
+func (t *ForgetTool) ExecuteContext(ctx context.Context, args map[string]interface{}) (string, error) {
+	key, _ := args["key"].(string)
+	if key == "" {
+		return "", fmt.Errorf("缺少 key 参数")
+	}
+	entry, err := t.store.FindMemory(memoryScopes(ctx).List(), key)
+	if err == nil {
+		err = t.store.DeleteMemoryIn(entry.Scope, key)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("记忆 [%s] 不存在", key)
+		}
+		return "", fmt.Errorf("删除记忆失败: %w", err)
+	}
+	return fmt.Sprintf("已删除记忆 [%s]%s", key, scopeLabel(entry.Scope)), nil
+}
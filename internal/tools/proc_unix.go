@@ -0,0 +1,19 @@
+//go:build !windows
+
+package tools
+
+import (
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// killProcessGroup 让命令在独立进程组中运行，取消或超时时杀掉整个进程组，
+// 避免 bash 派生的子进程残留并占住输出管道
+func killProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = 2 * time.Second
+}
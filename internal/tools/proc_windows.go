@@ -0,0 +1,13 @@
+//go:build windows
+
+package tools
+
+import (
+	"os/exec"
+	"time"
+)
+
+// killProcessGroup Windows 下只终止直接子进程，WaitDelay 保证管道被占用时也能返回
+func killProcessGroup(cmd *exec.Cmd) {
+	cmd.WaitDelay = 2 * time.Second
+}
@@ -46,6 +46,10 @@ func (t *PythonTool) Parameters() map[string]interface{} {
 }
 
 func (t *PythonTool) Execute(args map[string]interface{}) (string, error) {
+	return t.ExecuteContext(context.Background(), args)
+}
+
+func (t *PythonTool) ExecuteContext(parent context.Context, args map[string]interface{}) (string, error) {
 	code, _ := args["code"].(string)
 	if code == "" {
 		return "", fmt.Errorf("缺少 code 参数")
@@ -71,11 +75,12 @@ func (t *PythonTool) Execute(args map[string]interface{}) (string, error) {
 	tmpFile.Close()
 
 	// 执行
-	ctx, cancel := context.WithTimeout(context.Background(), t.timeout)
+	ctx, cancel := context.WithTimeout(parent, t.timeout)
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, pythonPath, tmpFile.Name())
 	cmd.Dir = t.workDir
+	killProcessGroup(cmd)
 
 	output, err := cmd.CombinedOutput()
 	result := string(output)
@@ -84,6 +89,9 @@ func (t *PythonTool) Execute(args map[string]interface{}) (string, error) {
 		result = result[:t.maxOutputSize] + fmt.Sprintf("\n\n... [输出被截断，超过 %d 字节]", t.maxOutputSize)
 	}
 
+	if parent.Err() != nil {
+		return result, fmt.Errorf("Python 执行已中止: %v", parent.Err())
+	}
 	if ctx.Err() == context.DeadlineExceeded {
 		return result, fmt.Errorf("Python 执行超时 (%v)", t.timeout)
 	}
@@ -1,6 +1,7 @@
 package tools
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -15,6 +16,13 @@ type ToolHandler interface {
 	Execute(args map[string]interface{}) (string, error)
 }
 
+// ContextToolHandler 可取消的工具：运行外部进程的工具实现该接口，
+// ctx 结束时终止进程
+type ContextToolHandler interface {
+	ToolHandler
+	ExecuteContext(ctx context.Context, args map[string]interface{}) (string, error)
+}
+
 // Registry 工具注册表
 type Registry struct {
 	tools map[string]ToolHandler
@@ -42,6 +50,11 @@ func (r *Registry) Register(tool ToolHandler) {
 
 // Execute 执行工具
 func (r *Registry) Execute(name string, args map[string]interface{}) (string, error) {
+	return r.ExecuteContext(context.Background(), name, args)
+}
+
+// ExecuteContext 执行工具，工具支持取消时把 ctx 传给它
+func (r *Registry) ExecuteContext(ctx context.Context, name string, args map[string]interface{}) (string, error) {
 	r.mu.RLock()
 	tool, ok := r.tools[name]
 	r.mu.RUnlock()
@@ -49,6 +62,9 @@ func (r *Registry) Execute(name string, args map[string]interface{}) (string, er
 	if !ok {
 		return "", fmt.Errorf("未知工具: %s", name)
 	}
+	if ct, ok := tool.(ContextToolHandler); ok {
+		return ct.ExecuteContext(ctx, args)
+	}
 	return tool.Execute(args)
 }
 
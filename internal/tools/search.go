@@ -0,0 +1,244 @@
+package tools
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	maxSearchMatches  = 200
+	maxSearchFileSize = 1 << 20 // 超过 1MB 的文件不搜索
+	maxListEntries    = 500
+)
+
+// 遍历时跳过的目录
+var skippedDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+	".idea":        true,
+	".vscode":      true,
+	"__pycache__":  true,
+}
+
+// SearchTool 代码搜索工具：在工作目录下按正则搜索文件内容
+type SearchTool struct{ workDir string }
+
+func (t *SearchTool) SetWorkDir(dir string) { t.workDir = dir }
+func (t *SearchTool) Name() string          { return "search" }
+func (t *SearchTool) Description() string {
+	return "在项目文件中按正则表达式搜索内容，返回 文件:行号: 内容。用于定位函数定义、调用点、配置项等。"
+}
+func (t *SearchTool) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"pattern": map[string]interface{}{"type": "string", "description": "正则表达式（Go RE2 语法）"},
+			"path":    map[string]interface{}{"type": "string", "description": "搜索的子目录（可选，默认为工作目录）"},
+			"glob":    map[string]interface{}{"type": "string", "description": "文件名匹配模式（可选），如 *.go"},
+		},
+		"required": []string{"pattern"},
+	}
+}
+
+func (t *SearchTool) Execute(args map[string]interface{}) (string, error) {
+	pattern, _ := args["pattern"].(string)
+	if pattern == "" {
+		return "", fmt.Errorf("缺少 pattern 参数")
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", fmt.Errorf("正则表达式无效: %v", err)
+	}
+	glob, _ := args["glob"].(string)
+	if glob != "" {
+		if _, err := filepath.Match(glob, ""); err != nil {
+			return "", fmt.Errorf("glob 无效: %v", err)
+		}
+	}
+	root := resolvePath(t.workDir, args["path"])
+
+	var out strings.Builder
+	matches := 0
+	err = walkFiles(root, func(path string, d fs.DirEntry) error {
+		if glob != "" && !matchGlob(glob, d.Name()) {
+			return nil
+		}
+		if info, err := d.Info(); err != nil || info.Size() > maxSearchFileSize {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil || isBinary(data) {
+			return nil
+		}
+		rel := relPath(t.workDir, path)
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), maxSearchFileSize)
+		for line := 1; scanner.Scan(); line++ {
+			if !re.Match(scanner.Bytes()) {
+				continue
+			}
+			text := strings.TrimSpace(scanner.Text())
+			if len(text) > 200 {
+				text = text[:200] + "..."
+			}
+			fmt.Fprintf(&out, "%s:%d: %s\n", rel, line, text)
+			matches++
+			if matches >= maxSearchMatches {
+				return fs.SkipAll
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("搜索失败: %v", err)
+	}
+	if matches == 0 {
+		return "没有匹配结果", nil
+	}
+	if matches >= maxSearchMatches {
+		fmt.Fprintf(&out, "... [结果过多，仅显示前 %d 条，请缩小搜索范围]\n", maxSearchMatches)
+	}
+	return out.String(), nil
+}
+
+// ListTool 目录浏览工具：列出工作目录下的文件
+type ListTool struct{ workDir string }
+
+func (t *ListTool) SetWorkDir(dir string) { t.workDir = dir }
+func (t *ListTool) Name() string          { return "list" }
+func (t *ListTool) Description() string {
+	return "列出目录下的文件（递归，跳过 .git、node_modules 等）。可用 glob 过滤文件名，用 depth 限制深度。"
+}
+func (t *ListTool) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"path":  map[string]interface{}{"type": "string", "description": "目录路径（可选，默认为工作目录）"},
+			"glob":  map[string]interface{}{"type": "string", "description": "文件名匹配模式（可选），如 *_test.go"},
+			"depth": map[string]interface{}{"type": "integer", "description": "最大递归深度（可选，默认 3）"},
+		},
+	}
+}
+
+func (t *ListTool) Execute(args map[string]interface{}) (string, error) {
+	root := resolvePath(t.workDir, args["path"])
+	glob, _ := args["glob"].(string)
+	if glob != "" {
+		if _, err := filepath.Match(glob, ""); err != nil {
+			return "", fmt.Errorf("glob 无效: %v", err)
+		}
+	}
+	depth := 3
+	if d, ok := args["depth"].(float64); ok && d > 0 {
+		depth = int(d)
+	}
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return "", fmt.Errorf("读取目录失败: %v", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s 不是目录", args["path"])
+	}
+
+	var entries []string
+	truncated := false
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || path == root {
+			return nil
+		}
+		rel, _ := filepath.Rel(root, path)
+		level := strings.Count(rel, string(filepath.Separator)) + 1
+		switch {
+		case d.IsDir() && skippedDirs[d.Name()]:
+			return fs.SkipDir
+		case d.IsDir():
+			if glob == "" {
+				entries = append(entries, filepath.ToSlash(rel)+"/")
+			}
+		case glob == "" || matchGlob(glob, d.Name()):
+			entries = append(entries, filepath.ToSlash(rel))
+		}
+		if len(entries) >= maxListEntries {
+			truncated = true
+			return fs.SkipAll
+		}
+		if d.IsDir() && level >= depth {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("读取目录失败: %v", err)
+	}
+	if len(entries) == 0 {
+		return "目录为空或没有匹配的文件", nil
+	}
+	sort.Strings(entries)
+	result := strings.Join(entries, "\n")
+	if truncated {
+		result += fmt.Sprintf("\n... [条目过多，仅显示前 %d 条]", maxListEntries)
+	}
+	return result, nil
+}
+
+// walkFiles 遍历 root 下的普通文件，跳过 skippedDirs 中的目录
+func walkFiles(root string, fn func(path string, d fs.DirEntry) error) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && skippedDirs[d.Name()] {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		return fn(path, d)
+	})
+}
+
+func resolvePath(workDir string, arg interface{}) string {
+	path, _ := arg.(string)
+	if path == "" {
+		return workDir
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(workDir, path)
+	}
+	return path
+}
+
+func relPath(workDir, path string) string {
+	if rel, err := filepath.Rel(workDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+func matchGlob(glob, name string) bool {
+	ok, _ := filepath.Match(glob, name)
+	return ok
+}
+
+// isBinary 粗略判断文件是否为二进制（前 8KB 中含 NUL 字节）
+func isBinary(data []byte) bool {
+	if len(data) > 8000 {
+		data = data[:8000]
+	}
+	return bytes.IndexByte(data, 0) >= 0
+}
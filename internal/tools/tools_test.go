@@ -1,10 +1,16 @@
 package tools
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/BlakeLiAFK/kele/internal/config"
+	"github.com/BlakeLiAFK/kele/internal/llm"
+	"github.com/BlakeLiAFK/kele/internal/memory"
 )
 
 // --- Registry 测试 ---
@@ -125,6 +131,23 @@ func TestBashSafeCommand(t *testing.T) {
 	}
 }
 
+func TestBashCancel(t *testing.T) {
+	cfg := config.Load()
+	bash := &BashTool{workDir: t.TempDir(), cfg: cfg, timeout: time.Minute}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	start := time.Now()
+	// 子进程 sleep 也应随进程组一起被杀掉
+	_, err := bash.ExecuteContext(ctx, map[string]interface{}{"command": "sleep 30 & wait"})
+	if err == nil || !strings.Contains(err.Error(), "中止") {
+		t.Errorf("取消后应返回中止错误, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("取消后命令应尽快返回, 耗时 %v", time.Since(start))
+	}
+}
+
 func TestBashMissingCommand(t *testing.T) {
 	cfg := config.Load()
 	bash := &BashTool{workDir: "/tmp", cfg: cfg}
@@ -358,6 +381,252 @@ func TestSendMessageToolRegisterTool(t *testing.T) {
 	}
 }
 
+// --- Executor.Fork 测试 ---
+
+func TestExecutorFork(t *testing.T) {
+	cfg := config.Load()
+	cfg.Memory.AuditLog = ""
+	e := NewExecutor(nil, cfg)
+	e.RegisterTool(&mockTool{name: "custom", result: "ok"})
+
+	dir := t.TempDir()
+	f := e.Fork(dir)
+
+	if f.GetWorkDir() != dir {
+		t.Errorf("Fork 工作目录 = %s, want %s", f.GetWorkDir(), dir)
+	}
+	if e.GetWorkDir() == dir {
+		t.Error("Fork 不应修改原执行器的工作目录")
+	}
+	if !f.registry.Has("custom") {
+		t.Error("Fork 应保留外部注册的工具")
+	}
+
+	result, err := f.registry.Execute("bash", map[string]interface{}{"command": "pwd"})
+	if err != nil {
+		t.Fatalf("bash 执行失败: %v", err)
+	}
+	if strings.TrimSpace(result) != dir {
+		t.Errorf("bash 应在 Fork 目录执行, 实际 %s", result)
+	}
+}
+
+func TestExecutorForkReadOnly(t *testing.T) {
+	cfg := config.Load()
+	cfg.Memory.AuditLog = ""
+	e := NewExecutor(nil, cfg)
+
+	f := e.ForkReadOnly(t.TempDir())
+	names := map[string]bool{}
+	for _, tool := range f.GetTools() {
+		names[tool.Function.Name] = true
+	}
+	for _, name := range ReadOnlyTools {
+		if !names[name] {
+			t.Errorf("只读执行器应包含 %s", name)
+		}
+	}
+	if names["bash"] || names["write"] || names["ask_user"] {
+		t.Errorf("只读执行器不应开放写入类工具: %v", names)
+	}
+
+	_, err := f.Execute(toolCall("write", `{"path":"x","content":"y"}`))
+	if err == nil || !strings.Contains(err.Error(), "不允许") {
+		t.Errorf("只读执行器应拒绝 write, got %v", err)
+	}
+	_, err = f.Execute(toolCall("git", `{"subcommand":"commit"}`))
+	if err == nil || !strings.Contains(err.Error(), "只读") {
+		t.Errorf("只读执行器应拒绝 git commit, got %v", err)
+	}
+
+	if len(e.GetTools()) <= len(f.GetTools()) {
+		t.Error("ForkReadOnly 不应限制原执行器")
+	}
+}
+
+func toolCall(name, args string) llm.ToolCall {
+	var tc llm.ToolCall
+	tc.Function.Name = name
+	tc.Function.Arguments = args
+	return tc
+}
+
+// --- SearchTool / ListTool 测试 ---
+
+func writeTree(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.go":             "package main\n\nfunc main() {\n\trun()\n}\n",
+		"pkg/run.go":          "package pkg\n\nfunc run() {}\n",
+		"pkg/run_test.go":     "package pkg\n",
+		"node_modules/x/a.js": "function run() {}\n",
+		".git/config":         "run\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestSearchTool(t *testing.T) {
+	dir := writeTree(t)
+	search := &SearchTool{workDir: dir}
+
+	result, err := search.Execute(map[string]interface{}{"pattern": `func run\(`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(result, "pkg/run.go:3: func run() {}") {
+		t.Errorf("应返回 文件:行号: 内容, 实际:\n%s", result)
+	}
+	if strings.Contains(result, "node_modules") || strings.Contains(result, ".git") {
+		t.Errorf("应跳过 node_modules 和 .git, 实际:\n%s", result)
+	}
+
+	result, _ = search.Execute(map[string]interface{}{"pattern": "run", "glob": "main.go"})
+	if !strings.Contains(result, "main.go:4:") || strings.Contains(result, "pkg/") {
+		t.Errorf("glob 过滤无效, 实际:\n%s", result)
+	}
+
+	if _, err := search.Execute(map[string]interface{}{"pattern": "("}); err == nil {
+		t.Error("无效正则应报错")
+	}
+}
+
+func TestListTool(t *testing.T) {
+	dir := writeTree(t)
+	list := &ListTool{workDir: dir}
+
+	result, err := list.Execute(map[string]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"main.go", "pkg/", "pkg/run.go"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("列表应包含 %s, 实际:\n%s", want, result)
+		}
+	}
+	if strings.Contains(result, "node_modules") || strings.Contains(result, ".git") {
+		t.Errorf("应跳过 node_modules 和 .git, 实际:\n%s", result)
+	}
+
+	result, _ = list.Execute(map[string]interface{}{"glob": "*_test.go"})
+	if strings.TrimSpace(result) != "pkg/run_test.go" {
+		t.Errorf("glob 过滤结果 = %q", result)
+	}
+
+	result, _ = list.Execute(map[string]interface{}{"depth": float64(1)})
+	if strings.Contains(result, "pkg/run.go") {
+		t.Errorf("depth=1 不应进入子目录, 实际:\n%s", result)
+	}
+}
+
+// --- 记忆工具测试 ---
+
+func TestMemoryTools(t *testing.T) {
+	dir := t.TempDir()
+	store, err := memory.NewStore(&config.Config{Memory: config.MemoryConfig{
+		DBPath:     filepath.Join(dir, "memory.db"),
+		MemoryFile: filepath.Join(dir, "MEMORY.md"),
+		SessionDir: filepath.Join(dir, "sessions"),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	r := NewRegistry()
+	for _, tool := range NewMemoryTools(store) {
+		r.Register(tool)
+	}
+
+	out, err := r.Execute("remember", map[string]interface{}{"key": "editor", "content": "用户使用 Neovim 编辑代码"})
+	if err != nil || !strings.Contains(out, "[editor]") {
+		t.Fatalf("remember 失败: %q %v", out, err)
+	}
+	if _, err := r.Execute("remember", map[string]interface{}{"key": "editor", "content": "重复"}); err == nil {
+		t.Error("已存在的 key 应拒绝 remember")
+	}
+
+	out, err = r.Execute("recall", map[string]interface{}{"query": "Neovim"})
+	if err != nil || !strings.Contains(out, "[editor] 用户使用 Neovim 编辑代码") {
+		t.Errorf("recall 应返回 key 和内容, 实际 %q %v", out, err)
+	}
+
+	if _, err := r.Execute("update_memory", map[string]interface{}{"key": "editor", "content": "用户改用 Helix 编辑代码"}); err != nil {
+		t.Fatalf("update_memory 失败: %v", err)
+	}
+	if v, _ := store.GetMemory("editor"); v != "用户改用 Helix 编辑代码" {
+		t.Errorf("更新后内容不对: %q", v)
+	}
+	if _, err := r.Execute("update_memory", map[string]interface{}{"key": "missing", "content": "x"}); err == nil {
+		t.Error("不存在的 key 应拒绝 update_memory")
+	}
+
+	if _, err := r.Execute("forget", map[string]interface{}{"key": "editor"}); err != nil {
+		t.Fatalf("forget 失败: %v", err)
+	}
+	if _, err := store.GetMemory("editor"); err == nil {
+		t.Error("forget 后记忆应被删除")
+	}
+	if _, err := r.Execute("forget", map[string]interface{}{"key": "editor"}); err == nil || !strings.Contains(err.Error(), "不存在") {
+		t.Errorf("重复 forget 应提示不存在, 实际 %v", err)
+	}
+
+	// 项目记忆只在该项目中可见
+	projectA := WithMemoryScopes(context.Background(), memory.Scopes{Project: "project:/src/a"})
+	projectB := WithMemoryScopes(context.Background(), memory.Scopes{Project: "project:/src/b"})
+	if _, err := r.ExecuteContext(projectA, "remember", map[string]interface{}{"key": "test_cmd", "content": "用 make check 运行测试", "scope": "project"}); err != nil {
+		t.Fatalf("remember 到项目失败: %v", err)
+	}
+	if out, _ := r.ExecuteContext(projectA, "recall", map[string]interface{}{"query": "make"}); !strings.Contains(out, "[test_cmd]（project）") {
+		t.Errorf("项目 A 应能找到项目记忆, 实际 %q", out)
+	}
+	if out, _ := r.ExecuteContext(projectB, "recall", map[string]interface{}{"query": "make"}); out != "未找到相关记忆" {
+		t.Errorf("项目 B 不应看到项目 A 的记忆, 实际 %q", out)
+	}
+	if _, err := r.Execute("remember", map[string]interface{}{"content": "x", "scope": "project"}); err == nil {
+		t.Error("没有项目时 scope=project 应返回错误")
+	}
+	if _, err := r.ExecuteContext(projectA, "forget", map[string]interface{}{"key": "test_cmd"}); err != nil {
+		t.Errorf("forget 应找到项目记忆: %v", err)
+	}
+}
+
+func TestConversationSearchTool(t *testing.T) {
+	dir := t.TempDir()
+	store, err := memory.NewStore(&config.Config{Memory: config.MemoryConfig{
+		DBPath:     filepath.Join(dir, "memory.db"),
+		MemoryFile: filepath.Join(dir, "MEMORY.md"),
+		SessionDir: filepath.Join(dir, "sessions"),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	store.SaveTurn(memory.MessageOrigin{SessionID: "s1", SessionName: "Chat 1", Channel: "cli"},
+		"部署方案用什么", "建议用 docker compose 部署")
+	tool := NewConversationSearchTool(store)
+
+	out, err := tool.Execute(map[string]interface{}{"query": "docker compose", "days": float64(7)})
+	if err != nil || !strings.Contains(out, "[Chat 1/cli] assistant") || !strings.Contains(out, "«docker»") {
+		t.Errorf("conversation_search 应返回会话、角色和命中摘要, 实际 %q %v", out, err)
+	}
+	out, _ = tool.Execute(map[string]interface{}{"query": "docker", "session": "s2"})
+	if !strings.Contains(out, "没有找到") {
+		t.Errorf("其他会话中不应命中, 实际 %q", out)
+	}
+	if _, err := tool.Execute(map[string]interface{}{}); err == nil {
+		t.Error("缺少 query 应报错")
+	}
+}
+
 // --- mock 工具 ---
 
 type mockSender struct {